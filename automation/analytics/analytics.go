@@ -0,0 +1,262 @@
+// Package analytics turns the usage and cost reports of the resource consumption service into
+// figures for FinOps: totals by directory, subaccount, service, plan and month, the burn rate of the
+// cloud credits with the date they run out at, and the month-over-month changes out of the ordinary.
+//
+//	d, err := analytics.Read(ctx, btpresources.New(sess), types.NewYearMonth(2024, 1), types.NewYearMonth(2024, 6))
+//	totals := d.Aggregate(analytics.BySubAccount, analytics.ByMonth)
+//	err = totals.WriteCSV(os.Stdout)
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/service/btpresources"
+	"github.com/nnicora/sap-sdk-go/service/types"
+	"sort"
+	"strings"
+)
+
+// Record is the usage, and the cost, of a metric of a plan by a subaccount in a month.
+type Record struct {
+	Month types.YearMonth `json:"month"`
+
+	DirectoryId    string `json:"directoryId,omitempty"`
+	DirectoryName  string `json:"directoryName,omitempty"`
+	SubAccountId   string `json:"subaccountId,omitempty"`
+	SubAccountName string `json:"subaccountName,omitempty"`
+
+	Service string `json:"service"`
+	Plan    string `json:"plan,omitempty"`
+	Metric  string `json:"metric,omitempty"`
+	Unit    string `json:"unit,omitempty"`
+
+	Usage    float64 `json:"usage"`
+	Cost     float64 `json:"cost"`
+	Currency string  `json:"currency,omitempty"`
+
+	// Whether the cost is estimated, i.e. not billed yet.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+func (r *Record) key() string {
+	return strings.Join([]string{r.Month.String(), r.SubAccountId, r.Service, r.Plan, r.Metric}, "\x00")
+}
+
+// Dataset is the records of a period.
+type Dataset struct {
+	Records []Record `json:"records"`
+}
+
+// Read reads the monthly usage and the monthly cost of the subaccounts of the global account from
+// month from to month to, both included, and merges the usage and cost of the same metric.
+func Read(ctx context.Context, api btpresources.ResourceAPI, from, to types.YearMonth) (*Dataset, error) {
+	usage, err := api.GetMonthlyUsage(ctx, &btpresources.GetMonthlyUsageInput{FromDate: from.Number(), ToDate: to.Number()})
+	if err != nil {
+		return nil, fmt.Errorf("could not read the monthly usage; %v", err)
+	}
+	cost, err := api.GetMonthlySubAccountsCost(ctx, &btpresources.GetMonthlySubAccountsCostInput{
+		FromDate: from.Number(),
+		ToDate:   to.Number(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read the monthly cost; %v", err)
+	}
+	return FromReports(usage.Content, cost.Content), nil
+}
+
+// ReadSubAccount reads the usage of a subaccount from month from to month to, both included, month by
+// month. The subaccount usage report has no cost.
+func ReadSubAccount(ctx context.Context, api btpresources.ResourceAPI, subaccountId string, from, to types.YearMonth) (*Dataset, error) {
+	last := to.AddMonths(1).Time().AddDate(0, 0, -1)
+	out, err := api.GetSubAccountUsage(ctx, &btpresources.GetSubAccountUsageInput{
+		SubAccountId:      subaccountId,
+		FromDate:          from.Number()*100 + 1,
+		ToDate:            uint32(last.Year()*10000 + int(last.Month())*100 + last.Day()),
+		PeriodPerspective: "MONTH",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read the usage of subaccount %s; %v", subaccountId, err)
+	}
+	d := &Dataset{}
+	index := make(map[string]int)
+	for _, u := range out.Content {
+		start := u.PeriodStartDate.Time()
+		d.add(index, Record{
+			Month:          types.NewYearMonth(start.Year(), start.Month()),
+			DirectoryId:    u.DirectoryId,
+			DirectoryName:  u.DirectoryName,
+			SubAccountId:   u.SubAccountId,
+			SubAccountName: u.SubAccountName,
+			Service:        serviceName(u.ServiceName, u.ServiceId),
+			Plan:           serviceName(u.PlanName, u.Plan),
+			Metric:         u.MetricName,
+			Unit:           u.UnitPlural,
+			Usage:          u.Usage,
+		})
+	}
+	return d, nil
+}
+
+// FromReports returns the records of the rows of the monthly usage and cost reports, merging the
+// usage and cost of the same metric of a plan by a subaccount in a month.
+func FromReports(usage []btpresources.MonthlyUsage, cost []btpresources.MonthlySubAccountsCost) *Dataset {
+	d := &Dataset{}
+	index := make(map[string]int)
+	for _, u := range usage {
+		d.add(index, Record{
+			Month:          u.ReportYearMonth,
+			DirectoryId:    u.DirectoryId,
+			DirectoryName:  u.DirectoryName,
+			SubAccountId:   u.SubAccountId,
+			SubAccountName: u.SubAccountName,
+			Service:        serviceName(u.ServiceName, u.ServiceId),
+			Plan:           serviceName(u.PlanName, u.Plan),
+			Metric:         u.MetricName,
+			Unit:           u.UnitPlural,
+			Usage:          u.Usage,
+		})
+	}
+	for _, c := range cost {
+		r := Record{
+			Month:          c.ReportYearMonth,
+			DirectoryId:    c.DirectoryId,
+			DirectoryName:  c.DirectoryName,
+			SubAccountId:   c.SubAccountId,
+			SubAccountName: c.SubAccountName,
+			Service:        serviceName(c.ServiceName, c.ServiceId),
+			Plan:           serviceName(c.PlanName, c.Plan),
+			Metric:         c.MetricName,
+			Unit:           c.UnitPlural,
+			Cost:           c.Cost,
+			Currency:       c.Currency,
+			Estimated:      c.Estimated,
+		}
+		// the usage reported along with the cost is the one of the usage report
+		if i, ok := index[r.key()]; ok {
+			merged := &d.Records[i]
+			merged.Cost += r.Cost
+			merged.Currency = r.Currency
+			merged.Estimated = merged.Estimated || r.Estimated
+			continue
+		}
+		r.Usage = c.Usage
+		d.add(index, r)
+	}
+	return d
+}
+
+// Adds the record, or its usage and cost to the record of the same key.
+func (d *Dataset) add(index map[string]int, r Record) {
+	if i, ok := index[r.key()]; ok {
+		d.Records[i].Usage += r.Usage
+		d.Records[i].Cost += r.Cost
+		return
+	}
+	index[r.key()] = len(d.Records)
+	d.Records = append(d.Records, r)
+}
+
+// The display name, or the ID when the name is missing.
+func serviceName(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+// Dimension is what the records are aggregated by.
+type Dimension string
+
+const (
+	ByDirectory  Dimension = "directory"
+	BySubAccount Dimension = "subaccount"
+	ByService    Dimension = "service"
+	ByPlan       Dimension = "plan"
+	ByMetric     Dimension = "metric"
+	ByMonth      Dimension = "month"
+)
+
+// Aggregate is the total usage and cost of the records sharing the values of the dimensions
+// aggregated by; the fields of the other dimensions are empty.
+type Aggregate struct {
+	// The month, as YYYY-MM.
+	Month string `json:"month,omitempty"`
+
+	DirectoryId    string `json:"directoryId,omitempty"`
+	DirectoryName  string `json:"directoryName,omitempty"`
+	SubAccountId   string `json:"subaccountId,omitempty"`
+	SubAccountName string `json:"subaccountName,omitempty"`
+	Service        string `json:"service,omitempty"`
+	Plan           string `json:"plan,omitempty"`
+	Metric         string `json:"metric,omitempty"`
+
+	// The usage of different metrics is summed unless aggregated by metric.
+	Usage    float64 `json:"usage"`
+	Cost     float64 `json:"cost"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+func (a *Aggregate) key() string {
+	return strings.Join([]string{a.DirectoryId, a.SubAccountId, a.Service, a.Plan, a.Metric, a.Month}, "\x00")
+}
+
+// Aggregates are the totals of a dataset.
+type Aggregates []Aggregate
+
+// Aggregate returns the totals of the records by the values of the dimensions, sorted by them; the
+// grand total when there are none. The plans are told apart by service even when not aggregated by
+// service.
+func (d *Dataset) Aggregate(dims ...Dimension) Aggregates {
+	by := make(map[Dimension]bool, len(dims))
+	for _, dim := range dims {
+		by[dim] = true
+	}
+	var totals Aggregates
+	index := make(map[string]int)
+	for _, r := range d.Records {
+		a := Aggregate{Currency: r.Currency}
+		if by[ByMonth] {
+			a.Month = r.Month.String()
+		}
+		if by[ByDirectory] {
+			a.DirectoryId, a.DirectoryName = r.DirectoryId, r.DirectoryName
+		}
+		if by[BySubAccount] {
+			a.SubAccountId, a.SubAccountName = r.SubAccountId, r.SubAccountName
+		}
+		if by[ByService] || by[ByPlan] {
+			a.Service = r.Service
+		}
+		if by[ByPlan] {
+			a.Plan = r.Plan
+		}
+		if by[ByMetric] {
+			a.Metric = r.Metric
+		}
+		i, ok := index[a.key()]
+		if !ok {
+			i = len(totals)
+			index[a.key()] = i
+			totals = append(totals, a)
+		}
+		totals[i].Usage += r.Usage
+		totals[i].Cost += r.Cost
+		if totals[i].Currency == "" {
+			totals[i].Currency = r.Currency
+		}
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].less(&totals[j]) })
+	return totals
+}
+
+// Orders by names rather than IDs, then by month.
+func (a *Aggregate) less(b *Aggregate) bool {
+	x := []string{a.DirectoryName, a.DirectoryId, a.SubAccountName, a.SubAccountId, a.Service, a.Plan, a.Metric, a.Month}
+	y := []string{b.DirectoryName, b.DirectoryId, b.SubAccountName, b.SubAccountId, b.Service, b.Plan, b.Metric, b.Month}
+	for i := range x {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return false
+}
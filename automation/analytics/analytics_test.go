@@ -0,0 +1,145 @@
+package analytics_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/automation/analytics"
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/service/btpresources"
+	"github.com/nnicora/sap-sdk-go/service/types"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestAnalytics(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	usage := func(month int32, subaccount, dir, service string, amount float64) btpfake.UsageRecord {
+		return btpfake.UsageRecord{ReportYearMonth: month, SubAccountId: subaccount, SubAccountName: subaccount,
+			DirectoryId: dir, DirectoryName: dir, ServiceName: service, PlanName: "standard", MetricName: "GB", Usage: amount}
+	}
+	cost := func(month int32, subaccount, dir, service string, amount float64) btpfake.CostRecord {
+		return btpfake.CostRecord{ReportYearMonth: month, SubAccountId: subaccount, SubAccountName: subaccount,
+			DirectoryId: dir, DirectoryName: dir, ServiceName: service, PlanName: "standard", MetricName: "GB", Cost: amount}
+	}
+	srv.AddUsage(
+		usage(202401, "sales-dev", "Sales", "objectstore", 10),
+		usage(202402, "sales-dev", "Sales", "objectstore", 11),
+		usage(202403, "sales-dev", "Sales", "objectstore", 30),
+		usage(202401, "hr-dev", "HR", "objectstore", 5),
+		usage(202402, "hr-dev", "HR", "objectstore", 5),
+		usage(202403, "hr-dev", "HR", "objectstore", 5),
+		usage(202402, "hr-dev", "HR", "hana", 2),
+	)
+	srv.AddCost(
+		cost(202401, "sales-dev", "Sales", "objectstore", 100),
+		cost(202402, "sales-dev", "Sales", "objectstore", 110),
+		cost(202403, "sales-dev", "Sales", "objectstore", 300),
+		cost(202401, "hr-dev", "HR", "objectstore", 50),
+		cost(202402, "hr-dev", "HR", "objectstore", 50),
+		cost(202403, "hr-dev", "HR", "objectstore", 50),
+		cost(202402, "hr-dev", "HR", "hana", 40),
+	)
+	api := btpresources.New(automationtest.Session(t, srv))
+	ctx := context.Background()
+
+	d, err := analytics.Read(ctx, api, types.NewYearMonth(2024, 1), types.NewYearMonth(2024, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Records) != 7 || d.Records[0].Usage != 10 || d.Records[0].Cost != 100 || d.Records[0].Currency != "EUR" {
+		t.Fatalf("expected the usage and cost to be merged, got %+v", d.Records)
+	}
+
+	byDirectory := d.Aggregate(analytics.ByDirectory)
+	if len(byDirectory) != 2 || byDirectory[0].DirectoryName != "HR" || byDirectory[0].Cost != 190 ||
+		byDirectory[1].Cost != 510 {
+		t.Fatalf("unexpected totals by directory %+v", byDirectory)
+	}
+	byMonth := d.Aggregate(analytics.ByService, analytics.ByMonth)
+	if len(byMonth) != 4 || byMonth[0].Service != "hana" || byMonth[1].Month != "2024-01" || byMonth[1].Cost != 150 {
+		t.Fatalf("unexpected totals by service and month %+v", byMonth)
+	}
+	if total := d.Aggregate(); len(total) != 1 || total[0].Cost != 700 {
+		t.Fatalf("unexpected grand total %+v", total)
+	}
+	var csv bytes.Buffer
+	if err := byMonth.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 5 || lines[0] != "month,directoryId,directoryName,subaccountId,subaccountName,service,plan,metric,usage,cost,currency" ||
+		lines[2] != "2024-01,,,,,objectstore,,,15,150,EUR" {
+		t.Fatalf("unexpected CSV\n%s", csv.String())
+	}
+
+	anomalies := d.Anomalies(&analytics.AnomalyOptions{MinChange: 20}, analytics.BySubAccount, analytics.ByService)
+	if len(anomalies) != 3 {
+		t.Fatalf("expected 3 anomalies, got %+v", anomalies)
+	}
+	// hana appears, then disappears, and objectstore of sales-dev goes from 110 to 300
+	if a := anomalies[0]; a.Service != "hana" || !a.New || a.Month != "2024-02" || a.Current != 40 {
+		t.Fatalf("unexpected anomaly %+v", a)
+	}
+	if a := anomalies[1]; a.Service != "hana" || a.Month != "2024-03" || a.Change != -1 || a.SubAccountId != "hr-dev" {
+		t.Fatalf("unexpected anomaly %+v", a)
+	}
+	if a := anomalies[2]; a.SubAccountId != "sales-dev" || a.PreviousMonth != "2024-02" || a.Previous != 110 || a.Current != 300 {
+		t.Fatalf("unexpected anomaly %+v", a)
+	}
+	var buf bytes.Buffer
+	if err := anomalies.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 3 || decoded[2]["previousMonth"] != "2024-02" {
+		t.Fatalf("unexpected JSON %s, %v", buf.String(), err)
+	}
+}
+
+func TestCredits(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.SetCloudCredits(btpfake.CloudCreditsContract{
+		ContractStartDate: "2024-01-01",
+		ContractEndDate:   "2025-12-31",
+		Currency:          "EUR",
+		Phases: []btpfake.CloudCreditsPhase{{
+			StartDate: "2024-01-01",
+			EndDate:   "2024-12-31",
+			Updates: []btpfake.CloudCreditsUpdate{
+				{Balance: 9000, CloudCreditsForPhase: 10000, UpdatedOn: "2024-01-11"},
+				// 2000 added, 1000 consumed
+				{Balance: 10000, CloudCreditsForPhase: 12000, UpdatedOn: "2024-01-21"},
+				{Balance: 9000, CloudCreditsForPhase: 12000, UpdatedOn: "2024-01-31"},
+			},
+		}},
+	})
+
+	forecasts, err := analytics.ReadCredits(context.Background(), btpresources.New(automationtest.Session(t, srv)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forecasts) != 1 {
+		t.Fatalf("expected 1 forecast, got %+v", forecasts)
+	}
+	f := forecasts[0]
+	if f.Consumed != 3000 || f.DailyBurn != 100 || f.Balance != 9000 || f.CloudCredits != 12000 {
+		t.Fatalf("unexpected forecast %+v", f)
+	}
+	// 9000 left at 100 a day from the 31st of January
+	if f.ExhaustionDate.Time() != time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC) || !f.ExhaustedBeforePhaseEnd {
+		t.Fatalf("unexpected exhaustion %s", f.ExhaustionDate)
+	}
+	var csv bytes.Buffer
+	if err := forecasts.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(csv.String(), "EUR,2024-01-01,2024-12-31,12000,9000,2024-01-31,3000,100,") {
+		t.Fatalf("unexpected CSV\n%s", csv.String())
+	}
+}
@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultThreshold is the relative change from a month to the next beyond which it is an anomaly.
+const DefaultThreshold = 0.5
+
+// AnomalyOptions tune the detection of anomalies.
+type AnomalyOptions struct {
+	// The relative change from a month to the next beyond which it is an anomaly, up or down, e.g.
+	// 0.5 for 50%; DefaultThreshold when zero.
+	Threshold float64
+
+	// The absolute change below which a change is not an anomaly, whatever its relative change, so
+	// that small amounts going up and down are left out.
+	MinChange float64
+
+	// Compares the usage instead of the cost.
+	Usage bool
+}
+
+// Anomaly is a change of the cost, or usage, of a group of records from a month to the next beyond
+// the threshold.
+type Anomaly struct {
+	// The group, the month changed to, and its totals.
+	Aggregate
+
+	// The month changed from, as YYYY-MM, and the cost, or usage, then and in the month changed to.
+	PreviousMonth string  `json:"previousMonth"`
+	Previous      float64 `json:"previous"`
+	Current       float64 `json:"current"`
+
+	// The relative change, e.g. 1.5 for +150% or -0.6 for -60%; zero when there was nothing the
+	// previous month.
+	Change float64 `json:"change"`
+
+	// Whether there was nothing the previous month.
+	New bool `json:"new,omitempty"`
+}
+
+// Anomalies are the anomalies of a dataset.
+type Anomalies []Anomaly
+
+// Anomalies compares the totals of the records by the values of the dimensions, from every month of
+// the dataset to the next, and returns the changes beyond the threshold, sorted by group and month. A
+// month without records of a group counts as nothing spent, or used, by the group.
+func (d *Dataset) Anomalies(opts *AnomalyOptions, dims ...Dimension) Anomalies {
+	if opts == nil {
+		opts = &AnomalyOptions{}
+	}
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	var groupDims []Dimension
+	for _, dim := range dims {
+		if dim != ByMonth {
+			groupDims = append(groupDims, dim)
+		}
+	}
+
+	var months []string
+	seen := make(map[string]bool)
+	for _, r := range d.Records {
+		if m := r.Month.String(); !seen[m] {
+			seen[m] = true
+			months = append(months, m)
+		}
+	}
+	sort.Strings(months)
+
+	// the totals of every group by month
+	groups := make(map[string]map[string]Aggregate)
+	var order []string
+	for _, a := range d.Aggregate(append(groupDims, ByMonth)...) {
+		month := a.Month
+		a.Month = ""
+		key := a.key()
+		if _, ok := groups[key]; !ok {
+			groups[key] = make(map[string]Aggregate)
+			order = append(order, key)
+		}
+		a.Month = month
+		groups[key][month] = a
+	}
+
+	var anomalies Anomalies
+	for _, key := range order {
+		byMonth := groups[key]
+		for i := 1; i < len(months); i++ {
+			prev, cur := byMonth[months[i-1]], byMonth[months[i]]
+			previous, current := prev.Cost, cur.Cost
+			if opts.Usage {
+				previous, current = prev.Usage, cur.Usage
+			}
+			delta := current - previous
+			if delta == 0 || math.Abs(delta) < opts.MinChange {
+				continue
+			}
+			an := Anomaly{Aggregate: cur, PreviousMonth: months[i-1], Previous: previous, Current: current}
+			if previous == 0 {
+				an.New = true
+			} else if an.Change = delta / math.Abs(previous); math.Abs(an.Change) < threshold {
+				continue
+			}
+			// a group gone in the month changed to is only known by the month before
+			if cur.Month == "" {
+				an.Aggregate = prev
+				an.Usage, an.Cost = 0, 0
+			}
+			an.Month = months[i]
+			anomalies = append(anomalies, an)
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,108 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/service/btpresources"
+	"github.com/nnicora/sap-sdk-go/service/types"
+	"math"
+	"sort"
+	"time"
+)
+
+// The days in an average month, to turn the daily burn rate into a monthly one.
+const daysPerMonth = 365.25 / 12
+
+// Forecast is the burn rate of the cloud credits of a phase of a contract, and the date they are
+// projected to run out at.
+type Forecast struct {
+	Currency   string     `json:"currency,omitempty"`
+	PhaseStart types.Date `json:"phaseStart"`
+	PhaseEnd   types.Date `json:"phaseEnd"`
+
+	// The credits of the phase and their balance, as of the last update of the phase.
+	CloudCredits float64    `json:"cloudCredits"`
+	Balance      float64    `json:"balance"`
+	UpdatedOn    types.Date `json:"updatedOn"`
+
+	// The credits consumed since the start of the phase; the credits added during the phase are not
+	// counted as consumed.
+	Consumed float64 `json:"consumed"`
+
+	// The credits consumed per day and per month, on average since the start of the phase.
+	DailyBurn   float64 `json:"dailyBurn"`
+	MonthlyBurn float64 `json:"monthlyBurn"`
+
+	// The day the balance is projected to reach zero at the burn rate; zero when nothing is burnt.
+	ExhaustionDate types.Date `json:"exhaustionDate"`
+
+	// Whether the credits are projected to run out before the end of the phase.
+	ExhaustedBeforePhaseEnd bool `json:"exhaustedBeforePhaseEnd"`
+}
+
+// Forecasts are the forecasts of several contracts.
+type Forecasts []Forecast
+
+// ReadCredits reads the cloud credits of the global account and returns the forecast of the current
+// phase of every contract.
+func ReadCredits(ctx context.Context, api btpresources.ResourceAPI) (Forecasts, error) {
+	out, err := api.GetCloudCreditsDetails(ctx, &btpresources.GetCloudCreditsDetailsInput{ViewPhases: "CURRENT"})
+	if err != nil {
+		return nil, fmt.Errorf("could not read the cloud credits; %v", err)
+	}
+	var forecasts Forecasts
+	for _, c := range out.Contracts {
+		if len(c.Phases) == 0 {
+			continue
+		}
+		// the current phase is the one which started last
+		current := c.Phases[0]
+		for _, p := range c.Phases[1:] {
+			if p.StartDate.Time().After(current.StartDate.Time()) {
+				current = p
+			}
+		}
+		forecasts = append(forecasts, ForecastPhase(c.Currency, current))
+	}
+	return forecasts, nil
+}
+
+// ForecastPhase computes the burn rate of the credits of the phase from the balances of its updates,
+// and projects the date the balance reaches zero at.
+func ForecastPhase(currency string, p btpresources.Phase) Forecast {
+	f := Forecast{Currency: currency, PhaseStart: p.StartDate, PhaseEnd: p.EndDate}
+	if len(p.Updates) == 0 {
+		return f
+	}
+	updates := append([]btpresources.PhaseUpdate{}, p.Updates...)
+	sort.SliceStable(updates, func(i, j int) bool {
+		return updates[i].UpdatedOn.Time().Before(updates[j].UpdatedOn.Time())
+	})
+
+	// the phase starts with the credits of its first update untouched; a later update adding credits
+	// raises the balance by as much
+	balance, credits := updates[0].CloudCreditsForPhase, updates[0].CloudCreditsForPhase
+	for _, u := range updates {
+		f.Consumed += math.Max(0, balance+(u.CloudCreditsForPhase-credits)-u.Balance)
+		balance, credits = u.Balance, u.CloudCreditsForPhase
+	}
+	last := updates[len(updates)-1]
+	f.CloudCredits, f.Balance, f.UpdatedOn = last.CloudCreditsForPhase, last.Balance, last.UpdatedOn
+
+	start := p.StartDate.Time()
+	if start.IsZero() {
+		start = updates[0].UpdatedOn.Time()
+	}
+	days := last.UpdatedOn.Time().Sub(start).Hours() / 24
+	if days <= 0 || f.Consumed <= 0 {
+		return f
+	}
+	f.DailyBurn = f.Consumed / days
+	f.MonthlyBurn = f.DailyBurn * daysPerMonth
+
+	left := time.Duration(math.Ceil(math.Max(0, f.Balance)/f.DailyBurn)) * 24 * time.Hour
+	exhaustion := last.UpdatedOn.Time().Add(left)
+	f.ExhaustionDate = types.NewDate(exhaustion.Year(), exhaustion.Month(), exhaustion.Day())
+	f.ExhaustedBeforePhaseEnd = !p.EndDate.IsZero() && exhaustion.Before(p.EndDate.Time())
+	return f
+}
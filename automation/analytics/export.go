@@ -0,0 +1,108 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes the records as an indented JSON document.
+func (d *Dataset) WriteJSON(w io.Writer) error {
+	return writeJSON(w, d)
+}
+
+// WriteCSV writes the records, one per row.
+func (d *Dataset) WriteCSV(w io.Writer) error {
+	header := []string{"month", "directoryId", "directoryName", "subaccountId", "subaccountName", "service", "plan",
+		"metric", "unit", "usage", "cost", "currency", "estimated"}
+	return writeCSV(w, header, len(d.Records), func(i int) []string {
+		r := d.Records[i]
+		return []string{r.Month.String(), r.DirectoryId, r.DirectoryName, r.SubAccountId, r.SubAccountName, r.Service,
+			r.Plan, r.Metric, r.Unit, number(r.Usage), number(r.Cost), r.Currency, strconv.FormatBool(r.Estimated)}
+	})
+}
+
+// WriteJSON writes the totals as an indented JSON array.
+func (a Aggregates) WriteJSON(w io.Writer) error {
+	if a == nil {
+		a = Aggregates{}
+	}
+	return writeJSON(w, a)
+}
+
+var aggregateHeader = []string{"month", "directoryId", "directoryName", "subaccountId", "subaccountName", "service",
+	"plan", "metric", "usage", "cost", "currency"}
+
+func (a *Aggregate) record() []string {
+	return []string{a.Month, a.DirectoryId, a.DirectoryName, a.SubAccountId, a.SubAccountName, a.Service, a.Plan,
+		a.Metric, number(a.Usage), number(a.Cost), a.Currency}
+}
+
+// WriteCSV writes the totals, one per row; the columns of the dimensions not aggregated by are empty.
+func (a Aggregates) WriteCSV(w io.Writer) error {
+	return writeCSV(w, aggregateHeader, len(a), func(i int) []string { return a[i].record() })
+}
+
+// WriteJSON writes the anomalies as an indented JSON array.
+func (a Anomalies) WriteJSON(w io.Writer) error {
+	if a == nil {
+		a = Anomalies{}
+	}
+	return writeJSON(w, a)
+}
+
+// WriteCSV writes the anomalies, one per row, with the totals of the month changed to.
+func (a Anomalies) WriteCSV(w io.Writer) error {
+	header := append(append([]string{}, aggregateHeader...), "previousMonth", "previous", "current", "change", "new")
+	return writeCSV(w, header, len(a), func(i int) []string {
+		an := a[i]
+		return append(an.record(), an.PreviousMonth, number(an.Previous), number(an.Current), number(an.Change),
+			strconv.FormatBool(an.New))
+	})
+}
+
+// WriteJSON writes the forecasts as an indented JSON array.
+func (f Forecasts) WriteJSON(w io.Writer) error {
+	if f == nil {
+		f = Forecasts{}
+	}
+	return writeJSON(w, f)
+}
+
+// WriteCSV writes the forecasts, one per row.
+func (f Forecasts) WriteCSV(w io.Writer) error {
+	header := []string{"currency", "phaseStart", "phaseEnd", "cloudCredits", "balance", "updatedOn", "consumed",
+		"dailyBurn", "monthlyBurn", "exhaustionDate", "exhaustedBeforePhaseEnd"}
+	return writeCSV(w, header, len(f), func(i int) []string {
+		fc := f[i]
+		return []string{fc.Currency, fc.PhaseStart.String(), fc.PhaseEnd.String(), number(fc.CloudCredits),
+			number(fc.Balance), fc.UpdatedOn.String(), number(fc.Consumed), number(fc.DailyBurn), number(fc.MonthlyBurn),
+			fc.ExhaustionDate.String(), strconv.FormatBool(fc.ExhaustedBeforePhaseEnd)}
+	})
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeCSV(w io.Writer, header []string, n int, record func(i int) []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := cw.Write(record(i)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Formats the number without exponent, and with no more decimals than needed.
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
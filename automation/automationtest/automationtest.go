@@ -0,0 +1,37 @@
+// Package automationtest provides the clients automation runs with in tests, backed by the fake of
+// the BTP APIs of package btpfake.
+//
+//	srv := btpfake.NewServer()
+//	defer srv.Close()
+//	c := automationtest.Clients(t, srv)
+package automationtest
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+	"testing"
+)
+
+// Session returns the session of the global account of the fake; the test fails when it cannot be built.
+func Session(t testing.TB, srv *btpfake.Server) *session.RuntimeSession {
+	t.Helper()
+	sess, err := srv.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+// Clients returns the clients of the global account of the fake. The APIs scoped to a subaccount are
+// called with the service key of the subaccount, and its Service Manager API with its Service Manager
+// binding, which is created when the subaccount has none.
+func Clients(t testing.TB, srv *btpfake.Server) *automation.Clients {
+	sess := Session(t, srv)
+	return automation.NewClients(sess, automation.ServiceManagementBindingSessions(
+		func(_ context.Context, guid string) (*sap.Config, error) { return srv.SubAccountConfig(guid), nil },
+		btpaccounts.New(sess), &automation.BindingOptions{CreateMissing: true}))
+}
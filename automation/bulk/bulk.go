@@ -0,0 +1,314 @@
+// Package bulk creates, updates and deletes many subaccounts at once, with a bounded number of calls
+// in flight, retries of the items failing transiently and an optional rate limit:
+//
+//	report := bulk.CreateSubAccounts(ctx, accounts, inputs, &bulk.Options{Parallelism: 8, Wait: true})
+//	for _, r := range report.Failed() {
+//		log.Printf("%s: %v", r.Key, r.Err)
+//	}
+//
+// Every item gets a result, in the order of the inputs; a failing item does not stop the others
+// unless Options.Mode is CancelOnFirstError.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultParallelism is the number of items run at the same time when Options.Parallelism is not
+// set.
+const DefaultParallelism = 4
+
+// DefaultRetryDelay is the delay before the first retry of an item when Options.RetryDelay is not
+// set; it doubles with every retry.
+const DefaultRetryDelay = time.Second
+
+// ErrSkipped is the error of the items never run, since the batch was canceled first.
+var ErrSkipped = errors.New("skipped, the batch was canceled")
+
+// Mode tells how a batch goes on once an item failed.
+type Mode int
+
+const (
+	// Continue runs every item, whatever the failures of the others.
+	Continue Mode = iota
+
+	// CancelOnFirstError cancels the items in flight and skips the remaining ones once an item failed.
+	CancelOnFirstError
+)
+
+// Options tune a batch.
+type Options struct {
+	// The number of items run at the same time; DefaultParallelism when zero.
+	Parallelism int
+
+	// How the batch goes on once an item failed; Continue by default.
+	Mode Mode
+
+	// The number of times an item is retried when its call fails transiently, i.e. without a response,
+	// or with a 429 or 5xx status. The waits for the subaccounts are not retried.
+	Retries int
+
+	// The delay before the first retry of an item; DefaultRetryDelay when zero.
+	RetryDelay time.Duration
+
+	// The number of calls started per second, retries included; unlimited when zero.
+	Rate float64
+
+	// Waits for every subaccount created or updated to be OK, and for every one deleted to be gone,
+	// before reporting its item as successful.
+	Wait bool
+
+	// The time between two polls of a subaccount when waiting; automation.DefaultPollInterval when zero.
+	PollInterval time.Duration
+
+	// Options applied to every call of the batch, e.g. request.WithMaxRetries(0) to let the batch
+	// handle the retries alone.
+	RequestOptions []request.Option
+
+	// Called with the result of every item once it is done, from the goroutine which ran it.
+	OnResult func(Result)
+}
+
+// Result is the outcome of one item of a batch.
+type Result struct {
+	// The position of the item among the inputs.
+	Index int
+
+	// The subdomain of the subaccount created, or the GUID of the one updated or deleted.
+	Key string
+
+	// The subaccount as returned by the call, or as waited for with Options.Wait; nil when the item
+	// failed, or deleted the subaccount and waited for it to be gone.
+	SubAccount *btpaccounts.SubAccount
+
+	// The number of calls made for the item, retries included.
+	Attempts int
+
+	Err error
+}
+
+// Report holds the results of a batch, in the order of its inputs.
+type Report struct {
+	Results []Result
+}
+
+// Succeeded returns the results of the items which succeeded.
+func (r *Report) Succeeded() []Result {
+	return r.filter(false)
+}
+
+// Failed returns the results of the items which failed or were skipped.
+func (r *Report) Failed() []Result {
+	return r.filter(true)
+}
+
+func (r *Report) filter(failed bool) []Result {
+	results := make([]Result, 0)
+	for _, res := range r.Results {
+		if (res.Err != nil) == failed {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// Err returns an error listing the items which failed, or nil when all of them succeeded.
+func (r *Report) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, res := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", res.Key, res.Err))
+	}
+	return fmt.Errorf("%d of %d items failed; %s", len(failed), len(r.Results), strings.Join(msgs, "; "))
+}
+
+// CreateSubAccounts creates the subaccounts. A creation failing with a 5xx status may still have
+// happened, in which case its retry fails since the subdomain is taken.
+func CreateSubAccounts(ctx context.Context, accounts btpaccounts.AccountsAPI, inputs []*btpaccounts.CreateSubAccountInput,
+	opts *Options) *Report {
+	if opts == nil {
+		opts = &Options{}
+	}
+	keys := make([]string, len(inputs))
+	for i, in := range inputs {
+		keys[i] = in.Subdomain
+	}
+	return run(ctx, keys, opts, func(ctx context.Context, i int, a *attempts) (*btpaccounts.SubAccount, error) {
+		var out *btpaccounts.CreateSubAccountOutput
+		err := a.call(ctx, func(ctx context.Context) (int32, error) {
+			var err error
+			if out, err = accounts.CreateSubAccount(ctx, inputs[i], opts.RequestOptions...); out == nil {
+				return 0, err
+			}
+			return out.StatusCode, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if opts.Wait {
+			return automation.WaitForSubAccount(ctx, accounts, out.Guid, opts.PollInterval, opts.RequestOptions...)
+		}
+		return &out.SubAccount, nil
+	})
+}
+
+// UpdateSubAccounts updates the subaccounts, e.g. their display names or custom properties.
+func UpdateSubAccounts(ctx context.Context, accounts btpaccounts.AccountsAPI, inputs []*btpaccounts.UpdateSubAccountInput,
+	opts *Options) *Report {
+	if opts == nil {
+		opts = &Options{}
+	}
+	keys := make([]string, len(inputs))
+	for i, in := range inputs {
+		keys[i] = in.SubAccountGuid
+	}
+	return run(ctx, keys, opts, func(ctx context.Context, i int, a *attempts) (*btpaccounts.SubAccount, error) {
+		var out *btpaccounts.UpdateSubAccountOutput
+		err := a.call(ctx, func(ctx context.Context) (int32, error) {
+			var err error
+			if out, err = accounts.UpdateSubAccount(ctx, inputs[i], opts.RequestOptions...); out == nil {
+				return 0, err
+			}
+			return out.StatusCode, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if opts.Wait {
+			return automation.WaitForSubAccount(ctx, accounts, out.Guid, opts.PollInterval, opts.RequestOptions...)
+		}
+		return &out.SubAccount, nil
+	})
+}
+
+// DeleteSubAccounts deletes the subaccounts.
+func DeleteSubAccounts(ctx context.Context, accounts btpaccounts.AccountsAPI, inputs []*btpaccounts.DeleteSubAccountInput,
+	opts *Options) *Report {
+	if opts == nil {
+		opts = &Options{}
+	}
+	keys := make([]string, len(inputs))
+	for i, in := range inputs {
+		keys[i] = in.SubAccountGuid
+	}
+	return run(ctx, keys, opts, func(ctx context.Context, i int, a *attempts) (*btpaccounts.SubAccount, error) {
+		var out *btpaccounts.DeleteSubAccountOutput
+		err := a.call(ctx, func(ctx context.Context) (int32, error) {
+			var err error
+			if out, err = accounts.DeleteSubAccount(ctx, inputs[i], opts.RequestOptions...); out == nil {
+				return 0, err
+			}
+			return out.StatusCode, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if opts.Wait {
+			return nil, automation.WaitForSubAccountDeletion(ctx, accounts, inputs[i].SubAccountGuid, opts.PollInterval,
+				opts.RequestOptions...)
+		}
+		return &out.SubAccount, nil
+	})
+}
+
+// The calls made for an item, sharing the rate limit of the batch.
+type attempts struct {
+	opts    *Options
+	limiter *limiter
+	n       int
+}
+
+// Runs the items with a pool of opts.Parallelism workers, and reports their results in order.
+func run(ctx context.Context, keys []string, opts *Options,
+	item func(ctx context.Context, i int, a *attempts) (*btpaccounts.SubAccount, error)) *Report {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	limiter := newLimiter(opts.Rate)
+	report := &Report{Results: make([]Result, len(keys))}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				res := Result{Index: i, Key: keys[i]}
+				if ctx.Err() != nil {
+					res.Err = ErrSkipped
+				} else {
+					a := &attempts{opts: opts, limiter: limiter}
+					res.SubAccount, res.Err = item(ctx, i, a)
+					res.Attempts = a.n
+				}
+				report.Results[i] = res
+				if res.Err != nil && opts.Mode == CancelOnFirstError {
+					cancel()
+				}
+				if opts.OnResult != nil {
+					opts.OnResult(res)
+				}
+			}
+		}()
+	}
+	for i := range keys {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return report
+}
+
+// Makes the call of an item, retrying it when it fails transiently; fn returns the status code of
+// the response along with the error.
+func (a *attempts) call(ctx context.Context, fn func(ctx context.Context) (int32, error)) error {
+	delay := a.opts.RetryDelay
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+	for {
+		if err := a.limiter.wait(ctx); err != nil {
+			return err
+		}
+		a.n++
+		status, err := fn(ctx)
+		if err == nil || a.n > a.opts.Retries || !transient(status, err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// Reports whether a call which failed with the status code is worth retrying; the calls failing before
+// being sent never are.
+func transient(status int32, err error) bool {
+	switch err.(type) {
+	case *request.ValidationError, *request.DryRunError:
+		return false
+	}
+	return status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
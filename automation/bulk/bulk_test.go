@@ -0,0 +1,157 @@
+package bulk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/bulk"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts/btpaccountsmock"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestBulkLifecycle(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "taken", DisplayName: "taken"})
+	srv.InjectFault(btpfake.Fault{Method: http.MethodPost, Path: "/accounts/v1/subaccounts", Status: http.StatusServiceUnavailable})
+	accounts := automationtest.Clients(t, srv).Accounts
+	ctx := context.Background()
+
+	var inputs []*btpaccounts.CreateSubAccountInput
+	for _, subdomain := range []string{"team-1", "team-2", "taken", "team-3", "team-4"} {
+		inputs = append(inputs, &btpaccounts.CreateSubAccountInput{DisplayName: subdomain, Region: "eu10", Subdomain: subdomain})
+	}
+	var mu sync.Mutex
+	done := 0
+	opts := &bulk.Options{
+		Parallelism:    2,
+		Retries:        1,
+		RetryDelay:     time.Millisecond,
+		Wait:           true,
+		PollInterval:   time.Millisecond,
+		RequestOptions: []request.Option{request.WithMaxRetries(0)},
+		OnResult: func(bulk.Result) {
+			mu.Lock()
+			done++
+			mu.Unlock()
+		},
+	}
+	report := bulk.CreateSubAccounts(ctx, accounts, inputs, opts)
+	if done != len(inputs) {
+		t.Fatalf("expected a callback per item, got %d", done)
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Index != 2 || failed[0].Attempts != 1 {
+		t.Fatalf("expected the taken subdomain alone to fail without retry, got %+v", failed)
+	}
+	if err := report.Err(); err == nil || !strings.HasPrefix(err.Error(), "1 of 5 items failed; taken: ") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	attempts := 0
+	for _, r := range report.Succeeded() {
+		if r.SubAccount == nil || r.SubAccount.State != btpaccounts.EntityStateOK || r.SubAccount.Subdomain != r.Key {
+			t.Fatalf("expected %s to be created, got %+v", r.Key, r.SubAccount)
+		}
+		attempts += r.Attempts
+	}
+	if attempts != 5 {
+		t.Fatalf("expected the unavailable service to cost a single retry, got %d attempts", attempts)
+	}
+
+	var updates []*btpaccounts.UpdateSubAccountInput
+	var deletes []*btpaccounts.DeleteSubAccountInput
+	for _, r := range report.Succeeded() {
+		updates = append(updates, &btpaccounts.UpdateSubAccountInput{
+			SubAccountGuid: r.SubAccount.Guid,
+			DisplayName:    strings.ToUpper(r.Key),
+		})
+		deletes = append(deletes, &btpaccounts.DeleteSubAccountInput{SubAccountGuid: r.SubAccount.Guid})
+	}
+	if err := bulk.UpdateSubAccounts(ctx, accounts, updates, &bulk.Options{Rate: 1000}).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if sa, _ := srv.SubAccount(updates[0].SubAccountGuid); sa.DisplayName != "TEAM-1" {
+		t.Fatalf("expected the display name to be updated, got %s", sa.DisplayName)
+	}
+
+	if err := bulk.DeleteSubAccounts(ctx, accounts, deletes, &bulk.Options{Wait: true, PollInterval: time.Millisecond}).Err(); err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range deletes {
+		if _, ok := srv.SubAccount(in.SubAccountGuid); ok {
+			t.Fatalf("expected subaccount %s to be deleted", in.SubAccountGuid)
+		}
+	}
+}
+
+func TestCancelOnFirstError(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	accounts := automationtest.Clients(t, srv).Accounts
+
+	var inputs []*btpaccounts.DeleteSubAccountInput
+	for i := 0; i < 10; i++ {
+		inputs = append(inputs, &btpaccounts.DeleteSubAccountInput{SubAccountGuid: fmt.Sprintf("missing-%d", i)})
+	}
+	report := bulk.DeleteSubAccounts(context.Background(), accounts, inputs,
+		&bulk.Options{Parallelism: 1, Mode: bulk.CancelOnFirstError})
+	if len(report.Results) != 10 || report.Results[0].Err == nil || report.Results[0].Err == bulk.ErrSkipped {
+		t.Fatalf("expected the first item to fail, got %+v", report.Results)
+	}
+	for _, r := range report.Results[2:] {
+		if r.Err != bulk.ErrSkipped || r.Attempts != 0 {
+			t.Fatalf("expected item %d to be skipped, got %+v", r.Index, r)
+		}
+	}
+	if n := len(srv.Requests()); n > 2 {
+		t.Fatalf("expected the batch to stop sending, got %d requests", n)
+	}
+}
+
+func TestCallWithoutResponse(t *testing.T) {
+	// the mock fails the calls it is not given a function for without any output
+	accounts := &btpaccountsmock.AccountsAPI{}
+	ctx := context.Background()
+	opts := &bulk.Options{Retries: 1, RetryDelay: time.Millisecond}
+
+	reports := map[string]*bulk.Report{
+		"create": bulk.CreateSubAccounts(ctx, accounts, []*btpaccounts.CreateSubAccountInput{{Subdomain: "a"}}, opts),
+		"update": bulk.UpdateSubAccounts(ctx, accounts, []*btpaccounts.UpdateSubAccountInput{{SubAccountGuid: "a"}}, opts),
+		"delete": bulk.DeleteSubAccounts(ctx, accounts, []*btpaccounts.DeleteSubAccountInput{{SubAccountGuid: "a"}}, opts),
+	}
+	for name, report := range reports {
+		// a call without a response is retried as a transient failure
+		if r := report.Results[0]; r.Err == nil || r.Attempts != 2 {
+			t.Fatalf("expected the %s to fail after 2 attempts, got %+v", name, r)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	accounts := automationtest.Clients(t, srv).Accounts
+
+	var inputs []*btpaccounts.UpdateSubAccountInput
+	for i := 0; i < 5; i++ {
+		sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: fmt.Sprintf("rate-%d", i), DisplayName: "rate"})
+		inputs = append(inputs, &btpaccounts.UpdateSubAccountInput{SubAccountGuid: sa.Guid, Description: "limited"})
+	}
+	start := time.Now()
+	if err := bulk.UpdateSubAccounts(context.Background(), accounts, inputs,
+		&bulk.Options{Parallelism: 5, Rate: 100}).Err(); err != nil {
+		t.Fatal(err)
+	}
+	// the first call starts at once, the next ones 10ms apart
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected the calls to be spaced, took %v", elapsed)
+	}
+}
@@ -0,0 +1,177 @@
+// Package automation holds what the packages automating a global account across services share:
+// the clients they run with and the waiting for asynchronous operations to complete.
+package automation
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/oauth2"
+	"github.com/nnicora/sap-sdk-go/sap/service"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Clients are the service clients automation runs with. The accounts and entitlements APIs act on
+// the whole global account, while the provisioning, SaaS and Service Manager APIs are scoped to a
+// subaccount: their clients are returned by SubAccount.
+type Clients struct {
+	Accounts     btpaccounts.AccountsAPI
+	Entitlements btpentitlements.EntitlementsAPI
+
+	// Returns the clients of the APIs scoped to a subaccount.
+	SubAccount func(ctx context.Context, subaccountGuid string) (*SubAccountClients, error)
+}
+
+// SubAccountClients are the clients of the APIs scoped to a subaccount.
+type SubAccountClients struct {
+	Provisioning      btpprovisioning.ProvisioningAPI
+	SaaS              btpsaasmanager.SaaSProvisioningAPI
+	ServiceManagement btpmanagment.ServiceManagementAPI
+}
+
+// SubAccountSessions returns the session to call the APIs scoped to a subaccount with.
+type SubAccountSessions func(ctx context.Context, subaccountGuid string) (service.RequesterConfig, error)
+
+// NewClients creates the clients of the global account from its session, and the clients of every
+// subaccount from the session returned by sessions; those are created once per subaccount, unless
+// asked for with a ReadOnly context.
+func NewClients(global service.RequesterConfig, sessions SubAccountSessions) *Clients {
+	var mu sync.Mutex
+	scoped := make(map[string]*SubAccountClients)
+
+	return &Clients{
+		Accounts:     btpaccounts.New(global),
+		Entitlements: btpentitlements.New(global),
+		SubAccount: func(ctx context.Context, subaccountGuid string) (*SubAccountClients, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if c, ok := scoped[subaccountGuid]; ok {
+				return c, nil
+			}
+			sess, err := sessions(ctx, subaccountGuid)
+			if err != nil {
+				return nil, fmt.Errorf("no session for subaccount %s; %v", subaccountGuid, err)
+			}
+			c := &SubAccountClients{
+				Provisioning:      btpprovisioning.New(sess),
+				SaaS:              btpsaasmanager.New(sess),
+				ServiceManagement: btpmanagment.New(sess),
+			}
+			if !isReadOnly(ctx) {
+				// the ones of a ReadOnly context may lack the Service Manager binding it did not create
+				scoped[subaccountGuid] = c
+			}
+			return c, nil
+		},
+	}
+}
+
+// SubAccountConfigs returns the configuration of the APIs scoped to a subaccount, e.g. built from a
+// service key created in it; every endpoint is called with its own OAuth2 configuration, or the
+// default one.
+type SubAccountConfigs func(ctx context.Context, subaccountGuid string) (*sap.Config, error)
+
+// StaticConfigs returns cfg for every subaccount, for credentials valid across the subaccounts.
+func StaticConfigs(cfg *sap.Config) SubAccountConfigs {
+	return func(context.Context, string) (*sap.Config, error) {
+		return cfg, nil
+	}
+}
+
+// BindingOptions tune the sessions authenticated with the Service Manager binding of the subaccounts.
+type BindingOptions struct {
+	// Creates the Service Manager binding of a subaccount which has none. The binding is a credential
+	// of the subaccount, which is left in place once created; without it, such a subaccount keeps the
+	// Service Manager endpoint of its configuration, and its Service Manager calls fail when it has none.
+	CreateMissing bool
+}
+
+type readOnlyKey struct{}
+
+// ReadOnly returns a context with which the sessions of ServiceManagementBindingSessions do not
+// create the Service Manager binding of a subaccount, whatever their BindingOptions, for the callers
+// which do not call the Service Manager API.
+func ReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+func isReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// ServiceManagementBindingSessions returns sessions with the configuration configs returns for each
+// subaccount, whose Service Manager endpoint is the one of the Service Manager binding of the
+// subaccount, authenticated with the credentials of the binding. The other endpoints keep their own
+// OAuth2 configuration: the binding is only valid for the Service Manager API.
+func ServiceManagementBindingSessions(configs SubAccountConfigs, accounts btpaccounts.AccountsAPI,
+	opts *BindingOptions) SubAccountSessions {
+	if opts == nil {
+		opts = &BindingOptions{}
+	}
+	return func(ctx context.Context, subaccountGuid string) (service.RequesterConfig, error) {
+		cfg, err := configs(ctx, subaccountGuid)
+		if err != nil {
+			return nil, err
+		}
+		binding, err := serviceManagementBinding(ctx, accounts, subaccountGuid,
+			opts.CreateMissing && !isReadOnly(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints := make(map[string]*sap.EndpointConfig, len(cfg.Endpoints)+1)
+		for id, e := range cfg.Endpoints {
+			endpoints[id] = e
+		}
+		if binding != nil {
+			auth := &oauth2.Config{
+				GrantType:    "client_credentials",
+				ClientID:     binding.ClientId,
+				ClientSecret: binding.ClientSecret,
+				TokenURL:     strings.TrimSuffix(binding.Url, "/") + "/oauth/token",
+			}
+			if cfg.DefaultOAuth2 != nil {
+				auth.Timeout = cfg.DefaultOAuth2.Timeout
+			}
+			endpoints[btpmanagment.EndpointsID] = &sap.EndpointConfig{Host: binding.SMUrl, OAuth2: auth}
+		}
+		return session.BuildFromConfig(&sap.Config{
+			Endpoints:     endpoints,
+			MaxRetries:    cfg.MaxRetries,
+			DefaultOAuth2: cfg.DefaultOAuth2,
+			DryRun:        cfg.DryRun,
+		})
+	}
+}
+
+// Returns the Service Manager binding of the subaccount, creating it when it has none and create is
+// set; nil when it has none.
+func serviceManagementBinding(ctx context.Context, accounts btpaccounts.AccountsAPI, subaccountGuid string,
+	create bool) (*btpaccounts.ServiceManagementBinding, error) {
+	out, err := accounts.GetSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.GetServiceManagementBindingInput{SubAccountGuid: subaccountGuid})
+	if err == nil {
+		return &out.ServiceManagementBinding, nil
+	}
+	if out == nil || out.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+	if !create {
+		return nil, nil
+	}
+	created, err := accounts.CreateSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.CreateServiceManagementBindingInput{SubAccountGuid: subaccountGuid})
+	if err != nil {
+		return nil, err
+	}
+	return &created.ServiceManagementBinding, nil
+}
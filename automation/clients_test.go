@@ -0,0 +1,109 @@
+package automation_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestServiceManagementBindingSessions(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dev", DisplayName: "dev"})
+	sess := automationtest.Session(t, srv)
+	accounts := btpaccounts.New(sess)
+	configs := func(_ context.Context, guid string) (*sap.Config, error) { return srv.SubAccountConfig(guid), nil }
+	ctx := context.Background()
+
+	// without a binding, the APIs scoped to the subaccount but Service Manager are called with its config
+	scoped, err := automation.NewClients(sess, automation.ServiceManagementBindingSessions(configs, accounts, nil)).
+		SubAccount(ctx, sa.Guid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.Provisioning.GetEnvironmentInstances(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.ServiceManagement.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{}); err == nil {
+		t.Fatal("expected the Service Manager call to fail without a binding")
+	}
+	out, err := accounts.GetSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.GetServiceManagementBindingInput{SubAccountGuid: sa.Guid})
+	if err == nil || out.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected no binding to be created, got %v", err)
+	}
+
+	// a read-only context creates no binding, nor keeps the clients lacking it
+	clients := automation.NewClients(sess, automation.ServiceManagementBindingSessions(configs, accounts,
+		&automation.BindingOptions{CreateMissing: true}))
+	if _, err := clients.SubAccount(automation.ReadOnly(ctx), sa.Guid); err != nil {
+		t.Fatal(err)
+	}
+	out, err = accounts.GetSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.GetServiceManagementBindingInput{SubAccountGuid: sa.Guid})
+	if err == nil || out.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected no binding to be created, got %v", err)
+	}
+
+	scoped, err = clients.SubAccount(ctx, sa.Guid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.ServiceManagement.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.SaaS.GetEntitledApplications(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServiceManagementBindingSessionsDryRun(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dev", DisplayName: "dev"})
+	sess := automationtest.Session(t, srv)
+	accounts := btpaccounts.New(sess)
+	ctx := context.Background()
+	if _, err := accounts.CreateSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.CreateServiceManagementBindingInput{SubAccountGuid: sa.Guid}); err != nil {
+		t.Fatal(err)
+	}
+
+	configs := func(_ context.Context, guid string) (*sap.Config, error) {
+		cfg := srv.SubAccountConfig(guid)
+		cfg.DryRun = true
+		return cfg, nil
+	}
+	scoped, err := automation.NewClients(sess, automation.ServiceManagementBindingSessions(configs, accounts, nil)).
+		SubAccount(ctx, sa.Guid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.ResetRequests()
+
+	// both the endpoints of the configuration and the one of the binding print their requests
+	if _, err := scoped.Provisioning.GetEnvironmentInstances(ctx); !isDryRun(err) {
+		t.Fatalf("expected a dry run error, got %v", err)
+	}
+	_, err = scoped.ServiceManagement.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{})
+	if dryRun, ok := err.(*request.DryRunError); !ok || !strings.HasPrefix(dryRun.URL, srv.URL+"/v1/") {
+		t.Fatalf("expected a dry run error of the Service Manager API, got %v", err)
+	}
+	if requests := srv.Requests(); len(requests) != 0 {
+		t.Fatalf("expected the dry run not to reach the service, got %+v", requests)
+	}
+}
+
+func isDryRun(err error) bool {
+	_, ok := err.(*request.DryRunError)
+	return ok
+}
@@ -0,0 +1,339 @@
+package entitlements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Declaration is the entitlements a subaccount or a directory should have, e.g. as read from a file
+// by ReadDeclaration.
+type Declaration struct {
+	// The subdomain of the subaccount, or the path of the directory, e.g. "Teams/Sales"; one of them.
+	SubAccount string `json:"subaccount,omitempty"`
+	Directory  string `json:"directory,omitempty"`
+
+	Entitlements []reconcile.Entitlement `json:"entitlements"`
+}
+
+// ReadDeclaration reads a declaration encoded in JSON.
+func ReadDeclaration(r io.Reader) (*Declaration, error) {
+	var d Declaration
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&d); err != nil {
+		return nil, fmt.Errorf("invalid declaration; %v", err)
+	}
+	if (d.SubAccount == "") == (d.Directory == "") {
+		return nil, fmt.Errorf("a declaration is either of a subaccount or of a directory")
+	}
+	seen := make(map[string]bool)
+	for _, e := range d.Entitlements {
+		key := e.Service + "/" + e.Plan
+		if e.Service == "" || e.Plan == "" {
+			return nil, fmt.Errorf("the entitlements need a service and a plan")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("plan %s is declared twice", key)
+		}
+		seen[key] = true
+	}
+	return &d, nil
+}
+
+// DriftKind is the kind of a difference between the declared and the assigned entitlements.
+type DriftKind string
+
+const (
+	// DriftMissing is a plan declared but not assigned.
+	DriftMissing DriftKind = "MISSING"
+
+	// DriftExtra is a plan assigned but not declared. The plans assigned automatically are not extra.
+	DriftExtra DriftKind = "EXTRA"
+
+	// DriftAmount is a plan assigned with another amount than the declared one.
+	DriftAmount DriftKind = "AMOUNT"
+
+	// DriftEnable is a plan without a numeric quota enabled while declared disabled.
+	DriftEnable DriftKind = "ENABLE"
+
+	// DriftAutoAssign is a plan of a directory whose autoAssign differs.
+	DriftAutoAssign DriftKind = "AUTO_ASSIGN"
+
+	// DriftAutoDistributeAmount is a plan of a directory whose autoDistributeAmount differs.
+	DriftAutoDistributeAmount DriftKind = "AUTO_DISTRIBUTE_AMOUNT"
+
+	// DriftDistribute is a plan of a directory declared distributed, which some of its subaccounts
+	// are not assigned.
+	DriftDistribute DriftKind = "DISTRIBUTE"
+)
+
+// Difference is a difference between the declared and the assigned entitlement of a plan.
+type Difference struct {
+	Kind    DriftKind `json:"kind"`
+	Service string    `json:"service"`
+	Plan    string    `json:"plan"`
+
+	// Whether the plan is assigned with an amount rather than enabled.
+	QuotaBased bool `json:"quotaBased"`
+
+	// The declared entitlement, nil for DriftExtra, and the assigned one, nil for DriftMissing.
+	Declared *reconcile.Entitlement `json:"declared,omitempty"`
+	Actual   *reconcile.Entitlement `json:"actual,omitempty"`
+
+	// The subdomains of the subaccounts the plan is not distributed to, for DriftDistribute.
+	SubAccounts []string `json:"subaccounts,omitempty"`
+}
+
+func (d Difference) String() string {
+	key := d.Service + "/" + d.Plan
+	switch d.Kind {
+	case DriftMissing:
+		return fmt.Sprintf("missing %s: %s", key, describe(d.Declared, d.QuotaBased))
+	case DriftExtra:
+		return fmt.Sprintf("extra %s: %s", key, describe(d.Actual, d.QuotaBased))
+	case DriftAmount:
+		return fmt.Sprintf("amount of %s: declared %d, assigned %d", key, d.Declared.Amount, d.Actual.Amount)
+	case DriftEnable:
+		return fmt.Sprintf("enable of %s: declared %t, assigned %t", key, d.Declared.Enable, d.Actual.Enable)
+	case DriftAutoAssign:
+		return fmt.Sprintf("autoAssign of %s: declared %t, assigned %t", key, d.Declared.AutoAssign, d.Actual.AutoAssign)
+	case DriftAutoDistributeAmount:
+		return fmt.Sprintf("autoDistributeAmount of %s: declared %d, assigned %d", key,
+			d.Declared.AutoDistributeAmount, d.Actual.AutoDistributeAmount)
+	case DriftDistribute:
+		return fmt.Sprintf("distribution of %s: not assigned to %s", key, strings.Join(d.SubAccounts, ", "))
+	}
+	return fmt.Sprintf("%s %s", d.Kind, key)
+}
+
+func describe(e *reconcile.Entitlement, quotaBased bool) string {
+	if quotaBased {
+		return fmt.Sprintf("amount %d", e.Amount)
+	}
+	return "enabled"
+}
+
+// Drift is the differences between the entitlements declared for a subaccount or a directory and the
+// ones assigned to it.
+type Drift struct {
+	EntityType EntityType `json:"entityType"`
+	EntityId   string     `json:"entityId"`
+
+	// The subdomain of the subaccount, or the path of the directory.
+	Name string `json:"name"`
+
+	// The differences, by plan.
+	Differences []Difference `json:"differences"`
+}
+
+// Detect compares the entitlements declared with the ones assigned to the subaccount or directory.
+func Detect(ctx context.Context, c *automation.Clients, d *Declaration) (*Drift, error) {
+	state, err := reconcile.Read(ctx, c, &reconcile.Model{})
+	if err != nil {
+		return nil, err
+	}
+	drift := &Drift{Differences: []Difference{}}
+	input := &btpentitlements.GetAssignmentsInput{IncludeAutoManagedPlans: true}
+	var children map[string]string
+	if d.SubAccount != "" {
+		sa, ok := state.SubAccounts[d.SubAccount]
+		if !ok {
+			return nil, fmt.Errorf("subaccount %s does not exist", d.SubAccount)
+		}
+		drift.EntityType, drift.EntityId, drift.Name = EntitySubAccount, sa.Guid, sa.Subdomain
+		input.SubAccountGuid = sa.Guid
+	} else {
+		dir, ok := state.Directories[d.Directory]
+		if !ok {
+			return nil, fmt.Errorf("directory %s does not exist", d.Directory)
+		}
+		if !hasString(dir.DirectoryFeatures, "ENTITLEMENTS") {
+			return nil, fmt.Errorf("directory %s does not manage entitlements", d.Directory)
+		}
+		drift.EntityType, drift.EntityId, drift.Name = EntityDirectory, dir.Guid, dir.Path
+		input.DirectoryGuid = dir.Guid
+		children = make(map[string]string)
+		for _, sa := range state.SubAccounts {
+			if sa.ParentPath == dir.Path {
+				children[sa.Guid] = sa.Subdomain
+			}
+		}
+	}
+
+	out, err := c.Entitlements.GetAssignments(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the entitlements of %s; %v", drift.Name, err)
+	}
+	actual := make(map[string]*reconcile.Assignment)
+	distributed := make(map[string]map[string]bool)
+	for _, svc := range out.AssignedServices {
+		for _, sp := range svc.ServicePlans {
+			key := svc.Name + "/" + sp.Name
+			for _, info := range sp.AssignmentInfo {
+				if _, ok := children[info.EntityId]; ok {
+					if distributed[key] == nil {
+						distributed[key] = make(map[string]bool)
+					}
+					distributed[key][info.EntityId] = true
+				}
+				if info.EntityId != drift.EntityId {
+					continue
+				}
+				a := &reconcile.Assignment{
+					Entitlement: reconcile.Entitlement{
+						Service:              svc.Name,
+						Plan:                 sp.Name,
+						AutoAssign:           info.AutoAssign,
+						AutoDistributeAmount: uint(info.AutoDistributeAmount),
+					},
+					QuotaBased:   sp.Category.QuotaBased(sp.Unlimited),
+					AutoAssigned: info.AutoAssigned,
+				}
+				if a.QuotaBased {
+					a.Amount = uint(info.Amount)
+				} else {
+					a.Enable = true
+				}
+				actual[key] = a
+			}
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, e := range d.Entitlements {
+		e := e
+		key := e.Service + "/" + e.Plan
+		declared[key] = true
+		a, ok := actual[key]
+		if !ok {
+			if e.Amount > 0 || e.Enable {
+				drift.Differences = append(drift.Differences, Difference{Kind: DriftMissing, Service: e.Service,
+					Plan: e.Plan, QuotaBased: e.Amount > 0, Declared: &e})
+			}
+			continue
+		}
+		diff := Difference{Service: e.Service, Plan: e.Plan, QuotaBased: a.QuotaBased, Declared: &e, Actual: &a.Entitlement}
+		add := func(kind DriftKind) {
+			diff.Kind = kind
+			drift.Differences = append(drift.Differences, diff)
+		}
+		if a.QuotaBased && a.Amount != e.Amount {
+			add(DriftAmount)
+		}
+		if !a.QuotaBased && a.Enable != e.Enable {
+			add(DriftEnable)
+		}
+		if drift.EntityType != EntityDirectory {
+			continue
+		}
+		if a.AutoAssign != e.AutoAssign {
+			add(DriftAutoAssign)
+		}
+		if a.QuotaBased && a.AutoDistributeAmount != e.AutoDistributeAmount {
+			add(DriftAutoDistributeAmount)
+		}
+		if e.Distribute {
+			var missing []string
+			for guid, subdomain := range children {
+				if !distributed[key][guid] {
+					missing = append(missing, subdomain)
+				}
+			}
+			if len(missing) > 0 {
+				sort.Strings(missing)
+				diff.SubAccounts = missing
+				add(DriftDistribute)
+				diff.SubAccounts = nil
+			}
+		}
+	}
+	for key, a := range actual {
+		if !declared[key] && !a.AutoAssigned {
+			drift.Differences = append(drift.Differences, Difference{Kind: DriftExtra, Service: a.Service, Plan: a.Plan,
+				QuotaBased: a.QuotaBased, Actual: &a.Entitlement})
+		}
+	}
+	sort.SliceStable(drift.Differences, func(i, j int) bool {
+		di, dj := drift.Differences[i], drift.Differences[j]
+		if di.Service+"/"+di.Plan != dj.Service+"/"+dj.Plan {
+			return di.Service+"/"+di.Plan < dj.Service+"/"+dj.Plan
+		}
+		return di.Kind < dj.Kind
+	})
+	return drift, nil
+}
+
+// InSync reports whether the assigned entitlements are the declared ones.
+func (d *Drift) InSync() bool {
+	return len(d.Differences) == 0
+}
+
+// WriteText writes the drift as text, one difference per line.
+func (d *Drift) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s %s: %d differences\n", strings.ToLower(string(d.EntityType)), d.Name,
+		len(d.Differences)); err != nil {
+		return err
+	}
+	for _, diff := range d.Differences {
+		if _, err := fmt.Fprintf(w, "\t%s\n", diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the drift as indented JSON.
+func (d *Drift) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// Assignments returns the assignments fixing the drift: the declared entitlement of the plans missing
+// or differing, and the removal of the extra ones.
+func (d *Drift) Assignments() []Assignment {
+	var assignments []Assignment
+	seen := make(map[string]bool)
+	for _, diff := range d.Differences {
+		key := diff.Service + "/" + diff.Plan
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		a := Assignment{EntityType: d.EntityType, EntityId: d.EntityId}
+		if diff.Kind == DriftExtra {
+			a.Service, a.Plan = diff.Service, diff.Plan
+		} else {
+			a.Entitlement = *diff.Declared
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments
+}
+
+// Inputs returns the request fixing the drift of a subaccount, or the one fixing the drift of a
+// directory; the other is nil, and so are both when there is no drift. The assignments can be checked
+// by a Planner first.
+func (d *Drift) Inputs() (*btpentitlements.UpdateSubAccountServicePlanInput, *btpentitlements.UpdateDirectoryEntitlementsInput) {
+	assignments := d.Assignments()
+	quota := make([]bool, 0, len(assignments))
+	byKey := make(map[string]bool)
+	for _, diff := range d.Differences {
+		byKey[diff.Service+"/"+diff.Plan] = diff.QuotaBased
+	}
+	for _, a := range assignments {
+		quota = append(quota, byKey[a.key()])
+	}
+	sub, dirs := inputs(assignments, quota)
+	if len(dirs) == 0 {
+		return sub, nil
+	}
+	return sub, dirs[0]
+}
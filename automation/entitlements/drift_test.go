@@ -0,0 +1,136 @@
+package entitlements_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/entitlements"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestDrift(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 20})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "hana", Plan: "hdi", Category: "SERVICE", Amount: 5})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	teams := srv.AddDirectory(btpfake.Directory{DisplayName: "Teams", DirectoryFeatures: []string{"DEFAULT", "ENTITLEMENTS"}})
+	a := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "a", DisplayName: "a", ParentGuid: teams.Guid})
+	x := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "x", DisplayName: "x"})
+	c := automationtest.Clients(t, srv)
+	ctx := context.Background()
+
+	p, err := entitlements.NewPlanner(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Check(
+		assignment(entitlements.EntityDirectory, teams.Guid, "objectstore", "standard",
+			reconcile.Entitlement{Amount: 5, AutoAssign: true, AutoDistributeAmount: 1}),
+		assignment(entitlements.EntitySubAccount, x.Guid, "objectstore", "standard", reconcile.Entitlement{Amount: 2}),
+		assignment(entitlements.EntitySubAccount, x.Guid, "sales-app", "basic", reconcile.Entitlement{Enable: true}),
+	).Apply(ctx, c, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := entitlements.ReadDeclaration(strings.NewReader(`{"subaccount": "x", "entitlements": [
+		{"service": "objectstore", "plan": "standard", "amount": 3},
+		{"service": "hana", "plan": "hdi", "amount": 1}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drift, err := entitlements.Detect(ctx, c, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text bytes.Buffer
+	if err := drift.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	want := "subaccount x: 3 differences\n" +
+		"\tmissing hana/hdi: amount 1\n" +
+		"\tamount of objectstore/standard: declared 3, assigned 2\n" +
+		"\textra sales-app/basic: enabled\n"
+	if text.String() != want {
+		t.Fatalf("unexpected text\n%s", text.String())
+	}
+	fix(t, ctx, c, drift)
+	if drift, err = entitlements.Detect(ctx, c, d); err != nil || !drift.InSync() {
+		t.Fatalf("expected subaccount x to be in sync, got %+v, %v", drift, err)
+	}
+	if amount, _ := srv.Assignment(x.Guid, "objectstore", "standard"); amount != 3 {
+		t.Fatalf("expected 3 objectstore/standard assigned, got %v", amount)
+	}
+
+	d = &entitlements.Declaration{Directory: "Teams", Entitlements: []reconcile.Entitlement{{Service: "objectstore",
+		Plan: "standard", Amount: 5, AutoAssign: true, Distribute: true, AutoDistributeAmount: 2}}}
+	if drift, err = entitlements.Detect(ctx, c, d); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := drift.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded entitlements.Drift
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Name != "Teams" || len(decoded.Differences) != 2 ||
+		decoded.Differences[0].Kind != entitlements.DriftAutoDistributeAmount ||
+		decoded.Differences[1].Kind != entitlements.DriftDistribute || decoded.Differences[1].SubAccounts[0] != "a" {
+		t.Fatalf("unexpected drift %s", buf.String())
+	}
+	fix(t, ctx, c, drift)
+	if drift, err = entitlements.Detect(ctx, c, d); err != nil || !drift.InSync() {
+		t.Fatalf("expected directory Teams to be in sync, got %+v, %v", drift, err)
+	}
+	if amount, _ := srv.Assignment(a.Guid, "objectstore", "standard"); amount != 2 {
+		t.Fatalf("expected 2 objectstore/standard distributed to a, got %v", amount)
+	}
+
+	// a plan without a quota declared disabled is removed
+	d = &entitlements.Declaration{SubAccount: "x", Entitlements: []reconcile.Entitlement{
+		{Service: "objectstore", Plan: "standard", Amount: 3},
+		{Service: "hana", Plan: "hdi", Amount: 1},
+		{Service: "sales-app", Plan: "basic"},
+	}}
+	if err := p.Check(assignment(entitlements.EntitySubAccount, x.Guid, "sales-app", "basic",
+		reconcile.Entitlement{Enable: true})).Apply(ctx, c, 0); err != nil {
+		t.Fatal(err)
+	}
+	if drift, err = entitlements.Detect(ctx, c, d); err != nil {
+		t.Fatal(err)
+	}
+	if len(drift.Differences) != 1 || drift.Differences[0].String() != "enable of sales-app/basic: declared false, assigned true" {
+		t.Fatalf("unexpected drift %+v", drift.Differences)
+	}
+	fix(t, ctx, c, drift)
+	if drift, err = entitlements.Detect(ctx, c, d); err != nil || !drift.InSync() {
+		t.Fatalf("expected subaccount x to be in sync, got %+v, %v", drift, err)
+	}
+
+	if _, err := entitlements.ReadDeclaration(strings.NewReader(`{"subaccount": "x", "directory": "Teams"}`)); err == nil {
+		t.Fatal("expected a declaration of both a subaccount and a directory to be rejected")
+	}
+}
+
+func fix(t *testing.T, ctx context.Context, c *automation.Clients, drift *entitlements.Drift) {
+	sub, dir := drift.Inputs()
+	if sub != nil {
+		if _, err := c.Entitlements.UpdateSubAccountServicePlan(ctx, sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dir != nil {
+		if _, err := c.Entitlements.UpdateDirectoryEntitlements(ctx, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package entitlements
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"time"
+)
+
+// Inputs returns the requests making the assignments checked: the one assigning the plans of the
+// subaccounts, nil when there are none, and one per directory, in the order of the checks.
+func (r *Report) Inputs() (*btpentitlements.UpdateSubAccountServicePlanInput, []*btpentitlements.UpdateDirectoryEntitlementsInput) {
+	assignments := make([]Assignment, 0, len(r.Checks))
+	quotaBased := make([]bool, 0, len(r.Checks))
+	for _, c := range r.Checks {
+		assignments = append(assignments, c.Assignment)
+		quotaBased = append(quotaBased, c.QuotaBased)
+	}
+	return inputs(assignments, quotaBased)
+}
+
+// Apply sends the assignments checked, once they would all be accepted, and waits for the job
+// assigning the plans of the subaccounts to complete. It returns Err, without sending anything, when
+// some would be rejected.
+func (r *Report) Apply(ctx context.Context, c *automation.Clients, pollInterval time.Duration) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	sub, dirs := r.Inputs()
+	for _, in := range dirs {
+		if _, err := c.Entitlements.UpdateDirectoryEntitlements(ctx, in); err != nil {
+			return err
+		}
+	}
+	if sub == nil {
+		return nil
+	}
+	out, err := c.Entitlements.UpdateSubAccountServicePlan(ctx, sub)
+	if err != nil {
+		return err
+	}
+	if out.JobStatusId == nil || *out.JobStatusId == "" {
+		return nil
+	}
+	return automation.WaitForEntitlementsJob(ctx, c.Entitlements, *out.JobStatusId, pollInterval)
+}
+
+// Returns the requests making the assignments, removing the ones with an amount of zero when the plan
+// is quota based, and the ones disabled otherwise.
+func inputs(assignments []Assignment, quotaBased []bool) (*btpentitlements.UpdateSubAccountServicePlanInput,
+	[]*btpentitlements.UpdateDirectoryEntitlementsInput) {
+	var sub *btpentitlements.UpdateSubAccountServicePlanInput
+	var dirs []*btpentitlements.UpdateDirectoryEntitlementsInput
+	byDirectory := make(map[string]*btpentitlements.UpdateDirectoryEntitlementsInput)
+
+	for i, a := range assignments {
+		var amount *uint
+		var enable *bool
+		if quotaBased[i] {
+			amount = new(uint)
+			*amount = a.Amount
+		} else {
+			enable = new(bool)
+			*enable = a.Enable
+		}
+		remove := a.Amount == 0 && !a.Enable
+
+		if a.EntityType == EntityDirectory {
+			in, ok := byDirectory[a.EntityId]
+			if !ok {
+				in = &btpentitlements.UpdateDirectoryEntitlementsInput{DirectoryGuid: a.EntityId}
+				byDirectory[a.EntityId] = in
+				dirs = append(dirs, in)
+			}
+			de := btpentitlements.DirectoryEntitlement{
+				Service:    a.Service,
+				Plan:       a.Plan,
+				Amount:     amount,
+				Enable:     enable,
+				Distribute: a.Distribute && !remove,
+				AutoAssign: a.AutoAssign && !remove,
+			}
+			if a.AutoDistributeAmount > 0 && !remove {
+				de.AutoDistributeAmount = new(uint)
+				*de.AutoDistributeAmount = a.AutoDistributeAmount
+			}
+			in.DirectoryEntitlements = append(in.DirectoryEntitlements, de)
+			continue
+		}
+
+		if sub == nil {
+			sub = &btpentitlements.UpdateSubAccountServicePlanInput{}
+		}
+		info := btpentitlements.AssignmentInfo{Amount: amount, Enable: enable, SubAccountGuid: a.EntityId}
+		merged := false
+		for j := range sub.SubAccountServicePlans {
+			sp := &sub.SubAccountServicePlans[j]
+			if sp.ServiceName == a.Service && sp.ServicePlanName == a.Plan {
+				sp.AssignmentInfo = append(sp.AssignmentInfo, info)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			sub.SubAccountServicePlans = append(sub.SubAccountServicePlans, btpentitlements.SubAccountServicePlan{
+				ServiceName:     a.Service,
+				ServicePlanName: a.Plan,
+				AssignmentInfo:  []btpentitlements.AssignmentInfo{info},
+			})
+		}
+	}
+	return sub, dirs
+}
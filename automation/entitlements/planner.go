@@ -0,0 +1,475 @@
+// Package entitlements checks entitlement assignments against the quota of the global account before
+// they are sent, so that an assignment which would fail is reported at once rather than by the
+// asynchronous job of the entitlements service.
+//
+//	p, err := entitlements.NewPlanner(ctx, clients)
+//	report := p.Check(assignments...)
+//	if err := report.Err(); err != nil {
+//		// the shortfalls, and the rules the assignments break
+//	}
+//
+// It also detects the drift of the entitlements assigned to a subaccount or a directory from the ones
+// declared for it, and returns the assignments fixing it.
+package entitlements
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/types"
+	"strings"
+)
+
+// EntityType is the type of the entity an entitlement is assigned to.
+type EntityType string
+
+const (
+	EntitySubAccount EntityType = "SUBACCOUNT"
+	EntityDirectory  EntityType = "DIRECTORY"
+)
+
+// Assignment is an entitlement to assign to a directory or a subaccount. An amount of zero, for a plan
+// with a numeric quota, or Enable false, for a plan without one, removes the assignment.
+type Assignment struct {
+	EntityType EntityType `json:"entityType"`
+	EntityId   string     `json:"entityId"`
+
+	reconcile.Entitlement
+}
+
+func (a Assignment) key() string {
+	return a.Service + "/" + a.Plan
+}
+
+// Planner holds the quota of a global account, as read by NewPlanner.
+type Planner struct {
+	global string
+
+	// The entities of the global account, by GUID.
+	entities map[string]*entity
+
+	// The plans entitled to the global account, by service/plan.
+	plans map[string]*plan
+}
+
+type entity struct {
+	typ    EntityType
+	name   string
+	parent string
+
+	// Whether the directory manages its entitlements, so that it is the quota source of its subaccounts.
+	managesEntitlements bool
+
+	// The subaccounts directly in the directory.
+	subAccounts []string
+}
+
+type plan struct {
+	service string
+	btpentitlements.ServicePlan
+
+	// The amounts assigned, by entity GUID.
+	assigned map[string]*assigned
+
+	// The amounts left to assign, by quota source: the global account or a directory.
+	remaining map[string]float64
+}
+
+type assigned struct {
+	amount       float64
+	autoAssigned bool
+}
+
+// NewPlanner reads the plans entitled to the global account, with their remaining amounts, and the
+// assignments of its directories and subaccounts.
+func NewPlanner(ctx context.Context, c *automation.Clients) (*Planner, error) {
+	ga, err := c.Accounts.GetGlobalAccount(ctx, &btpaccounts.GetGlobalAccountInput{Expand: true})
+	if err != nil {
+		return nil, err
+	}
+	p := &Planner{global: ga.Guid, entities: make(map[string]*entity), plans: make(map[string]*plan)}
+	p.addSubAccounts(ga.Guid, ga.Subaccounts)
+	p.addDirectories(ga.Guid, ga.Children)
+
+	out, err := c.Entitlements.GetGlobalAccountAssignments(ctx,
+		&btpentitlements.GlobalAccountAssignmentsInput{IncludeAutoManagedPlans: true})
+	if err != nil {
+		return nil, fmt.Errorf("could not read the entitlements of the global account; %v", err)
+	}
+	for _, svc := range out.EntitledServices {
+		for _, sp := range svc.ServicePlans {
+			p.plans[svc.Name+"/"+sp.Name] = &plan{
+				service:     svc.Name,
+				ServicePlan: sp,
+				assigned:    make(map[string]*assigned),
+				remaining:   map[string]float64{ga.Guid: float64(sp.RemainingAmount)},
+			}
+		}
+	}
+	p.addAssignments(out.AssignedServices)
+
+	// the directories hold the quota their subaccounts are assigned from
+	for guid, e := range p.entities {
+		if !e.managesEntitlements {
+			continue
+		}
+		out, err := c.Entitlements.GetAssignments(ctx, &btpentitlements.GetAssignmentsInput{
+			DirectoryGuid:           guid,
+			IncludeAutoManagedPlans: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not read the entitlements of %s; %v", e.name, err)
+		}
+		p.addAssignments(out.AssignedServices)
+	}
+	for _, pl := range p.plans {
+		for guid, a := range pl.assigned {
+			if _, ok := pl.remaining[guid]; !ok && p.entities[guid] != nil && p.entities[guid].managesEntitlements {
+				pl.remaining[guid] = a.amount
+			}
+		}
+	}
+	return p, nil
+}
+
+func (p *Planner) addDirectories(parent string, dirs []btpaccounts.Directory) {
+	for _, d := range dirs {
+		e := &entity{
+			typ:                 EntityDirectory,
+			name:                "directory " + d.DisplayName,
+			parent:              parent,
+			managesEntitlements: hasString(d.DirectoryFeatures, "ENTITLEMENTS"),
+		}
+		for _, sa := range d.SubAccounts {
+			e.subAccounts = append(e.subAccounts, sa.Guid)
+		}
+		p.entities[d.Guid] = e
+		p.addSubAccounts(d.Guid, d.SubAccounts)
+		p.addDirectories(d.Guid, d.Children)
+	}
+}
+
+func (p *Planner) addSubAccounts(parent string, sas []btpaccounts.SubAccount) {
+	for _, sa := range sas {
+		p.entities[sa.Guid] = &entity{typ: EntitySubAccount, name: "subaccount " + sa.Subdomain, parent: parent}
+	}
+}
+
+func (p *Planner) addAssignments(services []btpentitlements.AssignedService) {
+	for _, svc := range services {
+		for _, sp := range svc.ServicePlans {
+			pl, ok := p.plans[svc.Name+"/"+sp.Name]
+			if !ok {
+				continue
+			}
+			for _, info := range sp.AssignmentInfo {
+				pl.assigned[info.EntityId] = &assigned{amount: float64(info.Amount), autoAssigned: info.AutoAssigned}
+				if info.ParentType == string(EntityDirectory) && info.ParentId != "" {
+					pl.remaining[info.ParentId] = float64(info.ParentRemainingAmount)
+				}
+			}
+		}
+	}
+}
+
+// Check checks the assignments, in order, as if the ones before had been made: a later assignment
+// sees the quota taken, or given back, by the earlier ones. Only the assignments without problems
+// are taken into account for the next ones. The planner itself is not changed.
+func (p *Planner) Check(assignments ...Assignment) *Report {
+	plans := make(map[string]*plan, len(p.plans))
+	for key, pl := range p.plans {
+		plans[key] = pl.clone()
+	}
+
+	r := &Report{}
+	for _, a := range assignments {
+		r.Checks = append(r.Checks, p.check(plans, a))
+	}
+	return r
+}
+
+func (p *Planner) check(plans map[string]*plan, a Assignment) Check {
+	c := Check{Assignment: a}
+	problem := func(format string, args ...interface{}) {
+		c.Problems = append(c.Problems, fmt.Sprintf(format, args...))
+	}
+
+	pl, ok := plans[a.key()]
+	if !ok {
+		problem("plan %s of service %s is not entitled to the global account", a.Plan, a.Service)
+		return c
+	}
+	c.Category, c.QuotaBased = pl.Category, pl.quotaBased()
+	for _, se := range pl.SourceEntitlements {
+		c.SourceEntitlements = append(c.SourceEntitlements, se.EntitlementName)
+	}
+	e, ok := p.entities[a.EntityId]
+	if !ok || e.typ != a.EntityType {
+		problem("%s %s does not exist", strings.ToLower(string(a.EntityType)), a.EntityId)
+		return c
+	}
+	if current, ok := pl.assigned[a.EntityId]; ok {
+		c.Current = current.amount
+	}
+
+	remove := a.Amount == 0 && !a.Enable
+	if remove {
+		if current := pl.assigned[a.EntityId]; current != nil && current.autoAssigned {
+			problem("plan %s is assigned automatically to %s and cannot be removed", a.key(), e.name)
+		}
+	}
+
+	// the amount or enable semantics of the category
+	switch {
+	case c.QuotaBased && a.Enable && a.Amount == 0:
+		problem("plan %s has a numeric quota (%s); assign it an amount instead of enabling it", a.key(), pl.Category)
+	case !c.QuotaBased && a.Amount > 0:
+		problem("plan %s has no numeric quota (%s); enable it instead of assigning an amount", a.key(), pl.Category)
+	}
+	if pl.Category == types.ServiceCategoryElasticLimited && a.EntityType == EntitySubAccount && !remove {
+		for guid := range pl.assigned {
+			if guid != a.EntityId && p.entities[guid] != nil && p.entities[guid].typ == EntitySubAccount {
+				problem("plan %s can be enabled for only one subaccount, and is enabled for %s", a.key(), p.entities[guid].name)
+				break
+			}
+		}
+	}
+
+	// the rules of the distribution to subaccounts
+	if a.EntityType == EntitySubAccount {
+		if a.Distribute || a.AutoAssign || a.AutoDistributeAmount > 0 {
+			problem("only directories distribute plans to their subaccounts")
+		}
+		if max := pl.MaxAllowedSubAccountQuota; max > 0 && a.Amount > uint(max) {
+			problem("amount %d exceeds the maximum allowed subaccount quota %d of plan %s", a.Amount, max, a.key())
+		}
+	} else {
+		if !e.managesEntitlements {
+			problem("%s does not manage entitlements; enable its ENTITLEMENTS feature first", e.name)
+		}
+		if a.Distribute && !a.AutoAssign {
+			problem("distribute requires autoAssign")
+		}
+		if a.AutoDistributeAmount > 0 {
+			switch {
+			case !c.QuotaBased:
+				problem("autoDistributeAmount only applies to plans with a numeric quota")
+			case !a.AutoAssign && !a.Distribute:
+				problem("autoDistributeAmount requires autoAssign or distribute")
+			case a.AutoDistributeAmount > a.Amount:
+				problem("autoDistributeAmount %d exceeds the amount %d assigned to %s", a.AutoDistributeAmount, a.Amount, e.name)
+			}
+			if max := pl.MaxAllowedSubAccountQuota; max > 0 && a.AutoDistributeAmount > uint(max) {
+				problem("autoDistributeAmount %d exceeds the maximum allowed subaccount quota %d of plan %s",
+					a.AutoDistributeAmount, max, a.key())
+			}
+		}
+	}
+
+	// the quota
+	source := p.source(pl, a.EntityId)
+	c.Source = p.name(source)
+	if c.QuotaBased {
+		c.Available = pl.remaining[source] + c.Current
+		if requested := float64(a.Amount); requested > c.Available {
+			c.Shortfall = requested - c.Available
+			problem("insufficient quota for plan %s in %s; requested %v, available %v", a.key(), c.Source, requested, c.Available)
+		}
+		if a.EntityType == EntityDirectory && a.Distribute && a.AutoDistributeAmount > 0 && len(c.Problems) == 0 {
+			var children []string
+			for _, guid := range e.subAccounts {
+				if _, ok := pl.assigned[guid]; !ok {
+					children = append(children, guid)
+				}
+			}
+			needed := float64(len(children)) * float64(a.AutoDistributeAmount)
+			if left := pl.directoryRemaining(a.EntityId, float64(a.Amount), c.Current); needed > left {
+				c.Shortfall = needed - left
+				problem("insufficient quota to distribute plan %s to the %d subaccounts of %s; needed %v, left %v",
+					a.key(), len(children), e.name, needed, left)
+			}
+		}
+	}
+
+	if len(c.Problems) == 0 {
+		p.apply(pl, a, source, e)
+	}
+	return c
+}
+
+// Takes the assignment into account in the remaining amounts of the plan.
+func (p *Planner) apply(pl *plan, a Assignment, source string, e *entity) {
+	current := pl.assigned[a.EntityId]
+	amount := float64(a.Amount)
+	if !pl.quotaBased() {
+		amount = 0
+	}
+	if a.Amount == 0 && !a.Enable {
+		if current != nil {
+			pl.remaining[source] += current.amount
+			delete(pl.assigned, a.EntityId)
+			delete(pl.remaining, a.EntityId)
+		}
+		return
+	}
+
+	var before float64
+	if current != nil {
+		before = current.amount
+	}
+	pl.remaining[source] -= amount - before
+	if a.EntityType == EntityDirectory {
+		pl.remaining[a.EntityId] = pl.directoryRemaining(a.EntityId, amount, before)
+	}
+	pl.assigned[a.EntityId] = &assigned{amount: amount}
+
+	if a.EntityType == EntityDirectory && a.Distribute {
+		for _, guid := range e.subAccounts {
+			if _, ok := pl.assigned[guid]; ok {
+				continue
+			}
+			distributed := float64(a.AutoDistributeAmount)
+			if !pl.quotaBased() {
+				distributed = 0
+			}
+			pl.assigned[guid] = &assigned{amount: distributed}
+			pl.remaining[a.EntityId] -= distributed
+		}
+	}
+}
+
+// Returns the directory, or the global account, the quota of the plan is assigned to the entity from:
+// the nearest parent directory which manages entitlements and holds an assignment of the plan.
+func (p *Planner) source(pl *plan, guid string) string {
+	e := p.entities[guid]
+	for parent := e.parent; parent != p.global; {
+		d, ok := p.entities[parent]
+		if !ok {
+			break
+		}
+		if _, assigned := pl.assigned[parent]; assigned && d.managesEntitlements {
+			return parent
+		}
+		parent = d.parent
+	}
+	return p.global
+}
+
+func (p *Planner) name(guid string) string {
+	if e, ok := p.entities[guid]; ok {
+		return e.name
+	}
+	return "the global account"
+}
+
+func (pl *plan) clone() *plan {
+	c := &plan{service: pl.service, ServicePlan: pl.ServicePlan,
+		assigned: make(map[string]*assigned, len(pl.assigned)), remaining: make(map[string]float64, len(pl.remaining))}
+	for guid, a := range pl.assigned {
+		a := *a
+		c.assigned[guid] = &a
+	}
+	for guid, amount := range pl.remaining {
+		c.remaining[guid] = amount
+	}
+	return c
+}
+
+func (pl *plan) quotaBased() bool {
+	return pl.Category.QuotaBased(pl.Unlimited)
+}
+
+// Returns the amount the directory has left for its subaccounts once assigned amount instead of before.
+func (pl *plan) directoryRemaining(guid string, amount, before float64) float64 {
+	remaining, ok := pl.remaining[guid]
+	if !ok {
+		return amount
+	}
+	return remaining + amount - before
+}
+
+// Report is the result of a check, one Check per assignment.
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Check is the result of the check of an assignment.
+type Check struct {
+	Assignment
+
+	Category types.ServiceCategory `json:"category,omitempty"`
+
+	// Whether the plan is assigned with an amount rather than enabled.
+	QuotaBased bool `json:"quotaBased"`
+
+	// The directory, or the global account, the quota is assigned from.
+	Source string `json:"source,omitempty"`
+
+	// The amount assigned to the entity before the assignment.
+	Current float64 `json:"current"`
+
+	// The amount the entity can be assigned, including its current amount.
+	Available float64 `json:"available"`
+
+	// The amount missing for the assignment to succeed; zero when there is enough quota.
+	Shortfall float64 `json:"shortfall,omitempty"`
+
+	// The products the plan is entitled by, which more quota is bought for.
+	SourceEntitlements []string `json:"sourceEntitlements,omitempty"`
+
+	// Why the entitlements service would reject the assignment; empty when it would accept it.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// OK reports whether every assignment would be accepted.
+func (r *Report) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks of the assignments which would be rejected.
+func (r *Report) Failed() []Check {
+	var failed []Check
+	for _, c := range r.Checks {
+		if len(c.Problems) > 0 {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Shortfalls returns the checks of the assignments which lack quota.
+func (r *Report) Shortfalls() []Check {
+	var shortfalls []Check
+	for _, c := range r.Checks {
+		if c.Shortfall > 0 {
+			shortfalls = append(shortfalls, c)
+		}
+	}
+	return shortfalls
+}
+
+// Err returns an error listing the assignments which would be rejected, or nil when there are none.
+func (r *Report) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, c := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s for %s %s: %s", c.key(), strings.ToLower(string(c.EntityType)), c.EntityId,
+			strings.Join(c.Problems, ", ")))
+	}
+	return fmt.Errorf("%d of %d assignments would be rejected; %s", len(failed), len(r.Checks), strings.Join(msgs, "; "))
+}
+
+func hasString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package entitlements_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/entitlements"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func assignment(typ entitlements.EntityType, guid, service, plan string, e reconcile.Entitlement) entitlements.Assignment {
+	e.Service, e.Plan = service, plan
+	return entitlements.Assignment{EntityType: typ, EntityId: guid, Entitlement: e}
+}
+
+func TestPlanner(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 10,
+		MaxAllowedSubaccountQuota: 4})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	teams := srv.AddDirectory(btpfake.Directory{DisplayName: "Teams", DirectoryFeatures: []string{"DEFAULT", "ENTITLEMENTS"}})
+	legacy := srv.AddDirectory(btpfake.Directory{DisplayName: "Legacy"})
+	a := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "a", DisplayName: "a", ParentGuid: teams.Guid})
+	b := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "b", DisplayName: "b"})
+	c := automationtest.Clients(t, srv)
+	ctx := context.Background()
+
+	p, err := entitlements.NewPlanner(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := p.Check(
+		assignment(entitlements.EntityDirectory, teams.Guid, "objectstore", "standard",
+			reconcile.Entitlement{Amount: 5, AutoAssign: true, Distribute: true, AutoDistributeAmount: 2}),
+		assignment(entitlements.EntitySubAccount, b.Guid, "objectstore", "standard", reconcile.Entitlement{Amount: 4}),
+		assignment(entitlements.EntitySubAccount, b.Guid, "sales-app", "basic", reconcile.Entitlement{Enable: true}),
+	)
+	if err := report.Apply(ctx, c, 0); err != nil {
+		t.Fatal(err)
+	}
+	for guid, want := range map[string]float64{teams.Guid: 5, a.Guid: 2, b.Guid: 4} {
+		if amount, ok := srv.Assignment(guid, "objectstore", "standard"); !ok || amount != want {
+			t.Fatalf("expected %v objectstore/standard assigned to %s, got %v", want, guid, amount)
+		}
+	}
+	if _, ok := srv.Assignment(b.Guid, "sales-app", "basic"); !ok {
+		t.Fatal("expected sales-app/basic to be assigned")
+	}
+
+	if p, err = entitlements.NewPlanner(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	report = p.Check(
+		// 1 left in the global account, plus the 4 of b, and at most 4 per subaccount
+		assignment(entitlements.EntitySubAccount, b.Guid, "objectstore", "standard", reconcile.Entitlement{Amount: 6}),
+		// 3 left in the directory, plus the 2 of a
+		assignment(entitlements.EntitySubAccount, a.Guid, "objectstore", "standard", reconcile.Entitlement{Amount: 4}),
+		// 1 left in the directory once a has 4
+		assignment(entitlements.EntitySubAccount, a.Guid, "objectstore", "standard", reconcile.Entitlement{Amount: 6}),
+		assignment(entitlements.EntitySubAccount, a.Guid, "sales-app", "basic", reconcile.Entitlement{Amount: 1}),
+		assignment(entitlements.EntitySubAccount, a.Guid, "sales-app", "basic", reconcile.Entitlement{Enable: true, AutoAssign: true}),
+		assignment(entitlements.EntityDirectory, legacy.Guid, "objectstore", "standard", reconcile.Entitlement{Amount: 1, Distribute: true}),
+		assignment(entitlements.EntitySubAccount, a.Guid, "unknown", "plan", reconcile.Entitlement{Enable: true}),
+	)
+	if report.OK() || len(report.Failed()) != 6 {
+		t.Fatalf("expected 6 assignments to be rejected, got %+v", report.Failed())
+	}
+	checks := report.Checks
+	if checks[0].Source != "the global account" || checks[0].Available != 5 || checks[0].Shortfall != 1 ||
+		len(checks[0].Problems) != 2 || !strings.Contains(checks[0].Problems[0], "maximum allowed subaccount quota") {
+		t.Fatalf("unexpected check %+v", checks[0])
+	}
+	if checks[1].Source != "directory Teams" || checks[1].Available != 5 || len(checks[1].Problems) != 0 {
+		t.Fatalf("unexpected check %+v", checks[1])
+	}
+	if checks[2].Current != 4 || checks[2].Available != 5 || checks[2].Shortfall != 1 {
+		t.Fatalf("unexpected check %+v", checks[2])
+	}
+	if len(report.Shortfalls()) != 2 {
+		t.Fatalf("expected 2 shortfalls, got %+v", report.Shortfalls())
+	}
+	for i, want := range map[int]string{
+		3: "enable it instead of assigning an amount",
+		4: "only directories distribute",
+		5: "does not manage entitlements",
+		6: "not entitled to the global account",
+	} {
+		if !strings.Contains(strings.Join(checks[i].Problems, ", "), want) {
+			t.Fatalf("expected check %d to report %q, got %v", i, want, checks[i].Problems)
+		}
+	}
+	if !strings.Contains(strings.Join(checks[5].Problems, ", "), "distribute requires autoAssign") {
+		t.Fatalf("expected the distribution without autoAssign to be reported, got %v", checks[5].Problems)
+	}
+
+	// nothing is sent when an assignment would be rejected
+	if err := report.Apply(ctx, c, 0); err == nil || !strings.Contains(err.Error(), "6 of 7 assignments would be rejected") {
+		t.Fatalf("expected the report error, got %v", err)
+	}
+	if amount, _ := srv.Assignment(a.Guid, "objectstore", "standard"); amount != 2 {
+		t.Fatalf("expected the assignment of a to be unchanged, got %v", amount)
+	}
+}
@@ -0,0 +1,362 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultParallelism is the number of subaccounts crawled at the same time when Options.Parallelism
+// is not set.
+const DefaultParallelism = 4
+
+// Options tune a crawl.
+type Options struct {
+	// The number of subaccounts crawled at the same time; DefaultParallelism when zero.
+	Parallelism int
+
+	// Reads the Service Manager instances and bindings of the subaccounts as well. Their Service
+	// Manager API is called with the credentials of their Service Manager binding: with clients built
+	// with automation.BindingOptions.CreateMissing, the crawl creates one in every subaccount which
+	// has none, and is no longer read-only. The crawl is read-only otherwise.
+	IncludeServiceManagement bool
+}
+
+// Crawl takes a snapshot of the global account. The subaccounts are crawled concurrently, at most
+// Options.Parallelism at the same time; the first error cancels the crawl and is returned.
+func Crawl(ctx context.Context, c *automation.Clients, opts *Options) (*Snapshot, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	root, err := c.Accounts.GetAccountTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := newSnapshot(root)
+
+	if !opts.IncludeServiceManagement {
+		ctx = automation.ReadOnly(ctx)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, parallelism)
+loop:
+	for i := range s.SubAccounts {
+		sa := &s.SubAccounts[i]
+		if sa.State != string(btpaccounts.EntityStateOK) {
+			sa.NotCrawled = fmt.Sprintf("the subaccount is %s", sa.State)
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := crawlSubAccount(ctx, c, sa, opts); err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("could not crawl subaccount %s; %v", sa.Subdomain, err)
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Builds the snapshot of the directories and subaccounts of the account tree, without their content.
+func newSnapshot(root *btpaccounts.AccountNode) *Snapshot {
+	s := &Snapshot{
+		SchemaVersion: SchemaVersion,
+		TakenAt:       time.Now().UTC().Truncate(time.Second),
+		GlobalAccount: GlobalAccount{
+			Guid:        root.Guid(),
+			DisplayName: root.DisplayName(),
+			Subdomain:   root.Subdomain(),
+		},
+	}
+	_ = root.Walk(func(n *btpaccounts.AccountNode) error {
+		switch n.Kind {
+		case btpaccounts.AccountNodeDirectory:
+			d := n.Directory
+			s.Directories = append(s.Directories, Directory{
+				Guid:             d.Guid,
+				ParentGuid:       n.Parent.Guid(),
+				Path:             path(n),
+				DisplayName:      d.DisplayName,
+				Description:      d.Description,
+				Subdomain:        d.Subdomain,
+				State:            string(d.EntityState),
+				Features:         d.DirectoryFeatures,
+				CustomProperties: customProperties(d.CustomProperties),
+			})
+		case btpaccounts.AccountNodeSubAccount:
+			sa := n.SubAccount
+			s.SubAccounts = append(s.SubAccounts, SubAccount{
+				Guid:              sa.Guid,
+				ParentGuid:        n.Parent.Guid(),
+				Directory:         path(n.Parent),
+				Subdomain:         sa.Subdomain,
+				DisplayName:       sa.DisplayName,
+				Description:       sa.Description,
+				Region:            sa.Region,
+				State:             string(sa.State),
+				UsedForProduction: string(sa.UsedForProduction),
+				BetaEnabled:       sa.BetaEnabled,
+				CustomProperties:  customProperties(sa.CustomProperties),
+			})
+		}
+		return nil
+	})
+	sort.SliceStable(s.Directories, func(i, j int) bool {
+		return s.Directories[i].Path < s.Directories[j].Path
+	})
+	sort.SliceStable(s.SubAccounts, func(i, j int) bool {
+		return s.SubAccounts[i].Subdomain < s.SubAccounts[j].Subdomain
+	})
+	return s
+}
+
+// The display names of the directories from the global account down to the node, separated by
+// slashes; empty for the global account.
+func path(n *btpaccounts.AccountNode) string {
+	var names []string
+	for _, node := range n.Path() {
+		if node.Kind == btpaccounts.AccountNodeDirectory {
+			names = append(names, node.DisplayName())
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+func customProperties(props []btpaccounts.CustomProperties) map[string]string {
+	if len(props) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(props))
+	for _, p := range props {
+		out[p.Key] = p.Value
+	}
+	return out
+}
+
+func crawlSubAccount(ctx context.Context, c *automation.Clients, sa *SubAccount, opts *Options) error {
+	var err error
+	if sa.Entitlements, err = readEntitlements(ctx, c.Entitlements, sa.Guid); err != nil {
+		return fmt.Errorf("could not read the entitlements; %v", err)
+	}
+
+	scoped, err := c.SubAccount(ctx, sa.Guid)
+	if err != nil {
+		return err
+	}
+	envs, err := scoped.Provisioning.GetEnvironmentInstances(ctx)
+	if err != nil {
+		return fmt.Errorf("could not read the environments; %v", err)
+	}
+	for _, env := range envs.Environments {
+		sa.Environments = append(sa.Environments, Environment{
+			Id:      env.Id,
+			Name:    env.Name,
+			Type:    string(env.EnvironmentType),
+			Service: env.ServiceName,
+			Plan:    env.PlanName,
+			State:   string(env.State),
+		})
+	}
+	sort.SliceStable(sa.Environments, func(i, j int) bool {
+		a, b := sa.Environments[i], sa.Environments[j]
+		return a.Type < b.Type || a.Type == b.Type && a.Name < b.Name
+	})
+
+	apps, err := scoped.SaaS.GetEntitledApplications(ctx, &btpsaasmanager.GetEntitledApplicationsInput{})
+	if err != nil {
+		return fmt.Errorf("could not read the subscriptions; %v", err)
+	}
+	for _, app := range apps.Applications {
+		if app.State == "" || app.State == btpsaasmanager.SubscriptionStateNotSubscribed {
+			continue
+		}
+		sa.Subscriptions = append(sa.Subscriptions, Subscription{
+			Id:    app.SubscriptionId,
+			App:   app.AppName,
+			Plan:  app.PlanName,
+			State: string(app.State),
+			Error: app.SubscriptionError.ErrorMessage,
+		})
+	}
+	sort.SliceStable(sa.Subscriptions, func(i, j int) bool {
+		return sa.Subscriptions[i].App < sa.Subscriptions[j].App
+	})
+
+	if !opts.IncludeServiceManagement {
+		return nil
+	}
+	return readServiceManagement(ctx, scoped.ServiceManagement, sa)
+}
+
+// Reads the plans assigned to the subaccount from the response of GetAssignments, which also holds
+// the assignments of other entities.
+func readEntitlements(ctx context.Context, entitlements btpentitlements.EntitlementsAPI,
+	subAccountGuid string) ([]Entitlement, error) {
+	out, err := entitlements.GetAssignments(ctx, &btpentitlements.GetAssignmentsInput{
+		SubAccountGuid:          subAccountGuid,
+		IncludeAutoManagedPlans: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var assigned []Entitlement
+	for _, svc := range out.AssignedServices {
+		for _, plan := range svc.ServicePlans {
+			for _, info := range plan.AssignmentInfo {
+				if info.EntityId != subAccountGuid {
+					continue
+				}
+				assigned = append(assigned, Entitlement{
+					Service:      svc.Name,
+					Plan:         plan.Name,
+					Amount:       float64(info.Amount),
+					State:        string(info.EntityState),
+					AutoAssigned: info.AutoAssigned,
+				})
+			}
+		}
+	}
+	sort.SliceStable(assigned, func(i, j int) bool {
+		a, b := assigned[i], assigned[j]
+		return a.Service < b.Service || a.Service == b.Service && a.Plan < b.Plan
+	})
+	return assigned, nil
+}
+
+func readServiceManagement(ctx context.Context, sm btpmanagment.ServiceManagementAPI, sa *SubAccount) error {
+	offerings := make(map[string]string)
+	if err := readPages("service offerings", func(token string) (string, error) {
+		out, err := sm.GetServiceOfferings(ctx, &btpmanagment.GetServiceOfferingsInput{Token: token})
+		if err != nil {
+			return "", err
+		}
+		for _, o := range out.Items {
+			offerings[o.Id] = o.Name
+		}
+		return out.Token, nil
+	}); err != nil {
+		return err
+	}
+	plans := make(map[string]btpmanagment.PlanItem)
+	if err := readPages("service plans", func(token string) (string, error) {
+		out, err := sm.GetServicePlans(ctx, &btpmanagment.GetServicePlansInput{Token: token})
+		if err != nil {
+			return "", err
+		}
+		for _, p := range out.Items {
+			plans[p.Id] = p
+		}
+		return out.Token, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := readPages("service instances", func(token string) (string, error) {
+		out, err := sm.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{Token: token})
+		if err != nil {
+			return "", err
+		}
+		for _, item := range out.Items {
+			instance := ServiceInstance{
+				Id:     item.Id,
+				Name:   item.Name,
+				PlanId: item.ServicePlanId,
+				Ready:  item.Ready,
+				Usable: item.Usable,
+				Labels: item.Labels,
+			}
+			if plan, ok := plans[item.ServicePlanId]; ok {
+				instance.Service, instance.Plan = offerings[plan.ServiceOfferingId], plan.Name
+			}
+			sa.ServiceInstances = append(sa.ServiceInstances, instance)
+		}
+		return out.Token, nil
+	}); err != nil {
+		return err
+	}
+	sort.SliceStable(sa.ServiceInstances, func(i, j int) bool {
+		return sa.ServiceInstances[i].Name < sa.ServiceInstances[j].Name
+	})
+
+	if err := readPages("service bindings", func(token string) (string, error) {
+		out, err := sm.GetServiceBindings(ctx, &btpmanagment.GetServiceBindingsInput{Token: token})
+		if err != nil {
+			return "", err
+		}
+		for _, item := range out.Items {
+			sa.ServiceBindings = append(sa.ServiceBindings, ServiceBinding{
+				Id:         item.Id,
+				Name:       item.Name,
+				InstanceId: item.ServiceInstanceId,
+				Ready:      item.Ready,
+				Labels:     item.Labels,
+			})
+		}
+		return out.Token, nil
+	}); err != nil {
+		return err
+	}
+	sort.SliceStable(sa.ServiceBindings, func(i, j int) bool {
+		return sa.ServiceBindings[i].Name < sa.ServiceBindings[j].Name
+	})
+	return nil
+}
+
+// Reads the pages of a Service Manager list, calling read with the token of every page until one
+// has no next page. A token returned twice would read the same pages forever, so it fails the read.
+func readPages(what string, read func(token string) (string, error)) error {
+	seen := make(map[string]bool)
+	for token := ""; ; {
+		next, err := read(token)
+		if err != nil {
+			return fmt.Errorf("could not read the %s; %v", what, err)
+		}
+		if next == "" {
+			return nil
+		}
+		if seen[next] {
+			return fmt.Errorf("could not read the %s; the page token %s was returned twice", what, next)
+		}
+		seen[next] = true
+		token = next
+	}
+}
@@ -0,0 +1,95 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeType tells how an item changed between two snapshots.
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+	Changed ChangeType = "changed"
+)
+
+// Change is an item added, removed or changed between two snapshots.
+type Change struct {
+	Type ChangeType
+	Kind Kind
+
+	// Identifies the item: the path of a directory, the subdomain of a subaccount, or the subdomain
+	// followed by the name of what the subaccount holds, e.g. "fin-eu objectstore/standard".
+	Key string
+
+	// The fields which changed, only for Changed.
+	Fields []FieldChange
+}
+
+// FieldChange is a field of an item which changed between two snapshots. The custom properties and
+// labels are fields of their own, named property.<key> and label.<key>.
+type FieldChange struct {
+	Field string
+
+	// The values of the field, empty when it is not set.
+	Old, New string
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s", c.Type, c.Kind, c.Key)
+	for _, f := range c.Fields {
+		fmt.Fprintf(&b, "\n\t%s: %q -> %q", f.Field, f.Old, f.New)
+	}
+	return b.String()
+}
+
+// Diff returns the changes from the old snapshot to the new one: the items added or changed, in the
+// order of the new snapshot, then the items removed, in the order of the old one. Directories and
+// subaccounts are matched by GUID, so that renaming or moving them is a change rather than the
+// removal of an item and the addition of another.
+func Diff(old, new *Snapshot) []Change {
+	oldRows := old.rows()
+	index := make(map[string]*row, len(oldRows))
+	for i := range oldRows {
+		index[string(oldRows[i].kind)+" "+oldRows[i].id] = &oldRows[i]
+	}
+
+	var changes []Change
+	seen := make(map[string]bool)
+	for _, r := range new.rows() {
+		id := string(r.kind) + " " + r.id
+		seen[id] = true
+		before, ok := index[id]
+		if !ok {
+			changes = append(changes, Change{Type: Added, Kind: r.kind, Key: r.key})
+			continue
+		}
+		if fields := diffFields(before, &r); len(fields) > 0 {
+			changes = append(changes, Change{Type: Changed, Kind: r.kind, Key: r.key, Fields: fields})
+		}
+	}
+	for _, r := range oldRows {
+		if !seen[string(r.kind)+" "+r.id] {
+			changes = append(changes, Change{Type: Removed, Kind: r.kind, Key: r.key})
+		}
+	}
+	return changes
+}
+
+// The fields which differ, in the order of the new row followed by the ones only the old row has.
+func diffFields(old, new *row) []FieldChange {
+	var changes []FieldChange
+	for _, f := range new.fields {
+		if before := old.value(f.name); before != f.value {
+			changes = append(changes, FieldChange{Field: f.name, Old: before, New: f.value})
+		}
+	}
+	for _, f := range old.fields {
+		if new.value(f.name) == "" {
+			changes = append(changes, FieldChange{Field: f.name, Old: f.value})
+		}
+	}
+	return changes
+}
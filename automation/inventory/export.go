@@ -0,0 +1,69 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/internal/sapyaml"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes the snapshot as an indented JSON document.
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadJSON reads a snapshot written by WriteJSON. Snapshots of a later schema version are rejected.
+func ReadJSON(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot; %v", err)
+	}
+	if s.SchemaVersion < 1 || s.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("unsupported snapshot schema version %d", s.SchemaVersion)
+	}
+	return &s, nil
+}
+
+// WriteYAML writes the snapshot as a YAML document, with the same fields as the JSON one.
+func (s *Snapshot) WriteYAML(w io.Writer) error {
+	return sapyaml.Write(w, s)
+}
+
+// The columns of the CSV. The fields of an item which have no column of their own are written to
+// the details column as name=value pairs separated by semicolons.
+var csvColumns = []string{"kind", "id", "subaccount", "path", "name", "region", "service", "plan", "amount", "state"}
+
+// WriteCSV writes the snapshot flattened to one row per directory, subaccount, entitlement,
+// environment, subscription, service instance and service binding.
+func (s *Snapshot) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append(append([]string{}, csvColumns...), "details")); err != nil {
+		return err
+	}
+
+	for _, r := range s.rows() {
+		record := make([]string, len(csvColumns)+1)
+		record[0] = string(r.kind)
+		var details []string
+	fields:
+		for _, f := range r.fields {
+			for i := 1; i < len(csvColumns); i++ {
+				if csvColumns[i] == f.name {
+					record[i] = f.value
+					continue fields
+				}
+			}
+			details = append(details, f.name+"="+f.value)
+		}
+		record[len(csvColumns)] = strings.Join(details, "; ")
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,280 @@
+package inventory_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/inventory"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+// Seeds the fake with a directory holding a subaccount with an entitlement, an environment, a
+// subscription and a service instance with a binding, and with another empty subaccount.
+func seed(t *testing.T, srv *btpfake.Server, c *automation.Clients) {
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 10})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "cloudfoundry", Plan: "standard", Category: "PLATFORM", Unlimited: true})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	srv.AddApplication(btpfake.Application{AppName: "sales-app", PlanName: "basic"})
+
+	ctx := context.Background()
+	m := &reconcile.Model{
+		Directories: []reconcile.Directory{{
+			DisplayName:      "Finance",
+			CustomProperties: map[string]string{"cost-center": "42"},
+			SubAccounts: []reconcile.SubAccount{{
+				Subdomain:   "fin-eu",
+				DisplayName: "Finance EU",
+				Region:      "eu10",
+				Entitlements: []reconcile.Entitlement{
+					{Service: "objectstore", Plan: "standard", Amount: 2},
+					{Service: "cloudfoundry", Plan: "standard", Enable: true},
+					{Service: "sales-app", Plan: "basic", Enable: true},
+				},
+				Environments:  []reconcile.Environment{{Type: "cloudfoundry", Service: "cloudfoundry", Plan: "standard", Name: "fin-eu-org"}},
+				Subscriptions: []reconcile.Subscription{{App: "sales-app", Plan: "basic"}},
+			}},
+		}},
+		SubAccounts: []reconcile.SubAccount{{Subdomain: "sandbox", DisplayName: "Sandbox", Region: "eu10"}},
+	}
+	if _, err := reconcile.Run(ctx, c, m, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := reconcile.Read(ctx, c, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoped, err := c.SubAccount(ctx, state.SubAccounts["fin-eu"].Guid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance, err := scoped.ServiceManagement.CreateServiceInstance(ctx, &btpmanagment.CreateServiceInstanceInput{
+		Name:                "fin-xsuaa",
+		ServiceOfferingName: "xsuaa",
+		ServicePlanName:     "application",
+		Labels:              map[string][]string{"team": {"core"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.ServiceManagement.CreateServiceBinding(ctx, &btpmanagment.CreateServiceBindingInput{
+		Name:              "fin-app",
+		ServiceInstanceId: instance.Id,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrawl(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	c := automationtest.Clients(t, srv)
+	seed(t, srv, c)
+	ctx := context.Background()
+
+	s, err := inventory.Crawl(ctx, c, &inventory.Options{Parallelism: 2, IncludeServiceManagement: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.SchemaVersion != inventory.SchemaVersion || s.GlobalAccount.Guid == "" || s.TakenAt.IsZero() {
+		t.Fatalf("unexpected snapshot header %+v", s)
+	}
+	fin := s.Directory("Finance")
+	if fin == nil || fin.CustomProperties["cost-center"] != "42" || fin.ParentGuid != s.GlobalAccount.Guid {
+		t.Fatalf("unexpected directory %+v", fin)
+	}
+	if len(s.SubAccounts) != 2 || s.SubAccounts[0].Subdomain != "fin-eu" || s.SubAccounts[1].Subdomain != "sandbox" {
+		t.Fatalf("unexpected subaccounts %+v", s.SubAccounts)
+	}
+
+	finEu := s.SubAccount("fin-eu")
+	if finEu.Directory != "Finance" || finEu.ParentGuid != fin.Guid || finEu.Region != "eu10" {
+		t.Fatalf("unexpected subaccount %+v", finEu)
+	}
+	var plans []string
+	for _, e := range finEu.Entitlements {
+		plans = append(plans, e.Service+"/"+e.Plan)
+	}
+	if got := strings.Join(plans, ","); got != "cloudfoundry/standard,objectstore/standard,sales-app/basic" {
+		t.Fatalf("unexpected entitlements %s", got)
+	}
+	if finEu.Entitlements[1].Amount != 2 {
+		t.Fatalf("expected 2 objectstore/standard, got %v", finEu.Entitlements[1].Amount)
+	}
+	if len(finEu.Environments) != 1 || finEu.Environments[0].Name != "fin-eu-org" || finEu.Environments[0].State != "OK" {
+		t.Fatalf("unexpected environments %+v", finEu.Environments)
+	}
+	if len(finEu.Subscriptions) != 1 || finEu.Subscriptions[0].App != "sales-app" || finEu.Subscriptions[0].State != "SUBSCRIBED" {
+		t.Fatalf("unexpected subscriptions %+v", finEu.Subscriptions)
+	}
+	if len(finEu.ServiceInstances) != 1 || finEu.ServiceInstances[0].Service != "xsuaa" ||
+		finEu.ServiceInstances[0].Plan != "application" || finEu.ServiceInstances[0].Labels["team"][0] != "core" {
+		t.Fatalf("unexpected service instances %+v", finEu.ServiceInstances)
+	}
+	if len(finEu.ServiceBindings) != 1 || finEu.ServiceBindings[0].InstanceId != finEu.ServiceInstances[0].Id {
+		t.Fatalf("unexpected service bindings %+v", finEu.ServiceBindings)
+	}
+	if sandbox := s.SubAccount("sandbox"); sandbox.Directory != "" || len(sandbox.Environments) != 0 {
+		t.Fatalf("unexpected subaccount %+v", sandbox)
+	}
+
+	var buf bytes.Buffer
+	if err := s.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "clientsecret") {
+		t.Fatal("expected no credentials in the snapshot")
+	}
+	read, err := inventory.ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes := inventory.Diff(s, read); len(changes) != 0 {
+		t.Fatalf("expected no changes once read back, got %v", changes)
+	}
+	if _, err := inventory.ReadJSON(strings.NewReader(`{"schemaVersion": 99}`)); err == nil {
+		t.Fatal("expected a later schema version to be rejected")
+	}
+
+	buf.Reset()
+	if err := s.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"schemaVersion: 1\n",
+		"directories:\n  - guid: ",
+		"    customProperties:\n      cost-center: \"42\"\n",
+		"    entitlements:\n      - service: cloudfoundry\n        plan: standard\n",
+		"        labels:\n          team:\n            - core\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected %q in:\n%s", expected, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := s.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The header, the directory, the two subaccounts and the content of fin-eu.
+	if len(records) != 1+1+2+3+1+1+1+1 {
+		t.Fatalf("unexpected CSV:\n%v", records)
+	}
+	if got := strings.Join(records[0], ","); got != "kind,id,subaccount,path,name,region,service,plan,amount,state,details" {
+		t.Fatalf("unexpected CSV header %s", got)
+	}
+	if got := strings.Join(records[1], ","); got != "directory,"+fin.Guid+",,Finance,Finance,,,,,OK,parent="+
+		s.GlobalAccount.Guid+"; features=DEFAULT; property.cost-center=42" {
+		t.Fatalf("unexpected CSV record %s", got)
+	}
+}
+
+func TestCrawlReadOnly(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	c := automationtest.Clients(t, srv)
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dev", DisplayName: "dev"})
+	ctx := context.Background()
+
+	// the Service Manager API of the subaccount is not called unless asked for, so it is not given a binding
+	s, err := inventory.Crawl(ctx, c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev := s.SubAccount("dev"); dev == nil || dev.ServiceInstances != nil || dev.ServiceBindings != nil {
+		t.Fatalf("unexpected subaccount %+v", dev)
+	}
+	out, err := c.Accounts.GetSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.GetServiceManagementBindingInput{SubAccountGuid: sa.Guid})
+	if err == nil || out.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected no binding to be created, got %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := &inventory.Snapshot{
+		SchemaVersion: inventory.SchemaVersion,
+		Directories:   []inventory.Directory{{Guid: "d1", Path: "Finance", DisplayName: "Finance"}},
+		SubAccounts: []inventory.SubAccount{
+			{Guid: "s1", Subdomain: "fin-eu", Region: "eu10", State: "OK", ParentGuid: "d1", Directory: "Finance",
+				CustomProperties: map[string]string{"team": "core"},
+				Entitlements:     []inventory.Entitlement{{Service: "objectstore", Plan: "standard", Amount: 2}}},
+			{Guid: "s2", Subdomain: "old", Region: "eu10"},
+		},
+	}
+	new := &inventory.Snapshot{
+		SchemaVersion: inventory.SchemaVersion,
+		Directories:   []inventory.Directory{{Guid: "d1", Path: "Finance", DisplayName: "Finance"}},
+		SubAccounts: []inventory.SubAccount{
+			{Guid: "s1", Subdomain: "fin-eu", Region: "eu10", State: "OK",
+				CustomProperties: map[string]string{"team": "platform"},
+				Entitlements: []inventory.Entitlement{
+					{Service: "objectstore", Plan: "standard", Amount: 4},
+					{Service: "cloudfoundry", Plan: "standard"},
+				}},
+		},
+	}
+
+	var got []string
+	for _, c := range inventory.Diff(old, new) {
+		got = append(got, c.String())
+	}
+	expected := []string{
+		"changed subaccount fin-eu\n\tproperty.team: \"core\" -> \"platform\"\n\tpath: \"Finance\" -> \"\"\n\tparent: \"d1\" -> \"\"",
+		"changed entitlement fin-eu objectstore/standard\n\tamount: \"2\" -> \"4\"",
+		"added entitlement fin-eu cloudfoundry/standard",
+		"removed subaccount old",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestCrawlNotOK(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	c := automationtest.Clients(t, srv)
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "broken", DisplayName: "broken", State: "CREATION_FAILED"})
+
+	s, err := inventory.Crawl(context.Background(), c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if broken := s.SubAccount("broken"); broken == nil || broken.NotCrawled != "the subaccount is CREATION_FAILED" {
+		t.Fatalf("expected the subaccount to be marked as not crawled, got %+v", broken)
+	}
+	var b bytes.Buffer
+	if err := s.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "notCrawled=the subaccount is CREATION_FAILED") {
+		t.Fatalf("expected the CSV to tell the subaccount was not crawled, got %s", b.String())
+	}
+}
+
+func TestCrawlRepeatedPageToken(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	c := automationtest.Clients(t, srv)
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dev", DisplayName: "dev"})
+	srv.InjectFault(btpfake.Fault{Method: http.MethodGet, Path: "/v1/service_offerings", Status: http.StatusOK,
+		Body: `{"token":"page-2","items":[]}`, Times: 2})
+
+	_, err := inventory.Crawl(context.Background(), c, &inventory.Options{IncludeServiceManagement: true})
+	if err == nil || !strings.Contains(err.Error(), "page token page-2 was returned twice") {
+		t.Fatalf("expected the repeated token to fail the crawl, got %v", err)
+	}
+}
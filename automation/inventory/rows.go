@@ -0,0 +1,181 @@
+package inventory
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of an item of a snapshot.
+type Kind string
+
+const (
+	KindDirectory       Kind = "directory"
+	KindSubAccount      Kind = "subaccount"
+	KindEntitlement     Kind = "entitlement"
+	KindEnvironment     Kind = "environment"
+	KindSubscription    Kind = "subscription"
+	KindServiceInstance Kind = "serviceInstance"
+	KindServiceBinding  Kind = "serviceBinding"
+)
+
+// A snapshot flattened to one row per item, which is what the CSV holds and what Diff compares.
+type row struct {
+	kind Kind
+
+	// Identifies the item among the ones of its kind, across snapshots.
+	id string
+
+	// Identifies the item for a reader: the path of a directory, the subdomain of a subaccount, or
+	// the subdomain followed by the name of what the subaccount holds.
+	key string
+
+	// The non-empty fields of the item, in order.
+	fields []field
+}
+
+type field struct {
+	name, value string
+}
+
+func (r *row) add(name, value string) {
+	if value != "" {
+		r.fields = append(r.fields, field{name, value})
+	}
+}
+
+func (r *row) addBool(name string, value bool) {
+	if value {
+		r.add(name, "true")
+	}
+}
+
+// Adds the entries of the map, sorted by key, as fields named prefix.key.
+func (r *row) addMap(prefix string, m map[string]string) {
+	for _, k := range sortedKeys(m) {
+		r.add(prefix+"."+k, m[k])
+	}
+}
+
+func (r *row) addLabels(labels map[string][]string) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		r.add("label."+k, strings.Join(labels[k], ","))
+	}
+}
+
+func (r *row) value(name string) string {
+	for _, f := range r.fields {
+		if f.name == name {
+			return f.value
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// The rows of the snapshot: the directories, then every subaccount followed by what it holds.
+func (s *Snapshot) rows() []row {
+	var rows []row
+	for _, d := range s.Directories {
+		r := row{kind: KindDirectory, id: d.Guid, key: d.Path}
+		r.add("id", d.Guid)
+		r.add("path", d.Path)
+		r.add("name", d.DisplayName)
+		r.add("state", d.State)
+		r.add("parent", d.ParentGuid)
+		r.add("description", d.Description)
+		r.add("subdomain", d.Subdomain)
+		r.add("features", strings.Join(d.Features, ","))
+		r.addMap("property", d.CustomProperties)
+		rows = append(rows, r)
+	}
+
+	for _, sa := range s.SubAccounts {
+		r := row{kind: KindSubAccount, id: sa.Guid, key: sa.Subdomain}
+		r.add("id", sa.Guid)
+		r.add("subaccount", sa.Subdomain)
+		r.add("path", sa.Directory)
+		r.add("name", sa.DisplayName)
+		r.add("region", sa.Region)
+		r.add("state", sa.State)
+		r.add("notCrawled", sa.NotCrawled)
+		r.add("parent", sa.ParentGuid)
+		r.add("description", sa.Description)
+		r.add("usedForProduction", sa.UsedForProduction)
+		r.addBool("betaEnabled", sa.BetaEnabled)
+		r.addMap("property", sa.CustomProperties)
+		rows = append(rows, r)
+
+		for _, e := range sa.Entitlements {
+			plan := e.Service + "/" + e.Plan
+			r := row{kind: KindEntitlement, id: sa.Guid + " " + plan, key: sa.Subdomain + " " + plan}
+			r.add("subaccount", sa.Subdomain)
+			r.add("service", e.Service)
+			r.add("plan", e.Plan)
+			if e.Amount != 0 {
+				r.add("amount", strconv.FormatFloat(e.Amount, 'f', -1, 64))
+			}
+			r.add("state", e.State)
+			r.addBool("autoAssigned", e.AutoAssigned)
+			rows = append(rows, r)
+		}
+		for _, env := range sa.Environments {
+			r := row{kind: KindEnvironment, id: env.Id, key: sa.Subdomain + " " + env.Type + " " + env.Name}
+			r.add("id", env.Id)
+			r.add("subaccount", sa.Subdomain)
+			r.add("name", env.Name)
+			r.add("service", env.Service)
+			r.add("plan", env.Plan)
+			r.add("state", env.State)
+			r.add("type", env.Type)
+			rows = append(rows, r)
+		}
+		for _, sub := range sa.Subscriptions {
+			r := row{kind: KindSubscription, id: sa.Guid + " " + sub.App, key: sa.Subdomain + " " + sub.App}
+			r.add("id", sub.Id)
+			r.add("subaccount", sa.Subdomain)
+			r.add("name", sub.App)
+			r.add("plan", sub.Plan)
+			r.add("state", sub.State)
+			r.add("error", sub.Error)
+			rows = append(rows, r)
+		}
+		for _, i := range sa.ServiceInstances {
+			r := row{kind: KindServiceInstance, id: i.Id, key: sa.Subdomain + " " + i.Name}
+			r.add("id", i.Id)
+			r.add("subaccount", sa.Subdomain)
+			r.add("name", i.Name)
+			r.add("service", i.Service)
+			r.add("plan", i.Plan)
+			r.add("planId", i.PlanId)
+			r.addBool("ready", i.Ready)
+			r.addBool("usable", i.Usable)
+			r.addLabels(i.Labels)
+			rows = append(rows, r)
+		}
+		for _, b := range sa.ServiceBindings {
+			r := row{kind: KindServiceBinding, id: b.Id, key: sa.Subdomain + " " + b.Name}
+			r.add("id", b.Id)
+			r.add("subaccount", sa.Subdomain)
+			r.add("name", b.Name)
+			r.add("instanceId", b.InstanceId)
+			r.addBool("ready", b.Ready)
+			r.addLabels(b.Labels)
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,174 @@
+// Package inventory takes snapshots of everything in a global account: its directories and
+// subaccounts, and for every subaccount its entitlements, environment instances, SaaS subscriptions
+// and, when asked for, Service Manager instances and bindings.
+//
+//	snapshot, err := inventory.Crawl(ctx, clients, &inventory.Options{Parallelism: 8})
+//	err = snapshot.WriteYAML(os.Stdout)
+//
+// Snapshots are written as JSON, YAML or a flattened CSV, and two snapshots are compared with Diff.
+// Credentials, such as the ones of the service bindings, are never part of a snapshot.
+package inventory
+
+import (
+	"time"
+)
+
+// SchemaVersion is the version of the snapshot document written by this package. It is increased
+// whenever the document changes in a way older readers cannot handle.
+const SchemaVersion = 1
+
+// Snapshot is the content of a global account at a point in time.
+type Snapshot struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	TakenAt       time.Time `json:"takenAt"`
+
+	GlobalAccount GlobalAccount `json:"globalAccount"`
+
+	// The directories, sorted by path.
+	Directories []Directory `json:"directories"`
+
+	// The subaccounts, sorted by subdomain.
+	SubAccounts []SubAccount `json:"subaccounts"`
+}
+
+// GlobalAccount identifies the global account of a snapshot.
+type GlobalAccount struct {
+	Guid        string `json:"guid"`
+	DisplayName string `json:"displayName"`
+	Subdomain   string `json:"subdomain,omitempty"`
+}
+
+// Directory is a directory of the global account.
+type Directory struct {
+	Guid string `json:"guid"`
+
+	// The GUID of the parent directory, or of the global account.
+	ParentGuid string `json:"parentGuid"`
+
+	// The display names of the directories from the global account down to this one, separated by
+	// slashes, e.g. Finance/EU.
+	Path string `json:"path"`
+
+	DisplayName      string            `json:"displayName"`
+	Description      string            `json:"description,omitempty"`
+	Subdomain        string            `json:"subdomain,omitempty"`
+	State            string            `json:"state,omitempty"`
+	Features         []string          `json:"features,omitempty"`
+	CustomProperties map[string]string `json:"customProperties,omitempty"`
+}
+
+// SubAccount is a subaccount of the global account, with what it holds.
+type SubAccount struct {
+	Guid string `json:"guid"`
+
+	// The GUID of the parent directory, or of the global account.
+	ParentGuid string `json:"parentGuid"`
+
+	// The path of the parent directory, empty for the global account.
+	Directory string `json:"directory,omitempty"`
+
+	Subdomain         string            `json:"subdomain"`
+	DisplayName       string            `json:"displayName"`
+	Description       string            `json:"description,omitempty"`
+	Region            string            `json:"region"`
+	State             string            `json:"state,omitempty"`
+	UsedForProduction string            `json:"usedForProduction,omitempty"`
+	BetaEnabled       bool              `json:"betaEnabled,omitempty"`
+	CustomProperties  map[string]string `json:"customProperties,omitempty"`
+
+	// Why the content of the subaccount was not crawled, e.g. because it is not in the OK state;
+	// empty when it was.
+	NotCrawled string `json:"notCrawled,omitempty"`
+
+	// The entitlements assigned to the subaccount, sorted by service and plan.
+	Entitlements []Entitlement `json:"entitlements,omitempty"`
+
+	// The environment instances, sorted by type and name.
+	Environments []Environment `json:"environments,omitempty"`
+
+	// The SaaS applications the subaccount is subscribed to, or whose subscription failed, sorted
+	// by application.
+	Subscriptions []Subscription `json:"subscriptions,omitempty"`
+
+	// The Service Manager instances and bindings, sorted by name.
+	ServiceInstances []ServiceInstance `json:"serviceInstances,omitempty"`
+	ServiceBindings  []ServiceBinding  `json:"serviceBindings,omitempty"`
+}
+
+// Entitlement is a service plan assigned to a subaccount.
+type Entitlement struct {
+	Service string `json:"service"`
+	Plan    string `json:"plan"`
+
+	// The quota assigned, for the plans with a numeric quota.
+	Amount float64 `json:"amount,omitempty"`
+
+	State        string `json:"state,omitempty"`
+	AutoAssigned bool   `json:"autoAssigned,omitempty"`
+}
+
+// Environment is an environment instance of a subaccount.
+type Environment struct {
+	Id      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type"`
+	Service string `json:"service,omitempty"`
+	Plan    string `json:"plan,omitempty"`
+	State   string `json:"state,omitempty"`
+}
+
+// Subscription is the subscription of a subaccount to a SaaS application.
+type Subscription struct {
+	Id    string `json:"id,omitempty"`
+	App   string `json:"app"`
+	Plan  string `json:"plan,omitempty"`
+	State string `json:"state"`
+
+	// Why the subscription failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// ServiceInstance is a Service Manager instance of a subaccount.
+type ServiceInstance struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// The names of the service offering and plan of the instance, when found in the catalog of
+	// the subaccount.
+	Service string `json:"service,omitempty"`
+	Plan    string `json:"plan,omitempty"`
+	PlanId  string `json:"planId"`
+
+	Ready  bool                `json:"ready"`
+	Usable bool                `json:"usable"`
+	Labels map[string][]string `json:"labels,omitempty"`
+}
+
+// ServiceBinding is a Service Manager binding of a subaccount, without its credentials.
+type ServiceBinding struct {
+	Id         string              `json:"id"`
+	Name       string              `json:"name"`
+	InstanceId string              `json:"instanceId"`
+	Ready      bool                `json:"ready"`
+	Labels     map[string][]string `json:"labels,omitempty"`
+}
+
+// Directory returns the directory with the path; nil if there is none.
+func (s *Snapshot) Directory(path string) *Directory {
+	for i := range s.Directories {
+		if s.Directories[i].Path == path {
+			return &s.Directories[i]
+		}
+	}
+	return nil
+}
+
+// SubAccount returns the subaccount with the subdomain; nil if there is none.
+func (s *Snapshot) SubAccount(subdomain string) *SubAccount {
+	for i := range s.SubAccounts {
+		if s.SubAccounts[i].Subdomain == subdomain {
+			return &s.SubAccounts[i]
+		}
+	}
+	return nil
+}
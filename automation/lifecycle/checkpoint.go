@@ -0,0 +1,140 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the progress of a run of a workflow.
+type Checkpoint struct {
+	ID       string `json:"id"`
+	Workflow string `json:"workflow"`
+	Status   Status `json:"status"`
+
+	// The steps done, in the order they were done.
+	Completed []string `json:"completed,omitempty"`
+
+	// The steps undone after a failure, in the order they were undone.
+	Compensated []string `json:"compensated,omitempty"`
+
+	// The step which failed, and its error.
+	FailedStep string `json:"failedStep,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	// The values set by the steps.
+	Values map[string]string `json:"values,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (cp *Checkpoint) done(step string) bool {
+	return hasString(cp.Completed, step)
+}
+
+func (cp *Checkpoint) undone(step string) bool {
+	return hasString(cp.Compensated, step)
+}
+
+// Store saves the checkpoints of the runs.
+type Store interface {
+	// Load returns the checkpoint saved under the ID, or nil when there is none.
+	Load(ctx context.Context, id string) (*Checkpoint, error)
+
+	// Save saves the checkpoint under its ID, replacing the previous one.
+	Save(ctx context.Context, cp *Checkpoint) error
+}
+
+// FileStore returns a store saving every checkpoint as a JSON file named after its ID in the
+// directory, which is created when missing.
+func FileStore(dir string) Store {
+	return fileStore(dir)
+}
+
+type fileStore string
+
+func (dir fileStore) path(id string) string {
+	return filepath.Join(string(dir), filepath.Base(id)+".json")
+}
+
+func (dir fileStore) Load(_ context.Context, id string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(dir.path(id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s; %v", dir.path(id), err)
+	}
+	return &cp, nil
+}
+
+// Writes a temporary file renamed over the checkpoint, so that an interrupted save leaves the previous
+// checkpoint intact.
+func (dir fileStore) Save(_ context.Context, cp *Checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(string(dir), 0700); err != nil {
+		return err
+	}
+	path := dir.path(cp.ID)
+	if err := ioutil.WriteFile(path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// MemoryStore returns a store keeping the checkpoints in memory, e.g. to resume runs within a process.
+func MemoryStore() Store {
+	return &memoryStore{checkpoints: make(map[string][]byte)}
+}
+
+// Keeps the checkpoints encoded, so that the runs do not share them.
+type memoryStore struct {
+	mu          sync.Mutex
+	checkpoints map[string][]byte
+}
+
+func (s *memoryStore) Load(_ context.Context, id string) (*Checkpoint, error) {
+	s.mu.Lock()
+	data, ok := s.checkpoints[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, cp *Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.checkpoints[cp.ID] = data
+	s.mu.Unlock()
+	return nil
+}
+
+func hasString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
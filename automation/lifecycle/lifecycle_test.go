@@ -0,0 +1,318 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/lifecycle"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func newServer(t *testing.T, options ...func(*btpfake.Options)) (*btpfake.Server, *automation.Clients) {
+	srv := btpfake.NewServer(append([]func(*btpfake.Options){func(o *btpfake.Options) { o.AsyncSteps = 0 }}, options...)...)
+	t.Cleanup(srv.Close)
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 10})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "cloudfoundry", Plan: "standard", Category: "PLATFORM", Unlimited: true})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	srv.AddApplication(btpfake.Application{AppName: "sales-app", PlanName: "basic"})
+	return srv, automationtest.Clients(t, srv)
+}
+
+func salesOnboarding() *lifecycle.Onboarding {
+	return &lifecycle.Onboarding{
+		Subdomain:        "sales-dev",
+		DisplayName:      "Sales dev",
+		Region:           "eu10",
+		Admins:           []string{"jane@example.com"},
+		CustomProperties: map[string]string{"team": "sales"},
+		Entitlements: []reconcile.Entitlement{
+			{Service: "objectstore", Plan: "standard", Amount: 2},
+			{Service: "cloudfoundry", Plan: "standard", Enable: true},
+			{Service: "sales-app", Plan: "basic", Enable: true},
+		},
+		Environments:  []reconcile.Environment{{Type: "cloudfoundry", Service: "cloudfoundry", Plan: "standard", Name: "sales-dev-org"}},
+		Subscriptions: []reconcile.Subscription{{App: "sales-app", Plan: "basic"}},
+	}
+}
+
+func TestOnboardingAndOffboarding(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+	store := lifecycle.MemoryStore()
+
+	w := lifecycle.OnboardingWorkflow(c, salesOnboarding(), nil)
+	cp, err := w.Run(ctx, &lifecycle.Options{Store: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Status != lifecycle.StatusCompleted || len(cp.Completed) != len(w.Steps) {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+	guid := cp.Values[lifecycle.ValueSubAccount]
+	if sa, ok := srv.SubAccount(guid); !ok || sa.Subdomain != "sales-dev" {
+		t.Fatalf("expected subaccount sales-dev, got %+v", sa)
+	}
+	if amount, ok := srv.Assignment(guid, "objectstore", "standard"); !ok || amount != 2 {
+		t.Fatalf("expected 2 objectstore/standard assigned, got %v", amount)
+	}
+	if env, ok := srv.EnvironmentInstance(cp.Values[lifecycle.ValueEnvironmentPrefix+"cloudfoundry/sales-dev-org"]); !ok || env.Name != "sales-dev-org" {
+		t.Fatalf("unexpected environment %+v", env)
+	}
+	if s := srv.SubscriptionState(guid, "sales-app"); s != "SUBSCRIBED" {
+		t.Fatalf("expected sales-app to be subscribed, got %s", s)
+	}
+
+	// a completed run is not run again
+	var steps []string
+	if _, err := w.Run(ctx, &lifecycle.Options{Store: store, OnStep: func(step string, _ bool) {
+		steps = append(steps, step)
+	}}); err != nil || len(steps) != 0 {
+		t.Fatalf("expected no step to run again, got %v, %v", steps, err)
+	}
+
+	cp, err = lifecycle.OffboardingWorkflow(c, &lifecycle.Offboarding{Subdomain: "sales-dev"}, nil).Run(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Status != lifecycle.StatusCompleted || cp.Values[lifecycle.ValueSubAccount] != guid {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+	if _, ok := srv.SubAccount(guid); ok {
+		t.Fatal("expected the subaccount to be deleted")
+	}
+	if _, ok := srv.Assignment(guid, "objectstore", "standard"); ok {
+		t.Fatal("expected the entitlement to be removed")
+	}
+
+	// offboarding a subaccount already gone does nothing
+	if _, err := lifecycle.OffboardingWorkflow(c, &lifecycle.Offboarding{Subdomain: "sales-dev"}, nil).Run(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOnboardingEnvironmentsOfAType(t *testing.T) {
+	_, c := newServer(t)
+	o := salesOnboarding()
+	o.Environments = append(o.Environments,
+		reconcile.Environment{Type: "cloudfoundry", Service: "cloudfoundry", Plan: "standard", Name: "sales-test-org"})
+
+	var envs []string
+	for _, step := range lifecycle.OnboardingWorkflow(c, o, nil).Steps {
+		if strings.HasPrefix(step.Name, "environment:") {
+			envs = append(envs, step.Name)
+		}
+	}
+	if strings.Join(envs, ",") != "environment:cloudfoundry/sales-dev-org,environment:cloudfoundry/sales-test-org" {
+		t.Fatalf("expected a step per environment, got %v", envs)
+	}
+}
+
+func TestOnboardingValidation(t *testing.T) {
+	srv, c := newServer(t)
+	o := salesOnboarding()
+	o.Region = ""
+	o.Entitlements = append(o.Entitlements, reconcile.Entitlement{Service: "objectstore", Plan: "standard", Amount: 1})
+	o.Environments = append(o.Environments, o.Environments[0])
+
+	_, err := lifecycle.OnboardingWorkflow(c, o, nil).Run(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected the onboarding to be rejected")
+	}
+	for _, msg := range []string{
+		"needs a subdomain, a display name and a region",
+		"plan objectstore/standard is declared twice",
+		"environment cloudfoundry/sales-dev-org is declared twice",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected %q in %v", msg, err)
+		}
+	}
+	if len(srv.Requests()) != 0 {
+		t.Fatalf("expected no request, got %v", srv.Requests())
+	}
+}
+
+func TestOffboardingUnknownSubscription(t *testing.T) {
+	srv, c := newServer(t, func(o *btpfake.Options) { o.AsyncSteps = 2 })
+	ctx := context.Background()
+	opts := &lifecycle.WorkflowOptions{PollInterval: time.Millisecond}
+	o := salesOnboarding()
+	o.Subscriptions = nil
+	cp, err := lifecycle.OnboardingWorkflow(c, o, opts).Run(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guid := cp.Values[lifecycle.ValueSubAccount]
+	scoped, err := c.SubAccount(ctx, guid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.SaaS.SubscribeToApplication(ctx, &btpsaasmanager.SubscribeToApplicationInput{
+		AppName: "sales-app", PlanName: "basic"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the subscription in progress is read, then waiting for it fails
+	srv.InjectFault(btpfake.Fault{Method: http.MethodGet, Path: "/saas-manager/v1/applications",
+		Status: http.StatusInternalServerError, After: 1})
+	_, err = lifecycle.OffboardingWorkflow(c, &lifecycle.Offboarding{Subdomain: "sales-dev"}, opts).Run(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "step unsubscribe failed") {
+		t.Fatalf("expected the unsubscription to fail, got %v", err)
+	}
+	if _, ok := srv.SubAccount(guid); !ok {
+		t.Fatal("expected the subaccount to be kept")
+	}
+	if s := srv.SubscriptionState(guid, "sales-app"); s == "NOT_SUBSCRIBED" {
+		t.Fatalf("expected sales-app to be subscribed still, got %s", s)
+	}
+}
+
+func TestCompensation(t *testing.T) {
+	srv, c := newServer(t)
+	srv.InjectFault(btpfake.Fault{Method: http.MethodPost, Path: "/saas-manager/v1/applications/sales-app/subscription",
+		Status: http.StatusBadRequest, Body: `{"error":{"message":"subscription rejected"}}`})
+
+	var undone []string
+	cp, err := lifecycle.OnboardingWorkflow(c, salesOnboarding(), nil).Run(context.Background(), &lifecycle.Options{
+		OnStep: func(step string, undo bool) {
+			if undo {
+				undone = append(undone, step)
+			}
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "step subscription:sales-app failed") {
+		t.Fatalf("expected the subscription to fail, got %v", err)
+	}
+	if cp.Status != lifecycle.StatusCompensated || cp.FailedStep != "subscription:sales-app" {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+	if len(undone) != len(cp.Completed) || undone[len(undone)-1] != "create-subaccount" {
+		t.Fatalf("expected the completed steps to be undone in reverse order, got %v", undone)
+	}
+	if _, ok := srv.SubAccount(cp.Values[lifecycle.ValueSubAccount]); ok {
+		t.Fatal("expected the subaccount to be deleted")
+	}
+}
+
+func TestResume(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+	store := lifecycle.FileStore(t.TempDir())
+	srv.InjectFault(btpfake.Fault{Method: http.MethodPost, Path: "/provisioning/v1/environments",
+		Status: http.StatusBadRequest, Body: `{"error":{"message":"environment rejected"}}`})
+
+	w := lifecycle.OnboardingWorkflow(c, salesOnboarding(), nil)
+	opts := &lifecycle.Options{ID: "onboard-sales", Store: store, KeepOnFailure: true}
+	cp, err := w.Run(ctx, opts)
+	if err == nil || cp.Status != lifecycle.StatusFailed || cp.FailedStep != "environment:cloudfoundry/sales-dev-org" {
+		t.Fatalf("expected the environment to fail, got %+v, %v", cp, err)
+	}
+	guid := cp.Values[lifecycle.ValueSubAccount]
+	if _, ok := srv.SubAccount(guid); !ok {
+		t.Fatal("expected the subaccount to be kept")
+	}
+
+	var steps []string
+	opts.OnStep = func(step string, _ bool) { steps = append(steps, step) }
+	if cp, err = w.Run(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if cp.Status != lifecycle.StatusCompleted || cp.Values[lifecycle.ValueSubAccount] != guid {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+	if len(steps) != 2 || steps[0] != "environment:cloudfoundry/sales-dev-org" || steps[1] != "subscription:sales-app" {
+		t.Fatalf("expected only the remaining steps to run, got %v", steps)
+	}
+}
+
+func TestResumeSetsValues(t *testing.T) {
+	ctx := context.Background()
+	fail := true
+	w := &lifecycle.Workflow{Name: "values", Steps: []lifecycle.Step{
+		{Name: "a", Do: func(context.Context, *lifecycle.State) error { return nil }},
+		{Name: "b", DependsOn: []string{"a"}, Do: func(_ context.Context, s *lifecycle.State) error {
+			if fail {
+				return errors.New("not yet")
+			}
+			return s.Set("b", "done")
+		}},
+	}}
+	// the checkpoint saved by the first run has no values
+	opts := &lifecycle.Options{Store: lifecycle.FileStore(t.TempDir()), KeepOnFailure: true}
+	if cp, err := w.Run(ctx, opts); err == nil || cp.Status != lifecycle.StatusFailed {
+		t.Fatalf("expected step b to fail, got %+v, %v", cp, err)
+	}
+
+	fail = false
+	cp, err := w.Run(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Status != lifecycle.StatusCompleted || cp.Values["b"] != "done" {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+}
+
+func TestCompensationOutlivesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	do := func(context.Context, *lifecycle.State) error { return nil }
+	var undone []string
+	w := &lifecycle.Workflow{Name: "cancelled", Steps: []lifecycle.Step{
+		{Name: "a", Do: do, Undo: func(ctx context.Context, _ *lifecycle.State) error {
+			undone = append(undone, "a")
+			return ctx.Err()
+		}},
+		// the context of the run is done while the steps are undone
+		{Name: "b", DependsOn: []string{"a"}, Do: do, Undo: func(ctx context.Context, _ *lifecycle.State) error {
+			undone = append(undone, "b")
+			cancel()
+			return ctx.Err()
+		}},
+		{Name: "c", DependsOn: []string{"b"}, Do: func(context.Context, *lifecycle.State) error {
+			return errors.New("rejected")
+		}},
+	}}
+	cp, err := w.Run(ctx, &lifecycle.Options{Store: lifecycle.MemoryStore()})
+	if err == nil || cp.Status != lifecycle.StatusCompensated || strings.Join(undone, ",") != "b,a" {
+		t.Fatalf("expected the steps to be undone, got %+v, %v, %v", cp, undone, err)
+	}
+}
+
+func TestWorkflowOrder(t *testing.T) {
+	do := func(context.Context, *lifecycle.State) error { return nil }
+	w := &lifecycle.Workflow{Name: "cycle", Steps: []lifecycle.Step{
+		{Name: "a", DependsOn: []string{"b"}, Do: do},
+		{Name: "b", DependsOn: []string{"a"}, Do: do},
+	}}
+	if _, err := w.Run(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected the cycle to be rejected, got %v", err)
+	}
+
+	var steps []string
+	record := func(name string) func(context.Context, *lifecycle.State) error {
+		return func(context.Context, *lifecycle.State) error {
+			steps = append(steps, name)
+			return nil
+		}
+	}
+	w = &lifecycle.Workflow{Name: "order", Steps: []lifecycle.Step{
+		{Name: "c", DependsOn: []string{"b"}, Do: record("c")},
+		{Name: "a", Do: record("a")},
+		{Name: "b", DependsOn: []string{"a"}, Do: record("b")},
+	}}
+	if _, err := w.Run(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(steps, ",") != "a,b,c" {
+		t.Fatalf("unexpected order %v", steps)
+	}
+}
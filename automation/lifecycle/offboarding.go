@@ -0,0 +1,177 @@
+package lifecycle
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"sort"
+	"time"
+)
+
+// Offboarding describes the subaccount of a team to remove.
+type Offboarding struct {
+	Subdomain string
+
+	// Keeps the subaccount, only removing what the team has in it.
+	KeepSubAccount bool
+}
+
+// OffboardingWorkflow returns the workflow undoing an onboarding: it unsubscribes the subaccount from
+// its applications, deletes its environment instances, removes its entitlements, then deletes its
+// Service Manager binding and the subaccount itself. The binding goes after the other steps, so that
+// the Service Manager API of the subaccount can be called until then. Every step reads what is left
+// to remove, so that an interrupted run can be resumed, and nothing is done for a subaccount already
+// gone; the steps cannot be undone.
+func OffboardingWorkflow(c *automation.Clients, o *Offboarding, opts *WorkflowOptions) *Workflow {
+	if opts == nil {
+		opts = &WorkflowOptions{}
+	}
+	ob := &offboarding{clients: c, o: o, interval: opts.PollInterval}
+	w := &Workflow{
+		Name: "offboarding-" + o.Subdomain,
+		Steps: []Step{
+			{Name: "unsubscribe", Do: ob.unsubscribe},
+			{Name: "delete-environments", DependsOn: []string{"unsubscribe"}, Do: ob.deleteEnvironments},
+			{Name: "remove-entitlements", DependsOn: []string{"delete-environments"}, Do: ob.removeEntitlements},
+			{Name: "delete-service-management-binding", DependsOn: []string{"remove-entitlements"}, Do: ob.deleteBinding},
+		},
+	}
+	if !o.KeepSubAccount {
+		w.Steps = append(w.Steps, Step{
+			Name:      "delete-subaccount",
+			DependsOn: []string{"delete-service-management-binding"},
+			Do:        ob.deleteSubAccount,
+		})
+	}
+	return w
+}
+
+type offboarding struct {
+	clients  *automation.Clients
+	o        *Offboarding
+	interval time.Duration
+}
+
+// Reads the live state of the subaccount, and saves its GUID; nil when it does not exist.
+func (ob *offboarding) read(ctx context.Context, s *State) (*reconcile.SubAccountState, error) {
+	state, err := reconcile.Read(ctx, ob.clients, &reconcile.Model{SubAccounts: []reconcile.SubAccount{{Subdomain: ob.o.Subdomain}}})
+	if err != nil {
+		return nil, err
+	}
+	sa, ok := state.SubAccounts[ob.o.Subdomain]
+	if !ok {
+		return nil, nil
+	}
+	if s.Get(ValueSubAccount) != sa.Guid {
+		if err := s.Set(ValueSubAccount, sa.Guid); err != nil {
+			return nil, err
+		}
+	}
+	return sa, nil
+}
+
+func (ob *offboarding) unsubscribe(ctx context.Context, s *State) error {
+	sa, err := ob.read(ctx, s)
+	if sa == nil || err != nil {
+		return err
+	}
+	for _, app := range sortedApplications(sa.Subscriptions) {
+		state := app.State
+		if !state.IsTerminal() {
+			scoped, err := ob.clients.SubAccount(ctx, sa.Guid)
+			if err != nil {
+				return err
+			}
+			// a failed subscription leaves nothing to unsubscribe from, any other error leaves the
+			// state of the application unknown
+			waited, err := automation.WaitForSubscription(ctx, scoped.SaaS, app.AppName, ob.interval)
+			if waited == nil {
+				return err
+			}
+			state = waited.State
+		}
+		switch state {
+		case btpsaasmanager.SubscriptionStateNotSubscribed, btpsaasmanager.SubscriptionStateSubscribeFailed:
+			continue
+		}
+		if err := unsubscribe(ctx, ob.clients, sa.Guid, app.AppName, ob.interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ob *offboarding) deleteEnvironments(ctx context.Context, s *State) error {
+	sa, err := ob.read(ctx, s)
+	if sa == nil || err != nil {
+		return err
+	}
+	for _, env := range sa.Environments {
+		if err := deleteEnvironment(ctx, ob.clients, sa.Guid, env.Id, ob.interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Removes the entitlements assigned explicitly; the ones assigned automatically go with the subaccount.
+func (ob *offboarding) removeEntitlements(ctx context.Context, s *State) error {
+	sa, err := ob.read(ctx, s)
+	if sa == nil || err != nil {
+		return err
+	}
+	for _, key := range sortedAssignmentKeys(sa.Entitlements) {
+		a := sa.Entitlements[key]
+		if a.AutoAssigned {
+			continue
+		}
+		info := btpentitlements.AssignmentInfo{SubAccountGuid: sa.Guid}
+		if a.QuotaBased {
+			zero := uint(0)
+			info.Amount = &zero
+		} else {
+			disabled := false
+			info.Enable = &disabled
+		}
+		if err := assign(ctx, ob.clients, a.Service, a.Plan, info, ob.interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ob *offboarding) deleteBinding(ctx context.Context, s *State) error {
+	sa, err := ob.read(ctx, s)
+	if sa == nil || err != nil {
+		return err
+	}
+	return deleteBinding(ctx, ob.clients, sa.Guid)
+}
+
+func (ob *offboarding) deleteSubAccount(ctx context.Context, s *State) error {
+	sa, err := ob.read(ctx, s)
+	if sa == nil || err != nil {
+		return err
+	}
+	return deleteSubAccount(ctx, ob.clients, sa.Guid, ob.interval)
+}
+
+func sortedApplications(apps map[string]btpsaasmanager.Application) []btpsaasmanager.Application {
+	sorted := make([]btpsaasmanager.Application, 0, len(apps))
+	for _, app := range apps {
+		sorted = append(sorted, app)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].AppName < sorted[j].AppName })
+	return sorted
+}
+
+func sortedAssignmentKeys(assignments map[string]reconcile.Assignment) []string {
+	keys := make([]string, 0, len(assignments))
+	for k := range assignments {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
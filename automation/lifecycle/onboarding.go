@@ -0,0 +1,366 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The keys of the values the onboarding steps save in the state.
+const (
+	// The GUID of the subaccount.
+	ValueSubAccount = "subaccount"
+
+	// The prefix of the IDs of the environment instances, followed by their type and name, e.g.
+	// environment.cloudfoundry/sales-dev-org.
+	ValueEnvironmentPrefix = "environment."
+)
+
+// Onboarding describes the subaccount of a team and what it needs in it.
+type Onboarding struct {
+	Subdomain   string
+	DisplayName string
+	Description string
+	Region      string
+
+	// The GUID of the directory the subaccount is created in; the global account when empty.
+	ParentGuid string
+
+	Admins           []string
+	CustomProperties map[string]string
+
+	Entitlements  []reconcile.Entitlement
+	Environments  []reconcile.Environment
+	Subscriptions []reconcile.Subscription
+}
+
+// WorkflowOptions tune the steps of the onboarding and offboarding workflows.
+type WorkflowOptions struct {
+	// The time between two polls of the asynchronous operations; automation.DefaultPollInterval when
+	// zero.
+	PollInterval time.Duration
+}
+
+// OnboardingWorkflow returns the workflow creating the subaccount, waiting for it to be OK, and creating
+// its Service Manager binding; then assigning its entitlements, creating its environment instances
+// and subscribing it to its applications. When a step fails, the steps done are undone, down to the
+// deletion of the subaccount.
+func OnboardingWorkflow(c *automation.Clients, o *Onboarding, opts *WorkflowOptions) *Workflow {
+	if opts == nil {
+		opts = &WorkflowOptions{}
+	}
+	ob := &onboarding{clients: c, o: o, interval: opts.PollInterval}
+	w := &Workflow{Name: "onboarding-" + o.Subdomain, err: o.validate()}
+	w.Steps = append(w.Steps,
+		Step{Name: "create-subaccount", Do: ob.createSubAccount, Undo: ob.deleteSubAccount},
+		Step{Name: "service-management-binding", DependsOn: []string{"create-subaccount"},
+			Do: ob.createBinding, Undo: ob.deleteBinding},
+	)
+
+	entitlementSteps := make(map[string]string)
+	for _, e := range o.Entitlements {
+		e := e
+		name := "entitlement:" + e.Service + "/" + e.Plan
+		entitlementSteps[e.Service+"/"+e.Plan] = name
+		w.Steps = append(w.Steps, Step{
+			Name:      name,
+			DependsOn: []string{"create-subaccount"},
+			Do:        func(ctx context.Context, s *State) error { return ob.assign(ctx, s, e, false) },
+			Undo:      func(ctx context.Context, s *State) error { return ob.assign(ctx, s, e, true) },
+		})
+	}
+	// the environments and subscriptions need their plan assigned first
+	dependsOn := func(service, plan string) []string {
+		deps := []string{"service-management-binding"}
+		if name, ok := entitlementSteps[service+"/"+plan]; ok {
+			deps = append(deps, name)
+		}
+		return deps
+	}
+	for _, env := range o.Environments {
+		env := env
+		w.Steps = append(w.Steps, Step{
+			Name:      "environment:" + environmentKey(env),
+			DependsOn: dependsOn(env.Service, env.Plan),
+			Do:        func(ctx context.Context, s *State) error { return ob.createEnvironment(ctx, s, env) },
+			Undo:      func(ctx context.Context, s *State) error { return ob.deleteEnvironment(ctx, s, env) },
+		})
+	}
+	for _, sub := range o.Subscriptions {
+		sub := sub
+		w.Steps = append(w.Steps, Step{
+			Name:      "subscription:" + sub.App,
+			DependsOn: dependsOn(sub.App, sub.Plan),
+			Do:        func(ctx context.Context, s *State) error { return ob.subscribe(ctx, s, sub) },
+			Undo: func(ctx context.Context, s *State) error {
+				return unsubscribe(ctx, c, s.Get(ValueSubAccount), sub.App, ob.interval)
+			},
+		})
+	}
+	return w
+}
+
+// Validates the onboarding, returning all its errors at once.
+func (o *Onboarding) validate() error {
+	var errs []string
+	if o.Subdomain == "" || o.DisplayName == "" || o.Region == "" {
+		errs = append(errs, "the subaccount needs a subdomain, a display name and a region")
+	}
+	plans := make(map[string]bool)
+	for _, e := range o.Entitlements {
+		if e.Service == "" || e.Plan == "" {
+			errs = append(errs, "an entitlement has no service or plan")
+			continue
+		}
+		key := e.Service + "/" + e.Plan
+		if plans[key] {
+			errs = append(errs, fmt.Sprintf("plan %s is declared twice", key))
+		}
+		plans[key] = true
+		if (e.Amount > 0) == e.Enable {
+			errs = append(errs, fmt.Sprintf("plan %s needs either an amount or enable", key))
+		}
+	}
+	envs := make(map[string]bool)
+	for _, env := range o.Environments {
+		if env.Type == "" || env.Service == "" || env.Plan == "" || env.Name == "" {
+			errs = append(errs, fmt.Sprintf("environment %q needs a type, a service, a plan and a name", env.Name))
+			continue
+		}
+		if envs[environmentKey(env)] {
+			errs = append(errs, fmt.Sprintf("environment %s is declared twice", environmentKey(env)))
+		}
+		envs[environmentKey(env)] = true
+	}
+	apps := make(map[string]bool)
+	for _, sub := range o.Subscriptions {
+		if sub.App == "" {
+			errs = append(errs, "a subscription has no application")
+		}
+		if apps[sub.App] {
+			errs = append(errs, fmt.Sprintf("the subaccount subscribes twice to %s", sub.App))
+		}
+		apps[sub.App] = true
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid onboarding %s; %s", o.Subdomain, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Identifies the environment among the ones of the subaccount, which may have several of a type.
+func environmentKey(env reconcile.Environment) string {
+	return string(env.Type) + "/" + env.Name
+}
+
+type onboarding struct {
+	clients  *automation.Clients
+	o        *Onboarding
+	interval time.Duration
+}
+
+func (ob *onboarding) createSubAccount(ctx context.Context, s *State) error {
+	guid := s.Get(ValueSubAccount)
+	if guid == "" {
+		var props []btpaccounts.KeyValue
+		for _, k := range sortedKeys(ob.o.CustomProperties) {
+			props = append(props, btpaccounts.KeyValue{Key: k, Value: ob.o.CustomProperties[k]})
+		}
+		out, err := ob.clients.Accounts.CreateSubAccount(ctx, &btpaccounts.CreateSubAccountInput{
+			CustomProperties: props,
+			Description:      ob.o.Description,
+			DisplayName:      ob.o.DisplayName,
+			ParentGuid:       ob.o.ParentGuid,
+			Region:           ob.o.Region,
+			SubaccountAdmins: ob.o.Admins,
+			Subdomain:        ob.o.Subdomain,
+		})
+		if err != nil {
+			return err
+		}
+		guid = out.Guid
+		if err := s.Set(ValueSubAccount, guid); err != nil {
+			return err
+		}
+	}
+	_, err := automation.WaitForSubAccount(ctx, ob.clients.Accounts, guid, ob.interval)
+	return err
+}
+
+func (ob *onboarding) deleteSubAccount(ctx context.Context, s *State) error {
+	return deleteSubAccount(ctx, ob.clients, s.Get(ValueSubAccount), ob.interval)
+}
+
+func (ob *onboarding) createBinding(ctx context.Context, s *State) error {
+	guid := s.Get(ValueSubAccount)
+	out, err := ob.clients.Accounts.GetSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.GetServiceManagementBindingInput{SubAccountGuid: guid})
+	if err == nil || out == nil || out.StatusCode != http.StatusNotFound {
+		return err
+	}
+	_, err = ob.clients.Accounts.CreateSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.CreateServiceManagementBindingInput{SubAccountGuid: guid})
+	return err
+}
+
+func (ob *onboarding) deleteBinding(ctx context.Context, s *State) error {
+	return deleteBinding(ctx, ob.clients, s.Get(ValueSubAccount))
+}
+
+// Assigns the entitlement to the subaccount, or removes it with an amount of zero or disabled.
+func (ob *onboarding) assign(ctx context.Context, s *State, e reconcile.Entitlement, remove bool) error {
+	info := btpentitlements.AssignmentInfo{SubAccountGuid: s.Get(ValueSubAccount)}
+	switch {
+	case e.Amount > 0 && remove:
+		zero := uint(0)
+		info.Amount = &zero
+	case e.Amount > 0:
+		info.Amount = &e.Amount
+	default:
+		enable := !remove
+		info.Enable = &enable
+	}
+	return assign(ctx, ob.clients, e.Service, e.Plan, info, ob.interval)
+}
+
+func (ob *onboarding) createEnvironment(ctx context.Context, s *State, env reconcile.Environment) error {
+	scoped, err := ob.clients.SubAccount(ctx, s.Get(ValueSubAccount))
+	if err != nil {
+		return err
+	}
+	key := ValueEnvironmentPrefix + environmentKey(env)
+	id := s.Get(key)
+	if id == "" {
+		out, err := scoped.Provisioning.CreateEnvironmentInstance(ctx, &btpprovisioning.CreateEnvironmentInstanceInput{
+			EnvironmentType: env.Type,
+			Name:            env.Name,
+			Parameters:      env.Parameters,
+			PlanName:        env.Plan,
+			ServiceName:     env.Service,
+		})
+		if err != nil {
+			return err
+		}
+		id = out.Id
+		if err := s.Set(key, id); err != nil {
+			return err
+		}
+	}
+	_, err = automation.WaitForEnvironment(ctx, scoped.Provisioning, id, ob.interval)
+	return err
+}
+
+func (ob *onboarding) deleteEnvironment(ctx context.Context, s *State, env reconcile.Environment) error {
+	return deleteEnvironment(ctx, ob.clients, s.Get(ValueSubAccount), s.Get(ValueEnvironmentPrefix+environmentKey(env)), ob.interval)
+}
+
+func (ob *onboarding) subscribe(ctx context.Context, s *State, sub reconcile.Subscription) error {
+	scoped, err := ob.clients.SubAccount(ctx, s.Get(ValueSubAccount))
+	if err != nil {
+		return err
+	}
+	// a resumed run finds the subscription it requested in progress, or done
+	app, err := automation.WaitForSubscription(ctx, scoped.SaaS, sub.App, ob.interval)
+	if err == nil && app.State == btpsaasmanager.SubscriptionStateSubscribed {
+		return nil
+	}
+	if _, err := scoped.SaaS.SubscribeToApplication(ctx, &btpsaasmanager.SubscribeToApplicationInput{
+		AppName:  sub.App,
+		PlanName: sub.Plan,
+	}); err != nil {
+		return err
+	}
+	_, err = automation.WaitForSubscription(ctx, scoped.SaaS, sub.App, ob.interval)
+	return err
+}
+
+// The operations shared with the offboarding, which do nothing when there is nothing to remove.
+
+func deleteSubAccount(ctx context.Context, c *automation.Clients, guid string, interval time.Duration) error {
+	if guid == "" {
+		return nil
+	}
+	out, err := c.Accounts.DeleteSubAccount(ctx, &btpaccounts.DeleteSubAccountInput{SubAccountGuid: guid})
+	if err != nil {
+		if out != nil && out.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	return automation.WaitForSubAccountDeletion(ctx, c.Accounts, guid, interval)
+}
+
+func deleteBinding(ctx context.Context, c *automation.Clients, guid string) error {
+	out, err := c.Accounts.DeleteSubAccountServiceManagementBinding(ctx,
+		&btpaccounts.DeleteServiceManagementBindingInput{SubAccountGuid: guid})
+	if err != nil && (out == nil || out.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	return nil
+}
+
+func assign(ctx context.Context, c *automation.Clients, service, plan string, info btpentitlements.AssignmentInfo,
+	interval time.Duration) error {
+	out, err := c.Entitlements.UpdateSubAccountServicePlan(ctx, &btpentitlements.UpdateSubAccountServicePlanInput{
+		SubAccountServicePlans: []btpentitlements.SubAccountServicePlan{{
+			ServiceName:     service,
+			ServicePlanName: plan,
+			AssignmentInfo:  []btpentitlements.AssignmentInfo{info},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if out.JobStatusId == nil || *out.JobStatusId == "" {
+		return nil
+	}
+	return automation.WaitForEntitlementsJob(ctx, c.Entitlements, *out.JobStatusId, interval)
+}
+
+func deleteEnvironment(ctx context.Context, c *automation.Clients, guid, id string, interval time.Duration) error {
+	if id == "" {
+		return nil
+	}
+	scoped, err := c.SubAccount(ctx, guid)
+	if err != nil {
+		return err
+	}
+	out, err := scoped.Provisioning.DeleteEnvironmentInstance(ctx, &btpprovisioning.DeleteEnvironmentInstanceInput{EnvironmentInstanceId: id})
+	if err != nil {
+		if out != nil && out.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	return automation.WaitForEnvironmentDeletion(ctx, scoped.Provisioning, id, interval)
+}
+
+func unsubscribe(ctx context.Context, c *automation.Clients, guid, app string, interval time.Duration) error {
+	scoped, err := c.SubAccount(ctx, guid)
+	if err != nil {
+		return err
+	}
+	if err := scoped.SaaS.UnSubscribeFromApplication(ctx, &btpsaasmanager.UnSubscribeFromApplicationInput{AppName: app}); err != nil {
+		return err
+	}
+	_, err = automation.WaitForSubscription(ctx, scoped.SaaS, app, interval)
+	return err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
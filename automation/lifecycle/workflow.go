@@ -0,0 +1,302 @@
+// Package lifecycle onboards and offboards teams: it runs the steps creating a subaccount and what the
+// team needs in it, or removing them, as a workflow.
+//
+//	w := lifecycle.OnboardingWorkflow(clients, &lifecycle.Onboarding{...}, nil)
+//	cp, err := w.Run(ctx, &lifecycle.Options{ID: "onboard-sales", Store: lifecycle.FileStore("runs")})
+//
+// The steps of a workflow run one at a time, each once the steps it depends on are done. The progress
+// is saved in a checkpoint after every step, so that running the workflow again with the same ID
+// resumes an interrupted run where it stopped. When a step fails, the steps already done are undone in
+// the reverse order, unless Options.KeepOnFailure is set, in which case the run can be resumed once
+// the cause of the failure is fixed.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/internal/sapcontext"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Step is a unit of work of a workflow.
+type Step struct {
+	// Identifies the step in the workflow.
+	Name string
+
+	// The names of the steps which must be done before this one.
+	DependsOn []string
+
+	// Does the work of the step. It must be safe to call again after an interruption, e.g. by
+	// recording the IDs of what it creates in the state as soon as it has them.
+	Do func(ctx context.Context, s *State) error
+
+	// Undoes the work of the step when a later step fails; nil when there is nothing to undo.
+	Undo func(ctx context.Context, s *State) error
+}
+
+// Workflow is a graph of steps.
+type Workflow struct {
+	Name  string
+	Steps []Step
+
+	// The error of the description the workflow was built from, returned by Run before any step.
+	err error
+}
+
+// Status is the status of a run of a workflow.
+type Status string
+
+const (
+	// StatusRunning is the status of a run in progress, or interrupted.
+	StatusRunning Status = "RUNNING"
+
+	// StatusCompleted is the status of a run whose steps are all done.
+	StatusCompleted Status = "COMPLETED"
+
+	// StatusFailed is the status of a run stopped at a failed step, whose steps done are kept.
+	StatusFailed Status = "FAILED"
+
+	// StatusCompensated is the status of a run stopped at a failed step, whose steps done were undone.
+	StatusCompensated Status = "COMPENSATED"
+
+	// StatusCompensationFailed is the status of a run which failed to undo some of its steps; running
+	// it again retries undoing them.
+	StatusCompensationFailed Status = "COMPENSATION_FAILED"
+)
+
+// Options tune a run of a workflow.
+type Options struct {
+	// Identifies the run in the store; the name of the workflow when empty.
+	ID string
+
+	// Where the checkpoints of the run are saved; the run cannot be resumed when nil.
+	Store Store
+
+	// Keeps the steps done when a step fails, instead of undoing them, so that the run can be resumed.
+	KeepOnFailure bool
+
+	// Called before every step is done or undone.
+	OnStep func(step string, undo bool)
+
+	// How long undoing the steps done may take; DefaultCompensationTimeout when zero.
+	CompensationTimeout time.Duration
+}
+
+// DefaultCompensationTimeout is how long undoing the steps done may take, when the options of the run
+// do not tell.
+const DefaultCompensationTimeout = 10 * time.Minute
+
+// Run runs the steps of the workflow not done yet, resuming the checkpoint saved under the ID of the
+// run when there is one, and returns the checkpoint of the run. A run stopped by ctx stays RUNNING, so
+// that it can be resumed.
+func (w *Workflow) Run(ctx context.Context, opts *Options) (*Checkpoint, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	order, err := w.order()
+	if err != nil {
+		return nil, err
+	}
+	id := opts.ID
+	if id == "" {
+		id = w.Name
+	}
+
+	var cp *Checkpoint
+	if opts.Store != nil {
+		if cp, err = opts.Store.Load(ctx, id); err != nil {
+			return nil, fmt.Errorf("could not load the checkpoint of run %s; %v", id, err)
+		}
+	}
+	if cp == nil {
+		cp = &Checkpoint{ID: id, Workflow: w.Name, Status: StatusRunning, Values: make(map[string]string)}
+	} else if cp.Workflow != w.Name {
+		return nil, fmt.Errorf("run %s is a run of workflow %s, not %s", id, cp.Workflow, w.Name)
+	} else if cp.Values == nil {
+		// a checkpoint saved before any value was set has none
+		cp.Values = make(map[string]string)
+	}
+	r := &run{ctx: ctx, opts: opts, cp: cp}
+	r.state = &State{run: r}
+
+	switch cp.Status {
+	case StatusCompleted:
+		return cp, nil
+	case StatusCompensated:
+		return cp, fmt.Errorf("run %s was compensated after step %s failed; %s", id, cp.FailedStep, cp.Error)
+	case StatusCompensationFailed:
+		return cp, r.compensate(w, fmt.Errorf("step %s failed; %s", cp.FailedStep, cp.Error))
+	}
+
+	cp.Status, cp.FailedStep, cp.Error = StatusRunning, "", ""
+	for _, step := range order {
+		if cp.done(step.Name) {
+			continue
+		}
+		if opts.OnStep != nil {
+			opts.OnStep(step.Name, false)
+		}
+		if err := step.Do(ctx, r.state); err != nil {
+			if ctx.Err() != nil {
+				return cp, r.save(fmt.Errorf("run %s interrupted at step %s; %v", id, step.Name, err))
+			}
+			err = fmt.Errorf("step %s failed; %v", step.Name, err)
+			cp.FailedStep, cp.Error = step.Name, err.Error()
+			if opts.KeepOnFailure {
+				cp.Status = StatusFailed
+				return cp, r.save(err)
+			}
+			return cp, r.compensate(w, err)
+		}
+		cp.Completed = append(cp.Completed, step.Name)
+		if err := r.save(nil); err != nil {
+			return cp, err
+		}
+	}
+	cp.Status = StatusCompleted
+	return cp, r.save(nil)
+}
+
+// A run in progress.
+type run struct {
+	ctx   context.Context
+	opts  *Options
+	cp    *Checkpoint
+	state *State
+}
+
+// Saves the checkpoint, and returns cause, or the error of the save when there is no cause.
+func (r *run) save(cause error) error {
+	if r.opts.Store == nil {
+		return cause
+	}
+	r.cp.UpdatedAt = time.Now().UTC()
+	if err := r.opts.Store.Save(r.ctx, r.cp); err != nil {
+		if cause != nil {
+			return fmt.Errorf("%v; could not save the checkpoint of run %s; %v", cause, r.cp.ID, err)
+		}
+		return fmt.Errorf("could not save the checkpoint of run %s; %v", r.cp.ID, err)
+	}
+	return cause
+}
+
+// Undoes the completed steps in the reverse order, and returns the cause of the failure along with
+// the errors of the steps which could not be undone. The steps are undone, and the checkpoint saved,
+// on a context of their own, so that a run whose context is done by then is still compensated.
+func (r *run) compensate(w *Workflow, cause error) error {
+	timeout := r.opts.CompensationTimeout
+	if timeout <= 0 {
+		timeout = DefaultCompensationTimeout
+	}
+	ctx, cancel := context.WithTimeout(sapcontext.Detached(r.ctx), timeout)
+	defer cancel()
+	r.ctx = ctx
+
+	steps := make(map[string]*Step, len(w.Steps))
+	for i := range w.Steps {
+		steps[w.Steps[i].Name] = &w.Steps[i]
+	}
+
+	var errs []string
+	for i := len(r.cp.Completed) - 1; i >= 0; i-- {
+		name := r.cp.Completed[i]
+		step := steps[name]
+		if step == nil || step.Undo == nil || r.cp.undone(name) {
+			continue
+		}
+		if r.opts.OnStep != nil {
+			r.opts.OnStep(name, true)
+		}
+		if err := step.Undo(r.ctx, r.state); err != nil {
+			errs = append(errs, fmt.Sprintf("could not undo step %s; %v", name, err))
+			if r.ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		r.cp.Compensated = append(r.cp.Compensated, name)
+		if err := r.save(nil); err != nil {
+			errs = append(errs, err.Error())
+			break
+		}
+	}
+
+	r.cp.Status = StatusCompensated
+	if len(errs) > 0 {
+		r.cp.Status = StatusCompensationFailed
+		return r.save(fmt.Errorf("%v; %s", cause, strings.Join(errs, "; ")))
+	}
+	return r.save(cause)
+}
+
+// Returns the steps in an order where every step comes after the ones it depends on, keeping the
+// order of declaration otherwise.
+func (w *Workflow) order() ([]*Step, error) {
+	index := make(map[string]int, len(w.Steps))
+	for i, step := range w.Steps {
+		if step.Name == "" || step.Do == nil {
+			return nil, fmt.Errorf("step %d of workflow %s needs a name and a Do function", i, w.Name)
+		}
+		if _, ok := index[step.Name]; ok {
+			return nil, fmt.Errorf("workflow %s declares step %s twice", w.Name, step.Name)
+		}
+		index[step.Name] = i
+	}
+	pending := make([]int, len(w.Steps))
+	dependents := make(map[string][]int)
+	for i, step := range w.Steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("step %s depends on unknown step %s", step.Name, dep)
+			}
+			pending[i]++
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	var ready []int
+	for i := range w.Steps {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	order := make([]*Step, 0, len(w.Steps))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+		order = append(order, &w.Steps[i])
+		for _, d := range dependents[w.Steps[i].Name] {
+			if pending[d]--; pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	if len(order) != len(w.Steps) {
+		return nil, fmt.Errorf("the steps of workflow %s depend on each other in a cycle", w.Name)
+	}
+	return order, nil
+}
+
+// State holds the values the steps of a run share, e.g. the GUID of the subaccount created; it is
+// saved with the checkpoint.
+type State struct {
+	run *run
+}
+
+// Get returns the value saved under the key, or "" when there is none.
+func (s *State) Get(key string) string {
+	return s.run.cp.Values[key]
+}
+
+// Set saves the value under the key and saves the checkpoint at once, so that a resumed run finds it
+// even when the step setting it was interrupted.
+func (s *State) Set(key, value string) error {
+	s.run.cp.Values[key] = value
+	return s.run.save(nil)
+}
@@ -0,0 +1,402 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+)
+
+// Run reads the live state, plans the changes bringing it to the model and applies them. It returns
+// the plan, which is only partially applied when an error is returned.
+func Run(ctx context.Context, c *automation.Clients, m *Model, opts *Options) (*Plan, error) {
+	state, err := Read(ctx, c, m)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := NewPlan(m, state, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, plan.Apply(ctx, c, opts)
+}
+
+// Apply applies the actions of the plan one after the other, each once the actions it depends on are
+// applied, and waits for the asynchronous ones to complete. It stops at the first action which fails.
+func (p *Plan) Apply(ctx context.Context, c *automation.Clients, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	order, err := p.order()
+	if err != nil {
+		return err
+	}
+
+	ap := &applier{
+		ctx:               ctx,
+		clients:           c,
+		opts:              opts,
+		globalAccountGuid: p.globalAccountGuid,
+		directories:       make(map[string]string, len(p.directories)),
+		subAccounts:       make(map[string]string, len(p.subAccounts)),
+	}
+	for path, guid := range p.directories {
+		ap.directories[path] = guid
+	}
+	for subdomain, guid := range p.subAccounts {
+		ap.subAccounts[subdomain] = guid
+	}
+
+	for _, a := range order {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if opts.OnAction != nil {
+			opts.OnAction(a)
+		}
+		if a.run == nil {
+			return fmt.Errorf("%s was not planned by NewPlan", a)
+		}
+		if err := a.run(ap); err != nil {
+			return fmt.Errorf("%s failed; %v", a, err)
+		}
+	}
+	return nil
+}
+
+// Orders the actions so that each comes after the actions it depends on, keeping the order of the
+// plan otherwise. Dependencies on objects which no action of the plan acts on are ignored.
+func (p *Plan) order() ([]*Action, error) {
+	pending := make(map[string]int)
+	for _, a := range p.Actions {
+		pending[a.Ref()]++
+	}
+
+	order := make([]*Action, 0, len(p.Actions))
+	done := make([]bool, len(p.Actions))
+	for len(order) < len(p.Actions) {
+		progress := false
+		for i, a := range p.Actions {
+			if done[i] || !p.ready(a, pending) {
+				continue
+			}
+			done[i], progress = true, true
+			pending[a.Ref()]--
+			order = append(order, a)
+			break
+		}
+		if !progress {
+			return nil, fmt.Errorf("the actions of the plan depend on each other")
+		}
+	}
+	return order, nil
+}
+
+func (p *Plan) ready(a *Action, pending map[string]int) bool {
+	for _, dep := range a.DependsOn {
+		if dep != a.Ref() && pending[dep] > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type applier struct {
+	ctx     context.Context
+	clients *automation.Clients
+	opts    *Options
+
+	// GUIDs of the global account, and of the directories and subaccounts, including the ones created.
+	globalAccountGuid string
+	directories       map[string]string
+	subAccounts       map[string]string
+}
+
+func (ap *applier) directoryGuid(path string) (string, error) {
+	if path == "" {
+		return ap.globalAccountGuid, nil
+	}
+	if guid, ok := ap.directories[path]; ok {
+		return guid, nil
+	}
+	return "", fmt.Errorf("directory %s does not exist", path)
+}
+
+func (ap *applier) subAccountGuid(subdomain string) (string, error) {
+	if guid, ok := ap.subAccounts[subdomain]; ok {
+		return guid, nil
+	}
+	return "", fmt.Errorf("subaccount %s does not exist", subdomain)
+}
+
+func (ap *applier) scoped(subdomain string) (*automation.SubAccountClients, error) {
+	guid, err := ap.subAccountGuid(subdomain)
+	if err != nil {
+		return nil, err
+	}
+	return ap.clients.SubAccount(ap.ctx, guid)
+}
+
+func (ap *applier) createDirectory(path, parent string, d *Directory) error {
+	parentGuid, err := ap.directoryGuid(parent)
+	if err != nil {
+		return err
+	}
+	out, err := ap.clients.Accounts.CreateDirectory(ap.ctx, &btpaccounts.CreateDirectoryInput{
+		CustomProperties:  customProperties(d.CustomProperties),
+		Description:       d.Description,
+		DirectoryAdmins:   d.Admins,
+		DirectoryFeatures: d.Features,
+		DisplayName:       d.DisplayName,
+		ParentGuid:        parentGuid,
+		Subdomain:         d.Subdomain,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := automation.WaitForDirectory(ap.ctx, ap.clients.Accounts, out.Guid, ap.opts.PollInterval); err != nil {
+		return err
+	}
+	ap.directories[path] = out.Guid
+	return nil
+}
+
+func (ap *applier) updateDirectory(update *btpaccounts.UpdateDirectoryInput, features []string, subdomain string) error {
+	if len(features) > 0 {
+		if _, err := ap.clients.Accounts.UpdateDirectoryFeatures(ap.ctx, &btpaccounts.UpdateDirectoryFeaturesInput{
+			DirectoryGuid:     update.DirectoryGuid,
+			DirectoryFeatures: features,
+			Subdomain:         subdomain,
+		}); err != nil {
+			return err
+		}
+	}
+	if update.Description != "" || len(update.CustomProperties) > 0 {
+		if _, err := ap.clients.Accounts.UpdateDirectory(ap.ctx, update); err != nil {
+			return err
+		}
+	}
+	_, err := automation.WaitForDirectory(ap.ctx, ap.clients.Accounts, update.DirectoryGuid, ap.opts.PollInterval)
+	return err
+}
+
+func (ap *applier) deleteDirectory(path string) error {
+	guid, err := ap.directoryGuid(path)
+	if err != nil {
+		return err
+	}
+	if _, err := ap.clients.Accounts.DeleteDirectory(ap.ctx, &btpaccounts.DeleteDirectoryInput{DirectoryGuid: guid}); err != nil {
+		return err
+	}
+	if err := automation.WaitForDirectoryDeletion(ap.ctx, ap.clients.Accounts, guid, ap.opts.PollInterval); err != nil {
+		return err
+	}
+	delete(ap.directories, path)
+	return nil
+}
+
+func (ap *applier) createSubAccount(parent string, sa *SubAccount) error {
+	parentGuid, err := ap.directoryGuid(parent)
+	if err != nil {
+		return err
+	}
+	out, err := ap.clients.Accounts.CreateSubAccount(ap.ctx, &btpaccounts.CreateSubAccountInput{
+		BetaEnabled:       sa.BetaEnabled,
+		CustomProperties:  customProperties(sa.CustomProperties),
+		Description:       sa.Description,
+		DisplayName:       sa.DisplayName,
+		ParentGuid:        parentGuid,
+		Region:            sa.Region,
+		SubaccountAdmins:  sa.Admins,
+		Subdomain:         sa.Subdomain,
+		UsedForProduction: sa.UsedForProduction,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := automation.WaitForSubAccount(ap.ctx, ap.clients.Accounts, out.Guid, ap.opts.PollInterval); err != nil {
+		return err
+	}
+	ap.subAccounts[sa.Subdomain] = out.Guid
+	return nil
+}
+
+func (ap *applier) updateSubAccount(update *btpaccounts.UpdateSubAccountInput) error {
+	if _, err := ap.clients.Accounts.UpdateSubAccount(ap.ctx, update); err != nil {
+		return err
+	}
+	_, err := automation.WaitForSubAccount(ap.ctx, ap.clients.Accounts, update.SubAccountGuid, ap.opts.PollInterval)
+	return err
+}
+
+func (ap *applier) moveSubAccount(subdomain, parent string) error {
+	guid, err := ap.subAccountGuid(subdomain)
+	if err != nil {
+		return err
+	}
+	target, err := ap.directoryGuid(parent)
+	if err != nil {
+		return err
+	}
+	if _, err := ap.clients.Accounts.MoveSubAccount(ap.ctx, &btpaccounts.MoveSubAccountInput{
+		SubAccountGuid:    guid,
+		TargetAccountGuid: target,
+	}); err != nil {
+		return err
+	}
+	_, err = automation.WaitForSubAccount(ap.ctx, ap.clients.Accounts, guid, ap.opts.PollInterval)
+	return err
+}
+
+func (ap *applier) deleteSubAccount(subdomain string) error {
+	guid, err := ap.subAccountGuid(subdomain)
+	if err != nil {
+		return err
+	}
+	if _, err := ap.clients.Accounts.DeleteSubAccount(ap.ctx, &btpaccounts.DeleteSubAccountInput{SubAccountGuid: guid}); err != nil {
+		return err
+	}
+	if err := automation.WaitForSubAccountDeletion(ap.ctx, ap.clients.Accounts, guid, ap.opts.PollInterval); err != nil {
+		return err
+	}
+	delete(ap.subAccounts, subdomain)
+	return nil
+}
+
+// Assigns the entitlement to the directory at path ownerKey, or to the subaccount with subdomain
+// ownerKey; removes it instead when remove is set, with an amount of zero when the plan is quota based.
+func (ap *applier) assign(directory bool, ownerKey string, e Entitlement, remove, quotaBased bool) error {
+	var amount *uint
+	var enable *bool
+	switch {
+	case remove && quotaBased:
+		zero := uint(0)
+		amount = &zero
+	case remove:
+		disabled := false
+		enable = &disabled
+	case e.Amount > 0:
+		amount = &e.Amount
+	default:
+		enable = &e.Enable
+	}
+
+	if directory {
+		guid, err := ap.directoryGuid(ownerKey)
+		if err != nil {
+			return err
+		}
+		de := btpentitlements.DirectoryEntitlement{
+			Service:    e.Service,
+			Plan:       e.Plan,
+			Amount:     amount,
+			Enable:     enable,
+			Distribute: e.Distribute && !remove,
+			AutoAssign: e.AutoAssign && !remove,
+		}
+		if e.AutoDistributeAmount > 0 && !remove {
+			de.AutoDistributeAmount = &e.AutoDistributeAmount
+		}
+		_, err = ap.clients.Entitlements.UpdateDirectoryEntitlements(ap.ctx, &btpentitlements.UpdateDirectoryEntitlementsInput{
+			DirectoryGuid:         guid,
+			DirectoryEntitlements: []btpentitlements.DirectoryEntitlement{de},
+		})
+		return err
+	}
+
+	guid, err := ap.subAccountGuid(ownerKey)
+	if err != nil {
+		return err
+	}
+	out, err := ap.clients.Entitlements.UpdateSubAccountServicePlan(ap.ctx, &btpentitlements.UpdateSubAccountServicePlanInput{
+		SubAccountServicePlans: []btpentitlements.SubAccountServicePlan{{
+			ServiceName:     e.Service,
+			ServicePlanName: e.Plan,
+			AssignmentInfo: []btpentitlements.AssignmentInfo{{
+				Amount:         amount,
+				Enable:         enable,
+				SubAccountGuid: guid,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if out.JobStatusId == nil || *out.JobStatusId == "" {
+		return nil
+	}
+	return automation.WaitForEntitlementsJob(ap.ctx, ap.clients.Entitlements, *out.JobStatusId, ap.opts.PollInterval)
+}
+
+func (ap *applier) createEnvironment(subdomain string, env *Environment) error {
+	scoped, err := ap.scoped(subdomain)
+	if err != nil {
+		return err
+	}
+	out, err := scoped.Provisioning.CreateEnvironmentInstance(ap.ctx, &btpprovisioning.CreateEnvironmentInstanceInput{
+		EnvironmentType: env.Type,
+		Name:            env.Name,
+		Parameters:      env.Parameters,
+		PlanName:        env.Plan,
+		ServiceName:     env.Service,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = automation.WaitForEnvironment(ap.ctx, scoped.Provisioning, out.Id, ap.opts.PollInterval)
+	return err
+}
+
+func (ap *applier) updateEnvironment(subdomain string, update *btpprovisioning.UpdateEnvironmentInstanceInput) error {
+	scoped, err := ap.scoped(subdomain)
+	if err != nil {
+		return err
+	}
+	if _, err := scoped.Provisioning.UpdateEnvironmentInstance(ap.ctx, update); err != nil {
+		return err
+	}
+	_, err = automation.WaitForEnvironment(ap.ctx, scoped.Provisioning, update.EnvironmentInstanceId, ap.opts.PollInterval)
+	return err
+}
+
+func (ap *applier) deleteEnvironment(subdomain, id string) error {
+	scoped, err := ap.scoped(subdomain)
+	if err != nil {
+		return err
+	}
+	if _, err := scoped.Provisioning.DeleteEnvironmentInstance(ap.ctx,
+		&btpprovisioning.DeleteEnvironmentInstanceInput{EnvironmentInstanceId: id}); err != nil {
+		return err
+	}
+	return automation.WaitForEnvironmentDeletion(ap.ctx, scoped.Provisioning, id, ap.opts.PollInterval)
+}
+
+func (ap *applier) subscribe(subdomain string, sub Subscription) error {
+	scoped, err := ap.scoped(subdomain)
+	if err != nil {
+		return err
+	}
+	if _, err := scoped.SaaS.SubscribeToApplication(ap.ctx, &btpsaasmanager.SubscribeToApplicationInput{
+		AppName:  sub.App,
+		PlanName: sub.Plan,
+	}); err != nil {
+		return err
+	}
+	_, err = automation.WaitForSubscription(ap.ctx, scoped.SaaS, sub.App, ap.opts.PollInterval)
+	return err
+}
+
+func (ap *applier) unsubscribe(subdomain, app string) error {
+	scoped, err := ap.scoped(subdomain)
+	if err != nil {
+		return err
+	}
+	if err := scoped.SaaS.UnSubscribeFromApplication(ap.ctx, &btpsaasmanager.UnSubscribeFromApplicationInput{AppName: app}); err != nil {
+		return err
+	}
+	_, err = automation.WaitForSubscription(ap.ctx, scoped.SaaS, app, ap.opts.PollInterval)
+	return err
+}
@@ -0,0 +1,250 @@
+// Package reconcile brings the directories, subaccounts, entitlements, environments and SaaS
+// subscriptions of a global account to a declared state:
+//
+//	state, err := reconcile.Read(ctx, clients, model)
+//	plan, err := reconcile.NewPlan(model, state, &reconcile.Options{Prune: true})
+//	fmt.Print(plan)
+//	err = plan.Apply(ctx, clients, nil)
+//
+// Directories are identified by the path of their display names from the global account, e.g.
+// Finance/EU, and subaccounts by their subdomain. What the model does not declare is left untouched,
+// unless Options.Prune is set.
+package reconcile
+
+import (
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"strings"
+)
+
+// Model is the desired state of the directories and subaccounts of a global account.
+type Model struct {
+	// The directories directly under the global account.
+	Directories []Directory `json:"directories,omitempty"`
+
+	// The subaccounts directly under the global account.
+	SubAccounts []SubAccount `json:"subaccounts,omitempty"`
+}
+
+// Directory is the desired state of a directory and of its content.
+type Directory struct {
+	// Identifies the directory among the ones of its parent.
+	DisplayName string `json:"displayName"`
+	Description string `json:"description,omitempty"`
+
+	// Required when Features holds AUTHORIZATIONS.
+	Subdomain string `json:"subdomain,omitempty"`
+
+	// The features of the directory, among DEFAULT, ENTITLEMENTS and AUTHORIZATIONS; left as they are
+	// when nil. Features cannot be disabled once enabled.
+	Features []string `json:"features,omitempty"`
+
+	// The admins of the directory, set only when it is created.
+	Admins []string `json:"admins,omitempty"`
+
+	// The custom properties of the directory; left as they are when nil, removed when empty.
+	CustomProperties map[string]string `json:"customProperties,omitempty"`
+
+	// The entitlements assigned to the directory, which requires the ENTITLEMENTS feature.
+	Entitlements []Entitlement `json:"entitlements,omitempty"`
+
+	Directories []Directory  `json:"directories,omitempty"`
+	SubAccounts []SubAccount `json:"subaccounts,omitempty"`
+}
+
+// SubAccount is the desired state of a subaccount and of what runs in it.
+type SubAccount struct {
+	// Identifies the subaccount in the global account.
+	Subdomain   string `json:"subdomain"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description,omitempty"`
+
+	// The region of the subaccount, which cannot be changed once created.
+	Region string `json:"region"`
+
+	// Left as it is when empty.
+	UsedForProduction btpaccounts.UsedForProduction `json:"usedForProduction,omitempty"`
+
+	// Beta features cannot be disabled once enabled.
+	BetaEnabled bool `json:"betaEnabled,omitempty"`
+
+	// The admins of the subaccount, set only when it is created.
+	Admins []string `json:"admins,omitempty"`
+
+	// The custom properties of the subaccount; left as they are when nil, removed when empty.
+	CustomProperties map[string]string `json:"customProperties,omitempty"`
+
+	Entitlements  []Entitlement  `json:"entitlements,omitempty"`
+	Environments  []Environment  `json:"environments,omitempty"`
+	Subscriptions []Subscription `json:"subscriptions,omitempty"`
+}
+
+// Entitlement is a plan assigned to a directory or a subaccount.
+type Entitlement struct {
+	Service string `json:"service"`
+	Plan    string `json:"plan"`
+
+	// The quota assigned, for plans which have a numeric quota.
+	Amount uint `json:"amount,omitempty"`
+
+	// Whether the plan is assigned, for plans which have no numeric quota.
+	Enable bool `json:"enable,omitempty"`
+
+	// Whether the plan is also assigned to the subaccounts currently in the directory when the
+	// entitlement is created or updated. Directories only.
+	Distribute bool `json:"distribute,omitempty"`
+
+	// Whether the plan is assigned to the subaccounts added to the directory. Directories only.
+	AutoAssign bool `json:"autoAssign,omitempty"`
+
+	// The quota assigned to the subaccounts of the directory with AutoAssign or Distribute.
+	// Directories only.
+	AutoDistributeAmount uint `json:"autoDistributeAmount,omitempty"`
+}
+
+func (e Entitlement) key() string {
+	return e.Service + "/" + e.Plan
+}
+
+// Environment is an environment instance of a subaccount. A subaccount has at most one environment
+// instance of each type, which identifies it.
+type Environment struct {
+	Type    btpprovisioning.EnvironmentType `json:"type"`
+	Service string                          `json:"service"`
+	Plan    string                          `json:"plan"`
+
+	// The name of the instance, which cannot be changed once created.
+	Name string `json:"name"`
+
+	// The configuration parameters of the instance; left as they are when nil.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// Subscription is the subscription of a subaccount to a multitenant application. Changing the plan
+// of a subscription unsubscribes the subaccount and subscribes it again.
+type Subscription struct {
+	App  string `json:"app"`
+	Plan string `json:"plan,omitempty"`
+}
+
+// Validates the model, returning all its errors at once.
+func (m *Model) validate() error {
+	var errs []string
+	subdomains := make(map[string]bool)
+
+	var subAccounts func(parent string, sas []SubAccount)
+	subAccounts = func(parent string, sas []SubAccount) {
+		for _, sa := range sas {
+			if sa.Subdomain == "" {
+				errs = append(errs, fmt.Sprintf("subaccount %q in %s has no subdomain", sa.DisplayName, describeParent(parent)))
+				continue
+			}
+			if subdomains[sa.Subdomain] {
+				errs = append(errs, fmt.Sprintf("subaccount %s is declared twice", sa.Subdomain))
+			}
+			subdomains[sa.Subdomain] = true
+			if sa.DisplayName == "" || sa.Region == "" {
+				errs = append(errs, fmt.Sprintf("subaccount %s needs a display name and a region", sa.Subdomain))
+			}
+			errs = append(errs, validateEntitlements("subaccount "+sa.Subdomain, sa.Entitlements, false)...)
+
+			types := make(map[btpprovisioning.EnvironmentType]bool)
+			for _, env := range sa.Environments {
+				if env.Type == "" || env.Service == "" || env.Plan == "" || env.Name == "" {
+					errs = append(errs, fmt.Sprintf("environment %q of subaccount %s needs a type, a service, a plan and a name",
+						env.Name, sa.Subdomain))
+				}
+				if types[env.Type] {
+					errs = append(errs, fmt.Sprintf("subaccount %s declares two environments of type %s", sa.Subdomain, env.Type))
+				}
+				types[env.Type] = true
+			}
+			apps := make(map[string]bool)
+			for _, sub := range sa.Subscriptions {
+				if sub.App == "" {
+					errs = append(errs, fmt.Sprintf("a subscription of subaccount %s has no application", sa.Subdomain))
+				}
+				if apps[sub.App] {
+					errs = append(errs, fmt.Sprintf("subaccount %s subscribes twice to %s", sa.Subdomain, sub.App))
+				}
+				apps[sub.App] = true
+			}
+		}
+	}
+
+	var directories func(parent string, dirs []Directory)
+	directories = func(parent string, dirs []Directory) {
+		names := make(map[string]bool)
+		for _, d := range dirs {
+			if d.DisplayName == "" || strings.Contains(d.DisplayName, "/") {
+				errs = append(errs, fmt.Sprintf("directory %q in %s needs a display name without '/'", d.DisplayName, describeParent(parent)))
+				continue
+			}
+			if names[d.DisplayName] {
+				errs = append(errs, fmt.Sprintf("directory %s is declared twice", joinPath(parent, d.DisplayName)))
+			}
+			names[d.DisplayName] = true
+
+			path := joinPath(parent, d.DisplayName)
+			if len(d.Entitlements) > 0 && d.Features != nil && !hasString(d.Features, "ENTITLEMENTS") {
+				errs = append(errs, fmt.Sprintf("directory %s has entitlements but not the ENTITLEMENTS feature", path))
+			}
+			errs = append(errs, validateEntitlements("directory "+path, d.Entitlements, true)...)
+			directories(path, d.Directories)
+			subAccounts(path, d.SubAccounts)
+		}
+	}
+
+	directories("", m.Directories)
+	subAccounts("", m.SubAccounts)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid model; %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func validateEntitlements(owner string, entitlements []Entitlement, directory bool) []string {
+	var errs []string
+	plans := make(map[string]bool)
+	for _, e := range entitlements {
+		if e.Service == "" || e.Plan == "" {
+			errs = append(errs, fmt.Sprintf("an entitlement of %s has no service or plan", owner))
+			continue
+		}
+		if plans[e.key()] {
+			errs = append(errs, fmt.Sprintf("%s declares plan %s twice", owner, e.key()))
+		}
+		plans[e.key()] = true
+		if (e.Amount > 0) == e.Enable {
+			errs = append(errs, fmt.Sprintf("plan %s of %s needs either an amount or enable", e.key(), owner))
+		}
+		if !directory && (e.Distribute || e.AutoAssign || e.AutoDistributeAmount > 0) {
+			errs = append(errs, fmt.Sprintf("plan %s of %s can only be distributed by directories", e.key(), owner))
+		}
+	}
+	return errs
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+func describeParent(path string) string {
+	if path == "" {
+		return "the global account"
+	}
+	return "directory " + path
+}
+
+func hasString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
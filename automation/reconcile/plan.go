@@ -0,0 +1,698 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ActionType is what an action does to the object it acts on.
+type ActionType string
+
+const (
+	ActionCreate ActionType = "create"
+	ActionUpdate ActionType = "update"
+	ActionMove   ActionType = "move"
+	ActionDelete ActionType = "delete"
+)
+
+// Kind is the kind of object an action acts on.
+type Kind string
+
+const (
+	KindDirectory    Kind = "directory"
+	KindSubAccount   Kind = "subaccount"
+	KindEntitlement  Kind = "entitlement"
+	KindEnvironment  Kind = "environment"
+	KindSubscription Kind = "subscription"
+)
+
+// Options tune how a plan is computed and applied.
+type Options struct {
+	// Whether the directories, subaccounts, entitlements, environments and subscriptions which the
+	// model does not declare are deleted. Plans assigned automatically are kept.
+	Prune bool
+
+	// The time waited between two polls of the asynchronous operations.
+	// automation.DefaultPollInterval when zero.
+	PollInterval time.Duration
+
+	// Called before each action is applied.
+	OnAction func(action *Action)
+}
+
+// Action is a change of the live state.
+type Action struct {
+	Type ActionType
+	Kind Kind
+
+	// Identifies the object acted on: the path of a directory, the subdomain of a subaccount, or the
+	// directory or subaccount followed by the plan, environment type or application, e.g.
+	// "subaccount dev-eu objectstore/standard" for an entitlement.
+	Key string
+
+	// The GUID or ID of the live object, empty for creations.
+	ID string
+
+	// What changes, e.g. `description: "old" -> "new"`.
+	Changes []string
+
+	// The references, as returned by Ref, of the actions to apply before this one.
+	DependsOn []string
+
+	run func(a *applier) error
+}
+
+// Ref returns the reference to the object of the action, which other actions depend on.
+func (a *Action) Ref() string {
+	return string(a.Kind) + " " + a.Key
+}
+
+func (a *Action) String() string {
+	s := string(a.Type) + " " + a.Ref()
+	if len(a.Changes) > 0 {
+		s += " (" + strings.Join(a.Changes, ", ") + ")"
+	}
+	return s
+}
+
+// Plan is the list of actions bringing the live state to the one of the model.
+type Plan struct {
+	Actions []*Action
+
+	globalAccountGuid string
+	directories       map[string]string
+	subAccounts       map[string]string
+}
+
+// Empty tells whether the live state is already the one of the model.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// String lists the actions of the plan, one per line.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, a := range p.Actions {
+		b.WriteString(a.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// The phases of a plan, in the order they are applied.
+const (
+	phaseCreateDirectories = iota
+	phaseUpdateDirectories
+	phaseCreateSubAccounts
+	phaseUpdateSubAccounts
+	phaseMoveSubAccounts
+	phaseDirectoryEntitlements
+	phaseSubAccountEntitlements
+	phaseEnvironments
+	phaseSubscriptions
+	phaseDeleteSubscriptions
+	phaseDeleteEnvironments
+	phaseRemoveSubAccountEntitlements
+	phaseRemoveDirectoryEntitlements
+	phaseDeleteSubAccounts
+	phaseDeleteDirectories
+	phases
+)
+
+type planner struct {
+	state  *State
+	opts   *Options
+	phases [phases][]*Action
+	errs   []string
+}
+
+// NewPlan compares the model with the live state and returns the actions bringing the latter to the
+// former. It fails without any action when the model is invalid or asks for changes which cannot be
+// made, such as moving a subaccount to another region.
+func NewPlan(m *Model, s *State, opts *Options) (*Plan, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	p := &planner{state: s, opts: opts}
+	p.directories("", m.Directories)
+	p.subAccounts("", m.SubAccounts)
+	if opts.Prune {
+		p.prune(m)
+	}
+	if len(p.errs) > 0 {
+		return nil, fmt.Errorf("the model cannot be reconciled; %s", strings.Join(p.errs, "; "))
+	}
+
+	plan := &Plan{
+		globalAccountGuid: s.GlobalAccountGuid,
+		directories:       make(map[string]string),
+		subAccounts:       make(map[string]string),
+	}
+	for path, d := range s.Directories {
+		plan.directories[path] = d.Guid
+	}
+	for subdomain, sa := range s.SubAccounts {
+		plan.subAccounts[subdomain] = sa.Guid
+	}
+	for _, actions := range p.phases {
+		plan.Actions = append(plan.Actions, actions...)
+	}
+	return plan, nil
+}
+
+func (p *planner) add(phase int, a *Action) *Action {
+	p.phases[phase] = append(p.phases[phase], a)
+	return a
+}
+
+func (p *planner) fail(format string, args ...interface{}) {
+	p.errs = append(p.errs, fmt.Sprintf(format, args...))
+}
+
+func (p *planner) directories(parent string, dirs []Directory) {
+	for i := range dirs {
+		d := dirs[i]
+		path := joinPath(parent, d.DisplayName)
+		live, exists := p.state.Directories[path]
+
+		if !exists {
+			a := p.add(phaseCreateDirectories, &Action{Type: ActionCreate, Kind: KindDirectory, Key: path})
+			if parent != "" {
+				a.DependsOn = []string{refDirectory(parent)}
+			}
+			a.run = func(ap *applier) error { return ap.createDirectory(path, parent, &d) }
+		} else {
+			p.updateDirectory(&d, live)
+		}
+
+		owner := refDirectory(path)
+		current := map[string]Assignment{}
+		if exists {
+			current = live.Entitlements
+		}
+		for _, e := range d.Entitlements {
+			p.entitlement(phaseDirectoryEntitlements, owner, path, e, current, []string{owner})
+		}
+
+		p.directories(path, d.Directories)
+		p.subAccounts(path, d.SubAccounts)
+	}
+}
+
+func (p *planner) updateDirectory(d *Directory, live *DirectoryState) {
+	path := live.Path
+	var changes []string
+	if d.Subdomain != "" && live.Subdomain != "" && d.Subdomain != live.Subdomain {
+		p.fail("the subdomain of directory %s cannot be changed from %s to %s", path, live.Subdomain, d.Subdomain)
+	}
+
+	var features []string
+	if d.Features != nil {
+		features = append([]string{}, d.Features...)
+		if !hasString(features, "DEFAULT") {
+			features = append([]string{"DEFAULT"}, features...)
+		}
+		for _, f := range live.DirectoryFeatures {
+			if !hasString(features, f) {
+				p.fail("feature %s of directory %s cannot be disabled", f, path)
+			}
+		}
+		for _, f := range features {
+			if !hasString(live.DirectoryFeatures, f) {
+				changes = append(changes, "feature "+f+" enabled")
+			}
+		}
+		if len(changes) == 0 {
+			features = nil
+		}
+	}
+
+	update := &btpaccounts.UpdateDirectoryInput{DirectoryGuid: live.Guid}
+	if d.Description != "" && d.Description != live.Description {
+		update.Description = d.Description
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", live.Description, d.Description))
+	}
+	var propertyChanges []string
+	update.CustomProperties, propertyChanges = directoryProperties(d.CustomProperties, live.CustomProperties)
+	changes = append(changes, propertyChanges...)
+	if len(changes) == 0 {
+		return
+	}
+
+	a := p.add(phaseUpdateDirectories, &Action{Type: ActionUpdate, Kind: KindDirectory, Key: path, ID: live.Guid, Changes: changes})
+	a.run = func(ap *applier) error { return ap.updateDirectory(update, features, d.Subdomain) }
+}
+
+func (p *planner) subAccounts(parent string, sas []SubAccount) {
+	for i := range sas {
+		sa := sas[i]
+		live, exists := p.state.SubAccounts[sa.Subdomain]
+		owner := refSubAccount(sa.Subdomain)
+
+		if !exists {
+			a := p.add(phaseCreateSubAccounts, &Action{Type: ActionCreate, Kind: KindSubAccount, Key: sa.Subdomain})
+			if parent != "" {
+				a.DependsOn = []string{refDirectory(parent)}
+			}
+			a.run = func(ap *applier) error { return ap.createSubAccount(parent, &sa) }
+			live = &SubAccountState{}
+		} else {
+			p.updateSubAccount(&sa, live)
+			if live.ParentPath != parent {
+				a := p.add(phaseMoveSubAccounts, &Action{Type: ActionMove, Kind: KindSubAccount, Key: sa.Subdomain, ID: live.Guid,
+					Changes: []string{fmt.Sprintf("from %s to %s", describeParent(live.ParentPath), describeParent(parent))}})
+				if parent != "" {
+					a.DependsOn = []string{refDirectory(parent)}
+				}
+				a.run = func(ap *applier) error { return ap.moveSubAccount(sa.Subdomain, parent) }
+			}
+		}
+
+		for _, e := range sa.Entitlements {
+			deps := []string{owner}
+			for dir := parent; dir != ""; dir = parentPath(dir) {
+				deps = append(deps, refEntitlement(refDirectory(dir), e.key()))
+			}
+			p.entitlement(phaseSubAccountEntitlements, owner, sa.Subdomain, e, live.Entitlements, deps)
+		}
+		for _, env := range sa.Environments {
+			p.environment(sa.Subdomain, env, live.Environments)
+		}
+		for _, sub := range sa.Subscriptions {
+			p.subscription(sa.Subdomain, sub, live.Subscriptions)
+		}
+	}
+}
+
+func (p *planner) updateSubAccount(sa *SubAccount, live *SubAccountState) {
+	if sa.Region != live.Region {
+		p.fail("subaccount %s cannot be moved from region %s to %s", sa.Subdomain, live.Region, sa.Region)
+	}
+
+	var changes []string
+	update := &btpaccounts.UpdateSubAccountInput{SubAccountGuid: live.Guid}
+	if sa.DisplayName != live.DisplayName {
+		update.DisplayName = sa.DisplayName
+		changes = append(changes, fmt.Sprintf("displayName: %q -> %q", live.DisplayName, sa.DisplayName))
+	}
+	if sa.Description != "" && sa.Description != live.Description {
+		update.Description = sa.Description
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", live.Description, sa.Description))
+	}
+	if sa.UsedForProduction != "" && sa.UsedForProduction != live.UsedForProduction {
+		update.UsedForProduction = sa.UsedForProduction
+		changes = append(changes, fmt.Sprintf("usedForProduction: %s -> %s", live.UsedForProduction, sa.UsedForProduction))
+	}
+	if sa.BetaEnabled && !live.BetaEnabled {
+		update.BetaEnabled = true
+		changes = append(changes, "beta enabled")
+	}
+	var propertyChanges []string
+	update.CustomProperties, propertyChanges = subAccountProperties(sa.CustomProperties, live.CustomProperties)
+	changes = append(changes, propertyChanges...)
+	if len(changes) == 0 {
+		return
+	}
+
+	if update.DisplayName == "" {
+		// the display name is sent along, so that it is never cleared
+		update.DisplayName = live.DisplayName
+	}
+	a := p.add(phaseUpdateSubAccounts, &Action{Type: ActionUpdate, Kind: KindSubAccount, Key: sa.Subdomain, ID: live.Guid, Changes: changes})
+	a.run = func(ap *applier) error { return ap.updateSubAccount(update) }
+}
+
+// Plans the assignment of an entitlement to a directory (ownerKey is its path) or a subaccount
+// (ownerKey is its subdomain).
+func (p *planner) entitlement(phase int, owner, ownerKey string, e Entitlement, current map[string]Assignment, deps []string) {
+	a := &Action{Kind: KindEntitlement, Key: entitlementKey(owner, e.key()), DependsOn: deps}
+	live, exists := current[e.key()]
+	if !exists {
+		a.Type = ActionCreate
+		a.Changes = entitlementChanges(Entitlement{}, e)
+	} else {
+		a.Type = ActionUpdate
+		if a.Changes = entitlementChanges(live.Entitlement, e); len(a.Changes) == 0 {
+			return
+		}
+	}
+
+	directory := phase == phaseDirectoryEntitlements
+	a.run = func(ap *applier) error { return ap.assign(directory, ownerKey, e, false, false) }
+	p.add(phase, a)
+}
+
+func entitlementChanges(live, desired Entitlement) []string {
+	var changes []string
+	if live.Amount != desired.Amount {
+		changes = append(changes, fmt.Sprintf("amount: %d -> %d", live.Amount, desired.Amount))
+	}
+	if live.Enable != desired.Enable {
+		changes = append(changes, fmt.Sprintf("enable: %t -> %t", live.Enable, desired.Enable))
+	}
+	if live.AutoAssign != desired.AutoAssign {
+		changes = append(changes, fmt.Sprintf("autoAssign: %t -> %t", live.AutoAssign, desired.AutoAssign))
+	}
+	if live.AutoDistributeAmount != desired.AutoDistributeAmount {
+		changes = append(changes, fmt.Sprintf("autoDistributeAmount: %d -> %d", live.AutoDistributeAmount, desired.AutoDistributeAmount))
+	}
+	return changes
+}
+
+func (p *planner) environment(subdomain string, env Environment,
+	current map[btpprovisioning.EnvironmentType]btpprovisioning.EnvironmentInstance) {
+	owner := refSubAccount(subdomain)
+	a := &Action{Kind: KindEnvironment, Key: subdomain + " " + string(env.Type),
+		DependsOn: []string{owner, refEntitlement(owner, env.Service+"/"+env.Plan)}}
+
+	live, exists := current[env.Type]
+	if !exists {
+		a.Type = ActionCreate
+		a.Changes = []string{fmt.Sprintf("%s %s/%s", env.Name, env.Service, env.Plan)}
+		a.run = func(ap *applier) error { return ap.createEnvironment(subdomain, &env) }
+		p.add(phaseEnvironments, a)
+		return
+	}
+
+	if live.Name != env.Name || live.ServiceName != env.Service {
+		p.fail("environment %s of subaccount %s cannot be replaced by %s of service %s",
+			live.Name, subdomain, env.Name, env.Service)
+		return
+	}
+	update := &btpprovisioning.UpdateEnvironmentInstanceInput{EnvironmentInstanceId: live.Id}
+	if live.PlanName != env.Plan {
+		update.PlanName = env.Plan
+		a.Changes = append(a.Changes, fmt.Sprintf("plan: %s -> %s", live.PlanName, env.Plan))
+	}
+	if env.Parameters != nil && !sameParameters(live.Parameters, env.Parameters) {
+		update.Parameters = env.Parameters
+		a.Changes = append(a.Changes, "parameters changed")
+	}
+	if len(a.Changes) == 0 {
+		return
+	}
+	a.Type, a.ID = ActionUpdate, live.Id
+	a.run = func(ap *applier) error { return ap.updateEnvironment(subdomain, update) }
+	p.add(phaseEnvironments, a)
+}
+
+// Whether the parameters of an environment instance, as returned in JSON, are the desired ones.
+func sameParameters(live string, desired map[string]interface{}) bool {
+	current := map[string]interface{}{}
+	if live != "" {
+		if err := json.Unmarshal([]byte(live), &current); err != nil {
+			return false
+		}
+	}
+	// compares the JSON forms, so that numbers are compared as such
+	raw, err := json.Marshal(desired)
+	if err != nil {
+		return false
+	}
+	want := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &want); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(current, want)
+}
+
+func (p *planner) subscription(subdomain string, sub Subscription, current map[string]btpsaasmanager.Application) {
+	owner := refSubAccount(subdomain)
+	a := &Action{Kind: KindSubscription, Key: subdomain + " " + sub.App,
+		DependsOn: []string{owner, refEntitlement(owner, sub.App+"/"+sub.Plan)}}
+
+	live, exists := current[sub.App]
+	switch {
+	case !exists || live.State == btpsaasmanager.SubscriptionStateSubscribeFailed:
+		a.Type = ActionCreate
+		if sub.Plan != "" {
+			a.Changes = []string{"plan " + sub.Plan}
+		}
+		a.run = func(ap *applier) error { return ap.subscribe(subdomain, sub) }
+	case sub.Plan != "" && live.PlanName != sub.Plan:
+		a.Type, a.ID = ActionUpdate, live.SubscriptionId
+		a.Changes = []string{fmt.Sprintf("plan: %s -> %s, resubscribing", live.PlanName, sub.Plan)}
+		a.run = func(ap *applier) error {
+			if err := ap.unsubscribe(subdomain, sub.App); err != nil {
+				return err
+			}
+			return ap.subscribe(subdomain, sub)
+		}
+	default:
+		return
+	}
+	p.add(phaseSubscriptions, a)
+}
+
+// Deletes what the model does not declare.
+func (p *planner) prune(m *Model) {
+	declared := declaredEntities(m)
+	desired := desiredEntities(m)
+
+	for _, subdomain := range sortedKeys(p.state.SubAccounts) {
+		subdomain := subdomain
+		live := p.state.SubAccounts[subdomain]
+		owner := refSubAccount(subdomain)
+		if !declared[owner] {
+			a := p.add(phaseDeleteSubAccounts, &Action{Type: ActionDelete, Kind: KindSubAccount, Key: subdomain, ID: live.Guid})
+			a.run = func(ap *applier) error { return ap.deleteSubAccount(subdomain) }
+			continue
+		}
+
+		sa := desired.subAccounts[subdomain]
+		var removed []string
+		for _, envType := range sortedEnvironmentTypes(live.Environments) {
+			if hasEnvironment(sa.Environments, envType) {
+				continue
+			}
+			id := live.Environments[envType].Id
+			a := p.add(phaseDeleteEnvironments, &Action{Type: ActionDelete, Kind: KindEnvironment,
+				Key: subdomain + " " + string(envType), ID: id})
+			a.run = func(ap *applier) error { return ap.deleteEnvironment(subdomain, id) }
+			removed = append(removed, a.Ref())
+		}
+		for _, app := range sortedKeys(live.Subscriptions) {
+			app := app
+			if hasSubscription(sa.Subscriptions, app) {
+				continue
+			}
+			a := p.add(phaseDeleteSubscriptions, &Action{Type: ActionDelete, Kind: KindSubscription,
+				Key: subdomain + " " + app, ID: live.Subscriptions[app].SubscriptionId})
+			a.run = func(ap *applier) error { return ap.unsubscribe(subdomain, app) }
+			removed = append(removed, a.Ref())
+		}
+		p.pruneEntitlements(phaseRemoveSubAccountEntitlements, owner, subdomain, live.Entitlements, sa.Entitlements, removed)
+	}
+
+	for _, path := range sortedKeys(p.state.Directories) {
+		live := p.state.Directories[path]
+		owner := refDirectory(path)
+		if declared[owner] {
+			p.pruneEntitlements(phaseRemoveDirectoryEntitlements, owner, path, live.Entitlements,
+				desired.directories[path].Entitlements, nil)
+		}
+	}
+
+	// directories are deleted after their content, the deepest first
+	paths := sortedKeys(p.state.Directories)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return strings.Count(paths[i], "/") > strings.Count(paths[j], "/")
+	})
+	for _, path := range paths {
+		path := path
+		live := p.state.Directories[path]
+		if declared[refDirectory(path)] {
+			continue
+		}
+		a := p.add(phaseDeleteDirectories, &Action{Type: ActionDelete, Kind: KindDirectory, Key: path, ID: live.Guid})
+		for _, subdomain := range sortedKeys(p.state.SubAccounts) {
+			if p.state.SubAccounts[subdomain].ParentPath == path {
+				a.DependsOn = append(a.DependsOn, refSubAccount(subdomain))
+			}
+		}
+		for _, child := range sortedKeys(p.state.Directories) {
+			if p.state.Directories[child].ParentPath == path {
+				a.DependsOn = append(a.DependsOn, refDirectory(child))
+			}
+		}
+		a.run = func(ap *applier) error { return ap.deleteDirectory(path) }
+	}
+}
+
+func (p *planner) pruneEntitlements(phase int, owner, ownerKey string, current map[string]Assignment,
+	desired []Entitlement, deps []string) {
+	for _, key := range sortedKeys(current) {
+		live := current[key]
+		key := key
+		if live.AutoAssigned || hasEntitlement(desired, key) {
+			continue
+		}
+		a := p.add(phase, &Action{Type: ActionDelete, Kind: KindEntitlement, Key: entitlementKey(owner, key), DependsOn: deps})
+		directory := phase == phaseRemoveDirectoryEntitlements
+		a.run = func(ap *applier) error {
+			return ap.assign(directory, ownerKey, live.Entitlement, true, live.QuotaBased)
+		}
+	}
+}
+
+// The directories, by path, and subaccounts, by subdomain, of a model.
+type entities struct {
+	directories map[string]*Directory
+	subAccounts map[string]*SubAccount
+}
+
+func desiredEntities(m *Model) entities {
+	e := entities{directories: make(map[string]*Directory), subAccounts: make(map[string]*SubAccount)}
+	var walk func(parent string, dirs []Directory, sas []SubAccount)
+	walk = func(parent string, dirs []Directory, sas []SubAccount) {
+		for i := range sas {
+			e.subAccounts[sas[i].Subdomain] = &sas[i]
+		}
+		for i := range dirs {
+			path := joinPath(parent, dirs[i].DisplayName)
+			e.directories[path] = &dirs[i]
+			walk(path, dirs[i].Directories, dirs[i].SubAccounts)
+		}
+	}
+	walk("", m.Directories, m.SubAccounts)
+	return e
+}
+
+func directoryProperties(desired map[string]string, live []btpaccounts.CustomProperties) ([]btpaccounts.UpdateDirectoryProperties, []string) {
+	set, deleted, changes := diffProperties(desired, live)
+	var out []btpaccounts.UpdateDirectoryProperties
+	for _, kv := range set {
+		out = append(out, btpaccounts.UpdateDirectoryProperties{KeyValue: kv})
+	}
+	for _, k := range deleted {
+		out = append(out, btpaccounts.UpdateDirectoryProperties{KeyValue: btpaccounts.KeyValue{Key: k}, Delete: true})
+	}
+	return out, changes
+}
+
+func subAccountProperties(desired map[string]string, live []btpaccounts.CustomProperties) ([]btpaccounts.UpdateSubAccountProperties, []string) {
+	set, deleted, changes := diffProperties(desired, live)
+	var out []btpaccounts.UpdateSubAccountProperties
+	for _, kv := range set {
+		out = append(out, btpaccounts.UpdateSubAccountProperties{KeyValue: kv})
+	}
+	for _, k := range deleted {
+		out = append(out, btpaccounts.UpdateSubAccountProperties{KeyValue: btpaccounts.KeyValue{Key: k}, Delete: true})
+	}
+	return out, changes
+}
+
+// Returns the custom properties to set and the keys of the ones to delete; nothing when desired is nil.
+func diffProperties(desired map[string]string, live []btpaccounts.CustomProperties) ([]btpaccounts.KeyValue, []string, []string) {
+	if desired == nil {
+		return nil, nil, nil
+	}
+	current := make(map[string]string, len(live))
+	for _, p := range live {
+		current[p.Key] = p.Value
+	}
+
+	var set []btpaccounts.KeyValue
+	var deleted, changes []string
+	for _, k := range sortedKeys(desired) {
+		if v, ok := current[k]; !ok || v != desired[k] {
+			set = append(set, btpaccounts.KeyValue{Key: k, Value: desired[k]})
+			changes = append(changes, fmt.Sprintf("customProperty %s: %q -> %q", k, v, desired[k]))
+		}
+	}
+	for _, k := range sortedKeys(current) {
+		if _, ok := desired[k]; !ok {
+			deleted = append(deleted, k)
+			changes = append(changes, fmt.Sprintf("customProperty %s removed", k))
+		}
+	}
+	return set, deleted, changes
+}
+
+func customProperties(properties map[string]string) []btpaccounts.KeyValue {
+	var out []btpaccounts.KeyValue
+	for _, k := range sortedKeys(properties) {
+		out = append(out, btpaccounts.KeyValue{Key: k, Value: properties[k]})
+	}
+	return out
+}
+
+func refDirectory(path string) string {
+	return string(KindDirectory) + " " + path
+}
+
+func refSubAccount(subdomain string) string {
+	return string(KindSubAccount) + " " + subdomain
+}
+
+func refEntitlement(owner, plan string) string {
+	return string(KindEntitlement) + " " + entitlementKey(owner, plan)
+}
+
+func entitlementKey(owner, plan string) string {
+	return owner + " " + plan
+}
+
+func parentPath(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+func hasEntitlement(entitlements []Entitlement, key string) bool {
+	for _, e := range entitlements {
+		if e.key() == key {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnvironment(envs []Environment, envType btpprovisioning.EnvironmentType) bool {
+	for _, env := range envs {
+		if env.Type == envType {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSubscription(subs []Subscription, app string) bool {
+	for _, sub := range subs {
+		if sub.App == app {
+			return true
+		}
+	}
+	return false
+}
+
+// Sorted keys of a map with string keys.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedEnvironmentTypes(envs map[btpprovisioning.EnvironmentType]btpprovisioning.EnvironmentInstance) []btpprovisioning.EnvironmentType {
+	var out []btpprovisioning.EnvironmentType
+	for _, k := range sortedKeys(envs) {
+		out = append(out, btpprovisioning.EnvironmentType(k))
+	}
+	return out
+}
@@ -0,0 +1,238 @@
+package reconcile_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func seed(srv *btpfake.Server) {
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 10})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "cloudfoundry", Plan: "standard", Category: "PLATFORM", Unlimited: true})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "premium", Category: "APPLICATION"})
+	srv.AddApplication(btpfake.Application{AppName: "sales-app", PlanName: "basic"})
+	srv.AddApplication(btpfake.Application{AppName: "sales-app", PlanName: "premium"})
+
+	legacy := srv.AddDirectory(btpfake.Directory{DisplayName: "Legacy"})
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "old", DisplayName: "old", ParentGuid: legacy.Guid})
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "move-me", DisplayName: "Move me",
+		CustomProperties: []btpfake.CustomProperty{{Key: "obsolete", Value: "x"}}})
+}
+
+func model() *reconcile.Model {
+	return &reconcile.Model{
+		Directories: []reconcile.Directory{{
+			DisplayName:      "Finance",
+			Features:         []string{"DEFAULT", "ENTITLEMENTS"},
+			CustomProperties: map[string]string{"cost-center": "42"},
+			Entitlements:     []reconcile.Entitlement{{Service: "objectstore", Plan: "standard", Amount: 4}},
+			Directories: []reconcile.Directory{{
+				DisplayName: "EU",
+				SubAccounts: []reconcile.SubAccount{{
+					Subdomain:   "fin-eu",
+					DisplayName: "Finance EU",
+					Region:      "eu10",
+					Entitlements: []reconcile.Entitlement{
+						{Service: "objectstore", Plan: "standard", Amount: 2},
+						{Service: "cloudfoundry", Plan: "standard", Enable: true},
+						{Service: "sales-app", Plan: "basic", Enable: true},
+					},
+					Environments: []reconcile.Environment{{
+						Type: "cloudfoundry", Service: "cloudfoundry", Plan: "standard", Name: "fin-eu-org",
+					}},
+					Subscriptions: []reconcile.Subscription{{App: "sales-app", Plan: "basic"}},
+				}},
+			}},
+			SubAccounts: []reconcile.SubAccount{{
+				Subdomain:        "move-me",
+				DisplayName:      "Moved",
+				Region:           "eu10",
+				CustomProperties: map[string]string{"team": "core"},
+			}},
+		}},
+	}
+}
+
+func actions(plan *reconcile.Plan) []string {
+	var out []string
+	for _, a := range plan.Actions {
+		out = append(out, string(a.Type)+" "+a.Ref())
+	}
+	return out
+}
+
+func TestPlanAndApply(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	seed(srv)
+	c := automationtest.Clients(t, srv)
+	ctx := context.Background()
+	m := model()
+
+	state, err := reconcile.Read(ctx, c, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := reconcile.NewPlan(m, state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"create directory Finance",
+		"create directory Finance/EU",
+		"create subaccount fin-eu",
+		"update subaccount move-me",
+		"move subaccount move-me",
+		"create entitlement directory Finance objectstore/standard",
+		"create entitlement subaccount fin-eu objectstore/standard",
+		"create entitlement subaccount fin-eu cloudfoundry/standard",
+		"create entitlement subaccount fin-eu sales-app/basic",
+		"create environment fin-eu cloudfoundry",
+		"create subscription fin-eu sales-app",
+	}
+	if got := actions(plan); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+	if s := plan.Actions[3].String(); !strings.Contains(s, `displayName: "Move me" -> "Moved"`) ||
+		!strings.Contains(s, "customProperty obsolete removed") {
+		t.Fatalf("unexpected changes: %s", s)
+	}
+
+	pruned, err := reconcile.NewPlan(m, state, &reconcile.Options{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := actions(pruned)
+	expected = append(expected, "delete subaccount old", "delete directory Legacy")
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected plan:\n%s", pruned)
+	}
+
+	var applied int
+	if err := pruned.Apply(ctx, c, &reconcile.Options{
+		PollInterval: time.Millisecond,
+		OnAction:     func(*reconcile.Action) { applied++ },
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if applied != len(pruned.Actions) {
+		t.Fatalf("expected %d actions applied, got %d", len(pruned.Actions), applied)
+	}
+
+	state, err = reconcile.Read(ctx, c, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := state.Directories["Legacy"]; ok {
+		t.Fatal("expected directory Legacy to be deleted")
+	}
+	finEu := state.SubAccounts["fin-eu"]
+	if finEu == nil || finEu.ParentPath != "Finance/EU" {
+		t.Fatalf("expected subaccount fin-eu in Finance/EU, got %+v", finEu)
+	}
+	if amount, ok := srv.Assignment(finEu.Guid, "objectstore", "standard"); !ok || amount != 2 {
+		t.Fatalf("expected 2 objectstore/standard assigned to fin-eu, got %v", amount)
+	}
+	if env := finEu.Environments["cloudfoundry"]; env.State != "OK" || env.Name != "fin-eu-org" {
+		t.Fatalf("unexpected environment %+v", env)
+	}
+	if s := srv.SubscriptionState(finEu.Guid, "sales-app"); s != "SUBSCRIBED" {
+		t.Fatalf("expected sales-app to be subscribed, got %s", s)
+	}
+	moved := state.SubAccounts["move-me"]
+	if moved.ParentPath != "Finance" || moved.DisplayName != "Moved" || len(moved.CustomProperties) != 1 ||
+		moved.CustomProperties[0].Key != "team" {
+		t.Fatalf("unexpected subaccount %+v", moved.SubAccount)
+	}
+
+	again, err := reconcile.NewPlan(m, state, &reconcile.Options{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Empty() {
+		t.Fatalf("expected no changes once applied, got:\n%s", again)
+	}
+}
+
+func TestPlanChanges(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	seed(srv)
+	c := automationtest.Clients(t, srv)
+	ctx := context.Background()
+
+	m := model()
+	if _, err := reconcile.Run(ctx, c, m, &reconcile.Options{Prune: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	fin := &m.Directories[0]
+	fin.Entitlements[0].Amount = 6
+	finEu := &fin.Directories[0].SubAccounts[0]
+	finEu.Entitlements = finEu.Entitlements[:2]
+	finEu.Subscriptions = nil
+	state, err := reconcile.Read(ctx, c, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := reconcile.NewPlan(m, state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(plan); len(got) != 1 || got[0] != "update entitlement directory Finance objectstore/standard" {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+
+	plan, err = reconcile.NewPlan(m, state, &reconcile.Options{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"update entitlement directory Finance objectstore/standard",
+		"delete subscription fin-eu sales-app",
+		"delete entitlement subaccount fin-eu sales-app/basic",
+	}
+	if got := actions(plan); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+	if err := plan.Apply(ctx, c, nil); err != nil {
+		t.Fatal(err)
+	}
+	guid := state.SubAccounts["fin-eu"].Guid
+	if _, ok := srv.Assignment(guid, "sales-app", "basic"); ok {
+		t.Fatal("expected sales-app/basic to be removed")
+	}
+	if s := srv.SubscriptionState(guid, "sales-app"); s != "" && s != "NOT_SUBSCRIBED" {
+		t.Fatalf("expected sales-app to be unsubscribed, got %s", s)
+	}
+
+	finEu.Region = "us10"
+	fin.Features, fin.Entitlements = []string{"DEFAULT"}, nil
+	if _, err := reconcile.NewPlan(m, state, nil); err == nil ||
+		!strings.Contains(err.Error(), "cannot be moved from region eu10 to us10") ||
+		!strings.Contains(err.Error(), "feature ENTITLEMENTS of directory Finance cannot be disabled") {
+		t.Fatalf("expected the region and feature changes to be rejected, got %v", err)
+	}
+}
+
+func TestInvalidModel(t *testing.T) {
+	m := &reconcile.Model{
+		SubAccounts: []reconcile.SubAccount{
+			{Subdomain: "a", DisplayName: "a", Region: "eu10",
+				Entitlements: []reconcile.Entitlement{{Service: "s", Plan: "p"}}},
+			{Subdomain: "a", DisplayName: "a", Region: "eu10"},
+		},
+	}
+	_, err := reconcile.NewPlan(m, &reconcile.State{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "subaccount a is declared twice") ||
+		!strings.Contains(err.Error(), "needs either an amount or enable") {
+		t.Fatalf("expected the model to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+)
+
+// State is the live state of a global account, as read by Read.
+type State struct {
+	GlobalAccountGuid string
+
+	// The directories, by path.
+	Directories map[string]*DirectoryState
+
+	// The subaccounts, by subdomain.
+	SubAccounts map[string]*SubAccountState
+}
+
+// DirectoryState is the live state of a directory.
+type DirectoryState struct {
+	btpaccounts.Directory
+
+	Path string
+
+	// The path of the parent directory, empty for the global account.
+	ParentPath string
+
+	// The entitlements assigned to the directory, by service/plan. Only read for the directories of
+	// the model which manage entitlements.
+	Entitlements map[string]Assignment
+}
+
+// SubAccountState is the live state of a subaccount.
+type SubAccountState struct {
+	btpaccounts.SubAccount
+
+	// The path of the parent directory, empty for the global account.
+	ParentPath string
+
+	// The entitlements, environments and subscriptions are only read for the subaccounts of the
+	// model.
+
+	// The entitlements assigned to the subaccount, by service/plan.
+	Entitlements map[string]Assignment
+
+	// The environment instances, by type.
+	Environments map[btpprovisioning.EnvironmentType]btpprovisioning.EnvironmentInstance
+
+	// The applications the subaccount is subscribed to, or whose subscription failed, by name.
+	Subscriptions map[string]btpsaasmanager.Application
+}
+
+// Assignment is an entitlement assigned to a directory or a subaccount.
+type Assignment struct {
+	Entitlement
+
+	// Whether the plan has a numeric quota, so that it is assigned with an amount rather than enabled.
+	QuotaBased bool
+
+	// Whether the plan was assigned automatically, e.g. to every new subaccount. Such plans are not
+	// removed by pruning.
+	AutoAssigned bool
+}
+
+// Read reads the live state of the global account. The entitlements, environments and subscriptions
+// are only read for the directories and subaccounts declared by the model.
+func Read(ctx context.Context, c *automation.Clients, m *Model) (*State, error) {
+	ga, err := c.Accounts.GetGlobalAccount(ctx, &btpaccounts.GetGlobalAccountInput{Expand: true})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &State{
+		GlobalAccountGuid: ga.Guid,
+		Directories:       make(map[string]*DirectoryState),
+		SubAccounts:       make(map[string]*SubAccountState),
+	}
+	if err := s.addSubAccounts("", ga.Subaccounts); err != nil {
+		return nil, err
+	}
+	if err := s.addDirectories("", ga.Children); err != nil {
+		return nil, err
+	}
+
+	declared := declaredEntities(m)
+	for path, d := range s.Directories {
+		if !declared["directory "+path] || !hasString(d.DirectoryFeatures, "ENTITLEMENTS") {
+			continue
+		}
+		if d.Entitlements, err = readAssignments(ctx, c.Entitlements, d.Guid,
+			&btpentitlements.GetAssignmentsInput{DirectoryGuid: d.Guid}); err != nil {
+			return nil, err
+		}
+	}
+	for subdomain, sa := range s.SubAccounts {
+		if !declared["subaccount "+subdomain] {
+			continue
+		}
+		if err := readSubAccount(ctx, c, sa); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *State) addDirectories(parent string, dirs []btpaccounts.Directory) error {
+	for _, d := range dirs {
+		path := joinPath(parent, d.DisplayName)
+		if _, ok := s.Directories[path]; ok {
+			return fmt.Errorf("several directories are named %s", path)
+		}
+
+		children, subAccounts := d.Children, d.SubAccounts
+		d.Children, d.SubAccounts = nil, nil
+
+		s.Directories[path] = &DirectoryState{Directory: d, Path: path, ParentPath: parent}
+		if err := s.addSubAccounts(path, subAccounts); err != nil {
+			return err
+		}
+		if err := s.addDirectories(path, children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *State) addSubAccounts(parent string, sas []btpaccounts.SubAccount) error {
+	for _, sa := range sas {
+		if _, ok := s.SubAccounts[sa.Subdomain]; ok {
+			return fmt.Errorf("several subaccounts have the subdomain %s", sa.Subdomain)
+		}
+		s.SubAccounts[sa.Subdomain] = &SubAccountState{SubAccount: sa, ParentPath: parent}
+	}
+	return nil
+}
+
+func readSubAccount(ctx context.Context, c *automation.Clients, sa *SubAccountState) error {
+	var err error
+	if sa.Entitlements, err = readAssignments(ctx, c.Entitlements, sa.Guid,
+		&btpentitlements.GetAssignmentsInput{SubAccountGuid: sa.Guid}); err != nil {
+		return err
+	}
+
+	scoped, err := c.SubAccount(ctx, sa.Guid)
+	if err != nil {
+		return err
+	}
+	envs, err := scoped.Provisioning.GetEnvironmentInstances(ctx)
+	if err != nil {
+		return fmt.Errorf("could not read the environments of subaccount %s; %v", sa.Subdomain, err)
+	}
+	sa.Environments = make(map[btpprovisioning.EnvironmentType]btpprovisioning.EnvironmentInstance)
+	for _, env := range envs.Environments {
+		sa.Environments[env.EnvironmentType] = env
+	}
+
+	apps, err := scoped.SaaS.GetEntitledApplications(ctx, &btpsaasmanager.GetEntitledApplicationsInput{})
+	if err != nil {
+		return fmt.Errorf("could not read the subscriptions of subaccount %s; %v", sa.Subdomain, err)
+	}
+	sa.Subscriptions = make(map[string]btpsaasmanager.Application)
+	for _, app := range apps.Applications {
+		if app.State != "" && app.State != btpsaasmanager.SubscriptionStateNotSubscribed {
+			sa.Subscriptions[app.AppName] = app
+		}
+	}
+	return nil
+}
+
+// Reads the assignments of the entity from the response of GetAssignments, which also holds the
+// assignments of other entities, such as the subaccounts of a directory.
+func readAssignments(ctx context.Context, entitlements btpentitlements.EntitlementsAPI, entityId string,
+	input *btpentitlements.GetAssignmentsInput) (map[string]Assignment, error) {
+	out, err := entitlements.GetAssignments(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	assignments := make(map[string]Assignment)
+	for _, svc := range out.AssignedServices {
+		for _, plan := range svc.ServicePlans {
+			for _, info := range plan.AssignmentInfo {
+				if info.EntityId != entityId {
+					continue
+				}
+				a := Assignment{
+					Entitlement: Entitlement{
+						Service:              svc.Name,
+						Plan:                 plan.Name,
+						AutoAssign:           info.AutoAssign,
+						AutoDistributeAmount: uint(info.AutoDistributeAmount),
+					},
+					QuotaBased:   plan.Category.QuotaBased(plan.Unlimited),
+					AutoAssigned: info.AutoAssigned,
+				}
+				if a.QuotaBased {
+					a.Amount = uint(info.Amount)
+				} else {
+					a.Enable = true
+				}
+				assignments[a.key()] = a
+			}
+		}
+	}
+	return assignments, nil
+}
+
+// The directories ("directory <path>") and subaccounts ("subaccount <subdomain>") of the model.
+func declaredEntities(m *Model) map[string]bool {
+	declared := make(map[string]bool)
+	var walk func(parent string, dirs []Directory, sas []SubAccount)
+	walk = func(parent string, dirs []Directory, sas []SubAccount) {
+		for _, sa := range sas {
+			declared["subaccount "+sa.Subdomain] = true
+		}
+		for _, d := range dirs {
+			path := joinPath(parent, d.DisplayName)
+			declared["directory "+path] = true
+			walk(path, d.Directories, d.SubAccounts)
+		}
+	}
+	walk("", m.Directories, m.SubAccounts)
+	return declared
+}
@@ -0,0 +1,169 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Parameters are the values a template is instantiated with.
+type Parameters struct {
+	Subdomain   string
+	DisplayName string
+
+	// The region of the subaccount; the region of the template when empty.
+	Region string
+
+	// The path of the directory the subaccount is created in, e.g. Finance/EU; the global account when
+	// empty. The missing directories of the path are created.
+	Directory string
+
+	// The values of the other ${name} references of the template.
+	Values map[string]string
+}
+
+// Instantiate creates a subaccount from the template, then assigns its entitlements, creates its
+// environment instances and subscribes it to its applications. It returns the plan applied, which
+// is only partially applied when an error is returned, and fails when the subdomain is already taken.
+func Instantiate(ctx context.Context, c *automation.Clients, t *Template, p *Parameters, opts *reconcile.Options) (*reconcile.Plan, error) {
+	m, err := t.Model(p)
+	if err != nil {
+		return nil, err
+	}
+	state, err := reconcile.Read(ctx, c, m)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := state.SubAccounts[p.Subdomain]; ok {
+		return nil, fmt.Errorf("subaccount %s already exists", p.Subdomain)
+	}
+	plan, err := reconcile.NewPlan(m, state, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, plan.Apply(ctx, c, opts)
+}
+
+// Model returns the model of the subaccount instantiated with the parameters, in its directory, for
+// the reconcile package.
+func (t *Template) Model(p *Parameters) (*reconcile.Model, error) {
+	sa, err := t.SubAccount(p)
+	if err != nil {
+		return nil, err
+	}
+	m := &reconcile.Model{SubAccounts: []reconcile.SubAccount{sa}}
+	if p.Directory == "" {
+		return m, nil
+	}
+
+	// the directories are only declared by name, so that they are left as they are when they exist
+	names := strings.Split(p.Directory, "/")
+	dir := reconcile.Directory{DisplayName: names[len(names)-1], SubAccounts: m.SubAccounts}
+	for i := len(names) - 2; i >= 0; i-- {
+		dir = reconcile.Directory{DisplayName: names[i], Directories: []reconcile.Directory{dir}}
+	}
+	return &reconcile.Model{Directories: []reconcile.Directory{dir}}, nil
+}
+
+// SubAccount returns the subaccount instantiated with the parameters.
+func (t *Template) SubAccount(p *Parameters) (reconcile.SubAccount, error) {
+	region := p.Region
+	if region == "" {
+		region = t.Region
+	}
+	if p.Subdomain == "" || p.DisplayName == "" || region == "" {
+		return reconcile.SubAccount{}, fmt.Errorf("a subdomain, a display name and a region are required")
+	}
+
+	s := &substitution{values: map[string]string{
+		"subdomain":   p.Subdomain,
+		"displayName": p.DisplayName,
+		"region":      region,
+	}}
+	for k, v := range p.Values {
+		if _, ok := s.values[k]; !ok {
+			s.values[k] = v
+		}
+	}
+
+	sa := reconcile.SubAccount{
+		Subdomain:         p.Subdomain,
+		DisplayName:       p.DisplayName,
+		Region:            region,
+		Description:       s.string(t.Description),
+		UsedForProduction: t.UsedForProduction,
+		BetaEnabled:       t.BetaEnabled,
+		Entitlements:      append([]reconcile.Entitlement{}, t.Entitlements...),
+		Subscriptions:     append([]reconcile.Subscription{}, t.Subscriptions...),
+	}
+	if t.CustomProperties != nil {
+		sa.CustomProperties = make(map[string]string, len(t.CustomProperties))
+		for k, v := range t.CustomProperties {
+			sa.CustomProperties[k] = s.string(v)
+		}
+	}
+	for _, env := range t.Environments {
+		env.Name = s.string(env.Name)
+		if env.Parameters != nil {
+			env.Parameters = s.value(env.Parameters).(map[string]interface{})
+		}
+		sa.Environments = append(sa.Environments, env)
+	}
+
+	if len(s.missing) > 0 {
+		missing := make([]string, 0, len(s.missing))
+		for name := range s.missing {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return reconcile.SubAccount{}, fmt.Errorf("no value for the references %s of the template", strings.Join(missing, ", "))
+	}
+	return sa, nil
+}
+
+var reReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// Replaces the ${name} references by their values, collecting the ones which have none.
+type substitution struct {
+	values  map[string]string
+	missing map[string]bool
+}
+
+func (s *substitution) string(v string) string {
+	return reReference.ReplaceAllStringFunc(v, func(ref string) string {
+		name := reReference.FindStringSubmatch(ref)[1]
+		value, ok := s.values[name]
+		if !ok {
+			if s.missing == nil {
+				s.missing = make(map[string]bool)
+			}
+			s.missing[name] = true
+		}
+		return value
+	})
+}
+
+// Substitutes the strings of a value decoded from JSON, into a copy of it.
+func (s *substitution) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return s.string(v)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = s.value(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = s.value(e)
+		}
+		return l
+	}
+	return v
+}
@@ -0,0 +1,192 @@
+// Package template reads a subaccount into a reusable template, and instantiates the template into
+// new subaccounts; it covers the Cloud Foundry subaccounts CloneSubAccount does not:
+//
+//	t, err := template.FromSubAccount(ctx, clients, "sales-dev")
+//	err = t.WriteJSON(file)
+//	...
+//	plan, err := template.Instantiate(ctx, clients, t, &template.Parameters{
+//		Subdomain:   "sales-test",
+//		DisplayName: "Sales test",
+//		Region:      "eu10",
+//	}, nil)
+//
+// A template holds the custom properties, the entitlements, the environment instances and the
+// subscriptions of the subaccount. Its strings may refer to the parameters of the instantiation with
+// ${subdomain}, ${displayName}, ${region} or ${name} for the other values of Parameters.Values; the
+// subdomain and the display name of the source subaccount are replaced by such references when read.
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/internal/sapyaml"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"io"
+	"regexp"
+	"sort"
+)
+
+// SchemaVersion is the version of the template documents written by this package.
+const SchemaVersion = 1
+
+// Template is the content of a subaccount, to be instantiated into new ones.
+type Template struct {
+	SchemaVersion int `json:"schemaVersion"`
+
+	// The subdomain of the subaccount the template was read from.
+	Source string `json:"source,omitempty"`
+
+	// The region of the subaccounts, when the parameters of the instantiation give none.
+	Region string `json:"region,omitempty"`
+
+	Description       string                        `json:"description,omitempty"`
+	UsedForProduction btpaccounts.UsedForProduction `json:"usedForProduction,omitempty"`
+	BetaEnabled       bool                          `json:"betaEnabled,omitempty"`
+	CustomProperties  map[string]string             `json:"customProperties,omitempty"`
+
+	// The entitlements assigned explicitly, i.e. not the ones assigned automatically to every new
+	// subaccount.
+	Entitlements  []reconcile.Entitlement  `json:"entitlements,omitempty"`
+	Environments  []reconcile.Environment  `json:"environments,omitempty"`
+	Subscriptions []reconcile.Subscription `json:"subscriptions,omitempty"`
+}
+
+// FromSubAccount reads the subaccount with the subdomain into a template.
+func FromSubAccount(ctx context.Context, c *automation.Clients, subdomain string) (*Template, error) {
+	state, err := reconcile.Read(ctx, c, &reconcile.Model{SubAccounts: []reconcile.SubAccount{{Subdomain: subdomain}}})
+	if err != nil {
+		return nil, err
+	}
+	sa, ok := state.SubAccounts[subdomain]
+	if !ok {
+		return nil, fmt.Errorf("subaccount %s not found", subdomain)
+	}
+
+	p := newParameterizer(sa.Subdomain, sa.DisplayName)
+	t := &Template{
+		SchemaVersion:     SchemaVersion,
+		Source:            sa.Subdomain,
+		Region:            sa.Region,
+		Description:       p.string(sa.Description),
+		UsedForProduction: sa.UsedForProduction,
+		BetaEnabled:       sa.BetaEnabled,
+	}
+	if len(sa.CustomProperties) > 0 {
+		t.CustomProperties = make(map[string]string, len(sa.CustomProperties))
+		for _, prop := range sa.CustomProperties {
+			t.CustomProperties[prop.Key] = p.string(prop.Value)
+		}
+	}
+
+	for _, a := range sa.Entitlements {
+		if !a.AutoAssigned {
+			t.Entitlements = append(t.Entitlements, reconcile.Entitlement{
+				Service: a.Service,
+				Plan:    a.Plan,
+				Amount:  a.Amount,
+				Enable:  a.Enable,
+			})
+		}
+	}
+	sort.Slice(t.Entitlements, func(i, j int) bool {
+		a, b := t.Entitlements[i], t.Entitlements[j]
+		return a.Service < b.Service || a.Service == b.Service && a.Plan < b.Plan
+	})
+
+	for _, env := range sa.Environments {
+		e := reconcile.Environment{
+			Type:    env.EnvironmentType,
+			Service: env.ServiceName,
+			Plan:    env.PlanName,
+			Name:    p.string(env.Name),
+		}
+		if env.Parameters != "" {
+			var params map[string]interface{}
+			if err := json.Unmarshal([]byte(env.Parameters), &params); err != nil {
+				return nil, fmt.Errorf("could not read the parameters of environment %s; %v", env.Name, err)
+			}
+			e.Parameters = p.value(params).(map[string]interface{})
+		}
+		t.Environments = append(t.Environments, e)
+	}
+	sort.Slice(t.Environments, func(i, j int) bool { return t.Environments[i].Type < t.Environments[j].Type })
+
+	for _, app := range sa.Subscriptions {
+		if app.State == btpsaasmanager.SubscriptionStateSubscribeFailed {
+			continue
+		}
+		t.Subscriptions = append(t.Subscriptions, reconcile.Subscription{App: app.AppName, Plan: app.PlanName})
+	}
+	sort.Slice(t.Subscriptions, func(i, j int) bool { return t.Subscriptions[i].App < t.Subscriptions[j].App })
+	return t, nil
+}
+
+// WriteJSON writes the template as an indented JSON document.
+func (t *Template) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// WriteYAML writes the template as a YAML document.
+func (t *Template) WriteYAML(w io.Writer) error {
+	return sapyaml.Write(w, t)
+}
+
+// ReadJSON reads a template written by WriteJSON.
+func ReadJSON(r io.Reader) (*Template, error) {
+	var t Template
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, err
+	}
+	if t.SchemaVersion < 1 || t.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("unsupported template schema version %d", t.SchemaVersion)
+	}
+	return &t, nil
+}
+
+// Replaces the subdomain and the display name of the source subaccount by references to the
+// parameters. The subdomain is replaced wherever it appears as a word, e.g. in sales-dev-org, the
+// display name only in the strings equal to it.
+type parameterizer struct {
+	subdomain   *regexp.Regexp
+	displayName string
+}
+
+func newParameterizer(subdomain, displayName string) *parameterizer {
+	return &parameterizer{
+		subdomain:   regexp.MustCompile(`\b` + regexp.QuoteMeta(subdomain) + `\b`),
+		displayName: displayName,
+	}
+}
+
+func (p *parameterizer) string(s string) string {
+	if s == "" {
+		return s
+	}
+	if s == p.displayName {
+		return "${displayName}"
+	}
+	return p.subdomain.ReplaceAllLiteralString(s, "${subdomain}")
+}
+
+// Parameterizes the strings of a value decoded from JSON.
+func (p *parameterizer) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return p.string(v)
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = p.value(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = p.value(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+package template_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/automation/reconcile"
+	"github.com/nnicora/sap-sdk-go/automation/template"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestTemplate(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 10})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "cloudfoundry", Plan: "standard", Category: "PLATFORM", Unlimited: true})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	srv.AddApplication(btpfake.Application{AppName: "sales-app", PlanName: "basic"})
+	c := automationtest.Clients(t, srv)
+	ctx := context.Background()
+
+	if _, err := reconcile.Run(ctx, c, &reconcile.Model{SubAccounts: []reconcile.SubAccount{{
+		Subdomain:        "sales-dev",
+		DisplayName:      "Sales dev",
+		Description:      "Sales dev",
+		Region:           "eu10",
+		CustomProperties: map[string]string{"team": "sales", "url": "https://sales-dev.example.com"},
+		Entitlements: []reconcile.Entitlement{
+			{Service: "objectstore", Plan: "standard", Amount: 2},
+			{Service: "cloudfoundry", Plan: "standard", Enable: true},
+			{Service: "sales-app", Plan: "basic", Enable: true},
+		},
+		Environments: []reconcile.Environment{{Type: "cloudfoundry", Service: "cloudfoundry", Plan: "standard",
+			Name: "sales-dev-org", Parameters: map[string]interface{}{"instance_name": "sales-dev-org", "memory": 1024}}},
+		Subscriptions: []reconcile.Subscription{{App: "sales-app", Plan: "basic"}},
+	}}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := template.FromSubAccount(ctx, c, "sales-dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if tmpl, err = template.ReadJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Source != "sales-dev" || tmpl.Region != "eu10" || tmpl.Description != "${displayName}" ||
+		tmpl.CustomProperties["url"] != "https://${subdomain}.example.com" || tmpl.CustomProperties["team"] != "sales" {
+		t.Fatalf("unexpected template %+v", tmpl)
+	}
+	if len(tmpl.Entitlements) != 3 || tmpl.Entitlements[0].Service != "cloudfoundry" || tmpl.Entitlements[1].Amount != 2 {
+		t.Fatalf("unexpected entitlements %+v", tmpl.Entitlements)
+	}
+	if len(tmpl.Environments) != 1 || tmpl.Environments[0].Name != "${subdomain}-org" ||
+		tmpl.Environments[0].Parameters["instance_name"] != "${subdomain}-org" {
+		t.Fatalf("unexpected environments %+v", tmpl.Environments)
+	}
+	if len(tmpl.Subscriptions) != 1 || tmpl.Subscriptions[0].App != "sales-app" {
+		t.Fatalf("unexpected subscriptions %+v", tmpl.Subscriptions)
+	}
+
+	tmpl.CustomProperties["owner"] = "${owner}"
+	params := &template.Parameters{Subdomain: "sales-test", DisplayName: "Sales test", Directory: "Teams/Sales"}
+	if _, err := template.Instantiate(ctx, c, tmpl, params, nil); err == nil || !strings.Contains(err.Error(), "owner") {
+		t.Fatalf("expected the missing value to be reported, got %v", err)
+	}
+	params.Values = map[string]string{"owner": "jane"}
+	if _, err := template.Instantiate(ctx, c, tmpl, params, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := reconcile.Read(ctx, c, &reconcile.Model{SubAccounts: []reconcile.SubAccount{{Subdomain: "sales-test"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sa := state.SubAccounts["sales-test"]
+	if sa == nil || sa.ParentPath != "Teams/Sales" || sa.Region != "eu10" || sa.Description != "Sales test" {
+		t.Fatalf("unexpected subaccount %+v", sa)
+	}
+	if amount, ok := srv.Assignment(sa.Guid, "objectstore", "standard"); !ok || amount != 2 {
+		t.Fatalf("expected 2 objectstore/standard assigned, got %v", amount)
+	}
+	env := sa.Environments["cloudfoundry"]
+	if env.Name != "sales-test-org" || !strings.Contains(env.Parameters, `"instance_name":"sales-test-org"`) {
+		t.Fatalf("unexpected environment %+v", env)
+	}
+	if s := srv.SubscriptionState(sa.Guid, "sales-app"); s != "SUBSCRIBED" {
+		t.Fatalf("expected sales-app to be subscribed, got %s", s)
+	}
+	props := map[string]string{}
+	for _, p := range sa.CustomProperties {
+		props[p.Key] = p.Value
+	}
+	if props["url"] != "https://sales-test.example.com" || props["owner"] != "jane" {
+		t.Fatalf("unexpected custom properties %v", props)
+	}
+
+	if _, err := template.Instantiate(ctx, c, tmpl, params, nil); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected the taken subdomain to be rejected, got %v", err)
+	}
+}
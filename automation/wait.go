@@ -0,0 +1,226 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"net/http"
+	"time"
+)
+
+// DefaultPollInterval is the time waited between two polls when no interval is given.
+const DefaultPollInterval = 5 * time.Second
+
+// Poll calls check until it reports done or fails, waiting interval (DefaultPollInterval when zero)
+// between two calls. It returns ctx.Err() when ctx is done first.
+func Poll(ctx context.Context, interval time.Duration, check func(ctx context.Context) (bool, error)) error {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForSubAccount waits until the subaccount leaves the transitional states, and returns an error
+// when it ends up in a failed one.
+func WaitForSubAccount(ctx context.Context, accounts btpaccounts.AccountsAPI, guid string,
+	interval time.Duration, opts ...request.Option) (*btpaccounts.SubAccount, error) {
+	var sa btpaccounts.SubAccount
+	err := Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := accounts.GetSubAccount(ctx, &btpaccounts.GetSubAccountInput{SubAccountGuid: guid}, opts...)
+		if err != nil {
+			return false, err
+		}
+		sa = out.SubAccount
+		return stateDone("subaccount", guid, sa.State, sa.StateMessage)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+// WaitForSubAccountDeletion waits until the subaccount does not exist anymore.
+func WaitForSubAccountDeletion(ctx context.Context, accounts btpaccounts.AccountsAPI, guid string,
+	interval time.Duration, opts ...request.Option) error {
+	return Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := accounts.GetSubAccount(ctx, &btpaccounts.GetSubAccountInput{SubAccountGuid: guid}, opts...)
+		if err != nil {
+			if out == nil {
+				// the call failed before a response was received
+				return false, err
+			}
+			return notFound(out.StatusCode), ignoreNotFound(out.StatusCode, err)
+		}
+		if out.State == btpaccounts.EntityStateDeletionFailed {
+			return false, fmt.Errorf("deletion of subaccount %s failed; %s", guid, out.StateMessage)
+		}
+		return false, nil
+	})
+}
+
+// WaitForDirectory waits until the directory leaves the transitional states, and returns an error
+// when it ends up in a failed one.
+func WaitForDirectory(ctx context.Context, accounts btpaccounts.AccountsAPI, guid string,
+	interval time.Duration, opts ...request.Option) (*btpaccounts.Directory, error) {
+	var d btpaccounts.Directory
+	err := Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := accounts.GetDirectory(ctx, &btpaccounts.GetDirectoryInput{DirectoryGuid: guid}, opts...)
+		if err != nil {
+			return false, err
+		}
+		d = out.Directory
+		return stateDone("directory", guid, d.EntityState, d.StateMessage)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// WaitForDirectoryDeletion waits until the directory does not exist anymore.
+func WaitForDirectoryDeletion(ctx context.Context, accounts btpaccounts.AccountsAPI, guid string,
+	interval time.Duration, opts ...request.Option) error {
+	return Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := accounts.GetDirectory(ctx, &btpaccounts.GetDirectoryInput{DirectoryGuid: guid}, opts...)
+		if err != nil {
+			if out == nil {
+				// the call failed before a response was received
+				return false, err
+			}
+			return notFound(out.StatusCode), ignoreNotFound(out.StatusCode, err)
+		}
+		if out.EntityState == btpaccounts.EntityStateDeletionFailed {
+			return false, fmt.Errorf("deletion of directory %s failed; %s", guid, out.StateMessage)
+		}
+		return false, nil
+	})
+}
+
+// WaitForEntitlementsJob waits until the job of an entitlements update completes, and returns an
+// error when it fails.
+func WaitForEntitlementsJob(ctx context.Context, entitlements btpentitlements.EntitlementsAPI, jobId string,
+	interval time.Duration, opts ...request.Option) error {
+	return Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := entitlements.GetJobStatus(ctx, &btpentitlements.GetJobStatusInput{JobId: jobId}, opts...)
+		if err != nil {
+			return false, err
+		}
+		if out.Status.IsFailure() {
+			return false, fmt.Errorf("entitlements job %s failed; %s", jobId, out.Description)
+		}
+		return out.Status.IsTerminal(), nil
+	})
+}
+
+// WaitForEnvironment waits until the environment instance leaves the transitional states, and
+// returns an error when it ends up in a failed one.
+func WaitForEnvironment(ctx context.Context, provisioning btpprovisioning.ProvisioningAPI, id string,
+	interval time.Duration, opts ...request.Option) (*btpprovisioning.EnvironmentInstance, error) {
+	var env btpprovisioning.EnvironmentInstance
+	err := Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := provisioning.GetEnvironmentInstance(ctx,
+			&btpprovisioning.GetEnvironmentInstanceInput{EnvironmentInstanceId: id}, opts...)
+		if err != nil {
+			return false, err
+		}
+		env = out.EnvironmentInstance
+		if env.State.IsFailure() {
+			return false, fmt.Errorf("environment instance %s is %s; %s", id, env.State, env.StateMessage)
+		}
+		return env.State.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// WaitForEnvironmentDeletion waits until the environment instance does not exist anymore.
+func WaitForEnvironmentDeletion(ctx context.Context, provisioning btpprovisioning.ProvisioningAPI, id string,
+	interval time.Duration, opts ...request.Option) error {
+	return Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := provisioning.GetEnvironmentInstance(ctx,
+			&btpprovisioning.GetEnvironmentInstanceInput{EnvironmentInstanceId: id}, opts...)
+		if err != nil {
+			if out == nil {
+				// the call failed before a response was received
+				return false, err
+			}
+			return notFound(out.StatusCode), ignoreNotFound(out.StatusCode, err)
+		}
+		if out.State == btpprovisioning.EnvironmentStateDeletionFailed {
+			return false, fmt.Errorf("deletion of environment instance %s failed; %s", id, out.StateMessage)
+		}
+		return false, nil
+	})
+}
+
+// WaitForSubscription waits until the subscription of the subaccount the client is scoped to, to the
+// application, leaves the transitional states; it returns an error when it ends up in a failed one,
+// along with the application in that state. The returned application is in the NOT_SUBSCRIBED state
+// once an unsubscription completes.
+func WaitForSubscription(ctx context.Context, saas btpsaasmanager.SaaSProvisioningAPI, appName string,
+	interval time.Duration, opts ...request.Option) (*btpsaasmanager.Application, error) {
+	var app, failed *btpsaasmanager.Application
+	err := Poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		out, err := saas.GetEntitledApplications(ctx, &btpsaasmanager.GetEntitledApplicationsInput{}, opts...)
+		if err != nil {
+			return false, err
+		}
+		app = nil
+		for i := range out.Applications {
+			if out.Applications[i].AppName == appName {
+				app = &out.Applications[i]
+				break
+			}
+		}
+		if app == nil {
+			return false, fmt.Errorf("application %s is not entitled", appName)
+		}
+		if app.State.IsFailure() {
+			failed = app
+			return false, fmt.Errorf("subscription to application %s is %s; %s", appName, app.State,
+				app.SubscriptionError.ErrorMessage)
+		}
+		return app.State.IsTerminal(), nil
+	})
+	if err != nil {
+		return failed, err
+	}
+	return app, nil
+}
+
+func stateDone(kind, guid string, state btpaccounts.EntityState, message string) (bool, error) {
+	if state.IsFailure() {
+		return false, fmt.Errorf("%s %s is %s; %s", kind, guid, state, message)
+	}
+	return state.IsTerminal(), nil
+}
+
+func notFound(statusCode int32) bool {
+	return statusCode == http.StatusNotFound
+}
+
+func ignoreNotFound(statusCode int32, err error) error {
+	if notFound(statusCode) {
+		return nil
+	}
+	return err
+}
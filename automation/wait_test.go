@@ -0,0 +1,27 @@
+package automation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts/btpaccountsmock"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning/btpprovisioningmock"
+)
+
+func TestWaitForDeletionWithoutResponse(t *testing.T) {
+	// the mocks fail the calls they are not given a function for without any output
+	accounts := &btpaccountsmock.AccountsAPI{}
+	provisioning := &btpprovisioningmock.ProvisioningAPI{}
+	ctx := context.Background()
+
+	if err := automation.WaitForSubAccountDeletion(ctx, accounts, "guid", 0); err == nil {
+		t.Fatal("expected the subaccount call to fail")
+	}
+	if err := automation.WaitForDirectoryDeletion(ctx, accounts, "guid", 0); err == nil {
+		t.Fatal("expected the directory call to fail")
+	}
+	if err := automation.WaitForEnvironmentDeletion(ctx, provisioning, "id", 0); err == nil {
+		t.Fatal("expected the environment call to fail")
+	}
+}
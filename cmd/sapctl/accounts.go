@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+)
+
+func accountsCommand() *command {
+	return &command{
+		name:    "accounts",
+		summary: "manage the directories and subaccounts of the global account",
+		subcommands: []*command{{
+			name:    "subaccounts",
+			summary: "manage the subaccounts",
+			subcommands: []*command{{
+				name:    "list",
+				summary: "list the subaccounts of the global account, or of a directory",
+				flags:   listSubAccounts,
+			}},
+		}},
+	}
+}
+
+func listSubAccounts(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	directory := fs.String("directory", "", "list the subaccounts of the directory with this GUID")
+	filter := fs.String("custom-property", "", "list the subaccounts with these custom properties, e.g. team=core,env=dev")
+
+	return func(ctx context.Context) error {
+		properties, err := parsePairs(*filter)
+		if err != nil {
+			return err
+		}
+		sess, err := a.session()
+		if err != nil {
+			return err
+		}
+		out, err := btpaccounts.New(sess).GetSubAccounts(ctx,
+			&btpaccounts.GetSubAccountsInput{DirectoryGuid: *directory}, a.options()...)
+		if err != nil {
+			return err
+		}
+
+		subAccounts := make([]btpaccounts.SubAccount, 0, len(out.Value))
+		for _, sa := range out.Value {
+			if hasCustomProperties(sa.CustomProperties, properties) {
+				subAccounts = append(subAccounts, sa)
+			}
+		}
+		t := &table{columns: []string{"GUID", "SUBDOMAIN", "DISPLAY NAME", "REGION", "STATE", "PARENT"}}
+		for _, sa := range subAccounts {
+			t.add(sa.Guid, sa.Subdomain, sa.DisplayName, sa.Region, string(sa.State), sa.ParentGuid)
+		}
+		return a.out.print(subAccounts, t)
+	}
+}
+
+func hasCustomProperties(props []btpaccounts.CustomProperties, expected map[string]string) bool {
+	for key, value := range expected {
+		found := false
+		for _, p := range props {
+			if p.Key == key && p.Value == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// app is what the commands run with: the global flags, the clients built from the profile and the
+// output.
+type app struct {
+	configPath    string
+	profileName   string
+	output        string
+	dryRun        bool
+	createBinding bool
+	wait          bool
+	pollInterval  time.Duration
+
+	out     *printer
+	stdout  io.Writer
+	profile *Profile
+	cfg     *sap.Config
+	sess    *session.RuntimeSession
+	clients *automation.Clients
+}
+
+func newApp(stdout io.Writer) *app {
+	return &app{
+		stdout:       stdout,
+		pollInterval: automation.DefaultPollInterval,
+	}
+}
+
+// Checks the global flags once parsed.
+func (a *app) validate() error {
+	for _, f := range outputFormats {
+		if a.output == f {
+			a.out = &printer{w: a.stdout, format: f}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output %q, expected one of %s", a.output, strings.Join(outputFormats, ", "))
+}
+
+// Returns the session of the profile, built once.
+func (a *app) session() (*session.RuntimeSession, error) {
+	if a.sess != nil {
+		return a.sess, nil
+	}
+	p, err := loadProfile(a.configPath, a.profileName)
+	if err != nil {
+		return nil, err
+	}
+	a.profile, a.cfg = p, p.config()
+	if a.sess, err = session.BuildFromConfig(a.cfg); err != nil {
+		return nil, err
+	}
+	return a.sess, nil
+}
+
+// Returns the clients of the global account and of its subaccounts, built once.
+func (a *app) automation() (*automation.Clients, error) {
+	if a.clients != nil {
+		return a.clients, nil
+	}
+	sess, err := a.session()
+	if err != nil {
+		return nil, err
+	}
+	var accounts btpaccounts.AccountsAPI = btpaccounts.New(sess)
+	if a.dryRun {
+		accounts = noBindingCreation{accounts}
+	}
+	a.clients = automation.NewClients(sess, automation.ServiceManagementBindingSessions(a.subAccountConfig, accounts,
+		&automation.BindingOptions{CreateMissing: a.createBinding}))
+	return a.clients, nil
+}
+
+// Returns the configuration of the APIs scoped to the subaccount: the one of its profile, or the one
+// of the global account when it has none.
+func (a *app) subAccountConfig(_ context.Context, guid string) (*sap.Config, error) {
+	if p, ok := a.profile.SubAccounts[guid]; ok {
+		return p.config(), nil
+	}
+	return a.cfg, nil
+}
+
+// Returns the clients of the APIs scoped to the subaccount.
+func (a *app) subAccount(ctx context.Context, guid string) (*automation.SubAccountClients, error) {
+	c, err := a.automation()
+	if err != nil {
+		return nil, err
+	}
+	return c.SubAccount(ctx, guid)
+}
+
+// Returns the options of the calls of the commands, which print their request instead of sending it
+// with --dry-run.
+func (a *app) options() []request.Option {
+	if !a.dryRun {
+		return nil
+	}
+	return []request.Option{request.WithDryRun()}
+}
+
+// The accounts API used to find the Service Manager binding of a subaccount with --dry-run, which
+// refuses to create one.
+type noBindingCreation struct {
+	btpaccounts.AccountsAPI
+}
+
+func (noBindingCreation) CreateSubAccountServiceManagementBinding(context.Context,
+	*btpaccounts.CreateServiceManagementBindingInput, ...request.Option) (*btpaccounts.CreateServiceManagementBindingOutput, error) {
+	return nil, errors.New("the subaccount has no Service Manager binding, which a dry run does not create")
+}
+
+// Parses key=value pairs separated by commas, e.g. team=core,env=dev.
+func parsePairs(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		i := strings.Index(pair, "=")
+		if i <= 0 {
+			return nil, usageError(fmt.Sprintf("invalid pair %q, expected key=value", pair))
+		}
+		pairs[pair[:i]] = pair[i+1:]
+	}
+	return pairs, nil
+}
+
+// Returns the keys of the map, sorted.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"io"
+	"sort"
+	"strings"
+)
+
+// command is a node of the command tree: a group of subcommands, or a command run with its flags.
+type command struct {
+	name    string
+	summary string
+
+	// The subcommands of a group.
+	subcommands []*command
+
+	// Declares the flags of a runnable command, and returns the function running it.
+	flags func(fs *flag.FlagSet, a *app) func(ctx context.Context) error
+
+	// Whether the command starts an asynchronous operation, which --wait waits for.
+	async bool
+}
+
+func root() *command {
+	return &command{
+		name: "sapctl",
+		subcommands: []*command{
+			accountsCommand(),
+			entitlementsCommand(),
+			provisioningCommand(),
+			saasCommand(),
+			smCommand(),
+			eventsCommand(),
+			resourcesCommand(),
+			completionCommand(),
+		},
+	}
+}
+
+func (c *command) subcommand(name string) *command {
+	for _, sub := range c.subcommands {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// usageError is returned by the commands called with invalid flags, the usage of the command is
+// printed after it.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// Returns a usage error when one of the flags, given by name, is empty.
+func required(flags map[string]string) error {
+	var missing []string
+	for name, value := range flags {
+		if value == "" {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return usageError("missing " + strings.Join(missing, ", "))
+}
+
+// run runs the command line and returns the exit code: 0 on success, 1 when the command failed and
+// 2 when it was misused.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == completeCommand {
+		for _, candidate := range root().complete(args[1:]) {
+			fmt.Fprintln(stdout, candidate)
+		}
+		return 0
+	}
+
+	cmd, path := root(), []string{"sapctl"}
+	for cmd.flags == nil {
+		if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+			cmd.usage(stderr, path)
+			return 2
+		}
+		sub := cmd.subcommand(args[0])
+		if sub == nil {
+			fmt.Fprintf(stderr, "unknown command %q\n", strings.Join(append(path, args[0]), " "))
+			cmd.usage(stderr, path)
+			return 2
+		}
+		cmd, path, args = sub, append(path, sub.name), args[1:]
+	}
+
+	a := newApp(stdout)
+	fs := newFlagSet(cmd, path, a, stderr)
+	runCmd := cmd.flags(fs, a)
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+		return 2
+	}
+	if err := a.validate(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	err := runCmd(ctx)
+	if err == nil {
+		return 0
+	}
+	if dryRun, ok := err.(*request.DryRunError); ok {
+		fmt.Fprint(stdout, dryRun.String())
+		return 0
+	}
+	if _, ok := err.(usageError); ok {
+		fmt.Fprintln(stderr, err)
+		fs.Usage()
+		return 2
+	}
+	fmt.Fprintf(stderr, "%s: %v\n", strings.Join(path, " "), err)
+	return 1
+}
+
+// Returns the flag set of a runnable command, with the global flags registered.
+func newFlagSet(cmd *command, path []string, a *app, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&a.configPath, "config", "", "the configuration file; $SAPCTL_CONFIG or ~/.sapctl/config.json by default")
+	fs.StringVar(&a.profileName, "profile", "", "the profile of the configuration file; $SAPCTL_PROFILE or its default profile by default")
+	fs.StringVar(&a.output, "output", "table", "the output format: table, json or yaml")
+	fs.StringVar(&a.output, "o", "table", "shorthand for --output")
+	fs.BoolVar(&a.dryRun, "dry-run", false, "print the request of the command instead of sending it")
+	fs.BoolVar(&a.createBinding, "create-binding", false, "create the Service Manager binding of a subaccount which has none")
+	if cmd.async {
+		fs.BoolVar(&a.wait, "wait", false, "wait for the operation to complete")
+		fs.DurationVar(&a.pollInterval, "poll-interval", a.pollInterval, "the time between two polls while waiting")
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "%s - %s\n\nFlags:\n", fs.Name(), cmd.summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func (c *command) usage(w io.Writer, path []string) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n\nCommands:\n", strings.Join(path, " "))
+	for _, sub := range c.subcommands {
+		fmt.Fprintf(w, "  %-14s %s\n", sub.name, sub.summary)
+	}
+}
+
+// Returns the completions of the last word of the command line, the words before it being complete:
+// the subcommands of a group, the flags of a command, or the values of the --output flag.
+func (c *command) complete(words []string) []string {
+	var current string
+	if len(words) > 0 {
+		words, current = words[:len(words)-1], words[len(words)-1]
+	}
+
+	cmd := c
+	for _, w := range words {
+		if cmd.flags != nil {
+			break
+		}
+		if cmd = cmd.subcommand(w); cmd == nil {
+			return nil
+		}
+	}
+
+	var candidates []string
+	switch {
+	case cmd.flags == nil:
+		for _, sub := range cmd.subcommands {
+			candidates = append(candidates, sub.name)
+		}
+	case len(words) > 0 && (words[len(words)-1] == "-o" || words[len(words)-1] == "--output"):
+		candidates = outputFormats
+	case strings.HasPrefix(current, "-"):
+		a := newApp(io.Discard)
+		fs := newFlagSet(cmd, nil, a, io.Discard)
+		cmd.flags(fs, a)
+		fs.VisitAll(func(f *flag.Flag) {
+			if len(f.Name) > 1 {
+				candidates = append(candidates, "--"+f.Name)
+			}
+		})
+		sort.Strings(candidates)
+	}
+
+	var out []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			out = append(out, candidate)
+		}
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+// The hidden command the completion scripts call with the words of the command line, which prints
+// the completions of the last one.
+const completeCommand = "__complete"
+
+var completionScripts = map[string]string{
+	"bash": `_sapctl() {
+	local IFS=$'\n'
+	COMPREPLY=($(sapctl __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _sapctl sapctl
+`,
+	"zsh": `#compdef sapctl
+_sapctl() {
+	local -a completions
+	completions=("${(@f)$(sapctl __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	compadd -a completions
+}
+compdef _sapctl sapctl
+`,
+}
+
+func completionCommand() *command {
+	return &command{
+		name:    "completion",
+		summary: "print the completion script of a shell",
+		subcommands: []*command{
+			{name: "bash", summary: "print the bash completion script, e.g. source <(sapctl completion bash)", flags: completion("bash")},
+			{name: "zsh", summary: "print the zsh completion script, e.g. source <(sapctl completion zsh)", flags: completion("zsh")},
+		},
+	}
+}
+
+func completion(shell string) func(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	return func(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+		return func(context.Context) error {
+			_, err := fmt.Fprint(a.stdout, completionScripts[shell])
+			return err
+		}
+	}
+}
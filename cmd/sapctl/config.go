@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/oauth2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Config is the content of the configuration file.
+type Config struct {
+	// The profile used when none is given with --profile or $SAPCTL_PROFILE.
+	DefaultProfile string `json:"defaultProfile,omitempty"`
+
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+// Profile holds the endpoints of a global account and the credentials to call them with. The
+// secrets may reference environment variables, e.g. $SAPCTL_CLIENT_SECRET.
+type Profile struct {
+	// The hosts of the APIs, by endpoint ID: accounts, entitlements, events, provisioning,
+	// resources, saas-manager and service-manager.
+	Endpoints map[string]string `json:"endpoints"`
+
+	// client_credentials when empty, or password.
+	GrantType    string `json:"grantType,omitempty"`
+	TokenUrl     string `json:"tokenUrl"`
+	ClientId     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+
+	MaxRetries uint8 `json:"maxRetries,omitempty"`
+
+	// The endpoints and credentials of the APIs scoped to a subaccount, provisioning and
+	// saas-manager, by subaccount GUID, e.g. from a service key of the cis service with the local
+	// plan; a subaccount without any is called with the ones of the profile.
+	SubAccounts map[string]*Profile `json:"subaccounts,omitempty"`
+}
+
+// Returns the path of the configuration file: the given one, $SAPCTL_CONFIG or ~/.sapctl/config.json.
+func configPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	if path = os.Getenv("SAPCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".sapctl", "config.json"), nil
+}
+
+// Reads the profile from the configuration file: the given one, $SAPCTL_PROFILE or the default one.
+func loadProfile(path, name string) (*Profile, error) {
+	path, err := configPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the configuration; %v", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration %s; %v", path, err)
+	}
+
+	if name == "" {
+		name = os.Getenv("SAPCTL_PROFILE")
+	}
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	if name == "" && len(cfg.Profiles) == 1 {
+		for n := range cfg.Profiles {
+			name = n
+		}
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(cfg.Profiles))
+		for n := range cfg.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("no profile %q in %s, among %s", name, path, strings.Join(names, ", "))
+	}
+	return p, nil
+}
+
+// Returns the configuration of the SDK for the profile.
+func (p *Profile) config() *sap.Config {
+	grantType := p.GrantType
+	if grantType == "" {
+		grantType = "client_credentials"
+	}
+	cfg := &sap.Config{
+		Endpoints:  make(map[string]*sap.EndpointConfig, len(p.Endpoints)),
+		MaxRetries: p.MaxRetries,
+		DefaultOAuth2: &oauth2.Config{
+			GrantType:    grantType,
+			TokenURL:     p.TokenUrl,
+			ClientID:     p.ClientId,
+			ClientSecret: os.ExpandEnv(p.ClientSecret),
+			Username:     p.Username,
+			Password:     os.ExpandEnv(p.Password),
+			Timeout:      30 * time.Second,
+		},
+	}
+	for id, host := range p.Endpoints {
+		cfg.Endpoints[id] = &sap.EndpointConfig{Host: host}
+	}
+	return cfg
+}
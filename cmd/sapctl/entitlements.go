@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+)
+
+func entitlementsCommand() *command {
+	return &command{
+		name:    "entitlements",
+		summary: "manage the entitlements of the subaccounts",
+		subcommands: []*command{{
+			name:    "assign",
+			summary: "assign a service plan to a subaccount, with an amount or enabled",
+			flags:   assignEntitlement,
+			async:   true,
+		}},
+	}
+}
+
+// The result of an asynchronous operation tracked by a job.
+type jobResult struct {
+	JobId  string `json:"jobId,omitempty"`
+	Status string `json:"status"`
+}
+
+func assignEntitlement(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	subAccount := fs.String("subaccount", "", "the GUID of the subaccount")
+	service := fs.String("service", "", "the name of the service")
+	plan := fs.String("plan", "", "the name of the service plan")
+	amount := fs.Uint("amount", 0, "the quota to assign, for the plans with a numeric quota; 0 removes the plan")
+	enable := fs.Bool("enable", false, "enable the plan, for the plans without a numeric quota")
+	disable := fs.Bool("disable", false, "disable the plan, for the plans without a numeric quota")
+
+	return func(ctx context.Context) error {
+		if err := required(map[string]string{"subaccount": *subAccount, "service": *service, "plan": *plan}); err != nil {
+			return err
+		}
+		info := btpentitlements.AssignmentInfo{SubAccountGuid: *subAccount}
+		switch {
+		case *enable && *disable:
+			return usageError("--enable and --disable are exclusive")
+		case *enable || *disable:
+			if isSet(fs, "amount") {
+				return usageError("--amount cannot be given with --enable or --disable")
+			}
+			info.Enable = enable
+		case isSet(fs, "amount"):
+			n := *amount
+			info.Amount = &n
+		default:
+			return usageError("missing --amount, --enable or --disable")
+		}
+
+		sess, err := a.session()
+		if err != nil {
+			return err
+		}
+		entitlements := btpentitlements.New(sess)
+		out, err := entitlements.UpdateSubAccountServicePlan(ctx, &btpentitlements.UpdateSubAccountServicePlanInput{
+			SubAccountServicePlans: []btpentitlements.SubAccountServicePlan{{
+				ServiceName:     *service,
+				ServicePlanName: *plan,
+				AssignmentInfo:  []btpentitlements.AssignmentInfo{info},
+			}},
+		}, a.options()...)
+		if err != nil {
+			return err
+		}
+
+		result := jobResult{Status: "COMPLETED"}
+		if out.JobStatusId != nil {
+			result = jobResult{JobId: *out.JobStatusId, Status: "IN_PROGRESS"}
+			if a.wait {
+				if err := automation.WaitForEntitlementsJob(ctx, entitlements, result.JobId, a.pollInterval); err != nil {
+					return err
+				}
+				result.Status = "COMPLETED"
+			}
+		}
+		t := &table{columns: []string{"JOB", "STATUS"}}
+		t.add(result.JobId, result.Status)
+		return a.out.print(result, t)
+	}
+}
+
+// Whether the flag was given on the command line.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
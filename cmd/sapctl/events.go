@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/nnicora/sap-sdk-go/service/btpevents"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func eventsCommand() *command {
+	return &command{
+		name:    "events",
+		summary: "read the audit events of the global account",
+		subcommands: []*command{{
+			name:    "tail",
+			summary: "print the recent events, then the new ones as they occur",
+			flags:   tailEvents,
+		}},
+	}
+}
+
+func tailEvents(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	eventTypes := fs.String("type", "", "the types of the events to print, separated by commas, e.g. Subaccount_Creation")
+	entityTypes := fs.String("entity-type", "", "the types of the entities whose events to print, separated by commas, e.g. Subaccount")
+	entityId := fs.String("entity-id", "", "the ID of the entity whose events to print")
+	since := fs.Duration("since", time.Hour, "print the events of this period before following")
+	follow := fs.Bool("follow", true, "keep polling for new events until interrupted")
+	interval := fs.Duration("interval", 10*time.Second, "the time between two polls")
+
+	return func(ctx context.Context) error {
+		sess, err := a.session()
+		if err != nil {
+			return err
+		}
+		events := btpevents.New(sess)
+		a.out.stream = true
+
+		input := &btpevents.GetEventsInput{
+			EventType:      splitList(*eventTypes),
+			EntityType:     splitList(*entityTypes),
+			EntityId:       *entityId,
+			FromActionTime: time.Now().Add(-*since),
+			SortField:      "actionTime",
+			SortOrder:      "ASC",
+		}
+		// The events of the last action time printed, which the next poll returns again.
+		seen := make(map[int64]bool)
+		for {
+			from := input.FromActionTime
+			for input.PageNum = 1; ; input.PageNum++ {
+				out, err := events.GetEvents(ctx, input, a.options()...)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					return err
+				}
+				for _, e := range out.Events {
+					if seen[e.Id] {
+						continue
+					}
+					if actionTime := e.ActionTime.Time(); actionTime.After(from) {
+						from, seen = actionTime, make(map[int64]bool)
+					}
+					seen[e.Id] = true
+
+					t := &table{columns: []string{"ID", "TIME", "TYPE", "ENTITY TYPE", "ENTITY ID"}}
+					t.add(strconv.FormatInt(e.Id, 10), e.ActionTime.String(), e.EventType, e.EntityType, e.EntityId)
+					if err := a.out.print(e, t); err != nil {
+						return err
+					}
+				}
+				if !out.MorePages {
+					break
+				}
+			}
+			input.FromActionTime = from
+			if !*follow {
+				return nil
+			}
+
+			timer := time.NewTimer(*interval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			}
+		}
+	}
+}
+
+// Splits a list of values separated by commas; nil when empty.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
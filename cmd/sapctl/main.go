@@ -0,0 +1,66 @@
+// Command sapctl manages a global account from the command line, on top of the service clients of
+// the SDK. Its commands are grouped per service:
+//
+//	sapctl accounts subaccounts list [--directory <guid>]
+//	sapctl entitlements assign --subaccount <guid> --service <name> --plan <name> (--amount <n> | --enable) [--wait]
+//	sapctl provisioning env create --subaccount <guid> --type <type> --service <name> --plan <name> [--wait]
+//	sapctl saas subscribe --subaccount <guid> --app <name> [--plan <name>] [--wait]
+//	sapctl sm instances list --subaccount <guid> [--field-query <query>] [--label-query <query>]
+//	sapctl events tail [--type <type>,...] [--since <duration>] [--follow=false]
+//	sapctl resources usage [--from <YYYYMM>] [--to <YYYYMM>] [--subaccount <guid>]
+//	sapctl completion bash|zsh
+//
+// Every command takes the global flags:
+//
+//	--config <path>     the configuration file, $SAPCTL_CONFIG or ~/.sapctl/config.json by default
+//	--profile <name>    the profile of the configuration file, $SAPCTL_PROFILE or its default profile
+//	-o, --output <fmt>  table (the default), json or yaml
+//	--dry-run           prints the request of the command instead of sending it
+//	--create-binding    creates the Service Manager binding of a subaccount which has none
+//
+// The configuration file holds the endpoints and credentials of global accounts as named profiles:
+//
+//	{
+//	  "defaultProfile": "dev",
+//	  "profiles": {
+//	    "dev": {
+//	      "endpoints": {
+//	        "accounts": "https://accounts-service.cfapps.eu10.hana.ondemand.com",
+//	        "entitlements": "https://entitlements-service.cfapps.eu10.hana.ondemand.com"
+//	      },
+//	      "tokenUrl": "https://dev.authentication.eu10.hana.ondemand.com/oauth/token",
+//	      "clientId": "sb-ut-...",
+//	      "clientSecret": "$SAPCTL_CLIENT_SECRET",
+//	      "subaccounts": {
+//	        "<subaccount guid>": {
+//	          "endpoints": {
+//	            "provisioning": "https://provisioning-service.cfapps.eu10.hana.ondemand.com",
+//	            "saas-manager": "https://saas-manager.cfapps.eu10.hana.ondemand.com"
+//	          },
+//	          "tokenUrl": "https://dev-team.authentication.eu10.hana.ondemand.com/oauth/token",
+//	          "clientId": "sb-...",
+//	          "clientSecret": "$SAPCTL_DEV_TEAM_SECRET"
+//	        }
+//	      }
+//	    }
+//	  }
+//	}
+//
+// The commands acting within a subaccount, such as provisioning and saas, use the endpoints and
+// credentials of the subaccount in the profile, or the ones of the profile itself; the sm commands
+// authenticate with the Service Manager binding of the subaccount, which is only created, when the
+// subaccount has none, with --create-binding.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
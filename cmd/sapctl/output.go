@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/internal/sapyaml"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// The values of --output.
+var outputFormats = []string{"table", "json", "yaml"}
+
+// table is the tabular form of a result: its columns, and one row per item.
+type table struct {
+	columns []string
+	rows    [][]string
+}
+
+func (t *table) add(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// printer writes the results of the commands in the format given with --output.
+type printer struct {
+	w      io.Writer
+	format string
+
+	// Whether the results are written as they come rather than once: each one is then a line of
+	// JSON or a YAML document, and the header of the table is only written once.
+	stream bool
+	header bool
+}
+
+// Prints the result: value as JSON or YAML, or t as a table.
+func (p *printer) print(value interface{}, t *table) error {
+	switch p.format {
+	case "json":
+		enc := json.NewEncoder(p.w)
+		if !p.stream {
+			enc.SetIndent("", "  ")
+		}
+		return enc.Encode(value)
+	case "yaml":
+		if p.stream {
+			fmt.Fprintln(p.w, "---")
+		}
+		return sapyaml.Write(p.w, value)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	if !p.header {
+		fmt.Fprintln(tw, strings.Join(t.columns, "\t"))
+		p.header = p.stream
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+)
+
+func provisioningCommand() *command {
+	return &command{
+		name:    "provisioning",
+		summary: "manage the environment instances of the subaccounts",
+		subcommands: []*command{{
+			name:    "env",
+			summary: "manage the environment instances",
+			subcommands: []*command{{
+				name:    "create",
+				summary: "create an environment instance in a subaccount",
+				flags:   createEnvironment,
+				async:   true,
+			}},
+		}},
+	}
+}
+
+func createEnvironment(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	subAccount := fs.String("subaccount", "", "the GUID of the subaccount")
+	envType := fs.String("type", "", "the type of the environment, e.g. cloudfoundry or kyma")
+	service := fs.String("service", "", "the name of the service of the environment")
+	plan := fs.String("plan", "", "the name of the service plan of the environment")
+	name := fs.String("name", "", "the name of the environment instance")
+	landscape := fs.String("landscape", "", "the landscape to create the environment instance in, e.g. cf-eu10")
+	parameters := fs.String("parameters", "", "the parameters of the environment, as a JSON object")
+
+	return func(ctx context.Context) error {
+		if err := required(map[string]string{"subaccount": *subAccount, "type": *envType, "service": *service,
+			"plan": *plan}); err != nil {
+			return err
+		}
+		input := &btpprovisioning.CreateEnvironmentInstanceInput{
+			EnvironmentType: btpprovisioning.EnvironmentType(*envType),
+			ServiceName:     *service,
+			PlanName:        *plan,
+			Name:            *name,
+			LandscapeLabel:  *landscape,
+		}
+		if *parameters != "" {
+			if err := json.Unmarshal([]byte(*parameters), &input.Parameters); err != nil {
+				return usageError(fmt.Sprintf("invalid --parameters; %v", err))
+			}
+		}
+
+		scoped, err := a.subAccount(ctx, *subAccount)
+		if err != nil {
+			return err
+		}
+		out, err := scoped.Provisioning.CreateEnvironmentInstance(ctx, input, a.options()...)
+		if err != nil {
+			return err
+		}
+
+		env := &btpprovisioning.EnvironmentInstance{
+			Id:              out.Id,
+			Name:            *name,
+			EnvironmentType: input.EnvironmentType,
+			State:           btpprovisioning.EnvironmentStateCreating,
+		}
+		if a.wait {
+			if env, err = automation.WaitForEnvironment(ctx, scoped.Provisioning, out.Id, a.pollInterval); err != nil {
+				return err
+			}
+		}
+		t := &table{columns: []string{"ID", "NAME", "TYPE", "STATE"}}
+		t.add(env.Id, env.Name, string(env.EnvironmentType), string(env.State))
+		return a.out.print(env, t)
+	}
+}
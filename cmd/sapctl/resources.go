@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/service/btpresources"
+	"github.com/nnicora/sap-sdk-go/service/types"
+	"strconv"
+	"time"
+)
+
+func resourcesCommand() *command {
+	return &command{
+		name:    "resources",
+		summary: "read the usage and costs of the global account",
+		subcommands: []*command{{
+			name:    "usage",
+			summary: "print the monthly usage of the services",
+			flags:   monthlyUsage,
+		}},
+	}
+}
+
+func monthlyUsage(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	now := time.Now().UTC()
+	thisMonth := types.NewYearMonth(now.Year(), now.Month()).Number()
+	from := fs.Uint("from", uint(thisMonth), "the first month of the period, as YYYYMM")
+	to := fs.Uint("to", uint(thisMonth), "the last month of the period, as YYYYMM")
+	subAccount := fs.String("subaccount", "", "only print the usage of the subaccount with this GUID")
+
+	return func(ctx context.Context) error {
+		for _, m := range []uint{*from, *to} {
+			if m < 190001 || m%100 < 1 || m%100 > 12 {
+				return usageError(fmt.Sprintf("invalid month %d, expected YYYYMM", m))
+			}
+		}
+		sess, err := a.session()
+		if err != nil {
+			return err
+		}
+		out, err := btpresources.New(sess).GetMonthlyUsage(ctx, &btpresources.GetMonthlyUsageInput{
+			FromDate: uint32(*from),
+			ToDate:   uint32(*to),
+		}, a.options()...)
+		if err != nil {
+			return err
+		}
+
+		usage := make([]btpresources.MonthlyUsage, 0, len(out.Content))
+		for _, u := range out.Content {
+			if *subAccount == "" || u.SubAccountId == *subAccount {
+				usage = append(usage, u)
+			}
+		}
+		t := &table{columns: []string{"MONTH", "SUBACCOUNT", "SERVICE", "PLAN", "METRIC", "USAGE", "UNIT"}}
+		for _, u := range usage {
+			t.add(u.ReportYearMonth.String(), u.SubAccountName, u.ServiceName, u.PlanName, u.MetricName,
+				strconv.FormatFloat(u.Usage, 'f', -1, 64), u.UnitPlural)
+		}
+		return a.out.print(usage, t)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/nnicora/sap-sdk-go/automation"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+)
+
+func saasCommand() *command {
+	return &command{
+		name:    "saas",
+		summary: "manage the subscriptions of the subaccounts to SaaS applications",
+		subcommands: []*command{{
+			name:    "subscribe",
+			summary: "subscribe a subaccount to an application",
+			flags:   subscribe,
+			async:   true,
+		}},
+	}
+}
+
+// The state of the subscription of a subaccount to an application.
+type subscriptionResult struct {
+	App   string `json:"app"`
+	Plan  string `json:"plan,omitempty"`
+	State string `json:"state"`
+	Url   string `json:"url,omitempty"`
+}
+
+func subscribe(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	subAccount := fs.String("subaccount", "", "the GUID of the subaccount")
+	appName := fs.String("app", "", "the name of the application")
+	plan := fs.String("plan", "", "the plan of the application; its default plan when empty")
+
+	return func(ctx context.Context) error {
+		if err := required(map[string]string{"subaccount": *subAccount, "app": *appName}); err != nil {
+			return err
+		}
+		scoped, err := a.subAccount(ctx, *subAccount)
+		if err != nil {
+			return err
+		}
+		if _, err := scoped.SaaS.SubscribeToApplication(ctx, &btpsaasmanager.SubscribeToApplicationInput{
+			AppName:  *appName,
+			PlanName: *plan,
+		}, a.options()...); err != nil {
+			return err
+		}
+
+		result := subscriptionResult{App: *appName, Plan: *plan, State: string(btpsaasmanager.SubscriptionStateInProcess)}
+		if a.wait {
+			app, err := automation.WaitForSubscription(ctx, scoped.SaaS, *appName, a.pollInterval)
+			if err != nil {
+				return err
+			}
+			result = subscriptionResult{App: app.AppName, Plan: app.PlanName, State: string(app.State), Url: app.SubscriptionUrl}
+		}
+		t := &table{columns: []string{"APP", "PLAN", "STATE", "URL"}}
+		t.add(result.App, result.Plan, result.State, result.Url)
+		return a.out.print(result, t)
+	}
+}
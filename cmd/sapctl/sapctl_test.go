@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/automation/automationtest"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+// Sets the environment variable for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	previous, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// Writes a configuration file with a profile for the fake, with the credentials of the subaccounts,
+// and returns its path.
+func writeConfig(t *testing.T, srv *btpfake.Server, subaccounts ...string) string {
+	cfg := srv.Config()
+	p := profile(cfg)
+	p.ClientSecret = "$SAPCTL_TEST_SECRET"
+	setenv(t, "SAPCTL_TEST_SECRET", cfg.DefaultOAuth2.ClientSecret)
+	for _, guid := range subaccounts {
+		if p.SubAccounts == nil {
+			p.SubAccounts = make(map[string]*Profile)
+		}
+		p.SubAccounts[guid] = profile(srv.SubAccountConfig(guid))
+	}
+
+	data, err := json.Marshal(&Config{DefaultProfile: "fake", Profiles: map[string]*Profile{"fake": p}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func profile(cfg *sap.Config) *Profile {
+	p := &Profile{
+		Endpoints:    make(map[string]string),
+		TokenUrl:     cfg.DefaultOAuth2.TokenURL,
+		ClientId:     cfg.DefaultOAuth2.ClientID,
+		ClientSecret: cfg.DefaultOAuth2.ClientSecret,
+	}
+	for id, e := range cfg.Endpoints {
+		p.Endpoints[id] = e.Host
+	}
+	return p
+}
+
+// Runs sapctl with the configuration, and returns its exit code and outputs.
+func sapctl(config string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append(args, "--config", config), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func mustRun(t *testing.T, config string, args ...string) string {
+	t.Helper()
+	code, stdout, stderr := sapctl(config, args...)
+	if code != 0 {
+		t.Fatalf("sapctl %s exited with %d:\n%s", strings.Join(args, " "), code, stderr)
+	}
+	return stdout
+}
+
+func TestSubAccountsList(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	config := writeConfig(t, srv)
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "core-dev", DisplayName: "Core dev",
+		CustomProperties: []btpfake.CustomProperty{{Key: "team", Value: "core"}}})
+	srv.AddSubAccount(btpfake.SubAccount{Subdomain: "web-dev", DisplayName: "Web dev"})
+
+	out := mustRun(t, config, "accounts", "subaccounts", "list")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 3 ||
+		!strings.HasPrefix(lines[0], "GUID") || !strings.Contains(out, "core-dev") || !strings.Contains(out, "web-dev") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+
+	var subAccounts []btpaccounts.SubAccount
+	out = mustRun(t, config, "accounts", "subaccounts", "list", "-o", "json", "--custom-property", "team=core")
+	if err := json.Unmarshal([]byte(out), &subAccounts); err != nil {
+		t.Fatal(err)
+	}
+	if len(subAccounts) != 1 || subAccounts[0].Subdomain != "core-dev" {
+		t.Fatalf("unexpected subaccounts %+v", subAccounts)
+	}
+
+	out = mustRun(t, config, "accounts", "subaccounts", "list", "--output", "yaml", "--custom-property", "team=core")
+	if !strings.HasPrefix(out, "- ") || !strings.Contains(out, "  subdomain: core-dev\n") {
+		t.Fatalf("unexpected YAML:\n%s", out)
+	}
+
+	if code, _, stderr := sapctl(config, "accounts", "subaccounts", "list", "-o", "xml"); code != 2 ||
+		!strings.Contains(stderr, "invalid output") {
+		t.Fatalf("expected the output to be rejected, got %d: %s", code, stderr)
+	}
+	if code, _, _ := sapctl(config, "accounts", "directories"); code != 2 {
+		t.Fatalf("expected an unknown command to be rejected, got %d", code)
+	}
+	if code, _, stderr := sapctl(config, "accounts", "subaccounts", "list", "--profile", "prod"); code != 1 ||
+		!strings.Contains(stderr, `no profile "prod"`) {
+		t.Fatalf("expected an unknown profile to fail, got %d: %s", code, stderr)
+	}
+}
+
+func TestAsyncCommands(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.AddEntitlement(btpfake.Entitlement{Service: "objectstore", Plan: "standard", Category: "SERVICE", Amount: 10})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "cloudfoundry", Plan: "standard", Category: "PLATFORM", Unlimited: true})
+	srv.AddEntitlement(btpfake.Entitlement{Service: "sales-app", Plan: "basic", Category: "APPLICATION"})
+	srv.AddApplication(btpfake.Application{AppName: "sales-app", PlanName: "basic"})
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dev", DisplayName: "dev"})
+	config := writeConfig(t, srv, sa.Guid)
+
+	if code, _, stderr := sapctl(config, "entitlements", "assign", "--subaccount", sa.Guid, "--service", "objectstore"); code != 2 ||
+		!strings.Contains(stderr, "missing --plan") {
+		t.Fatalf("expected the missing flag to be reported, got %d: %s", code, stderr)
+	}
+
+	out := mustRun(t, config, "entitlements", "assign", "--subaccount", sa.Guid, "--service", "objectstore",
+		"--plan", "standard", "--amount", "2", "--dry-run")
+	if !strings.HasPrefix(out, "PUT "+srv.URL) || !strings.Contains(out, `"amount":2`) {
+		t.Fatalf("unexpected dry run:\n%s", out)
+	}
+	if _, ok := srv.Assignment(sa.Guid, "objectstore", "standard"); ok {
+		t.Fatal("expected the dry run not to assign the plan")
+	}
+
+	wait := []string{"--wait", "--poll-interval", "1ms", "-o", "json"}
+	out = mustRun(t, config, append([]string{"entitlements", "assign", "--subaccount", sa.Guid, "--service", "objectstore",
+		"--plan", "standard", "--amount", "2"}, wait...)...)
+	if amount, ok := srv.Assignment(sa.Guid, "objectstore", "standard"); !ok || amount != 2 || !strings.Contains(out, `"COMPLETED"`) {
+		t.Fatalf("expected 2 objectstore/standard assigned, got %v:\n%s", amount, out)
+	}
+	for _, plan := range []string{"cloudfoundry/standard", "sales-app/basic"} {
+		names := strings.Split(plan, "/")
+		mustRun(t, config, append([]string{"entitlements", "assign", "--subaccount", sa.Guid, "--service", names[0],
+			"--plan", names[1], "--enable"}, wait...)...)
+	}
+
+	out = mustRun(t, config, append([]string{"provisioning", "env", "create", "--subaccount", sa.Guid, "--type", "cloudfoundry",
+		"--service", "cloudfoundry", "--plan", "standard", "--name", "dev-org", "--parameters", `{"instance_name": "dev-org"}`},
+		wait...)...)
+	var env struct{ Id, State string }
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatal(err)
+	}
+	if created, ok := srv.EnvironmentInstance(env.Id); !ok || env.State != "OK" || created.Name != "dev-org" {
+		t.Fatalf("unexpected environment:\n%s", out)
+	}
+
+	out = mustRun(t, config, append([]string{"saas", "subscribe", "--subaccount", sa.Guid, "--app", "sales-app"}, wait...)...)
+	if s := srv.SubscriptionState(sa.Guid, "sales-app"); s != "SUBSCRIBED" || !strings.Contains(out, `"state": "SUBSCRIBED"`) {
+		t.Fatalf("expected sales-app to be subscribed, got %s:\n%s", s, out)
+	}
+
+	out = mustRun(t, config, "events", "tail", "--follow=false", "--type", "EntityEntitlements_Update", "-o", "json")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 3 || !strings.Contains(lines[0], sa.Guid) {
+		t.Fatalf("expected one event per entitlement update, got:\n%s", out)
+	}
+}
+
+func TestServiceInstancesList(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	config := writeConfig(t, srv)
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dev", DisplayName: "dev"})
+
+	c := automationtest.Clients(t, srv)
+	ctx := context.Background()
+	scoped, err := c.SubAccount(ctx, sa.Guid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"core-xsuaa", "web-xsuaa"} {
+		if _, err := scoped.ServiceManagement.CreateServiceInstance(ctx, &btpmanagment.CreateServiceInstanceInput{
+			Name:                name,
+			ServiceOfferingName: "xsuaa",
+			ServicePlanName:     "application",
+			Labels:              map[string][]string{"team": {strings.TrimSuffix(name, "-xsuaa")}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := mustRun(t, config, "sm", "instances", "list", "--subaccount", sa.Guid, "--label-query", "team eq 'core'")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "core-xsuaa") ||
+		!strings.Contains(lines[1], "team=core") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+}
+
+func TestResourcesUsage(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	config := writeConfig(t, srv)
+	srv.AddUsage(
+		btpfake.UsageRecord{ReportYearMonth: 202301, SubAccountId: "s1", SubAccountName: "dev", ServiceName: "objectstore",
+			PlanName: "standard", MetricName: "storage", Usage: 12.5, UnitPlural: "GB"},
+		btpfake.UsageRecord{ReportYearMonth: 202302, SubAccountId: "s2", SubAccountName: "prod", ServiceName: "objectstore",
+			PlanName: "standard", MetricName: "storage", Usage: 40, UnitPlural: "GB"},
+		btpfake.UsageRecord{ReportYearMonth: 202303, SubAccountId: "s1", SubAccountName: "dev", ServiceName: "objectstore",
+			PlanName: "standard", MetricName: "storage", Usage: 20, UnitPlural: "GB"},
+	)
+
+	out := mustRun(t, config, "resources", "usage", "--from", "202301", "--to", "202302", "--subaccount", "s1")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "2023-01") ||
+		!strings.Contains(lines[1], "12.5") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+	if code, _, _ := sapctl(config, "resources", "usage", "--from", "202313"); code != 2 {
+		t.Fatalf("expected the month to be rejected, got %d", code)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	complete := func(words ...string) string {
+		return strings.Join(root().complete(words), " ")
+	}
+	if got := complete("s"); got != "saas sm" {
+		t.Fatalf("unexpected completions %q", got)
+	}
+	if got := complete("accounts", "subaccounts", ""); got != "list" {
+		t.Fatalf("unexpected completions %q", got)
+	}
+	if got := complete("saas", "subscribe", "--p"); got != "--plan --poll-interval --profile" {
+		t.Fatalf("unexpected completions %q", got)
+	}
+	if got := complete("sm", "instances", "list", "-o", ""); got != "table json yaml" {
+		t.Fatalf("unexpected completions %q", got)
+	}
+
+	var stdout bytes.Buffer
+	if code := run(context.Background(), []string{"completion", "bash"}, &stdout, ioutil.Discard); code != 0 ||
+		!strings.Contains(stdout.String(), "complete -o default -F _sapctl sapctl") {
+		t.Fatalf("unexpected bash completion:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"strconv"
+	"strings"
+)
+
+func smCommand() *command {
+	return &command{
+		name:    "sm",
+		summary: "manage the Service Manager resources of the subaccounts",
+		subcommands: []*command{{
+			name:    "instances",
+			summary: "manage the service instances",
+			subcommands: []*command{{
+				name:    "list",
+				summary: "list the service instances of a subaccount",
+				flags:   listServiceInstances,
+			}},
+		}},
+	}
+}
+
+func listServiceInstances(fs *flag.FlagSet, a *app) func(ctx context.Context) error {
+	subAccount := fs.String("subaccount", "", "the GUID of the subaccount")
+	fieldQuery := fs.String("field-query", "", "the field query the instances match, e.g. \"usable eq 'true'\"")
+	labelQuery := fs.String("label-query", "", "the label query the instances match, e.g. \"team eq 'core'\"")
+
+	return func(ctx context.Context) error {
+		if err := required(map[string]string{"subaccount": *subAccount}); err != nil {
+			return err
+		}
+		scoped, err := a.subAccount(ctx, *subAccount)
+		if err != nil {
+			return err
+		}
+
+		instances := make([]btpmanagment.InstanceItem, 0)
+		for token, first := "", true; first || token != ""; first = false {
+			out, err := scoped.ServiceManagement.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{
+				FieldQuery: *fieldQuery,
+				LabelQuery: *labelQuery,
+				Token:      token,
+			}, a.options()...)
+			if err != nil {
+				return err
+			}
+			instances = append(instances, out.Items...)
+			token = out.Token
+		}
+
+		t := &table{columns: []string{"ID", "NAME", "PLAN", "READY", "USABLE", "LABELS"}}
+		for _, i := range instances {
+			var labels []string
+			for _, key := range sortedKeys(i.Labels) {
+				labels = append(labels, key+"="+strings.Join(i.Labels[key], "|"))
+			}
+			t.add(i.Id, i.Name, i.ServicePlanId, strconv.FormatBool(i.Ready), strconv.FormatBool(i.Usable),
+				strings.Join(labels, ","))
+		}
+		return a.out.print(instances, t)
+	}
+}
@@ -0,0 +1,631 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const generatedHeader = "// Code generated by sapgen. DO NOT EDIT."
+
+type config struct {
+	Package     string // name of the Go package
+	Client      string // client type, e.g. AccountsV1
+	ServiceName string // label of the service
+	EndpointsID string // ID to lookup the service endpoint with
+	ServiceID   string // first path segment added by the requester
+	APIVersion  string // second path segment added by the requester
+	BasePath    string // prefix stripped from the operation paths; defaults to /ServiceID/APIVersion
+	File        string // file of the operations without tags
+}
+
+func (c *config) basePath() string {
+	if c.BasePath != "" {
+		return "/" + strings.Trim(c.BasePath, "/")
+	}
+	var parts []string
+	for _, p := range []string{c.ServiceID, c.APIVersion} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+type generator struct {
+	cfg  *config
+	doc  *document
+	over *overrides
+
+	files    map[string]*file
+	cur      *file           // file of the operation being generated
+	declared map[string]bool // Go type names already declared
+	refs     map[string]string
+	methods  map[string]bool
+	warnings []string
+}
+
+// A generated file: the operations, then the types they declared first.
+type file struct {
+	decls   bytes.Buffer // operations
+	types   bytes.Buffer // named and nested types
+	usesFmt bool
+}
+
+func newGenerator(cfg *config, doc *document, over *overrides) *generator {
+	if over == nil {
+		over = &overrides{}
+	}
+	return &generator{
+		cfg:      cfg,
+		doc:      doc,
+		over:     over,
+		files:    make(map[string]*file),
+		declared: make(map[string]bool),
+		refs:     make(map[string]string),
+		methods:  make(map[string]bool),
+	}
+}
+
+func (g *generator) warnf(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+// Sources of the operations files of the package, by file name.
+func (g *generator) api() (map[string][]byte, error) {
+	ops, err := g.doc.operations()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		name := g.fileName(op)
+		if g.files[name] == nil {
+			g.files[name] = &file{}
+		}
+		g.cur = g.files[name]
+		if err := g.operation(op); err != nil {
+			return nil, fmt.Errorf("%s %s: %v", strings.ToUpper(op.method), op.path, err)
+		}
+	}
+
+	out := make(map[string][]byte, len(g.files))
+	for name, f := range g.files {
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "%s\n\npackage %s\n\n", generatedHeader, g.cfg.Package)
+		fmt.Fprintf(&b, "import (\n")
+		if f.usesFmt {
+			fmt.Fprintf(&b, "\t\"bytes\"\n")
+		}
+		fmt.Fprintf(&b, "\t\"context\"\n")
+		if f.usesFmt {
+			fmt.Fprintf(&b, "\t\"fmt\"\n")
+		}
+		fmt.Fprintf(&b, "\t\"github.com/nnicora/sap-sdk-go/sap/http/request\"\n")
+		fmt.Fprintf(&b, "\t\"github.com/nnicora/sap-sdk-go/service/types\"\n)\n")
+		b.Write(f.decls.Bytes())
+		b.Write(f.types.Bytes())
+		src, err := formatSource(b.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		out[name] = src
+	}
+	return out, nil
+}
+
+// File of an operation: the one of its first tag, or the default one when it has none.
+func (g *generator) fileName(op *operation) string {
+	if len(op.Tags) == 0 {
+		if g.cfg.File == "" {
+			return "api.go"
+		}
+		return g.cfg.File
+	}
+	if name, ok := g.over.Files[op.Tags[0]]; ok {
+		return name
+	}
+	var words []string
+	for _, w := range strings.FieldsFunc(op.Tags[0], func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		words = append(words, strings.ToLower(w))
+	}
+	return strings.Join(append(words, "api.go"), "-")
+}
+
+// Source of the client, written only for new packages so hand-made changes are kept.
+func (g *generator) service() ([]byte, error) {
+	var b bytes.Buffer
+	if err := serviceTemplate.Execute(&b, g.cfg); err != nil {
+		return nil, err
+	}
+	return formatSource(b.Bytes())
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not compile: %v\n%s", err, src)
+	}
+	return out, nil
+}
+
+func (g *generator) operation(op *operation) error {
+	name := g.methodName(op)
+	title := firstLine(op.Summary)
+	if title == "" {
+		title = firstLine(op.Description)
+	}
+	if title == "" {
+		title = name
+	}
+
+	path := op.path
+	full := g.doc.basePath() + path
+	if base := g.cfg.basePath(); base != "/" && strings.HasPrefix(full, base+"/") {
+		path = strings.TrimPrefix(full, base)
+	}
+
+	var input fieldList
+	for _, p := range op.Parameters {
+		var dest string
+		switch p.In {
+		case "path":
+			dest = "uri"
+		case "query":
+			dest = "querystring"
+		case "header":
+			dest = "header"
+		default:
+			continue
+		}
+		typ, err := g.goType(p.Schema, name+g.goName(p.Name))
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(typ, "types.") {
+			// timestamps of parameters are sent as written by the caller
+			typ = "string"
+		}
+		if !p.Required && dest != "uri" && isScalar(typ) && typ != "string" {
+			// zero values would be sent otherwise
+			typ = "*" + typ
+		}
+		over := g.over.field(name+"Input", p.Name)
+		if over.Type != "" {
+			typ = over.Type
+		}
+		tag := fmt.Sprintf(`dest:"%s" dest-name:"%s" json:"-"`, dest, p.Name)
+		if p.Required && dest != "uri" {
+			// URI parameters are always required by the validation of the requests
+			tag += ` required:"true"`
+		}
+		fieldName := g.goName(p.Name)
+		if over.Name != "" {
+			fieldName = over.Name
+		}
+		input.add(field{
+			name: fieldName,
+			typ:  typ,
+			tag:  tag,
+			doc:  docWithEnum(p.Description, p.Schema),
+		})
+	}
+
+	body, err := g.doc.requestBody(op)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		resolved, _, err := g.doc.deref(body)
+		if err != nil {
+			return err
+		}
+		if !isObject(resolved) {
+			g.warnf("%s: request body is not an object, skipped", name)
+			return nil
+		}
+		props, _, err := g.doc.properties(body)
+		if err != nil {
+			return err
+		}
+		if err := g.addProperties(&input, props, name, name+"Input"); err != nil {
+			return err
+		}
+	}
+
+	var output fieldList
+	resp, respSchema, _, err := g.doc.successResponse(op)
+	if err != nil {
+		return err
+	}
+	wrapValues := false
+	if respSchema != nil {
+		resolved, ref, err := g.doc.deref(respSchema)
+		if err != nil {
+			return err
+		}
+		switch {
+		case resolved == nil:
+		case isObject(resolved) && ref != "" && len(resolved.Properties) > 0 && !g.over.InlineResponses:
+			typ, err := g.goType(respSchema, name+"Result")
+			if err != nil {
+				return err
+			}
+			output.add(field{name: typ, embedded: true})
+		case isObject(resolved):
+			props, _, err := g.doc.properties(resolved)
+			if err != nil {
+				return err
+			}
+			if err := g.addProperties(&output, props, name, name+"Output"); err != nil {
+				return err
+			}
+		case resolved.Type == "array":
+			typ, err := g.goType(respSchema, name+"Item")
+			if err != nil {
+				return err
+			}
+			output.add(field{name: "Values", typ: typ, tag: `json:"values,omitempty"`, doc: docLines(resp.Description)})
+			wrapValues = true
+			g.cur.usesFmt = true
+		}
+	}
+
+	w := &g.cur.decls
+	fmt.Fprintf(w, "\n// %s %s\n", strings.ToUpper(op.method), full)
+	fmt.Fprintf(w, "// %s\n", title)
+	fmt.Fprintf(w, "type %sInput struct {\n", name)
+	input.write(w)
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "type %sOutput struct {\n", name)
+	output.write(w)
+	if len(output) > 0 {
+		fmt.Fprintf(w, "\n")
+	}
+	fmt.Fprintf(w, "\tError *types.Error `json:\"error,omitempty\"`\n")
+	fmt.Fprintf(w, "\ttypes.StatusAndBodyFromResponse\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	if op.Deprecated {
+		fmt.Fprintf(w, "// Deprecated: the operation is deprecated by the service.\n")
+	}
+	fmt.Fprintf(w, "func (c *%s) %s(ctx context.Context, input *%sInput, opts ...request.Option) (*%sOutput, error) {\n",
+		g.cfg.Client, name, name, name)
+	fmt.Fprintf(w, "\treq, out := c.%sRequest(ctx, input)\n", name)
+	fmt.Fprintf(w, "\treq.ApplyOptions(opts...)\n")
+	fmt.Fprintf(w, "\treturn out, req.Send()\n")
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "func (c *%s) %sRequest(ctx context.Context, input *%sInput) (*request.Request, *%sOutput) {\n",
+		g.cfg.Client, name, name, name)
+	fmt.Fprintf(w, "\top := &request.Operation{\n")
+	fmt.Fprintf(w, "\t\tName: %q,\n", title)
+	fmt.Fprintf(w, "\t\tHttp: request.HTTP{\n")
+	fmt.Fprintf(w, "\t\t\tMethod: request.%s,\n", strings.ToUpper(op.method))
+	fmt.Fprintf(w, "\t\t\tPath:   %q,\n", path)
+	fmt.Fprintf(w, "\t\t},\n\t}\n\n")
+	fmt.Fprintf(w, "\tif input == nil {\n\t\tinput = &%sInput{}\n\t}\n\n", name)
+	fmt.Fprintf(w, "\toutput := &%sOutput{}\n", name)
+	if !wrapValues {
+		fmt.Fprintf(w, "\treturn c.newRequest(ctx, op, input, output), output\n}\n")
+		return nil
+	}
+	fmt.Fprintf(w, "\treq := c.newRequest(ctx, op, input, output)\n\n")
+	fmt.Fprintf(w, "\t// the service answers with a JSON array, wrapped to decode into Values\n")
+	fmt.Fprintf(w, "\treq.ResponseBodyHandler = func(statusCode int, body []byte) ([]byte, error) {\n")
+	fmt.Fprintf(w, "\t\tif statusCode >= 200 && statusCode < 300 && len(bytes.TrimSpace(body)) > 0 {\n")
+	fmt.Fprintf(w, "\t\t\treturn []byte(fmt.Sprintf(\"{ \\\"values\\\": %%s }\", body)), nil\n")
+	fmt.Fprintf(w, "\t\t}\n\t\treturn body, nil\n\t}\n")
+	fmt.Fprintf(w, "\treturn req, output\n}\n")
+	return nil
+}
+
+func (g *generator) methodName(op *operation) string {
+	key := op.OperationID
+	if key == "" {
+		key = strings.ToUpper(op.method) + " " + op.path
+	}
+	if name, ok := g.over.Operations[key]; ok {
+		g.methods[name] = true
+		return name
+	}
+
+	name := g.goName(op.OperationID)
+	if name == "" {
+		name = g.goName(op.method)
+		for _, segment := range strings.Split(op.path, "/") {
+			name += g.goName(strings.Trim(segment, "{}"))
+		}
+	}
+	base := name
+	for i := 2; g.methods[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.methods[name] = true
+	return name
+}
+
+// Adds the fields of the properties to the struct typeName; owner prefixes the names of the nested types.
+func (g *generator) addProperties(fields *fieldList, props map[string]*schema, owner, typeName string) error {
+	for _, jsonName := range sortedKeys(props) {
+		prop := props[jsonName]
+		typ, err := g.goType(prop, owner+g.goName(jsonName))
+		if err != nil {
+			return err
+		}
+		if typ == typeName {
+			// a type cannot contain itself
+			typ = "*" + typ
+		}
+		over := g.over.field(typeName, jsonName)
+		if over.Type != "" {
+			typ = over.Type
+		}
+		name := g.goName(jsonName)
+		if over.Name != "" {
+			name = over.Name
+		}
+		resolved, _, err := g.doc.deref(prop)
+		if err != nil {
+			return err
+		}
+		if !fields.add(field{
+			name: name,
+			typ:  typ,
+			tag:  fmt.Sprintf(`json:"%s,omitempty"`, jsonName),
+			doc:  docWithEnum(resolved.Description, resolved),
+		}) {
+			g.warnf("%s: duplicate field %s, skipped", typeName, name)
+		}
+	}
+	return nil
+}
+
+// Go type of a schema, declaring named and nested object types on the way.
+func (g *generator) goType(s *schema, hint string) (string, error) {
+	if s == nil {
+		return "interface{}", nil
+	}
+	if s.Ref != "" {
+		target, err := g.doc.schema(s.Ref)
+		if err != nil {
+			return "", err
+		}
+		if !isObject(target) || len(target.Properties) == 0 && len(target.AllOf) == 0 {
+			// aliases of primitives, arrays and maps are inlined
+			return g.goType(target, g.goName(refName(s.Ref)))
+		}
+		name, ok := g.refs[s.Ref]
+		if !ok {
+			name = g.typeName(g.goName(refName(s.Ref)))
+			g.refs[s.Ref] = name
+			if err := g.declareStruct(name, target); err != nil {
+				return "", err
+			}
+		}
+		return name, nil
+	}
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 {
+		return g.goType(s.AllOf[0], hint)
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "types.ISOTime", nil
+		case "date":
+			return "types.Date", nil
+		}
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int32", nil
+	case "number":
+		if s.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "array":
+		elem, err := g.goType(s.Items, hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	}
+
+	if isObject(s) {
+		if len(s.Properties) == 0 && len(s.AllOf) == 0 {
+			if values := additionalProperties(s); values != nil {
+				elem, err := g.goType(values, hint+"Value")
+				if err != nil {
+					return "", err
+				}
+				return "map[string]" + elem, nil
+			}
+			return "map[string]interface{}", nil
+		}
+		name := g.typeName(hint)
+		if err := g.declareStruct(name, s); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+	return "interface{}", nil
+}
+
+// Unique Go type name, never ending like the Input and Output types of the operations.
+func (g *generator) typeName(name string) string {
+	if o, ok := g.over.Types[name]; ok {
+		name = o
+	}
+	if strings.HasSuffix(name, "Input") || strings.HasSuffix(name, "Output") {
+		name += "Data"
+	}
+	base := name
+	for i := 2; g.declared[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.declared[name] = true
+	return name
+}
+
+func (g *generator) declareStruct(name string, s *schema) error {
+	props, _, err := g.doc.properties(s)
+	if err != nil {
+		return err
+	}
+	var fields fieldList
+	if err := g.addProperties(&fields, props, name, name); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	for _, line := range docLines(s.Description) {
+		fmt.Fprintf(&b, "//%s\n", line)
+	}
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	fields.write(&b)
+	fmt.Fprintf(&b, "}\n")
+
+	// nested types were declared while collecting the fields, so they come first
+	g.cur.types.WriteString("\n")
+	g.cur.types.Write(b.Bytes())
+	return nil
+}
+
+type field struct {
+	name     string
+	typ      string
+	tag      string
+	doc      []string
+	embedded bool
+}
+
+type fieldList []field
+
+func (l *fieldList) add(f field) bool {
+	for _, existing := range *l {
+		if existing.name == f.name {
+			return false
+		}
+	}
+	*l = append(*l, f)
+	return true
+}
+
+func (l fieldList) write(w *bytes.Buffer) {
+	for i, f := range l {
+		if i > 0 && len(f.doc) > 0 {
+			w.WriteString("\n")
+		}
+		for _, line := range f.doc {
+			fmt.Fprintf(w, "\t//%s\n", line)
+		}
+		if f.embedded {
+			fmt.Fprintf(w, "\t%s\n", f.name)
+			continue
+		}
+		fmt.Fprintf(w, "\t%s %s `%s`\n", f.name, f.typ, f.tag)
+	}
+}
+
+func docWithEnum(description string, s *schema) []string {
+	lines := docLines(description)
+	if s == nil || len(s.Enum) == 0 {
+		return lines
+	}
+	values := make([]string, 0, len(s.Enum))
+	for _, v := range s.Enum {
+		values = append(values, fmt.Sprint(v))
+	}
+	return append(lines, "Enum:", "\t[ "+strings.Join(values, ", ")+" ]")
+}
+
+func docLines(text string) []string {
+	text = strings.TrimSpace(strings.Replace(text, "\r\n", "\n", -1))
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return lines
+}
+
+func firstLine(text string) string {
+	if lines := docLines(text); len(lines) > 0 {
+		return lines[0]
+	}
+	return ""
+}
+
+func isObject(s *schema) bool {
+	return s != nil && (s.Type == "object" || s.Type == "" && (len(s.Properties) > 0 || len(s.AllOf) > 0))
+}
+
+func isScalar(typ string) bool {
+	switch typ {
+	case "string", "bool", "int32", "int64", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+func additionalProperties(s *schema) *schema {
+	raw := bytes.TrimSpace(s.AdditionalProperties)
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil
+	}
+	values := &schema{}
+	if err := json.Unmarshal(raw, values); err != nil {
+		return nil
+	}
+	return values
+}
+
+// Go identifier of an OpenAPI name, with the words of the overrides replaced.
+func (g *generator) goName(name string) string {
+	return g.over.identifier(goName(name))
+}
+
+// Exported Go identifier of an OpenAPI name, e.g. "subaccount_id" becomes "SubaccountId".
+func goName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out != "" && unicode.IsDigit(rune(out[0])) {
+		out = "N" + out
+	}
+	return out
+}
+
+// Keys of a map with string keys, sorted.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
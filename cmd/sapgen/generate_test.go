@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generate(t *testing.T) string {
+	doc, err := loadDocument("testdata/resources.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGenerator(&config{Package: "btpresources", Client: "ResourceV1", ServiceID: "reports", APIVersion: "v1"}, doc, nil)
+	files, err := g.api()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, ok := files["api.go"]
+	if len(files) != 1 || !ok {
+		t.Fatalf("expected the operations without tags in api.go, got %d files", len(files))
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "api.go", src, 0); err != nil {
+		t.Fatal(err)
+	}
+	return string(src)
+}
+
+func TestGenerateOperations(t *testing.T) {
+	src := generate(t)
+
+	expected := []string{
+		// paths are relative to the service ID and version, the comment keeps the full one
+		"// GET /reports/v1/monthlyUsage\n// Get monthly usage data for a global account\ntype GetMonthlyUsageInput struct {",
+		"Path:   \"/monthlyUsage\",",
+		"ToDate         int32  `dest:\"querystring\" dest-name:\"toDate\" json:\"-\" required:\"true\"`",
+		"AcceptLanguage string `dest:\"header\" dest-name:\"Accept-Language\" json:\"-\"`",
+		"SubaccountGUID string `dest:\"uri\" dest-name:\"subaccountGUID\" json:\"-\"`",
+		"func (c *ResourceV1) GetMonthlyUsage(ctx context.Context, input *GetMonthlyUsageInput, opts ...request.Option) (*GetMonthlyUsageOutput, error) {",
+		"func (c *ResourceV1) UpdateLabelsRequest(ctx context.Context, input *UpdateLabelsInput) (*request.Request, *UpdateLabelsOutput) {",
+		// request bodies are flattened into the input
+		"Labels         map[string][]string `json:\"labels,omitempty\"`",
+		// named response schemas are embedded, nested ones declared
+		"type GetMonthlyUsageOutput struct {\n\tMonthlyUsageResponseList\n",
+		"Contracts       []CloudCreditsDetailsResponseObjectContractsItem `json:\"contracts,omitempty\"`",
+		"//Enum:\n\t//\t[ ALL, CURRENT ]",
+		// dates are typed
+		"PhaseStartDate types.Date `json:\"phaseStartDate,omitempty\"`",
+		// arrays are decoded into Values
+		"Values []Label `json:\"values,omitempty\"`",
+		"func (c *ResourceV1) GetSubaccountsSubaccountGUIDLabels(",
+	}
+	for _, e := range expected {
+		if !strings.Contains(src, e) {
+			t.Errorf("expected generated code to contain:\n%s", e)
+		}
+	}
+	if t.Failed() {
+		t.Log(src)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	g := newGenerator(&config{File: "api.go"}, nil, &overrides{Files: map[string]string{"Labels": "labels.go"}})
+	for tags, expected := range map[string]string{
+		"":                "api.go",
+		"Service Brokers": "service-brokers-api.go",
+		"Job Management":  "job-management-api.go",
+		"Labels":          "labels.go",
+	} {
+		op := &operation{}
+		if tags != "" {
+			op.Tags = []string{tags}
+		}
+		if name := g.fileName(op); name != expected {
+			t.Errorf("%q: expected %s, got %s", tags, expected, name)
+		}
+	}
+}
+
+// Directive of the service packages generated by sapgen, run from their directory.
+const generateDirective = "//go:generate go run ../../cmd/sapgen "
+
+// Every service package with a sapgen directive is reproduced byte for byte from its document
+// and overrides; the other ones are maintained by hand.
+func TestRegeneratePackage(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("..", "..", "service", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var generated int
+	for _, dir := range dirs {
+		args := directiveArgs(t, dir)
+		if args == nil {
+			continue
+		}
+		generated++
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			inv, err := parseArgs(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			inv.spec = filepath.Join(dir, inv.spec)
+			inv.out = filepath.Join(dir, inv.out)
+			if inv.overrides != "" {
+				inv.overrides = filepath.Join(dir, inv.overrides)
+			}
+			g, files, err := inv.generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range g.warnings {
+				t.Error(w)
+			}
+			for name, src := range files {
+				existing, err := ioutil.ReadFile(filepath.Join(inv.out, name))
+				if err != nil {
+					t.Fatalf("%s is not a file of the package: %v", name, err)
+				}
+				if !bytes.Equal(existing, src) {
+					t.Errorf("%s differs from the generated code, run go generate in %s", name, dir)
+				}
+			}
+		})
+	}
+	if generated == 0 {
+		t.Fatal("no service package is generated by sapgen")
+	}
+}
+
+// Arguments of the sapgen directive of the package, nil when it has none.
+func directiveArgs(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(string(src), "\n") {
+			if strings.HasPrefix(line, generateDirective) {
+				return strings.Fields(strings.TrimPrefix(line, generateDirective))
+			}
+		}
+	}
+	return nil
+}
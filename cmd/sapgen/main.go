@@ -0,0 +1,224 @@
+// Command sapgen generates a service package from the OpenAPI (or Swagger 2) JSON
+// document of a BTP API, as published on the SAP API Business Hub.
+//
+// The operations are written to one file per tag, e.g. service-brokers-api.go, and to api.go
+// when they have none: one Input and Output struct per operation, tagged for the request
+// pipeline (dest/dest-name for path, query and header parameters, json for the body), the
+// operation method with its XxxRequest builder, and the types of the schemas they use. The
+// client in service.go is only written when the package does not have one yet, so it can be
+// adapted by hand afterwards.
+//
+// The names, types and files of an existing package are kept with the overrides read from
+// sapgen.json in its directory, or from the file given with -overrides, e.g.
+//
+//	{
+//		"words": {"Subaccount": "SubAccount"},
+//		"operations": {"getMonthlyUsage": "GetMonthlyUsage"},
+//		"types": {"MonthlyUsageResponseObject": "MonthlyUsage"},
+//		"fields": {"GetMonthlyUsageInput.fromDate": {"type": "uint32"}},
+//		"files": {"Resource Consumption": "api.go"},
+//		"inlineResponses": true
+//	}
+//
+//	go run ./cmd/sapgen -spec accounts.json -package btpaccounts -client AccountsV1 \
+//		-service-name "Accounts Service V1" -endpoints-id accounts -service-id accounts -api-version v1
+//
+// Only btpresources is generated so far, by go generate from its openapi.json and sapgen.json;
+// the other service packages predate sapgen and are maintained by hand. The tests check that
+// every package with a sapgen directive is reproduced byte for byte.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("sapgen: ")
+
+	inv, err := parseArgs(os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Print(err)
+		os.Exit(2)
+	}
+	g, files, err := inv.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, w := range g.warnings {
+		log.Print(w)
+	}
+
+	out := inv.out
+	if err := os.MkdirAll(out, 0755); err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(out, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	servicePath := filepath.Join(out, "service.go")
+	if _, err := os.Stat(servicePath); os.IsNotExist(err) {
+		src, err := g.service()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ioutil.WriteFile(servicePath, src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	fmt.Printf("generated %s\n", out)
+}
+
+// What to generate, as given on the command line.
+type invocation struct {
+	cfg       config
+	spec      string // path of the OpenAPI document
+	out       string // directory of the package
+	overrides string // path of the overrides, if not the one of the package
+}
+
+func parseArgs(args []string) (*invocation, error) {
+	var inv invocation
+	fs := flag.NewFlagSet("sapgen", flag.ContinueOnError)
+	fs.StringVar(&inv.spec, "spec", "", "path of the OpenAPI JSON document")
+	fs.StringVar(&inv.out, "out", "", "directory of the generated package; defaults to service/<package>")
+	fs.StringVar(&inv.cfg.File, "file", "api.go", "name of the file of the operations without tags")
+	fs.StringVar(&inv.overrides, "overrides", "", "path of the overrides; defaults to "+overridesFile+" in the package directory, when present")
+	fs.StringVar(&inv.cfg.Package, "package", "", "name of the Go package, e.g. btpaccounts")
+	fs.StringVar(&inv.cfg.Client, "client", "", "name of the client type, e.g. AccountsV1")
+	fs.StringVar(&inv.cfg.ServiceName, "service-name", "", "label of the service; defaults to the title of the document")
+	fs.StringVar(&inv.cfg.EndpointsID, "endpoints-id", "", "ID to lookup the service endpoint with; defaults to the service ID")
+	fs.StringVar(&inv.cfg.ServiceID, "service-id", "", "first path segment of every operation, e.g. accounts")
+	fs.StringVar(&inv.cfg.APIVersion, "api-version", "v1", "second path segment of every operation")
+	fs.StringVar(&inv.cfg.BasePath, "base-path", "", "prefix stripped from the operation paths; defaults to /<service-id>/<api-version>")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if inv.spec == "" || inv.cfg.Package == "" || inv.cfg.Client == "" {
+		fs.Usage()
+		return nil, fmt.Errorf("the -spec, -package and -client flags are required")
+	}
+	if inv.out == "" {
+		inv.out = filepath.Join("service", inv.cfg.Package)
+	}
+	if inv.cfg.EndpointsID == "" {
+		inv.cfg.EndpointsID = inv.cfg.ServiceID
+	}
+	return &inv, nil
+}
+
+// Generates the operations files of the package, by file name.
+func (inv *invocation) generate() (*generator, map[string][]byte, error) {
+	doc, err := loadDocument(inv.spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inv.cfg.ServiceName == "" {
+		inv.cfg.ServiceName = doc.Info.Title
+	}
+	over, err := packageOverrides(inv.overrides, inv.out)
+	if err != nil {
+		return nil, nil, err
+	}
+	g := newGenerator(&inv.cfg, doc, over)
+	files, err := g.api()
+	return g, files, err
+}
+
+// Reads the given overrides, or the ones of the package directory when it has any.
+func packageOverrides(path, dir string) (*overrides, error) {
+	if path != "" {
+		return loadOverrides(path)
+	}
+	path = filepath.Join(dir, overridesFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return loadOverrides(path)
+}
+
+var serviceTemplate = template.Must(template.New("service").Parse(`package {{.Package}}
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/endpoints"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/http/request/processors/jsonbuiltin"
+	"github.com/nnicora/sap-sdk-go/sap/metainfo"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
+	"github.com/nnicora/sap-sdk-go/sap/service"
+)
+
+//go:generate go run ../../internal/tools/apigen -type {{.Client}}
+
+type {{.Client}} struct {
+	*service.Requester
+}
+
+const (
+	ServiceName = {{printf "%q" .ServiceName}} // Label of service.
+	EndpointsID = {{printf "%q" .EndpointsID}} // ID to lookup a service endpoint with.
+	ServiceID   = {{printf "%q" .ServiceID}} // ServiceID is a unique identifier of a specific service.
+)
+
+func New(p service.RequesterConfig, opts ...request.Option) *{{.Client}} {
+	c, err := p.ServiceConfig(EndpointsID)
+	if err != nil {
+		c.Processors.Using(request.Validate).PushFrontHandler(func(t interface{}) {
+			r := t.(*request.Request)
+			r.Error = err
+		})
+	}
+	return newRequester(c.RuntimeConfig, c.Processors, c.Endpoint, opts...)
+}
+
+func newRequester(cfg *sap.RuntimeConfig, p *processors.Processors, endpoint *endpoints.Endpoint, opts ...request.Option) *{{.Client}} {
+	svc := &{{.Client}}{
+		Requester: service.NewRequester(
+			cfg,
+			metainfo.ServiceInfo{
+				ServiceName: ServiceName,
+				ServiceID:   ServiceID,
+				Endpoint:    endpoint,
+				APIVersion:  {{printf "%q" .APIVersion}},
+			},
+			p,
+			service.WithRequestOptions(opts...),
+		),
+	}
+
+	// Processors
+	p.Using(request.Build).
+		PushBack(&jsonbuiltin.BuildProcessor).
+		PushBack(&jsonbuiltin.MarshalToRequestJSONBodyProcessor)
+
+	p.Using(request.Unmarshal).
+		PushBack(&jsonbuiltin.UnmarshalResponseJSONBodyProcessor)
+
+	p.Using(request.UnmarshalError).
+		PushBack(&jsonbuiltin.UnmarshalErrorResponseJSONBodyProcessor)
+
+	p.Using(request.UnmarshalMeta).
+		PushBack(&jsonbuiltin.UnmarshalMetaProcessor)
+
+	return svc
+}
+
+func (svc *{{.Client}}) newRequest(ctx context.Context, op *request.Operation, in, out interface{}) *request.Request {
+	return svc.NewRequest(ctx, op, in, out)
+}
+`))
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// Name of the overrides file read from the directory of the package when -overrides is not given.
+const overridesFile = "sapgen.json"
+
+// Overrides adapt the generated code to the API a package already has, so that it can be
+// regenerated without breaking its callers.
+type overrides struct {
+	// Words replaced in every generated identifier, e.g. "Subaccount": "SubAccount".
+	Words map[string]string `json:"words,omitempty"`
+
+	// Go names of the operations, by operation ID or by "METHOD /path" for the ones without any.
+	Operations map[string]string `json:"operations,omitempty"`
+
+	// Go names of the types, by the name they are otherwise generated with.
+	Types map[string]string `json:"types,omitempty"`
+
+	// Go names and types of the fields, by "Type.name" where name is the one of the property or
+	// parameter, e.g. "GetMonthlyUsageInput.fromDate".
+	Fields map[string]fieldOverride `json:"fields,omitempty"`
+
+	// Files of the operations, by tag; the operations of the other tags are written to
+	// <tag>-api.go, and the ones without any to the file given with -file.
+	Files map[string]string `json:"files,omitempty"`
+
+	// Copy the properties of named response schemas into the outputs rather than embedding their type.
+	InlineResponses bool `json:"inlineResponses,omitempty"`
+}
+
+type fieldOverride struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+func loadOverrides(path string) (*overrides, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	o := &overrides{}
+	if err := json.Unmarshal(data, o); err != nil {
+		return nil, fmt.Errorf("%s: invalid overrides: %v", path, err)
+	}
+	return o, nil
+}
+
+// Identifier with the words replaced; the longest words are replaced first.
+func (o *overrides) identifier(name string) string {
+	words := make([]string, 0, len(o.Words))
+	for w := range o.Words {
+		words = append(words, w)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+		return words[i] < words[j]
+	})
+	for _, w := range words {
+		name = strings.Replace(name, w, o.Words[w], -1)
+	}
+	return name
+}
+
+func (o *overrides) field(owner, name string) fieldOverride {
+	return o.Fields[owner+"."+name]
+}
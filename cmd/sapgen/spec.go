@@ -0,0 +1,318 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+// OpenAPI 3 or Swagger 2 document, only the parts used for code generation.
+type document struct {
+	Swagger string `json:"swagger"`
+	OpenAPI string `json:"openapi"`
+
+	Info struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	} `json:"info"`
+
+	// Swagger 2
+	BasePath    string                `json:"basePath"`
+	Definitions map[string]*schema    `json:"definitions"`
+	Parameters  map[string]*parameter `json:"parameters"`
+	Responses   map[string]*response  `json:"responses"`
+
+	// OpenAPI 3
+	Servers    []struct{ URL string } `json:"servers"`
+	Components struct {
+		Schemas       map[string]*schema      `json:"schemas"`
+		Parameters    map[string]*parameter   `json:"parameters"`
+		Responses     map[string]*response    `json:"responses"`
+		RequestBodies map[string]*requestBody `json:"requestBodies"`
+	} `json:"components"`
+
+	Paths map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+type schema struct {
+	Ref         string        `json:"$ref"`
+	Type        string        `json:"type"`
+	Format      string        `json:"format"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Enum        []interface{} `json:"enum"`
+	Example     interface{}   `json:"example"`
+
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	AllOf                []*schema          `json:"allOf"`
+}
+
+type parameter struct {
+	Ref         string `json:"$ref"`
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+
+	// Swagger 2 keeps the type of non-body parameters inline.
+	Type   string        `json:"type"`
+	Format string        `json:"format"`
+	Enum   []interface{} `json:"enum"`
+	Items  *schema       `json:"items"`
+
+	Schema *schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema  *schema     `json:"schema"`
+	Example interface{} `json:"example"`
+}
+
+type requestBody struct {
+	Ref         string                `json:"$ref"`
+	Description string                `json:"description"`
+	Required    bool                  `json:"required"`
+	Content     map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Ref         string                 `json:"$ref"`
+	Description string                 `json:"description"`
+	Schema      *schema                `json:"schema"`
+	Examples    map[string]interface{} `json:"examples"`
+	Content     map[string]*mediaType  `json:"content"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Deprecated  bool                 `json:"deprecated"`
+	Tags        []string             `json:"tags"`
+	Parameters  []*parameter         `json:"parameters"`
+	RequestBody *requestBody         `json:"requestBody"`
+	Responses   map[string]*response `json:"responses"`
+
+	// filled in while loading
+	method string
+	path   string
+}
+
+func loadDocument(path string) (*document, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("%s: only JSON documents are supported: %v", path, err)
+	}
+	if doc.Swagger == "" && doc.OpenAPI == "" {
+		return nil, fmt.Errorf("%s: neither a Swagger 2 nor an OpenAPI 3 document", path)
+	}
+	return doc, nil
+}
+
+// Path prefix every operation path is relative to.
+func (d *document) basePath() string {
+	if d.Swagger != "" {
+		return strings.TrimSuffix(d.BasePath, "/")
+	}
+	if len(d.Servers) > 0 {
+		if u, err := url.Parse(d.Servers[0].URL); err == nil {
+			return strings.TrimSuffix(u.Path, "/")
+		}
+	}
+	return ""
+}
+
+var httpMethods = []string{"get", "post", "put", "patch", "delete", "head"}
+
+// Operations in a stable order: by path, then by HTTP method.
+func (d *document) operations() ([]*operation, error) {
+	paths := sortedKeys(d.Paths)
+
+	var out []*operation
+	for _, path := range paths {
+		item := d.Paths[path]
+
+		var shared []*parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+		}
+
+		for _, method := range httpMethods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			op := &operation{}
+			if err := json.Unmarshal(raw, op); err != nil {
+				return nil, fmt.Errorf("%s %s: %v", strings.ToUpper(method), path, err)
+			}
+			op.method, op.path = method, path
+
+			params := make([]*parameter, 0, len(shared)+len(op.Parameters))
+			for _, p := range append(append([]*parameter{}, shared...), op.Parameters...) {
+				resolved, err := d.parameter(p)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %v", strings.ToUpper(method), path, err)
+				}
+				params = overrideParameter(params, resolved)
+			}
+			op.Parameters = params
+			out = append(out, op)
+		}
+	}
+	return out, nil
+}
+
+// Operation level parameters override path level ones with the same name and location.
+func overrideParameter(params []*parameter, p *parameter) []*parameter {
+	for i, existing := range params {
+		if existing.Name == p.Name && existing.In == p.In {
+			params[i] = p
+			return params
+		}
+	}
+	return append(params, p)
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func (d *document) parameter(p *parameter) (*parameter, error) {
+	for p.Ref != "" {
+		name := refName(p.Ref)
+		next := d.Parameters[name]
+		if next == nil {
+			next = d.Components.Parameters[name]
+		}
+		if next == nil {
+			return nil, fmt.Errorf("unresolved parameter %s", p.Ref)
+		}
+		p = next
+	}
+	if p.Schema == nil && p.In != "body" {
+		p.Schema = &schema{Type: p.Type, Format: p.Format, Enum: p.Enum, Items: p.Items}
+	}
+	return p, nil
+}
+
+// Named schema a reference points to.
+func (d *document) schema(ref string) (*schema, error) {
+	name := refName(ref)
+	s := d.Definitions[name]
+	if s == nil {
+		s = d.Components.Schemas[name]
+	}
+	if s == nil {
+		return nil, fmt.Errorf("unresolved schema %s", ref)
+	}
+	return s, nil
+}
+
+// Follows references until a schema with a body, returning the name of the last reference.
+func (d *document) deref(s *schema) (*schema, string, error) {
+	name := ""
+	for s != nil && s.Ref != "" {
+		name = refName(s.Ref)
+		next, err := d.schema(s.Ref)
+		if err != nil {
+			return nil, "", err
+		}
+		s = next
+	}
+	return s, name, nil
+}
+
+// Properties of an object schema, allOf compositions merged.
+func (d *document) properties(s *schema) (map[string]*schema, []string, error) {
+	s, _, err := d.deref(s)
+	if err != nil || s == nil {
+		return nil, nil, err
+	}
+	props := make(map[string]*schema)
+	var required []string
+	for _, part := range s.AllOf {
+		p, r, err := d.properties(part)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range p {
+			props[k] = v
+		}
+		required = append(required, r...)
+	}
+	for k, v := range s.Properties {
+		props[k] = v
+	}
+	return props, append(required, s.Required...), nil
+}
+
+// JSON schema of the request body, if any.
+func (d *document) requestBody(op *operation) (*schema, error) {
+	if op.RequestBody != nil {
+		body := op.RequestBody
+		for body.Ref != "" {
+			next := d.Components.RequestBodies[refName(body.Ref)]
+			if next == nil {
+				return nil, fmt.Errorf("unresolved request body %s", body.Ref)
+			}
+			body = next
+		}
+		return jsonSchema(body.Content), nil
+	}
+	for _, p := range op.Parameters {
+		if p.In == "body" {
+			return p.Schema, nil
+		}
+	}
+	return nil, nil
+}
+
+// Schema of the first successful response with a JSON body, and its status code.
+func (d *document) successResponse(op *operation) (*response, *schema, string, error) {
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		resp := op.Responses[code]
+		for resp.Ref != "" {
+			name := refName(resp.Ref)
+			next := d.Responses[name]
+			if next == nil {
+				next = d.Components.Responses[name]
+			}
+			if next == nil {
+				return nil, nil, "", fmt.Errorf("unresolved response %s", resp.Ref)
+			}
+			resp = next
+		}
+		if s := resp.Schema; s != nil {
+			return resp, s, code, nil
+		}
+		if s := jsonSchema(resp.Content); s != nil {
+			return resp, s, code, nil
+		}
+	}
+	return nil, nil, "", nil
+}
+
+func jsonSchema(content map[string]*mediaType) *schema {
+	for _, mime := range sortedKeys(content) {
+		if strings.Contains(mime, "json") && content[mime] != nil {
+			return content[mime].Schema
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package sapcontext holds the contexts the SDK derives from the ones of its callers.
+package sapcontext
+
+import (
+	"context"
+	"time"
+)
+
+// Detached returns a context holding the values of parent, which is never cancelled nor has a
+// deadline, e.g. to undo what a cancelled call has done.
+func Detached(parent context.Context) context.Context {
+	return detached{parent: parent}
+}
+
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
+
+func (d detached) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
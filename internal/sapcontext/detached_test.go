@@ -0,0 +1,31 @@
+package sapcontext
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type key struct{}
+
+func TestDetached(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.WithValue(context.Background(), key{}, "value"), time.Minute)
+	cancel()
+
+	ctx := Detached(parent)
+	if ctx.Err() != nil || ctx.Done() != nil {
+		t.Fatalf("expected the detached context to outlive its parent, got %v", ctx.Err())
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("expected no deadline")
+	}
+	if v := ctx.Value(key{}); v != "value" {
+		t.Fatalf("expected the value of the parent, got %v", v)
+	}
+
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if child.Err() != nil {
+		t.Fatalf("expected a live child, got %v", child.Err())
+	}
+}
@@ -0,0 +1,180 @@
+// Package sapyaml writes values as YAML documents, with the fields their JSON encoding has.
+package sapyaml
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Write writes the value as a YAML document. The value is encoded to JSON first, so that its json
+// struct tags and marshalers apply, and the order of the fields is kept.
+func Write(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	doc, err := decodeOrdered(dec)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	switch d := doc.(type) {
+	case []field:
+		if len(d) == 0 {
+			bw.WriteString("{}\n")
+		}
+		writeMapping(bw, d, 0)
+	case []interface{}:
+		if len(d) == 0 {
+			bw.WriteString("[]\n")
+		}
+		writeSequence(bw, d, 0)
+	default:
+		bw.WriteString(scalar(d) + "\n")
+	}
+	return bw.Flush()
+}
+
+// Marshal returns the value as a YAML document, see Write.
+func Marshal(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	if err := Write(&b, v); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// A field of a JSON object, the objects being decoded as their fields in order to keep it.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// Decodes the next JSON value as []field for objects, []interface{} for arrays, or a scalar.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		fields := []field{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{key.(string), value})
+		}
+		_, err := dec.Token()
+		return fields, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for dec.More() {
+			item, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	}
+	return tok, nil
+}
+
+func writeMapping(w *bufio.Writer, fields []field, indent int) {
+	for _, f := range fields {
+		w.WriteString(strings.Repeat(" ", indent))
+		w.WriteString(quote(f.key))
+		w.WriteByte(':')
+		writeValue(w, f.value, indent)
+	}
+}
+
+func writeSequence(w *bufio.Writer, items []interface{}, indent int) {
+	for _, item := range items {
+		w.WriteString(strings.Repeat(" ", indent))
+		w.WriteByte('-')
+		switch v := item.(type) {
+		case []field:
+			if len(v) == 0 {
+				w.WriteString(" {}\n")
+				continue
+			}
+			// The first field goes on the line of the dash, the others are aligned with it.
+			w.WriteString(" " + quote(v[0].key) + ":")
+			writeValue(w, v[0].value, indent+2)
+			writeMapping(w, v[1:], indent+2)
+		case []interface{}:
+			if len(v) == 0 {
+				w.WriteString(" []\n")
+				continue
+			}
+			w.WriteByte('\n')
+			writeSequence(w, v, indent+2)
+		default:
+			writeValue(w, v, indent)
+		}
+	}
+}
+
+// Writes the value of a mapping key, or of a sequence item, written at the indent.
+func writeValue(w *bufio.Writer, value interface{}, indent int) {
+	switch v := value.(type) {
+	case []field:
+		if len(v) == 0 {
+			w.WriteString(" {}\n")
+			return
+		}
+		w.WriteByte('\n')
+		writeMapping(w, v, indent+2)
+	case []interface{}:
+		if len(v) == 0 {
+			w.WriteString(" []\n")
+			return
+		}
+		w.WriteByte('\n')
+		writeSequence(w, v, indent+2)
+	default:
+		w.WriteString(" " + scalar(v) + "\n")
+	}
+}
+
+func scalar(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return quote(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return "null"
+}
+
+var (
+	plain    = regexp.MustCompile(`^[A-Za-z_/][A-Za-z0-9_./@-]*$`)
+	reserved = regexp.MustCompile(`^(?i:y|n|yes|no|on|off|true|false|null)$`)
+)
+
+// Returns the string as a plain scalar when it cannot be read as anything else, double-quoted
+// otherwise.
+func quote(s string) string {
+	if plain.MatchString(s) && !reserved.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
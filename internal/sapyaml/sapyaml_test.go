@@ -0,0 +1,41 @@
+package sapyaml
+
+import (
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	v := map[string]interface{}{
+		"name":   "core",
+		"labels": map[string][]string{"team": {"a", "b:c"}},
+		"items":  []interface{}{map[string]interface{}{"id": "1", "ready": true}, []int{}},
+		"empty":  map[string]string{},
+		"amount": 1.5,
+		"flag":   "yes",
+		"none":   nil,
+	}
+	expected := `amount: 1.5
+empty: {}
+flag: "yes"
+items:
+  - id: "1"
+    ready: true
+  - []
+labels:
+  team:
+    - a
+    - "b:c"
+name: core
+none: null
+`
+	out, err := Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+	if out, _ := Marshal([]string{}); string(out) != "[]\n" {
+		t.Fatalf("unexpected empty sequence %q", out)
+	}
+}
@@ -0,0 +1,7 @@
+package times
+
+import (
+	"time"
+)
+
+var NilTime, _ = time.Parse(time.RFC3339, "0001-01-01T00:00:00Z")
@@ -0,0 +1,476 @@
+// Command apigen generates the API interface of a service client together with
+// a mock implementation of it.
+//
+// It is meant to be run through go:generate from the service package:
+//
+//	//go:generate go run ../../internal/tools/apigen -type AccountsV1
+//
+// which writes interface.go next to the client, declaring AccountsAPI with every
+// exported method of AccountsV1, and <package>mock/mock.go with a func-field mock.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	interfaceFile   = "interface.go"
+	generatedHeader = "// Code generated by apigen. DO NOT EDIT."
+)
+
+type param struct {
+	name     string
+	typ      ast.Expr
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []ast.Expr
+}
+
+type generator struct {
+	fset     *token.FileSet
+	pkgName  string
+	pkgPath  string
+	typeName string
+	iface    string
+	methods  []method
+	imports  map[string]string // package name -> import path, as used by the methods
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("apigen: ")
+
+	typeName := flag.String("type", "", "name of the service client type, e.g. AccountsV1")
+	iface := flag.String("interface", "", "name of the generated interface; defaults to the type name without the version suffix plus API")
+	flag.Parse()
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *iface == "" {
+		*iface = strings.TrimRight(strings.TrimSuffix(*typeName, "V1"), "0123456789") + "API"
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkgPath, err := importPath(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := &generator{
+		fset:     token.NewFileSet(),
+		pkgPath:  pkgPath,
+		typeName: *typeName,
+		iface:    *iface,
+		imports:  make(map[string]string),
+	}
+	if err := g.parse(dir); err != nil {
+		log.Fatal(err)
+	}
+	if len(g.methods) == 0 {
+		log.Fatalf("no exported methods found for %s", g.typeName)
+	}
+
+	if err := write(filepath.Join(dir, interfaceFile), g.interfaceSource()); err != nil {
+		log.Fatal(err)
+	}
+	mockDir := filepath.Join(dir, g.pkgName+"mock")
+	if err := os.MkdirAll(mockDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := write(filepath.Join(mockDir, "mock.go"), g.mockSource()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Resolves the import path of dir from the closest go.mod.
+func importPath(dir string) (string, error) {
+	for root := dir; ; root = filepath.Dir(root) {
+		data, err := ioutil.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			module := ""
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module ") {
+					module = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+					break
+				}
+			}
+			if module == "" {
+				return "", fmt.Errorf("no module declaration in %s", filepath.Join(root, "go.mod"))
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("go.mod not found for %s", dir)
+		}
+	}
+}
+
+func (g *generator) parse(dir string) error {
+	pkgs, err := parser.ParseDir(g.fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != interfaceFile
+	}, 0)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) != 1 {
+		return fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+
+	for name, pkg := range pkgs {
+		g.pkgName = name
+
+		fileNames := make([]string, 0, len(pkg.Files))
+		for fileName := range pkg.Files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+
+		for _, fileName := range fileNames {
+			if err := g.parseFile(pkg.Files[fileName]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) parseFile(file *ast.File) error {
+	fileImports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		fileImports[name] = path
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || !fn.Name.IsExported() || receiverName(fn.Recv) != g.typeName {
+			continue
+		}
+
+		m := method{name: fn.Name.Name}
+		for _, field := range fn.Type.Params.List {
+			typ, variadic := field.Type, false
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				typ, variadic = ellipsis.Elt, true
+			}
+			names := field.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", len(m.params)))}
+			}
+			for _, n := range names {
+				name := n.Name
+				if name == "_" {
+					name = fmt.Sprintf("p%d", len(m.params))
+				}
+				m.params = append(m.params, param{name: name, typ: typ, variadic: variadic})
+			}
+		}
+		if fn.Type.Results != nil {
+			for _, field := range fn.Type.Results.List {
+				for i := 0; i < len(field.Names) || i == 0; i++ {
+					m.results = append(m.results, field.Type)
+				}
+			}
+		}
+
+		var missing error
+		ast.Inspect(fn.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					path, found := fileImports[id.Name]
+					if !found {
+						missing = fmt.Errorf("%s: unknown package %s", fn.Name.Name, id.Name)
+					}
+					g.imports[id.Name] = path
+				}
+				return false
+			}
+			return true
+		})
+		if missing != nil {
+			return missing
+		}
+		g.methods = append(g.methods, m)
+	}
+	return nil
+}
+
+func receiverName(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+	typ := recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if id, ok := typ.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func (g *generator) interfaceSource() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n\npackage %s\n\n", generatedHeader, g.pkgName)
+	g.writeImports(&b, nil)
+
+	fmt.Fprintf(&b, "// %s is the interface implemented by %s, to be used in place of the\n", g.iface, g.typeName)
+	fmt.Fprintf(&b, "// concrete client where callers need to stub the service, see package %smock.\n", g.pkgName)
+	fmt.Fprintf(&b, "type %s interface {\n", g.iface)
+	for _, m := range g.methods {
+		fmt.Fprintf(&b, "\t%s(%s) %s\n", m.name, g.paramList(m, false), g.resultList(m, false))
+	}
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "var _ %s = (*%s)(nil)\n", g.iface, g.typeName)
+	return b.Bytes()
+}
+
+func (g *generator) mockSource() []byte {
+	mockPkg := g.pkgName + "mock"
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n\n", generatedHeader)
+	fmt.Fprintf(&b, "// Package %s provides a mock of %s.%s.\n", mockPkg, g.pkgName, g.iface)
+	fmt.Fprintf(&b, "package %s\n\n", mockPkg)
+	g.writeImports(&b, map[string]string{"fmt": "fmt", g.pkgName: g.pkgPath})
+
+	fmt.Fprintf(&b, "// %s implements %s.%s by delegating every call to the function\n", g.iface, g.pkgName, g.iface)
+	fmt.Fprintf(&b, "// field of the same name; calling a method whose function is not set fails with an error.\n")
+	fmt.Fprintf(&b, "type %s struct {\n", g.iface)
+	for _, m := range g.methods {
+		fmt.Fprintf(&b, "\t%sFunc func(%s) %s\n", m.name, g.paramList(m, true), g.resultList(m, true))
+	}
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "var _ %s.%s = (*%s)(nil)\n\n", g.pkgName, g.iface, g.iface)
+
+	fmt.Fprintf(&b, "func notStubbed(method string) error {\n")
+	fmt.Fprintf(&b, "\treturn fmt.Errorf(\"%s: %%s is not stubbed\", method)\n", mockPkg)
+	fmt.Fprintf(&b, "}\n")
+
+	for _, m := range g.methods {
+		args := make([]string, 0, len(m.params))
+		for _, p := range m.params {
+			if p.variadic {
+				args = append(args, p.name+"...")
+			} else {
+				args = append(args, p.name)
+			}
+		}
+		zero := make([]string, 0, len(m.results))
+		for _, r := range m.results {
+			if id, ok := r.(*ast.Ident); ok && id.Name == "error" {
+				zero = append(zero, fmt.Sprintf("notStubbed(%q)", m.name))
+			} else {
+				zero = append(zero, zeroValue(r, g.typeString(r, true)))
+			}
+		}
+
+		fmt.Fprintf(&b, "\nfunc (m *%s) %s(%s) %s {\n", g.iface, m.name, g.paramList(m, true), g.resultList(m, true))
+		fmt.Fprintf(&b, "\tif m.%sFunc == nil {\n", m.name)
+		if len(zero) == 0 {
+			fmt.Fprintf(&b, "\t\tpanic(notStubbed(%q))\n", m.name)
+		} else {
+			fmt.Fprintf(&b, "\t\treturn %s\n", strings.Join(zero, ", "))
+		}
+		fmt.Fprintf(&b, "\t}\n")
+		if len(m.results) == 0 {
+			fmt.Fprintf(&b, "\tm.%sFunc(%s)\n", m.name, strings.Join(args, ", "))
+		} else {
+			fmt.Fprintf(&b, "\treturn m.%sFunc(%s)\n", m.name, strings.Join(args, ", "))
+		}
+		fmt.Fprintf(&b, "}\n")
+	}
+	return b.Bytes()
+}
+
+func (g *generator) writeImports(b *bytes.Buffer, extra map[string]string) {
+	imports := make(map[string]string)
+	for name, path := range g.imports {
+		imports[name] = path
+	}
+	for name, path := range extra {
+		imports[name] = path
+	}
+	if len(imports) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(imports))
+	for name, path := range imports {
+		if filepath.Base(path) != name {
+			path = name + " " + strconv.Quote(path)
+		} else {
+			path = strconv.Quote(path)
+		}
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.Trim(paths[i], `"`) < strings.Trim(paths[j], `"`)
+	})
+
+	fmt.Fprintf(b, "import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(b, "\t%s\n", path)
+	}
+	fmt.Fprintf(b, ")\n\n")
+}
+
+func (g *generator) paramList(m method, qualify bool) string {
+	params := make([]string, 0, len(m.params))
+	for _, p := range m.params {
+		typ := g.typeString(p.typ, qualify)
+		if p.variadic {
+			typ = "..." + typ
+		}
+		params = append(params, p.name+" "+typ)
+	}
+	return strings.Join(params, ", ")
+}
+
+func (g *generator) resultList(m method, qualify bool) string {
+	results := make([]string, 0, len(m.results))
+	for _, r := range m.results {
+		results = append(results, g.typeString(r, qualify))
+	}
+	if len(results) == 1 {
+		return results[0]
+	}
+	if len(results) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(results, ", ") + ")"
+}
+
+// Prints a type expression; with qualify, types declared in the service package get its package name.
+func (g *generator) typeString(expr ast.Expr, qualify bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if qualify && t.IsExported() {
+			return g.pkgName + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return g.typeString(t.X, false) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(t.X, qualify)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + g.typeString(t.Elt, qualify)
+		}
+		return "[" + g.node(t.Len) + "]" + g.typeString(t.Elt, qualify)
+	case *ast.MapType:
+		return "map[" + g.typeString(t.Key, qualify) + "]" + g.typeString(t.Value, qualify)
+	case *ast.Ellipsis:
+		return "..." + g.typeString(t.Elt, qualify)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + g.typeString(t.Value, qualify)
+		case ast.RECV:
+			return "<-chan " + g.typeString(t.Value, qualify)
+		}
+		return "chan " + g.typeString(t.Value, qualify)
+	case *ast.FuncType:
+		params := make([]string, 0)
+		for _, f := range t.Params.List {
+			for i := 0; i < len(f.Names) || i == 0; i++ {
+				params = append(params, g.typeString(f.Type, qualify))
+			}
+		}
+		results := make([]string, 0)
+		if t.Results != nil {
+			for _, f := range t.Results.List {
+				for i := 0; i < len(f.Names) || i == 0; i++ {
+					results = append(results, g.typeString(f.Type, qualify))
+				}
+			}
+		}
+		out := "func(" + strings.Join(params, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			out += " " + results[0]
+		default:
+			out += " (" + strings.Join(results, ", ") + ")"
+		}
+		return out
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+	}
+	return g.node(expr)
+}
+
+func (g *generator) node(n ast.Node) string {
+	var b bytes.Buffer
+	if err := format.Node(&b, g.fset, n); err != nil {
+		log.Fatal(err)
+	}
+	return b.String()
+}
+
+func zeroValue(expr ast.Expr, typ string) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return "nil"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil"
+		}
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+			"uintptr", "float32", "float64", "byte", "rune":
+			return "0"
+		}
+	}
+	return "*new(" + typ + ")"
+}
+
+func write(path string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("formatting %s: %v\n%s", path, err, src)
+	}
+	return ioutil.WriteFile(path, formatted, 0644)
+}
@@ -14,7 +14,7 @@ func HostAlive(host string) (bool, error) {
 	if u.Host == "" {
 		return ipLookup(u.Path)
 	}
-	return ipLookup(u.Host)
+	return ipLookup(u.Hostname())
 }
 
 func ipLookup(host string) (bool, error) {
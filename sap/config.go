@@ -18,6 +18,10 @@ type Config struct {
 	Endpoints  map[string]*EndpointConfig
 	MaxRetries uint8
 
+	// Validate, build and sign the requests of the session, but return them as a
+	// *request.DryRunError instead of sending them.
+	DryRun bool
+
 	DefaultOAuth2 *oauth2.Config
 }
 
@@ -1,15 +1,16 @@
 package defaults
 
 import (
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/sap/http/request/coreprocessors"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
 )
 
 func Processors() processors.Processors {
 	ps := processors.New()
 	ps.Using(request.Validate).
 		PushBack(&coreprocessors.ValidateEndpointProcessor).
+		PushBack(&coreprocessors.ValidateInputProcessor).
 		StopOnError()
 	ps.Using(request.Build).
 		StopOnError()
@@ -17,8 +18,13 @@ func Processors() processors.Processors {
 		PushBack(&coreprocessors.BuildContentLengthProcessor)
 	ps.Using(request.Send).
 		PushBack(&coreprocessors.ValidateReqSigProcessor).
-		PushBack(&coreprocessors.SendProcessor)
+		PushBack(&coreprocessors.SendProcessor).
+		StopOnError()
 	ps.Using(request.ValidateResponse).
 		PushBack(&coreprocessors.ValidateResponseProcessor)
+	ps.Using(request.Retry).
+		PushBack(&coreprocessors.RetryableProcessor)
+	ps.Using(request.AfterRetry).
+		PushBack(&coreprocessors.AfterRetryProcessor)
 	return ps
 }
@@ -90,6 +90,10 @@ func (r *Request) readFromHttpResponseToStruct(value reflect.Value, structField
 		var err error
 		switch structField.Tag.Get(fieldTagSrc) {
 		case "header":
+			// no response when the request failed before being sent, e.g. in dry-run mode
+			if r.HTTPResponse == nil {
+				return
+			}
 			err = updateFromHeader(&r.HTTPResponse.Header, value, name)
 		case "body":
 			err = updateFromBody(r.ResponseBody, value, name)
@@ -419,6 +423,17 @@ func convertType(v reflect.Value, tag reflect.StructTag) (str string, err error)
 			return "", fmt.Errorf("unable to encode JSONValue, %v", err)
 		}
 	default:
+		// named types, such as the enumerations of the services, are sent as their underlying kind
+		switch v.Kind() {
+		case reflect.String:
+			return convertType(v.Convert(reflect.TypeOf("")), tag)
+		case reflect.Bool:
+			return convertType(v.Convert(reflect.TypeOf(false)), tag)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return convertType(v.Convert(reflect.TypeOf(int64(0))), tag)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return convertType(v.Convert(reflect.TypeOf(uint64(0))), tag)
+		}
 		err := fmt.Errorf("unsupported value for param %v (%s)", v.Interface(), v.Type())
 		return "", err
 	}
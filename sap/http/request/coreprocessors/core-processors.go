@@ -2,18 +2,20 @@ package coreprocessors
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/internal/saperr"
 	"github.com/nnicora/sap-sdk-go/internal/sapio"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
+	"time"
 )
 
 // BuildContentLengthProcessor builds the content length of a request based on the body,
@@ -69,7 +71,7 @@ var ValidateReqSigProcessor = processors.DefaultProcessor{
 
 // SendProcessor is a request handler to send service request using HTTP client.
 var SendProcessor = processors.DefaultProcessor{
-	Name: "core.SendProcessor",
+	Name: request.SendProcessorName,
 	Handler: func(t interface{}) {
 		r := t.(*request.Request)
 		sender := sendFollowRedirects
@@ -77,6 +79,17 @@ var SendProcessor = processors.DefaultProcessor{
 			sender = sendWithoutFollowRedirects
 		}
 
+		if r.AttemptTimeout > 0 {
+			// the response body is read below, so the attempt context can be released on return
+			ctx, cancel := context.WithTimeout(r.Context(), r.AttemptTimeout)
+			defer cancel()
+			reqOrig := r.HTTPRequest
+			r.HTTPRequest = r.HTTPRequest.WithContext(ctx)
+			defer func() {
+				r.HTTPRequest = reqOrig
+			}()
+		}
+
 		if request.NoBody == r.HTTPRequest.Body {
 			// Strip off the request body if the NoBody reader was used as a
 			// place holder for a request body. This prevents the SDK from
@@ -193,3 +206,105 @@ var ValidateEndpointProcessor = processors.DefaultProcessor{
 		}
 	},
 }
+
+// RetryableProcessor marks failed attempts that are worth retrying: throttling, and for the
+// idempotent methods or with Request.RetryNonIdempotent, transport errors and temporary
+// unavailability of the service. A POST which failed that way may have been processed, and would
+// be done twice if retried.
+var RetryableProcessor = processors.DefaultProcessor{
+	Name: "core.RetryableProcessor",
+	Handler: func(t interface{}) {
+		r := t.(*request.Request)
+		if r.Error == nil || r.Context().Err() != nil {
+			r.Retryable = false
+			return
+		}
+		if _, ok := r.Error.(*request.DryRunError); ok {
+			r.Retryable = false
+			return
+		}
+		if r.HTTPResponse != nil && r.HTTPResponse.StatusCode == http.StatusTooManyRequests {
+			// throttled requests are not processed
+			r.Retryable = true
+			return
+		}
+		if !r.RetryNonIdempotent && !idempotent(r.HTTPRequest.Method) {
+			r.Retryable = false
+			return
+		}
+		if r.HTTPResponse == nil {
+			r.Retryable = true
+			return
+		}
+		switch r.HTTPResponse.StatusCode {
+		case 0, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			r.Retryable = true
+		}
+	},
+}
+
+// Whether calling the method again has the same effect as calling it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// AfterRetryProcessor waits before the next attempt; the delay doubles with every retry
+// unless the service asks for a specific one with the Retry-After header.
+var AfterRetryProcessor = processors.DefaultProcessor{
+	Name: "core.AfterRetryProcessor",
+	Handler: func(t interface{}) {
+		r := t.(*request.Request)
+		if !r.WillRetry() {
+			return
+		}
+
+		delay := retryDelay(r)
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		ctx := r.Context()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			r.Error = fmt.Errorf("CanceledErrorCode, request context canceled; %s", ctx.Err())
+			r.Retryable = false
+		}
+	},
+}
+
+const (
+	minRetryDelay = 100 * time.Millisecond
+	maxRetryDelay = 5 * time.Second
+)
+
+func retryDelay(r *request.Request) time.Duration {
+	if r.HTTPResponse != nil {
+		if seconds, err := strconv.Atoi(r.HTTPResponse.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if delay := time.Duration(seconds) * time.Second; delay < maxRetryDelay {
+				return delay
+			}
+			return maxRetryDelay
+		}
+	}
+	delay := minRetryDelay << uint(r.RetryCount)
+	if delay <= 0 || delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// ValidateInputProcessor checks the input against the rules of its field tags, see
+// request.ValidateInput, so that invalid requests fail before reaching the service.
+var ValidateInputProcessor = processors.DefaultProcessor{
+	Name: "core.ValidateInputProcessor",
+	Handler: func(t interface{}) {
+		r := t.(*request.Request)
+		if err := r.ValidateInput(); err != nil {
+			r.Error = err
+		}
+	},
+}
@@ -0,0 +1,137 @@
+package request
+
+import (
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// SendProcessorName is the name of the processor of the Send phase which sends the request
+// over HTTP, the one a dry run replaces.
+const SendProcessorName = "core.SendProcessor"
+
+// Redacted replaces the values of the headers listed in RedactedHeaders in a DryRunError.
+const Redacted = "REDACTED"
+
+// RedactedHeaders are the headers whose values never leave a dry run, since they carry
+// credentials.
+var RedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Csrf-Token"}
+
+// DryRunError is returned by the requests made in dry-run mode instead of sending them; it holds
+// the request as it would have been sent, once validated, built and signed.
+type DryRunError struct {
+	Operation string
+	Method    string
+	URL       string
+	Header    http.Header
+	Body      []byte
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("DryRun, %s not sent; %s %s", e.Operation, e.Method, e.URL)
+}
+
+// String renders the request line, the headers sorted by name, and the body.
+func (e *DryRunError) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", e.Method, e.URL)
+	for _, name := range sortedHeaderNames(e.Header) {
+		for _, v := range e.Header[name] {
+			fmt.Fprintf(&b, "%s: %s\n", name, v)
+		}
+	}
+	if len(e.Body) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", e.Body)
+	}
+	return b.String()
+}
+
+// Curl renders the request as a curl command, e.g. to replay it once reviewed; the redacted
+// headers have to be filled in first.
+func (e *DryRunError) Curl() string {
+	args := []string{"curl", "-X", e.Method, shellQuote(e.URL)}
+	for _, name := range sortedHeaderNames(e.Header) {
+		// curl computes the length of the body itself
+		if name == "Content-Length" {
+			continue
+		}
+		for _, v := range e.Header[name] {
+			args = append(args, "-H", shellQuote(name+": "+v))
+		}
+	}
+	if len(e.Body) > 0 {
+		args = append(args, "--data-binary", shellQuote(string(e.Body)))
+	}
+	return strings.Join(args, " ")
+}
+
+// NewDryRunError renders the HTTP request of r, with the values of RedactedHeaders replaced.
+func NewDryRunError(r *Request) *DryRunError {
+	e := &DryRunError{
+		Method: r.HTTPRequest.Method,
+		URL:    r.HTTPRequest.URL.String(),
+		Header: r.HTTPRequest.Header.Clone(),
+	}
+	if r.Operation != nil {
+		e.Operation = r.Operation.Name
+	}
+	if e.Header == nil {
+		e.Header = make(http.Header)
+	}
+	for _, name := range RedactedHeaders {
+		if values := e.Header.Values(name); len(values) > 0 {
+			e.Header.Del(name)
+			for range values {
+				e.Header.Add(name, Redacted)
+			}
+		}
+	}
+	if r.HTTPRequest.GetBody != nil {
+		if body, err := r.HTTPRequest.GetBody(); err == nil {
+			e.Body, _ = ioutil.ReadAll(body)
+			body.Close()
+		}
+	}
+	return e
+}
+
+// DryRunProcessor takes the place of the processor sending the request in dry-run mode, and
+// fails the request with a *DryRunError.
+var DryRunProcessor = processors.DefaultProcessor{
+	Name: "request.DryRunProcessor",
+	Handler: func(t interface{}) {
+		r := t.(*Request)
+		r.Error = NewDryRunError(r)
+		r.Retryable = false
+	},
+}
+
+// EnableDryRun replaces the processor sending the requests, so that the requests run through
+// ps are validated, built and signed, then returned as a *DryRunError instead of being sent.
+func EnableDryRun(ps *processors.Processors) {
+	ps.Using(Send).Swap(SendProcessorName, &DryRunProcessor)
+}
+
+// WithDryRun makes the call return its request as a *DryRunError instead of sending it.
+func WithDryRun() Option {
+	return func(r *Request) {
+		EnableDryRun(r.Processors)
+	}
+}
+
+func sortedHeaderNames(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,60 @@
+package request_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestDryRun(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "dry", DisplayName: "dry"})
+
+	svc := btpaccounts.New(newSession(t, srv))
+	_, err := svc.DeleteSubAccount(context.Background(), &btpaccounts.DeleteSubAccountInput{SubAccountGuid: sa.Guid},
+		request.WithDryRun(), request.WithHeader("Authorization", "Bearer secret"), request.WithMaxRetries(3))
+	dryRun, ok := err.(*request.DryRunError)
+	if !ok {
+		t.Fatalf("expected a dry run error, got %v", err)
+	}
+	if dryRun.Method != http.MethodDelete || dryRun.URL != srv.URL+"/accounts/v1/subaccounts/"+sa.Guid {
+		t.Fatalf("unexpected request %s %s", dryRun.Method, dryRun.URL)
+	}
+	if h := dryRun.Header.Get("Authorization"); h != request.Redacted {
+		t.Fatalf("expected the authorization to be redacted, got %q", h)
+	}
+	if _, ok := srv.SubAccount(sa.Guid); !ok || len(srv.Requests()) != 0 {
+		t.Fatal("expected the dry run not to reach the service")
+	}
+
+	cfg := srv.Config()
+	cfg.DryRun = true
+	sess, err := session.BuildFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = btpaccounts.New(sess).CreateSubAccount(context.Background(), &btpaccounts.CreateSubAccountInput{
+		DisplayName: "it's new",
+		Region:      "eu10",
+		Subdomain:   "new",
+	})
+	dryRun, ok = err.(*request.DryRunError)
+	if !ok {
+		t.Fatalf("expected a dry run error, got %v", err)
+	}
+	curl := dryRun.Curl()
+	if !strings.HasPrefix(curl, "curl -X POST '"+srv.URL+"/accounts/v1/subaccounts' -H 'Content-Type: application/json'") ||
+		!strings.Contains(curl, `"displayName":"it'\''s new"`) || strings.Contains(curl, "Content-Length") {
+		t.Fatalf("unexpected curl command %s", curl)
+	}
+	if !strings.Contains(dryRun.String(), "\n\n{") || len(srv.Requests()) != 0 {
+		t.Fatalf("unexpected dry run:\n%s", dryRun)
+	}
+}
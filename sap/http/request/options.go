@@ -0,0 +1,84 @@
+package request
+
+import (
+	"github.com/nnicora/sap-sdk-go/sap/processors"
+	"time"
+)
+
+// Option modifies a Request before it is sent, e.g. to add headers or processors
+// for a single call. Every service operation accepts a variadic list of options.
+type Option func(*Request)
+
+// ApplyOptions applies the options to the request in the order given, nil options are skipped.
+func (r *Request) ApplyOptions(opts ...Option) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+}
+
+// WithHeader sets a header of the request, overriding the value mapped from the input.
+func WithHeader(key, value string) Option {
+	return WithProcessorFunc(Build, "request.WithHeader", func(r *Request) {
+		r.HTTPRequest.Header.Set(key, value)
+	})
+}
+
+// WithAcceptLanguage sets the Accept-Language header, for operations returning localized texts.
+func WithAcceptLanguage(language string) Option {
+	return WithHeader("Accept-Language", language)
+}
+
+// WithQuery sets a query parameter of the request, overriding the value mapped from the input.
+func WithQuery(key, value string) Option {
+	return WithProcessorFunc(Build, "request.WithQuery", func(r *Request) {
+		query := r.HTTPRequest.URL.Query()
+		query.Set(key, value)
+		r.HTTPRequest.URL.RawQuery = query.Encode()
+	})
+}
+
+// WithAttemptTimeout bounds the duration of every single attempt of the request.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(r *Request) {
+		r.AttemptTimeout = timeout
+	}
+}
+
+// WithMaxRetries overrides the number of retries configured for the session.
+func WithMaxRetries(maxRetries int) Option {
+	return func(r *Request) {
+		r.MaxRetries = maxRetries
+	}
+}
+
+// WithNonIdempotentRetries retries the failed attempts of a POST or PATCH request too, for the
+// operations which are safe to call again, e.g. the ones reading through a POST.
+func WithNonIdempotentRetries() Option {
+	return func(r *Request) {
+		r.RetryNonIdempotent = true
+	}
+}
+
+// WithProcessor adds the processor to the back of the given phase, e.g. Build or Unmarshal.
+func WithProcessor(phase processors.Type, p processors.Processor) Option {
+	return func(r *Request) {
+		r.Processors.Using(phase).PushBack(p)
+	}
+}
+
+// WithProcessorFunc adds a named processor calling fn to the back of the given phase.
+func WithProcessorFunc(phase processors.Type, name string, fn func(*Request)) Option {
+	return WithProcessor(phase, &processors.DefaultProcessor{
+		Name: name,
+		Handler: func(t interface{}) {
+			fn(t.(*Request))
+		},
+	})
+}
+
+// Describe lists the processors the request runs through, per phase and in execution order.
+func (r *Request) Describe() string {
+	return r.Processors.Describe()
+}
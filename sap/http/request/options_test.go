@@ -0,0 +1,81 @@
+package request_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestRequestOptions(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+
+	// client level options apply to every call, call options are applied after them
+	svc := btpentitlements.New(newSession(t, srv), request.WithHeader("X-Client", "sdk"))
+	if _, err := svc.GetDataCenters(context.Background(),
+		request.WithAcceptLanguage("de"), request.WithQuery("mode", "test")); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := srv.Requests()[0]
+	if h := recorded.Header.Get("Accept-Language"); h != "de" {
+		t.Fatalf("expected Accept-Language de, got %q", h)
+	}
+	if h := recorded.Header.Get("X-Client"); h != "sdk" {
+		t.Fatalf("expected the client level header, got %q", h)
+	}
+	if q := recorded.Query.Get("mode"); q != "test" {
+		t.Fatalf("expected query parameter mode=test, got %q", q)
+	}
+}
+
+func TestRequestRetries(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.InjectFault(btpfake.Fault{Method: http.MethodGet, Path: "/accounts/v1/globalAccount", Status: http.StatusServiceUnavailable, Times: 2})
+
+	svc := btpaccounts.New(newSession(t, srv))
+	if _, err := svc.GetGlobalAccount(context.Background(), &btpaccounts.GetGlobalAccountInput{},
+		request.WithMaxRetries(1)); err == nil {
+		t.Fatal("expected the call to fail after a single retry")
+	}
+	if _, err := svc.GetGlobalAccount(context.Background(), &btpaccounts.GetGlobalAccountInput{},
+		request.WithMaxRetries(1), request.WithAttemptTimeout(5*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Fatalf("expected 3 recorded requests, got %d", n)
+	}
+}
+
+func TestRequestRetriesIdempotentOnly(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.InjectFault(btpfake.Fault{Method: http.MethodPost, Path: "/accounts/v1/subaccounts", Status: http.StatusServiceUnavailable, Times: 1})
+
+	// a POST may have been processed before failing, so it is not sent again
+	svc := btpaccounts.New(newSession(t, srv))
+	input := &btpaccounts.CreateSubAccountInput{DisplayName: "dev", Region: "eu10", Subdomain: "dev"}
+	if _, err := svc.CreateSubAccount(context.Background(), input, request.WithMaxRetries(2)); err == nil {
+		t.Fatal("expected the call to fail without being retried")
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Fatalf("expected a single recorded request, got %d", n)
+	}
+
+	srv.ResetRequests()
+	srv.InjectFault(btpfake.Fault{Method: http.MethodPost, Path: "/accounts/v1/subaccounts", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, err := svc.CreateSubAccount(context.Background(), input,
+		request.WithMaxRetries(2), request.WithNonIdempotentRetries()); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Fatalf("expected the call to be retried once, got %d recorded requests", n)
+	}
+}
@@ -1,6 +1,6 @@
 package request
 
-import "github.com/nnicora/sap-sdk-go/internal/processors"
+import "github.com/nnicora/sap-sdk-go/sap/processors"
 
 const (
 	Validate processors.Type = iota
@@ -18,3 +18,13 @@ const (
 	CompleteAttempt
 	Complete
 )
+
+func init() {
+	names := []string{
+		"Validate", "Build", "BuildStream", "Sign", "Send", "ValidateResponse", "Unmarshal",
+		"UnmarshalStream", "UnmarshalMeta", "UnmarshalError", "Retry", "AfterRetry", "CompleteAttempt", "Complete",
+	}
+	for i, name := range names {
+		processors.NamePhase(processors.Type(i), name)
+	}
+}
@@ -3,9 +3,10 @@ package jsonbuiltin
 import (
 	"bytes"
 	"encoding/json"
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/internal/saperr"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
+	"reflect"
 )
 
 var BuildProcessor = processors.DefaultProcessor{
@@ -42,7 +43,7 @@ func Build(t interface{}) {
 
 func MarshalToJSONRequestBody(t interface{}) {
 	r := t.(*request.Request)
-	if r.InputData != nil {
+	if r.InputData != nil && hasBody(reflect.TypeOf(r.InputData)) {
 		body := &bytes.Buffer{}
 		if err := json.NewEncoder(body).Encode(r.InputData); err != nil {
 			r.Error = err
@@ -53,6 +54,31 @@ func MarshalToJSONRequestBody(t interface{}) {
 	}
 }
 
+// Whether a value has anything to encode into a body; inputs holding only path, query and header
+// parameters (tagged json:"-") are sent without one.
+func hasBody(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("json") == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			if hasBody(f.Type) {
+				return true
+			}
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 func UnmarshalJSONResponseBody(t interface{}) {
 	r := t.(*request.Request)
 	if r.OutputData != nil && len(r.ResponseBody) > 0 {
@@ -3,11 +3,11 @@ package request
 import (
 	"bytes"
 	"context"
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/internal/saperr"
 	"github.com/nnicora/sap-sdk-go/internal/sapio"
 	"github.com/nnicora/sap-sdk-go/sap"
 	"github.com/nnicora/sap-sdk-go/sap/metainfo"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
 	"io"
 	"net/http"
 	"net/url"
@@ -40,9 +40,16 @@ type Request struct {
 
 	Error error
 
-	//RetryCount int
-	Retryable bool
-	//RetryDelay time.Duration
+	RetryCount int
+	MaxRetries int
+	Retryable  bool
+
+	// Retries the failed attempts of a POST or PATCH as well, which the service may have processed
+	// before failing; only the throttled ones are retried otherwise.
+	RetryNonIdempotent bool
+
+	// Timeout of a single attempt, the context of the request still bounds all attempts together.
+	AttemptTimeout time.Duration
 
 	DisableFollowRedirects bool
 
@@ -67,7 +74,13 @@ func New(ctx context.Context, cfg *sap.RuntimeConfig, serviceInfo metainfo.Servi
 	operation *Operation, params interface{}, data interface{}) *Request {
 
 	httpReq, _ := createHttpRequest(ctx, &serviceInfo, operation)
-	return &Request{
+
+	// every request works on its own copy so per-call processors do not leak into the service
+	if processors != nil {
+		p := processors.Copy()
+		processors = &p
+	}
+	r := &Request{
 		RuntimeConfig: cfg,
 		ServiceInfo:   serviceInfo,
 		Processors:    processors,
@@ -80,6 +93,10 @@ func New(ctx context.Context, cfg *sap.RuntimeConfig, serviceInfo metainfo.Servi
 
 		context: ctx,
 	}
+	if cfg != nil {
+		r.MaxRetries = int(cfg.MaxRetries)
+	}
+	return r
 }
 func createHttpRequest(ctx context.Context, serviceInfo *metainfo.ServiceInfo, operation *Operation) (*http.Request, error) {
 	httpOp := operation.Http
@@ -144,6 +161,12 @@ func (r *Request) Build() error {
 		if r.Error != nil {
 			return r.Error
 		}
+		if r.InputDataFilled() {
+			r.writeToHttpRequestFrom(r.InputData)
+			if r.Error != nil {
+				return r.Error
+			}
+		}
 		r.Processors.Using(Build).Exec(r)
 		if r.Error != nil {
 			return r.Error
@@ -186,11 +209,6 @@ func (r *Request) Send() error {
 		return err
 	}
 
-	if r.InputDataFilled() {
-		r.writeToHttpRequestFrom(r.InputData)
-		//r.buildBody()
-	}
-
 	for {
 		r.Error = nil
 		r.AttemptTime = time.Now()
@@ -209,6 +227,11 @@ func (r *Request) Send() error {
 		r.Processors.Using(Retry).Exec(r)
 		r.Processors.Using(AfterRetry).Exec(r)
 
+		if !r.WillRetry() {
+			return r.Error
+		}
+		r.RetryCount++
+
 		if err := r.prepareRetry(); err != nil {
 			r.Error = err
 			return err
@@ -216,10 +239,19 @@ func (r *Request) Send() error {
 	}
 }
 
+// WillRetry reports whether the failed attempt is going to be retried.
+func (r *Request) WillRetry() bool {
+	return r.Error != nil && r.Retryable && r.RetryCount < r.MaxRetries
+}
+
 func (r *Request) prepareRetry() error {
 	r.HTTPRequest = copyHTTPRequest(r.HTTPRequest, nil)
-	if r.Error != nil {
-		return r.Error
+	if r.requestBody != nil {
+		// the error of the failed attempt is dropped, the next attempt starts clean
+		r.Error = nil
+		if r.ResetBody(); r.Error != nil {
+			return r.Error
+		}
 	}
 
 	// Closing response body to ensure that no response body is leaked
@@ -0,0 +1,52 @@
+package request_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func newSession(t *testing.T, srv *btpfake.Server) *session.RuntimeSession {
+	sess, err := srv.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func TestRequestBuilder(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "builder", DisplayName: "builder"})
+
+	svc := btpaccounts.New(newSession(t, srv))
+	req, out := svc.GetSubAccountRequest(context.Background(), &btpaccounts.GetSubAccountInput{SubAccountGuid: sa.Guid})
+	req.ApplyOptions(func(r *request.Request) {
+		r.HTTPRequest.Header.Set("X-Correlation-Id", "builder")
+	})
+	if d := req.Describe(); !strings.Contains(d, "Send (stop on error): core.ValidateReqSigProcessor, core.SendProcessor") {
+		t.Fatalf("unexpected pipeline:\n%s", d)
+	}
+	if err := req.Build(); err != nil {
+		t.Fatal(err)
+	}
+	if path := req.HTTPRequest.URL.Path; path != "/accounts/v1/subaccounts/"+sa.Guid {
+		t.Fatalf("unexpected path %s", path)
+	}
+
+	if err := req.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if out.Guid != sa.Guid {
+		t.Fatalf("unexpected subaccount %s", out.Guid)
+	}
+	requests := srv.Requests()
+	if h := requests[len(requests)-1].Header.Get("X-Correlation-Id"); h != "builder" {
+		t.Fatalf("expected the header set by the option, got %q", h)
+	}
+}
@@ -0,0 +1,270 @@
+package request
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tags of the input fields checked by ValidateInput:
+//
+//	required:"true"   the field must be set, i.e. not be the zero value or nil
+//	enum:"A,B"        the value must be one of the listed ones; enum:"true" accepts the
+//	                  values reported as valid by the Valid() method of the field type
+//	pattern:"^...$"   the string value must match the regular expression
+//	min:"1" max:"150" bounds of a number, or of the length of a string, slice or map
+//
+// Except for required, the rules apply only to fields that are set. URI parameters are
+// always required, since the path cannot be built without them.
+const (
+	fieldTagRequired = "required"
+	fieldTagEnum     = "enum"
+	fieldTagPattern  = "pattern"
+	fieldTagMin      = "min"
+	fieldTagMax      = "max"
+)
+
+// ValidationError lists every field of an input which violates its rules; it is
+// returned before anything is sent.
+type ValidationError struct {
+	Operation string
+	Fields    []FieldError
+}
+
+// FieldError is a single violated rule. Field is the path of the field in the input,
+// e.g. Entitlements[0].ServiceName, or the {placeholder} of the path left unresolved.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+	return fmt.Sprintf("InvalidParameter, %d validation error(s) found for %s; %s",
+		len(e.Fields), e.Operation, strings.Join(msgs, "; "))
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// Has reports whether the field violates any rule.
+func (e *ValidationError) Has(field string) bool {
+	for _, f := range e.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+var rePlaceholder = regexp.MustCompile(`\{([^{}]+?)\+?\}`)
+
+// ValidateInput checks the input of the request against the rules of its field tags and
+// makes sure every {placeholder} of the path is given a value; it returns a *ValidationError
+// listing all the violations, or nil.
+func (r *Request) ValidateInput() error {
+	var fields []FieldError
+	resolved := make(map[string]bool)
+	if r.InputDataFilled() {
+		fields = validateStruct(reflect.ValueOf(r.InputData).Elem(), "", resolved)
+	}
+
+	if r.HTTPRequest != nil && r.HTTPRequest.URL != nil {
+		for _, m := range rePlaceholder.FindAllStringSubmatch(r.HTTPRequest.URL.Path, -1) {
+			if _, ok := resolved[m[1]]; !ok {
+				resolved[m[1]] = false
+				fields = append(fields, FieldError{Field: m[0], Rule: "placeholder", Message: "is not resolved by any parameter"})
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	name := ""
+	if r.Operation != nil {
+		name = r.Operation.Name
+	}
+	return &ValidationError{Operation: name, Fields: fields}
+}
+
+// Validates the fields of a struct; the URI parameters met are recorded in uri.
+func validateStruct(v reflect.Value, prefix string, uri map[string]bool) []FieldError {
+	var out []FieldError
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		if f.Tag.Get("ignore") != "" {
+			continue
+		}
+		fv := v.Field(i)
+		if f.Anonymous {
+			if fv = reflect.Indirect(fv); fv.IsValid() && fv.Kind() == reflect.Struct {
+				out = append(out, validateStruct(fv, prefix, uri)...)
+			}
+			continue
+		}
+
+		name := prefix + f.Name
+		required := f.Tag.Get(fieldTagRequired) == "true"
+		if f.Tag.Get(fieldTagDest) == "uri" {
+			required = true
+			destName := f.Tag.Get(fieldTagDestName)
+			if destName == "" {
+				destName = f.Name
+			}
+			uri[destName] = true
+		}
+
+		if isZero(fv) {
+			if required {
+				out = append(out, FieldError{Field: name, Rule: fieldTagRequired, Message: "is required"})
+			}
+			continue
+		}
+		out = append(out, validateValue(reflect.Indirect(fv), name, f.Tag, uri)...)
+	}
+	return out
+}
+
+func validateValue(v reflect.Value, name string, tag reflect.StructTag, uri map[string]bool) []FieldError {
+	var out []FieldError
+	if e := checkEnum(v, name, tag.Get(fieldTagEnum)); e != nil {
+		out = append(out, *e)
+	}
+	if e := checkPattern(v, name, tag.Get(fieldTagPattern)); e != nil {
+		out = append(out, *e)
+	}
+	if e := checkBounds(v, name, tag); e != nil {
+		out = append(out, *e)
+	}
+
+	// nested inputs, e.g. the items of a bulk update, have rules of their own
+	switch v.Kind() {
+	case reflect.Struct:
+		if _, ok := v.Interface().(time.Time); !ok {
+			out = append(out, validateStruct(v, name+".", map[string]bool{})...)
+		}
+	case reflect.Slice, reflect.Array:
+		if elem := v.Type().Elem(); elem.Kind() == reflect.Struct || elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct {
+			for i := 0; i < v.Len(); i++ {
+				if item := reflect.Indirect(v.Index(i)); item.IsValid() {
+					out = append(out, validateValue(item, fmt.Sprintf("%s[%d]", name, i), "", uri)...)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+type validator interface {
+	Valid() bool
+}
+
+func checkEnum(v reflect.Value, name, enum string) *FieldError {
+	if enum == "" {
+		return nil
+	}
+	if enum == "true" {
+		if e, ok := v.Interface().(validator); ok && !e.Valid() {
+			return &FieldError{Field: name, Rule: fieldTagEnum, Message: fmt.Sprintf("has the unknown value %v", v.Interface())}
+		}
+		return nil
+	}
+	values := strings.Split(enum, ",")
+	var items []reflect.Value
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i))
+		}
+	} else {
+		items = []reflect.Value{v}
+	}
+	for _, item := range items {
+		s := fmt.Sprint(item.Interface())
+		found := false
+		for _, allowed := range values {
+			if s == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &FieldError{Field: name, Rule: fieldTagEnum, Message: fmt.Sprintf("must be one of [%s], got %s", enum, s)}
+		}
+	}
+	return nil
+}
+
+// compiled patterns of the tags, shared by all requests
+var patterns sync.Map
+
+func checkPattern(v reflect.Value, name, pattern string) *FieldError {
+	if pattern == "" || v.Kind() != reflect.String {
+		return nil
+	}
+	re, ok := patterns.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return &FieldError{Field: name, Rule: fieldTagPattern, Message: fmt.Sprintf("has an invalid pattern %s; %v", pattern, err)}
+		}
+		re, _ = patterns.LoadOrStore(pattern, compiled)
+	}
+	if !re.(*regexp.Regexp).MatchString(v.String()) {
+		return &FieldError{Field: name, Rule: fieldTagPattern, Message: fmt.Sprintf("must match %s, got %q", pattern, v.String())}
+	}
+	return nil
+}
+
+func checkBounds(v reflect.Value, name string, tag reflect.StructTag) *FieldError {
+	lower, upper := tag.Get(fieldTagMin), tag.Get(fieldTagMax)
+	if lower == "" && upper == "" {
+		return nil
+	}
+
+	var n float64
+	what := "value"
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = v.Float()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		n, what = float64(v.Len()), "length"
+	default:
+		return nil
+	}
+
+	if bound, err := strconv.ParseFloat(lower, 64); err == nil && n < bound {
+		return &FieldError{Field: name, Rule: fieldTagMin, Message: fmt.Sprintf("%s must be at least %s, got %v", what, lower, n)}
+	}
+	if bound, err := strconv.ParseFloat(upper, 64); err == nil && n > bound {
+		return &FieldError{Field: name, Rule: fieldTagMax, Message: fmt.Sprintf("%s must be at most %s, got %v", what, upper, n)}
+	}
+	return nil
+}
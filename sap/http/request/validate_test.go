@@ -0,0 +1,87 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/sap/endpoints"
+	"github.com/nnicora/sap-sdk-go/sap/metainfo"
+)
+
+type color string
+
+func (c color) Valid() bool {
+	return c == "red" || c == "green"
+}
+
+type item struct {
+	Name string `json:"name" required:"true"`
+}
+
+type validatedInput struct {
+	Guid      string   `dest:"uri" dest-name:"guid" json:"-"`
+	PageSize  uint32   `dest:"querystring" dest-name:"pageSize" json:"-" max:"150"`
+	Subdomain string   `json:"subdomain" required:"true" pattern:"^[a-z0-9-]{1,63}$"`
+	Mode      string   `json:"mode" enum:"FAST,SLOW"`
+	Color     color    `json:"color" enum:"true"`
+	Admins    []string `json:"admins" min:"1"`
+	Items     []item   `json:"items"`
+}
+
+func newValidatedRequest(path string, input interface{}) *Request {
+	info := metainfo.ServiceInfo{Endpoint: &endpoints.Endpoint{Host: "https://example.com"}}
+	op := &Operation{Name: "Validated", Http: HTTP{Method: GET, Path: path, UsePathAsIs: true}}
+	return New(context.Background(), nil, info, nil, op, input, nil)
+}
+
+func TestValidateInput(t *testing.T) {
+	r := newValidatedRequest("/things/{guid}/{other}", &validatedInput{
+		PageSize:  151,
+		Subdomain: "Not_Valid",
+		Mode:      "MEDIUM",
+		Color:     "blue",
+		Items:     []item{{Name: "a"}, {}},
+	})
+
+	err := r.ValidateInput()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	var rules []string
+	for _, f := range verr.Fields {
+		rules = append(rules, f.Field+":"+f.Rule)
+	}
+	expected := []string{
+		"Guid:required",
+		"PageSize:max",
+		"Subdomain:pattern",
+		"Mode:enum",
+		"Color:enum",
+		"Items[1].Name:required",
+		"{other}:placeholder",
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+	if !verr.Has("Subdomain") || verr.Has("Admins") {
+		t.Fatalf("unexpected fields reported: %v", err)
+	}
+}
+
+func TestValidateInputPasses(t *testing.T) {
+	r := newValidatedRequest("/things/{guid}", &validatedInput{
+		Guid:      "guid",
+		PageSize:  150,
+		Subdomain: "my-subdomain-1",
+		Mode:      "FAST",
+		Color:     "red",
+		Admins:    []string{"admin@example.com"},
+		Items:     []item{{Name: "a"}},
+	})
+	if err := r.ValidateInput(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,241 @@
+package processors
+
+// A item represents an entry in the list which
+// is being run.
+type item struct {
+	index     int
+	processor Processor
+	arg       interface{}
+}
+
+// An entry of the list, processors are kept ordered by ascending priority.
+type entry struct {
+	processor Processor
+	priority  int
+}
+
+// PriorityDefault is the priority of processors added with PushBack and PushFront
+// to an empty list; processors with a lower priority run first.
+const PriorityDefault = 0
+
+// A List manages zero or more processors of a phase, in execution order.
+type List struct {
+	list []entry
+
+	// Called after each request processor in the list is called. If set
+	// and the func returns true the list will continue to iterate
+	// over the request processors. If false is returned the list
+	// will stop iterating.
+	//
+	// Should be used if extra logic to be performed between each processor
+	// in the list. This can be used to terminate a list's iteration
+	// based on a condition such as error like, processorListStopOnError.
+	// Or for logging like processorListLogItem.
+	afterEachFn func(i item) bool
+}
+
+// copy creates a copy of the processor list.
+func (l *List) copy() List {
+	n := List{
+		afterEachFn: l.afterEachFn,
+	}
+	if len(l.list) == 0 {
+		return n
+	}
+
+	n.list = append(make([]entry, 0, len(l.list)), l.list...)
+	return n
+}
+
+// Clear clears the processor list.
+func (l *List) Clear() *List {
+	l.list = l.list[0:0]
+	return l
+}
+
+// Len returns the number of processors in the list.
+func (l *List) Len() int {
+	return len(l.list)
+}
+
+// Names returns the labels of the processors in execution order.
+func (l *List) Names() []string {
+	names := make([]string, 0, len(l.list))
+	for _, e := range l.list {
+		names = append(names, e.processor.Label())
+	}
+	return names
+}
+
+// StopsOnError reports whether the list stops at the first processor setting an error.
+func (l *List) StopsOnError() bool {
+	return l.afterEachFn != nil
+}
+
+// PushBackHandler pushes processor f to the back of the processor list.
+func (l *List) PushBackHandler(f func(arg interface{})) *List {
+	l.PushBack(&DefaultProcessor{"-", f})
+	return l
+}
+
+// PushBack pushes named processor f to the back of the processor list,
+// with the priority of the processor currently at the back.
+func (l *List) PushBack(n Processor) *List {
+	priority := PriorityDefault
+	if len(l.list) > 0 {
+		priority = l.list[len(l.list)-1].priority
+	}
+	l.insert(len(l.list), entry{n, priority})
+	return l
+}
+
+// PushFrontHandler pushes processor f to the front of the processor list.
+func (l *List) PushFrontHandler(f func(arg interface{})) *List {
+	l.PushFront(&DefaultProcessor{"-", f})
+	return l
+}
+
+// PushFront pushes named processor f to the front of the processor list,
+// with the priority of the processor currently at the front.
+func (l *List) PushFront(n Processor) *List {
+	priority := PriorityDefault
+	if len(l.list) > 0 {
+		priority = l.list[0].priority
+	}
+	l.insert(0, entry{n, priority})
+	return l
+}
+
+// PushWithPriority adds named processor n after every processor with a lower or
+// equal priority and before the ones with a higher priority.
+func (l *List) PushWithPriority(n Processor, priority int) *List {
+	i := 0
+	for i < len(l.list) && l.list[i].priority <= priority {
+		i++
+	}
+	l.insert(i, entry{n, priority})
+	return l
+}
+
+// InsertBefore inserts processor n right before the first processor named anchor,
+// returning false and leaving the list untouched when there is no such processor.
+func (l *List) InsertBefore(anchor string, n Processor) bool {
+	for i, e := range l.list {
+		if e.processor.Label() == anchor {
+			l.insert(i, entry{n, e.priority})
+			return true
+		}
+	}
+	return false
+}
+
+// InsertAfter inserts processor n right after the last processor named anchor,
+// returning false and leaving the list untouched when there is no such processor.
+func (l *List) InsertAfter(anchor string, n Processor) bool {
+	for i := len(l.list) - 1; i >= 0; i-- {
+		if e := l.list[i]; e.processor.Label() == anchor {
+			l.insert(i+1, entry{n, e.priority})
+			return true
+		}
+	}
+	return false
+}
+
+func (l *List) insert(i int, e entry) {
+	l.list = append(l.list, entry{})
+	copy(l.list[i+1:], l.list[i:])
+	l.list[i] = e
+}
+
+// Remove removes a DefaultProcessor n
+func (l *List) Remove(n Processor) *List {
+	l.RemoveByName(n.Label())
+	return l
+}
+
+// RemoveByName removes a DefaultProcessor by name.
+func (l *List) RemoveByName(name string) *List {
+	for i := 0; i < len(l.list); i++ {
+		m := l.list[i].processor
+		if m.Label() == name {
+			// Shift array preventing creating new arrays
+			copy(l.list[i:], l.list[i+1:])
+			l.list[len(l.list)-1] = entry{}
+			l.list = l.list[:len(l.list)-1]
+
+			// decrement list so next check to length is correct
+			i--
+		}
+	}
+	return l
+}
+
+// SwapNamed will swap out any existing processors with the same name as the
+// passed in DefaultProcessor returning true if processors were swapped. False is
+// returned otherwise.
+func (l *List) SwapNamed(n Processor) (swapped bool) {
+	for i := 0; i < len(l.list); i++ {
+		if l.list[i].processor.Label() == n.Label() {
+			l.list[i].processor = n
+			swapped = true
+		}
+	}
+
+	return swapped
+}
+
+// Swap will swap out all processors matching the name passed in. The matched
+// processors will be swapped in. True is returned if the processors were swapped.
+func (l *List) Swap(name string, replace Processor) bool {
+	var swapped bool
+
+	for i := 0; i < len(l.list); i++ {
+		if l.list[i].processor.Label() == name {
+			l.list[i].processor = replace
+			swapped = true
+		}
+	}
+
+	return swapped
+}
+
+// SetBackNamed will replace the named processor if it exists in the processor list.
+// If the processor does not exist the processor will be added to the end of the list.
+func (l *List) SetBackNamed(n Processor) *List {
+	if !l.SwapNamed(n) {
+		l.PushBack(n)
+	}
+	return l
+}
+
+// SetFrontNamed will replace the named processor if it exists in the processor list.
+// If the processor does not exist the processor will be added to the beginning of
+// the list.
+func (l *List) SetFrontNamed(n Processor) *List {
+	if !l.SwapNamed(n) {
+		l.PushFront(n)
+	}
+	return l
+}
+
+func (l *List) StopOnError() {
+	l.afterEachFn = func(i item) bool {
+		if errorChecker, ok := i.arg.(ErrorChecker); ok {
+			return errorChecker.HasError()
+		}
+		return false
+	}
+}
+
+func (l *List) Exec(arg interface{}) {
+	for i, e := range l.list {
+		h := e.processor
+		h.Execute(arg)
+		it := item{
+			index: i, processor: h, arg: arg,
+		}
+		if l.afterEachFn != nil && l.afterEachFn(it) {
+			return
+		}
+	}
+}
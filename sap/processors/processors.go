@@ -0,0 +1,89 @@
+package processors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Type identifies a phase of the request pipeline, e.g. request.Build or request.Send.
+type Type uint8
+
+var phaseNames = make(map[Type]string)
+
+// NamePhase registers the name of a phase, used by String and Describe.
+func NamePhase(t Type, name string) {
+	phaseNames[t] = name
+}
+
+func (t Type) String() string {
+	if name, ok := phaseNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Phase(%d)", uint8(t))
+}
+
+type Processors struct {
+	m map[Type]*List
+}
+
+func New() Processors {
+	return Processors{m: make(map[Type]*List)}
+}
+
+func (ps *Processors) getOrCreate(pType Type) *List {
+	if ps.m == nil {
+		ps.m = make(map[Type]*List)
+	}
+	if ps.m[pType] == nil {
+		ps.m[pType] = &List{}
+	}
+	return ps.m[pType]
+}
+
+func (ps *Processors) Using(pType Type) *List {
+	return ps.getOrCreate(pType)
+}
+
+func (ps *Processors) Copy() Processors {
+	mCopy := make(map[Type]*List)
+	for k, v := range ps.m {
+		l := v.copy()
+		mCopy[k] = &l
+	}
+	return Processors{
+		m: mCopy,
+	}
+}
+
+// Phases returns the phases having at least one processor, in execution order.
+func (ps *Processors) Phases() []Type {
+	phases := make([]Type, 0, len(ps.m))
+	for t, l := range ps.m {
+		if l.Len() > 0 {
+			phases = append(phases, t)
+		}
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		return phases[i] < phases[j]
+	})
+	return phases
+}
+
+// Describe lists the effective pipeline, one phase per line followed by its processors, e.g.
+//
+//	Send (stop on error): core.ValidateReqSigProcessor, core.SendProcessor
+func (ps *Processors) Describe() string {
+	var b strings.Builder
+	for _, t := range ps.Phases() {
+		l := ps.m[t]
+		b.WriteString(t.String())
+		if l.StopsOnError() {
+			b.WriteString(" (stop on error)")
+		}
+		b.WriteString(": ")
+		b.WriteString(strings.Join(l.Names(), ", "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
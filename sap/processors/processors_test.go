@@ -0,0 +1,58 @@
+package processors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func named(name string) Processor {
+	return &DefaultProcessor{Name: name, Handler: func(interface{}) {}}
+}
+
+func TestListOrdering(t *testing.T) {
+	l := &List{}
+	l.PushBack(named("b")).PushFront(named("a"))
+	l.PushWithPriority(named("last"), 10)
+	l.PushWithPriority(named("first"), -10)
+	l.PushBack(named("after-last"))
+
+	if !l.InsertBefore("b", named("before-b")) {
+		t.Fatal("expected anchor b to be found")
+	}
+	if !l.InsertAfter("b", named("after-b")) {
+		t.Fatal("expected anchor b to be found")
+	}
+	if l.InsertAfter("missing", named("x")) {
+		t.Fatal("expected a missing anchor to be reported")
+	}
+	// a default priority processor still runs before the high priority ones
+	l.PushWithPriority(named("default"), PriorityDefault)
+
+	expected := []string{"first", "a", "before-b", "b", "after-b", "default", "last", "after-last"}
+	if names := l.Names(); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	const validate, send Type = 0, 4
+	NamePhase(validate, "Validate")
+	NamePhase(send, "Send")
+
+	ps := New()
+	ps.Using(send).PushBack(named("core.SendProcessor"))
+	ps.Using(send).InsertBefore("core.SendProcessor", named("trace"))
+	ps.Using(validate).PushBack(named("core.ValidateEndpointProcessor")).StopOnError()
+	ps.Using(Type(9))
+
+	expected := "Validate (stop on error): core.ValidateEndpointProcessor\nSend: trace, core.SendProcessor\n"
+	if d := ps.Describe(); d != expected {
+		t.Fatalf("unexpected description:\n%s", d)
+	}
+
+	c := ps.Copy()
+	c.Using(send).RemoveByName("trace")
+	if names := ps.Using(send).Names(); len(names) != 2 {
+		t.Fatalf("expected the copy not to affect the original, got %v", names)
+	}
+}
@@ -2,11 +2,11 @@ package service
 
 import (
 	"context"
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/sap"
 	"github.com/nnicora/sap-sdk-go/sap/endpoints"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/sap/metainfo"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
 )
 
 type Config struct {
@@ -21,6 +21,9 @@ type Requester struct {
 
 	RuntimeConfig *sap.RuntimeConfig
 	Processors    *processors.Processors
+
+	// Options applied to every request, before the options of the call itself.
+	Options []request.Option
 }
 
 type RequesterConfig interface {
@@ -40,6 +43,15 @@ func NewRequester(cfg *sap.RuntimeConfig, info metainfo.ServiceInfo, processors
 	return svc
 }
 
+// Adds options applied to every request created by the requester.
+func WithRequestOptions(opts ...request.Option) func(*Requester) {
+	return func(r *Requester) {
+		r.Options = append(r.Options, opts...)
+	}
+}
+
 func (r *Requester) NewRequest(ctx context.Context, op *request.Operation, in interface{}, out interface{}) *request.Request {
-	return request.New(ctx, r.RuntimeConfig, r.ServiceInfo, r.Processors, op, in, out)
+	req := request.New(ctx, r.RuntimeConfig, r.ServiceInfo, r.Processors, op, in, out)
+	req.ApplyOptions(r.Options...)
+	return req
 }
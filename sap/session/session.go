@@ -2,12 +2,13 @@ package session
 
 import (
 	"fmt"
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/internal/utils"
 	"github.com/nnicora/sap-sdk-go/sap"
 	"github.com/nnicora/sap-sdk-go/sap/endpoints"
 	"github.com/nnicora/sap-sdk-go/sap/http/defaults"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/sap/oauth2"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
 	"github.com/nnicora/sap-sdk-go/sap/service"
 	"net/http"
 )
@@ -132,6 +133,9 @@ func BuildFromConfig(c *sap.Config) (*RuntimeSession, error) {
 		},
 		Processors: defaults.Processors(),
 	}
+	if c.DryRun {
+		request.EnableDryRun(&rs.Processors)
+	}
 	if err := rs.HardUpdate(c); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,267 @@
+package btpaccounts
+
+import (
+	"context"
+	"errors"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// AccountNodeKind is the kind of entity held by a node of the account tree.
+type AccountNodeKind string
+
+const (
+	AccountNodeGlobalAccount AccountNodeKind = "GLOBAL_ACCOUNT"
+	AccountNodeDirectory     AccountNodeKind = "DIRECTORY"
+	AccountNodeSubAccount    AccountNodeKind = "SUBACCOUNT"
+)
+
+// AccountNode is a node of the account tree: the global account at its root, the directories and
+// the subaccounts.
+type AccountNode struct {
+	Kind AccountNodeKind
+
+	// The entity of the node, according to Kind. Their children and subaccounts are not kept, they
+	// are the children of the node instead.
+	GlobalAccount *GlobalAccountOutput
+	Directory     *Directory
+	SubAccount    *SubAccount
+
+	// The node of the parent directory or global account; nil for the root.
+	Parent *AccountNode
+
+	// The directories, then the subaccounts, directly under the node.
+	Children []*AccountNode
+}
+
+// NewAccountTree builds the account tree from a global account read with its content, that is with
+// GetGlobalAccountInput.Expand set, and returns its root.
+func NewAccountTree(ga *GlobalAccountOutput) *AccountNode {
+	global := *ga
+	root := &AccountNode{Kind: AccountNodeGlobalAccount, GlobalAccount: &global}
+	root.addChildren(global.Children, global.Subaccounts)
+	global.Children, global.Subaccounts = nil, nil
+	return root
+}
+
+func (n *AccountNode) addChildren(dirs []Directory, subAccounts []SubAccount) {
+	for i := range dirs {
+		d := dirs[i]
+		child := &AccountNode{Kind: AccountNodeDirectory, Directory: &d, Parent: n}
+		child.addChildren(d.Children, d.SubAccounts)
+		d.Children, d.SubAccounts = nil, nil
+		n.Children = append(n.Children, child)
+	}
+	for i := range subAccounts {
+		sa := subAccounts[i]
+		n.Children = append(n.Children, &AccountNode{Kind: AccountNodeSubAccount, SubAccount: &sa, Parent: n})
+	}
+}
+
+// GetAccountTree gets the global account with its directories and subaccounts, and returns the root
+// of their tree.
+func (c *AccountsV1) GetAccountTree(ctx context.Context, opts ...request.Option) (*AccountNode, error) {
+	out, err := c.GetGlobalAccount(ctx, &GetGlobalAccountInput{Expand: true}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountTree(out), nil
+}
+
+// Guid returns the GUID of the entity of the node.
+func (n *AccountNode) Guid() string {
+	switch n.Kind {
+	case AccountNodeGlobalAccount:
+		return n.GlobalAccount.Guid
+	case AccountNodeDirectory:
+		return n.Directory.Guid
+	case AccountNodeSubAccount:
+		return n.SubAccount.Guid
+	}
+	return ""
+}
+
+// DisplayName returns the display name of the entity of the node.
+func (n *AccountNode) DisplayName() string {
+	switch n.Kind {
+	case AccountNodeGlobalAccount:
+		return n.GlobalAccount.DisplayName
+	case AccountNodeDirectory:
+		return n.Directory.DisplayName
+	case AccountNodeSubAccount:
+		return n.SubAccount.DisplayName
+	}
+	return ""
+}
+
+// Subdomain returns the subdomain of the entity of the node, empty for directories which do not
+// manage their authorizations.
+func (n *AccountNode) Subdomain() string {
+	switch n.Kind {
+	case AccountNodeGlobalAccount:
+		return n.GlobalAccount.Subdomain
+	case AccountNodeDirectory:
+		return n.Directory.Subdomain
+	case AccountNodeSubAccount:
+		return n.SubAccount.Subdomain
+	}
+	return ""
+}
+
+// CustomProperties returns the custom properties of the entity of the node.
+func (n *AccountNode) CustomProperties() []CustomProperties {
+	switch n.Kind {
+	case AccountNodeGlobalAccount:
+		return n.GlobalAccount.CustomProperties
+	case AccountNodeDirectory:
+		return n.Directory.CustomProperties
+	case AccountNodeSubAccount:
+		return n.SubAccount.CustomProperties
+	}
+	return nil
+}
+
+// Path returns the nodes from the root of the tree down to this one.
+func (n *AccountNode) Path() []*AccountNode {
+	var path []*AccountNode
+	for node := n; node != nil; node = node.Parent {
+		path = append(path, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// SkipChildren is returned by the function passed to Walk to skip the children of a node.
+var SkipChildren = errors.New("skip children")
+
+// Walk calls fn for the node and then for its descendants, depth first and in the order of Children.
+// Walk stops at the first error returned by fn, and returns it, except for SkipChildren which only
+// skips the children of the node.
+func (n *AccountNode) Walk(fn func(node *AccountNode) error) error {
+	if err := fn(n); err != nil {
+		if err == SkipChildren {
+			return nil
+		}
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.Walk(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errFound = errors.New("found")
+
+// Returns the first node, in the order of Walk, accepted by match.
+func (n *AccountNode) find(match func(node *AccountNode) bool) *AccountNode {
+	var found *AccountNode
+	_ = n.Walk(func(node *AccountNode) error {
+		if match(node) {
+			found = node
+			return errFound
+		}
+		return nil
+	})
+	return found
+}
+
+// FindByGuid returns the node, from this one down, of the entity with the GUID; nil if there is none.
+func (n *AccountNode) FindByGuid(guid string) *AccountNode {
+	return n.find(func(node *AccountNode) bool {
+		return node.Guid() == guid
+	})
+}
+
+// FindBySubdomain returns the node, from this one down, of the entity with the subdomain; nil if there
+// is none.
+func (n *AccountNode) FindBySubdomain(subdomain string) *AccountNode {
+	if subdomain == "" {
+		return nil
+	}
+	return n.find(func(node *AccountNode) bool {
+		return node.Subdomain() == subdomain
+	})
+}
+
+// PathTo returns the nodes from the root of the tree down to the entity with the GUID; nil if the
+// entity is not under this node.
+func (n *AccountNode) PathTo(guid string) []*AccountNode {
+	if node := n.FindByGuid(guid); node != nil {
+		return node.Path()
+	}
+	return nil
+}
+
+// Flatten returns the node and its descendants, in the order of Walk.
+func (n *AccountNode) Flatten() []*AccountNode {
+	return n.Filter()
+}
+
+// AccountNodeFilter tells whether a node of the account tree is to be kept.
+type AccountNodeFilter func(node *AccountNode) bool
+
+// Filter returns the nodes, from this one down and in the order of Walk, accepted by all the filters.
+func (n *AccountNode) Filter(filters ...AccountNodeFilter) []*AccountNode {
+	var out []*AccountNode
+	_ = n.Walk(func(node *AccountNode) error {
+		for _, accept := range filters {
+			if !accept(node) {
+				return nil
+			}
+		}
+		out = append(out, node)
+		return nil
+	})
+	return out
+}
+
+// OfKind keeps the nodes of the given kind.
+func OfKind(kind AccountNodeKind) AccountNodeFilter {
+	return func(node *AccountNode) bool {
+		return node.Kind == kind
+	}
+}
+
+// WithCustomProperty keeps the nodes with the custom property; with any value when value is empty.
+func WithCustomProperty(key, value string) AccountNodeFilter {
+	return func(node *AccountNode) bool {
+		for _, p := range node.CustomProperties() {
+			if p.Key == key && (value == "" || p.Value == value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// WithDirectoryFeature keeps the directories with the feature enabled, e.g. ENTITLEMENTS.
+func WithDirectoryFeature(feature string) AccountNodeFilter {
+	return func(node *AccountNode) bool {
+		if node.Kind != AccountNodeDirectory {
+			return false
+		}
+		for _, f := range node.Directory.DirectoryFeatures {
+			if f == feature {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// InRegion keeps the subaccounts of the region.
+func InRegion(region string) AccountNodeFilter {
+	return func(node *AccountNode) bool {
+		return node.Kind == AccountNodeSubAccount && node.SubAccount.Region == region
+	}
+}
+
+// WithUsedForProduction keeps the subaccounts with the given production usage.
+func WithUsedForProduction(usage UsedForProduction) AccountNodeFilter {
+	return func(node *AccountNode) bool {
+		return node.Kind == AccountNodeSubAccount && node.SubAccount.UsedForProduction == usage
+	}
+}
@@ -0,0 +1,104 @@
+package btpaccounts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const expandedGlobalAccount = `{
+	"guid": "ga", "displayName": "Global", "subdomain": "global",
+	"children": [{
+		"guid": "finance", "displayName": "Finance", "directoryFeatures": ["DEFAULT", "ENTITLEMENTS"],
+		"customProperties": [{"key": "cost-center", "value": "42"}],
+		"children": [{
+			"guid": "eu", "displayName": "EU", "directoryFeatures": ["DEFAULT"],
+			"subaccounts": [
+				{"guid": "fin-eu-prod", "displayName": "Prod", "subdomain": "fin-eu-prod", "region": "eu10",
+					"usedForProduction": "USED_FOR_PRODUCTION", "customProperties": [{"key": "team", "value": "core"}]},
+				{"guid": "fin-eu-dev", "displayName": "Dev", "subdomain": "fin-eu-dev", "region": "eu10",
+					"usedForProduction": "NOT_USED_FOR_PRODUCTION"}
+			]
+		}],
+		"subaccounts": [{"guid": "fin-us", "displayName": "US", "subdomain": "fin-us", "region": "us10"}]
+	}],
+	"subaccounts": [{"guid": "sandbox", "displayName": "Sandbox", "subdomain": "sandbox", "region": "eu10"}]
+}`
+
+func guids(nodes []*AccountNode) []string {
+	var out []string
+	for _, n := range nodes {
+		out = append(out, n.Guid())
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAccountTree(t *testing.T) {
+	var ga GlobalAccountOutput
+	if err := json.Unmarshal([]byte(expandedGlobalAccount), &ga); err != nil {
+		t.Fatal(err)
+	}
+	root := NewAccountTree(&ga)
+
+	if got, expected := guids(root.Flatten()), []string{"ga", "finance", "eu", "fin-eu-prod", "fin-eu-dev", "fin-us", "sandbox"}; !equal(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if root.GlobalAccount.Children != nil || root.Children[0].Directory.Children != nil {
+		t.Fatal("expected the children to be moved to the nodes")
+	}
+
+	node := root.FindBySubdomain("fin-eu-dev")
+	if node == nil || node.Kind != AccountNodeSubAccount || node.Parent.DisplayName() != "EU" {
+		t.Fatalf("unexpected node %+v", node)
+	}
+	if got, expected := guids(root.PathTo("fin-eu-dev")), []string{"ga", "finance", "eu", "fin-eu-dev"}; !equal(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if root.FindByGuid("missing") != nil || root.PathTo("missing") != nil || root.FindBySubdomain("") != nil {
+		t.Fatal("expected nothing to be found")
+	}
+
+	var visited []string
+	_ = root.Walk(func(n *AccountNode) error {
+		visited = append(visited, n.Guid())
+		if n.Guid() == "eu" {
+			return SkipChildren
+		}
+		return nil
+	})
+	if expected := []string{"ga", "finance", "eu", "fin-us", "sandbox"}; !equal(visited, expected) {
+		t.Fatalf("expected %v, got %v", expected, visited)
+	}
+
+	filters := []struct {
+		filters  []AccountNodeFilter
+		expected []string
+	}{
+		{[]AccountNodeFilter{OfKind(AccountNodeDirectory)}, []string{"finance", "eu"}},
+		{[]AccountNodeFilter{WithDirectoryFeature("ENTITLEMENTS")}, []string{"finance"}},
+		{[]AccountNodeFilter{WithCustomProperty("cost-center", "")}, []string{"finance"}},
+		{[]AccountNodeFilter{WithCustomProperty("team", "core")}, []string{"fin-eu-prod"}},
+		{[]AccountNodeFilter{WithCustomProperty("team", "other")}, nil},
+		{[]AccountNodeFilter{InRegion("eu10")}, []string{"fin-eu-prod", "fin-eu-dev", "sandbox"}},
+		{[]AccountNodeFilter{InRegion("eu10"), WithUsedForProduction(UsedForProductionNotUsed)}, []string{"fin-eu-dev"}},
+	}
+	for i, f := range filters {
+		if got := guids(root.Filter(f.filters...)); !equal(got, f.expected) {
+			t.Errorf("filter %d: expected %v, got %v", i, f.expected, got)
+		}
+	}
+	if got := guids(root.Children[0].Filter(OfKind(AccountNodeSubAccount))); !equal(got, []string{"fin-eu-prod", "fin-eu-dev", "fin-us"}) {
+		t.Fatalf("unexpected subaccounts of Finance %v", got)
+	}
+}
@@ -0,0 +1,374 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpaccountsmock provides a mock of btpaccounts.AccountsAPI.
+package btpaccountsmock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+)
+
+// AccountsAPI implements btpaccounts.AccountsAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type AccountsAPI struct {
+	GetAccountTreeFunc                                  func(ctx context.Context, opts ...request.Option) (*btpaccounts.AccountNode, error)
+	CreateDirectoryFunc                                 func(ctx context.Context, input *btpaccounts.CreateDirectoryInput, opts ...request.Option) (*btpaccounts.CreateDirectoryOutput, error)
+	CreateDirectoryRequestFunc                          func(ctx context.Context, input *btpaccounts.CreateDirectoryInput) (*request.Request, *btpaccounts.CreateDirectoryOutput)
+	GetDirectoryFunc                                    func(ctx context.Context, input *btpaccounts.GetDirectoryInput, opts ...request.Option) (*btpaccounts.GetDirectoryOutput, error)
+	GetDirectoryRequestFunc                             func(ctx context.Context, input *btpaccounts.GetDirectoryInput) (*request.Request, *btpaccounts.GetDirectoryOutput)
+	DeleteDirectoryFunc                                 func(ctx context.Context, input *btpaccounts.DeleteDirectoryInput, opts ...request.Option) (*btpaccounts.DeleteDirectoryOutput, error)
+	DeleteDirectoryRequestFunc                          func(ctx context.Context, input *btpaccounts.DeleteDirectoryInput) (*request.Request, *btpaccounts.DeleteDirectoryOutput)
+	UpdateDirectoryFunc                                 func(ctx context.Context, input *btpaccounts.UpdateDirectoryInput, opts ...request.Option) (*btpaccounts.UpdateDirectoryOutput, error)
+	UpdateDirectoryRequestFunc                          func(ctx context.Context, input *btpaccounts.UpdateDirectoryInput) (*request.Request, *btpaccounts.UpdateDirectoryOutput)
+	UpdateDirectoryFeaturesFunc                         func(ctx context.Context, input *btpaccounts.UpdateDirectoryFeaturesInput, opts ...request.Option) (*btpaccounts.UpdateDirectoryFeaturesOutput, error)
+	UpdateDirectoryFeaturesRequestFunc                  func(ctx context.Context, input *btpaccounts.UpdateDirectoryFeaturesInput) (*request.Request, *btpaccounts.UpdateDirectoryFeaturesOutput)
+	GetDirectoryCustomPropertiesFunc                    func(ctx context.Context, input *btpaccounts.GetDirectoryCustomPropertiesInput, opts ...request.Option) (*btpaccounts.GetDirectoryCustomPropertiesOutput, error)
+	GetDirectorCustomPropertiesFunc                     func(ctx context.Context, input *btpaccounts.GetDirectoryCustomPropertiesInput, opts ...request.Option) (*btpaccounts.GetDirectoryCustomPropertiesOutput, error)
+	GetDirectoryCustomPropertiesRequestFunc             func(ctx context.Context, input *btpaccounts.GetDirectoryCustomPropertiesInput) (*request.Request, *btpaccounts.GetDirectoryCustomPropertiesOutput)
+	GetGlobalAccountFunc                                func(ctx context.Context, input *btpaccounts.GetGlobalAccountInput, opts ...request.Option) (*btpaccounts.GlobalAccountOutput, error)
+	GetGlobalAccountRequestFunc                         func(ctx context.Context, input *btpaccounts.GetGlobalAccountInput) (*request.Request, *btpaccounts.GlobalAccountOutput)
+	UpdateGlobalAccountFunc                             func(ctx context.Context, input *btpaccounts.UpdateGlobalAccountInput, opts ...request.Option) (*btpaccounts.GlobalAccountOutput, error)
+	UpdateGlobalAccountRequestFunc                      func(ctx context.Context, input *btpaccounts.UpdateGlobalAccountInput) (*request.Request, *btpaccounts.GlobalAccountOutput)
+	GetJobStatusFunc                                    func(ctx context.Context, input *btpaccounts.GetJobStatusInput, opts ...request.Option) (*btpaccounts.GetJobStatusOutput, error)
+	GetJobStatusRequestFunc                             func(ctx context.Context, input *btpaccounts.GetJobStatusInput) (*request.Request, *btpaccounts.GetJobStatusOutput)
+	GetSubAccountsFunc                                  func(ctx context.Context, input *btpaccounts.GetSubAccountsInput, opts ...request.Option) (*btpaccounts.GetSubAccountsOutput, error)
+	GetSubAccountsRequestFunc                           func(ctx context.Context, input *btpaccounts.GetSubAccountsInput) (*request.Request, *btpaccounts.GetSubAccountsOutput)
+	CreateSubAccountFunc                                func(ctx context.Context, input *btpaccounts.CreateSubAccountInput, opts ...request.Option) (*btpaccounts.CreateSubAccountOutput, error)
+	CreateSubAccountRequestFunc                         func(ctx context.Context, input *btpaccounts.CreateSubAccountInput) (*request.Request, *btpaccounts.CreateSubAccountOutput)
+	CloneSubAccountFunc                                 func(ctx context.Context, input *btpaccounts.CloneSubAccountInput, opts ...request.Option) (*btpaccounts.CloneSubAccountOutput, error)
+	CloneSubAccountRequestFunc                          func(ctx context.Context, input *btpaccounts.CloneSubAccountInput) (*request.Request, *btpaccounts.CloneSubAccountOutput)
+	GetSubAccountFunc                                   func(ctx context.Context, input *btpaccounts.GetSubAccountInput, opts ...request.Option) (*btpaccounts.GetSubAccountOutput, error)
+	GetSubAccountRequestFunc                            func(ctx context.Context, input *btpaccounts.GetSubAccountInput) (*request.Request, *btpaccounts.GetSubAccountOutput)
+	DeleteSubAccountFunc                                func(ctx context.Context, input *btpaccounts.DeleteSubAccountInput, opts ...request.Option) (*btpaccounts.DeleteSubAccountOutput, error)
+	DeleteSubAccountRequestFunc                         func(ctx context.Context, input *btpaccounts.DeleteSubAccountInput) (*request.Request, *btpaccounts.DeleteSubAccountOutput)
+	UpdateSubAccountFunc                                func(ctx context.Context, input *btpaccounts.UpdateSubAccountInput, opts ...request.Option) (*btpaccounts.UpdateSubAccountOutput, error)
+	UpdateSubAccountRequestFunc                         func(ctx context.Context, input *btpaccounts.UpdateSubAccountInput) (*request.Request, *btpaccounts.UpdateSubAccountOutput)
+	GetSubAccountCustomPropertiesFunc                   func(ctx context.Context, input *btpaccounts.GetCustomPropertiesInput, opts ...request.Option) (*btpaccounts.GetCustomPropertiesOutput, error)
+	GetSubAccountCustomPropertiesRequestFunc            func(ctx context.Context, input *btpaccounts.GetCustomPropertiesInput) (*request.Request, *btpaccounts.GetCustomPropertiesOutput)
+	MoveManySubAccountsFunc                             func(ctx context.Context, input *btpaccounts.MoveManySubAccountsInput, opts ...request.Option) (*btpaccounts.MoveManySubAccountsOutput, error)
+	MoveManySubAccountsRequestFunc                      func(ctx context.Context, input *btpaccounts.MoveManySubAccountsInput) (*request.Request, *btpaccounts.MoveManySubAccountsOutput)
+	MoveSubAccountFunc                                  func(ctx context.Context, input *btpaccounts.MoveSubAccountInput, opts ...request.Option) (*btpaccounts.MoveSubAccountOutput, error)
+	MoveSubAccountRequestFunc                           func(ctx context.Context, input *btpaccounts.MoveSubAccountInput) (*request.Request, *btpaccounts.MoveSubAccountOutput)
+	GetSubAccountServiceManagementBindingFunc           func(ctx context.Context, input *btpaccounts.GetServiceManagementBindingInput, opts ...request.Option) (*btpaccounts.GetServiceManagementBindingOutput, error)
+	GetSubAccountServiceManagementBindingRequestFunc    func(ctx context.Context, input *btpaccounts.GetServiceManagementBindingInput) (*request.Request, *btpaccounts.GetServiceManagementBindingOutput)
+	CreateSubAccountServiceManagementBindingFunc        func(ctx context.Context, input *btpaccounts.CreateServiceManagementBindingInput, opts ...request.Option) (*btpaccounts.CreateServiceManagementBindingOutput, error)
+	CreateSubAccountServiceManagementBindingRequestFunc func(ctx context.Context, input *btpaccounts.CreateServiceManagementBindingInput) (*request.Request, *btpaccounts.CreateServiceManagementBindingOutput)
+	DeleteSubAccountServiceManagementBindingFunc        func(ctx context.Context, input *btpaccounts.DeleteServiceManagementBindingInput, opts ...request.Option) (*btpaccounts.DeleteServiceManagementBindingOutput, error)
+	DeleteSubAccountServiceManagementBindingRequestFunc func(ctx context.Context, input *btpaccounts.DeleteServiceManagementBindingInput) (*request.Request, *btpaccounts.DeleteServiceManagementBindingOutput)
+}
+
+var _ btpaccounts.AccountsAPI = (*AccountsAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpaccountsmock: %s is not stubbed", method)
+}
+
+func (m *AccountsAPI) GetAccountTree(ctx context.Context, opts ...request.Option) (*btpaccounts.AccountNode, error) {
+	if m.GetAccountTreeFunc == nil {
+		return nil, notStubbed("GetAccountTree")
+	}
+	return m.GetAccountTreeFunc(ctx, opts...)
+}
+
+func (m *AccountsAPI) CreateDirectory(ctx context.Context, input *btpaccounts.CreateDirectoryInput, opts ...request.Option) (*btpaccounts.CreateDirectoryOutput, error) {
+	if m.CreateDirectoryFunc == nil {
+		return nil, notStubbed("CreateDirectory")
+	}
+	return m.CreateDirectoryFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) CreateDirectoryRequest(ctx context.Context, input *btpaccounts.CreateDirectoryInput) (*request.Request, *btpaccounts.CreateDirectoryOutput) {
+	if m.CreateDirectoryRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateDirectoryRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetDirectory(ctx context.Context, input *btpaccounts.GetDirectoryInput, opts ...request.Option) (*btpaccounts.GetDirectoryOutput, error) {
+	if m.GetDirectoryFunc == nil {
+		return nil, notStubbed("GetDirectory")
+	}
+	return m.GetDirectoryFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetDirectoryRequest(ctx context.Context, input *btpaccounts.GetDirectoryInput) (*request.Request, *btpaccounts.GetDirectoryOutput) {
+	if m.GetDirectoryRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetDirectoryRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) DeleteDirectory(ctx context.Context, input *btpaccounts.DeleteDirectoryInput, opts ...request.Option) (*btpaccounts.DeleteDirectoryOutput, error) {
+	if m.DeleteDirectoryFunc == nil {
+		return nil, notStubbed("DeleteDirectory")
+	}
+	return m.DeleteDirectoryFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) DeleteDirectoryRequest(ctx context.Context, input *btpaccounts.DeleteDirectoryInput) (*request.Request, *btpaccounts.DeleteDirectoryOutput) {
+	if m.DeleteDirectoryRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteDirectoryRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) UpdateDirectory(ctx context.Context, input *btpaccounts.UpdateDirectoryInput, opts ...request.Option) (*btpaccounts.UpdateDirectoryOutput, error) {
+	if m.UpdateDirectoryFunc == nil {
+		return nil, notStubbed("UpdateDirectory")
+	}
+	return m.UpdateDirectoryFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) UpdateDirectoryRequest(ctx context.Context, input *btpaccounts.UpdateDirectoryInput) (*request.Request, *btpaccounts.UpdateDirectoryOutput) {
+	if m.UpdateDirectoryRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateDirectoryRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) UpdateDirectoryFeatures(ctx context.Context, input *btpaccounts.UpdateDirectoryFeaturesInput, opts ...request.Option) (*btpaccounts.UpdateDirectoryFeaturesOutput, error) {
+	if m.UpdateDirectoryFeaturesFunc == nil {
+		return nil, notStubbed("UpdateDirectoryFeatures")
+	}
+	return m.UpdateDirectoryFeaturesFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) UpdateDirectoryFeaturesRequest(ctx context.Context, input *btpaccounts.UpdateDirectoryFeaturesInput) (*request.Request, *btpaccounts.UpdateDirectoryFeaturesOutput) {
+	if m.UpdateDirectoryFeaturesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateDirectoryFeaturesRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetDirectoryCustomProperties(ctx context.Context, input *btpaccounts.GetDirectoryCustomPropertiesInput, opts ...request.Option) (*btpaccounts.GetDirectoryCustomPropertiesOutput, error) {
+	if m.GetDirectoryCustomPropertiesFunc == nil {
+		return nil, notStubbed("GetDirectoryCustomProperties")
+	}
+	return m.GetDirectoryCustomPropertiesFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetDirectorCustomProperties(ctx context.Context, input *btpaccounts.GetDirectoryCustomPropertiesInput, opts ...request.Option) (*btpaccounts.GetDirectoryCustomPropertiesOutput, error) {
+	if m.GetDirectorCustomPropertiesFunc == nil {
+		return nil, notStubbed("GetDirectorCustomProperties")
+	}
+	return m.GetDirectorCustomPropertiesFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetDirectoryCustomPropertiesRequest(ctx context.Context, input *btpaccounts.GetDirectoryCustomPropertiesInput) (*request.Request, *btpaccounts.GetDirectoryCustomPropertiesOutput) {
+	if m.GetDirectoryCustomPropertiesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetDirectoryCustomPropertiesRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetGlobalAccount(ctx context.Context, input *btpaccounts.GetGlobalAccountInput, opts ...request.Option) (*btpaccounts.GlobalAccountOutput, error) {
+	if m.GetGlobalAccountFunc == nil {
+		return nil, notStubbed("GetGlobalAccount")
+	}
+	return m.GetGlobalAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetGlobalAccountRequest(ctx context.Context, input *btpaccounts.GetGlobalAccountInput) (*request.Request, *btpaccounts.GlobalAccountOutput) {
+	if m.GetGlobalAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetGlobalAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) UpdateGlobalAccount(ctx context.Context, input *btpaccounts.UpdateGlobalAccountInput, opts ...request.Option) (*btpaccounts.GlobalAccountOutput, error) {
+	if m.UpdateGlobalAccountFunc == nil {
+		return nil, notStubbed("UpdateGlobalAccount")
+	}
+	return m.UpdateGlobalAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) UpdateGlobalAccountRequest(ctx context.Context, input *btpaccounts.UpdateGlobalAccountInput) (*request.Request, *btpaccounts.GlobalAccountOutput) {
+	if m.UpdateGlobalAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateGlobalAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetJobStatus(ctx context.Context, input *btpaccounts.GetJobStatusInput, opts ...request.Option) (*btpaccounts.GetJobStatusOutput, error) {
+	if m.GetJobStatusFunc == nil {
+		return nil, notStubbed("GetJobStatus")
+	}
+	return m.GetJobStatusFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetJobStatusRequest(ctx context.Context, input *btpaccounts.GetJobStatusInput) (*request.Request, *btpaccounts.GetJobStatusOutput) {
+	if m.GetJobStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetJobStatusRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetSubAccounts(ctx context.Context, input *btpaccounts.GetSubAccountsInput, opts ...request.Option) (*btpaccounts.GetSubAccountsOutput, error) {
+	if m.GetSubAccountsFunc == nil {
+		return nil, notStubbed("GetSubAccounts")
+	}
+	return m.GetSubAccountsFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetSubAccountsRequest(ctx context.Context, input *btpaccounts.GetSubAccountsInput) (*request.Request, *btpaccounts.GetSubAccountsOutput) {
+	if m.GetSubAccountsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetSubAccountsRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) CreateSubAccount(ctx context.Context, input *btpaccounts.CreateSubAccountInput, opts ...request.Option) (*btpaccounts.CreateSubAccountOutput, error) {
+	if m.CreateSubAccountFunc == nil {
+		return nil, notStubbed("CreateSubAccount")
+	}
+	return m.CreateSubAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) CreateSubAccountRequest(ctx context.Context, input *btpaccounts.CreateSubAccountInput) (*request.Request, *btpaccounts.CreateSubAccountOutput) {
+	if m.CreateSubAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateSubAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) CloneSubAccount(ctx context.Context, input *btpaccounts.CloneSubAccountInput, opts ...request.Option) (*btpaccounts.CloneSubAccountOutput, error) {
+	if m.CloneSubAccountFunc == nil {
+		return nil, notStubbed("CloneSubAccount")
+	}
+	return m.CloneSubAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) CloneSubAccountRequest(ctx context.Context, input *btpaccounts.CloneSubAccountInput) (*request.Request, *btpaccounts.CloneSubAccountOutput) {
+	if m.CloneSubAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CloneSubAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetSubAccount(ctx context.Context, input *btpaccounts.GetSubAccountInput, opts ...request.Option) (*btpaccounts.GetSubAccountOutput, error) {
+	if m.GetSubAccountFunc == nil {
+		return nil, notStubbed("GetSubAccount")
+	}
+	return m.GetSubAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetSubAccountRequest(ctx context.Context, input *btpaccounts.GetSubAccountInput) (*request.Request, *btpaccounts.GetSubAccountOutput) {
+	if m.GetSubAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetSubAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) DeleteSubAccount(ctx context.Context, input *btpaccounts.DeleteSubAccountInput, opts ...request.Option) (*btpaccounts.DeleteSubAccountOutput, error) {
+	if m.DeleteSubAccountFunc == nil {
+		return nil, notStubbed("DeleteSubAccount")
+	}
+	return m.DeleteSubAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) DeleteSubAccountRequest(ctx context.Context, input *btpaccounts.DeleteSubAccountInput) (*request.Request, *btpaccounts.DeleteSubAccountOutput) {
+	if m.DeleteSubAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteSubAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) UpdateSubAccount(ctx context.Context, input *btpaccounts.UpdateSubAccountInput, opts ...request.Option) (*btpaccounts.UpdateSubAccountOutput, error) {
+	if m.UpdateSubAccountFunc == nil {
+		return nil, notStubbed("UpdateSubAccount")
+	}
+	return m.UpdateSubAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) UpdateSubAccountRequest(ctx context.Context, input *btpaccounts.UpdateSubAccountInput) (*request.Request, *btpaccounts.UpdateSubAccountOutput) {
+	if m.UpdateSubAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateSubAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetSubAccountCustomProperties(ctx context.Context, input *btpaccounts.GetCustomPropertiesInput, opts ...request.Option) (*btpaccounts.GetCustomPropertiesOutput, error) {
+	if m.GetSubAccountCustomPropertiesFunc == nil {
+		return nil, notStubbed("GetSubAccountCustomProperties")
+	}
+	return m.GetSubAccountCustomPropertiesFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetSubAccountCustomPropertiesRequest(ctx context.Context, input *btpaccounts.GetCustomPropertiesInput) (*request.Request, *btpaccounts.GetCustomPropertiesOutput) {
+	if m.GetSubAccountCustomPropertiesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetSubAccountCustomPropertiesRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) MoveManySubAccounts(ctx context.Context, input *btpaccounts.MoveManySubAccountsInput, opts ...request.Option) (*btpaccounts.MoveManySubAccountsOutput, error) {
+	if m.MoveManySubAccountsFunc == nil {
+		return nil, notStubbed("MoveManySubAccounts")
+	}
+	return m.MoveManySubAccountsFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) MoveManySubAccountsRequest(ctx context.Context, input *btpaccounts.MoveManySubAccountsInput) (*request.Request, *btpaccounts.MoveManySubAccountsOutput) {
+	if m.MoveManySubAccountsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.MoveManySubAccountsRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) MoveSubAccount(ctx context.Context, input *btpaccounts.MoveSubAccountInput, opts ...request.Option) (*btpaccounts.MoveSubAccountOutput, error) {
+	if m.MoveSubAccountFunc == nil {
+		return nil, notStubbed("MoveSubAccount")
+	}
+	return m.MoveSubAccountFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) MoveSubAccountRequest(ctx context.Context, input *btpaccounts.MoveSubAccountInput) (*request.Request, *btpaccounts.MoveSubAccountOutput) {
+	if m.MoveSubAccountRequestFunc == nil {
+		return nil, nil
+	}
+	return m.MoveSubAccountRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) GetSubAccountServiceManagementBinding(ctx context.Context, input *btpaccounts.GetServiceManagementBindingInput, opts ...request.Option) (*btpaccounts.GetServiceManagementBindingOutput, error) {
+	if m.GetSubAccountServiceManagementBindingFunc == nil {
+		return nil, notStubbed("GetSubAccountServiceManagementBinding")
+	}
+	return m.GetSubAccountServiceManagementBindingFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) GetSubAccountServiceManagementBindingRequest(ctx context.Context, input *btpaccounts.GetServiceManagementBindingInput) (*request.Request, *btpaccounts.GetServiceManagementBindingOutput) {
+	if m.GetSubAccountServiceManagementBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetSubAccountServiceManagementBindingRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) CreateSubAccountServiceManagementBinding(ctx context.Context, input *btpaccounts.CreateServiceManagementBindingInput, opts ...request.Option) (*btpaccounts.CreateServiceManagementBindingOutput, error) {
+	if m.CreateSubAccountServiceManagementBindingFunc == nil {
+		return nil, notStubbed("CreateSubAccountServiceManagementBinding")
+	}
+	return m.CreateSubAccountServiceManagementBindingFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) CreateSubAccountServiceManagementBindingRequest(ctx context.Context, input *btpaccounts.CreateServiceManagementBindingInput) (*request.Request, *btpaccounts.CreateServiceManagementBindingOutput) {
+	if m.CreateSubAccountServiceManagementBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateSubAccountServiceManagementBindingRequestFunc(ctx, input)
+}
+
+func (m *AccountsAPI) DeleteSubAccountServiceManagementBinding(ctx context.Context, input *btpaccounts.DeleteServiceManagementBindingInput, opts ...request.Option) (*btpaccounts.DeleteServiceManagementBindingOutput, error) {
+	if m.DeleteSubAccountServiceManagementBindingFunc == nil {
+		return nil, notStubbed("DeleteSubAccountServiceManagementBinding")
+	}
+	return m.DeleteSubAccountServiceManagementBindingFunc(ctx, input, opts...)
+}
+
+func (m *AccountsAPI) DeleteSubAccountServiceManagementBindingRequest(ctx context.Context, input *btpaccounts.DeleteServiceManagementBindingInput) (*request.Request, *btpaccounts.DeleteServiceManagementBindingOutput) {
+	if m.DeleteSubAccountServiceManagementBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteSubAccountServiceManagementBindingRequestFunc(ctx, input)
+}
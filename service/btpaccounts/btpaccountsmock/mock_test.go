@@ -0,0 +1,44 @@
+package btpaccountsmock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts/btpaccountsmock"
+)
+
+func subAccountState(ctx context.Context, api btpaccounts.AccountsAPI, guid string) (btpaccounts.EntityState, error) {
+	out, err := api.GetSubAccount(ctx, &btpaccounts.GetSubAccountInput{SubAccountGuid: guid})
+	if err != nil {
+		return "", err
+	}
+	return out.State, nil
+}
+
+func TestStubbedCall(t *testing.T) {
+	mock := &btpaccountsmock.AccountsAPI{
+		GetSubAccountFunc: func(ctx context.Context, input *btpaccounts.GetSubAccountInput,
+			opts ...request.Option) (*btpaccounts.GetSubAccountOutput, error) {
+			out := &btpaccounts.GetSubAccountOutput{}
+			out.Guid, out.State = input.SubAccountGuid, "OK"
+			return out, nil
+		},
+	}
+
+	state, err := subAccountState(context.Background(), mock, "guid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != "OK" {
+		t.Fatalf("expected state OK, got %s", state)
+	}
+}
+
+func TestNotStubbedCall(t *testing.T) {
+	mock := &btpaccountsmock.AccountsAPI{}
+	if _, err := mock.DeleteSubAccount(context.Background(), &btpaccounts.DeleteSubAccountInput{}); err == nil {
+		t.Fatal("expected an error for a method that is not stubbed")
+	}
+}
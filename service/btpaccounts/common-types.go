@@ -1,6 +1,6 @@
 package btpaccounts
 
-import "github.com/nnicora/sap-sdk-go/internal/times"
+import "github.com/nnicora/sap-sdk-go/service/types"
 
 type SubAccount struct {
 	//Whether the subaccount can use beta services and applications.
@@ -10,7 +10,7 @@ type SubAccount struct {
 	CreatedBy string `json:"createdBy,omitempty"`
 
 	//The date the subaccount was created. Dates and times are in UTC format.
-	CreatedDate times.JavaTime `json:"createdDate,omitempty"`
+	CreatedDate types.EpochTime `json:"createdDate,omitempty"`
 
 	//The custom properties assigned to the subaccount.
 	CustomProperties []CustomProperties `json:"customProperties,omitempty"`
@@ -28,7 +28,7 @@ type SubAccount struct {
 	Guid string `json:"guid,omitempty"`
 
 	//The date the subaccount was last modified. Dates and times are in UTC format.
-	ModifiedDate times.JavaTime `json:"modifiedDate,omitempty"`
+	ModifiedDate types.EpochTime `json:"modifiedDate,omitempty"`
 
 	//The features of parent entity of the subaccount.
 	//
@@ -36,6 +36,9 @@ type SubAccount struct {
 	//	[ DEFAULT, ENTITLEMENTS, AUTHORIZATIONS, CRM ]
 	ParentFeatures []string `json:"parentFeatures,omitempty"`
 
+	//Labels assigned to the subaccount, a label key with its list of values.
+	Labels map[string][]string `json:"labels,omitempty"`
+
 	//The GUID of the subaccount’s parent entity. If the subaccount is located directly in the global account
 	//	(not in a directory), then this is the GUID of the global account.
 	ParentGuid string `json:"parentGUID,omitempty"`
@@ -49,7 +52,7 @@ type SubAccount struct {
 	//	[ STARTED, CREATING, UPDATING, MOVING, PROCESSING, DELETING, OK, PENDING_REVIEW, CANCELED, CREATION_FAILED,
 	//	UPDATE_FAILED, UPDATE_ACCOUNT_TYPE_FAILED, UPDATE_DIRECTORY_TYPE_FAILED, PROCESSING_FAILED, DELETION_FAILED,
 	//	MOVE_FAILED, MIGRATING, MIGRATION_FAILED, ROLLBACK_MIGRATION_PROCESSING, MIGRATED ]
-	State string `json:"state,omitempty"`
+	State EntityState `json:"state,omitempty"`
 
 	//Information about the state of the subaccount.
 	StateMessage string `json:"stateMessage,omitempty"`
@@ -59,6 +62,10 @@ type SubAccount struct {
 	//Maximum length is 63 characters. Cannot be changed after the subaccount has been created.
 	Subdomain string `json:"subdomain,omitempty"`
 
+	//The technical name of the subaccount. Refers to: (1) the platform-based account name for Neo subaccounts, or
+	//(2) the account identifier (tenant ID) in XSUAA for multi-environment subaccounts.
+	TechnicalName string `json:"technicalName,omitempty"`
+
 	//Whether the subaccount is used for production purposes. This flag can help your cloud operator to take appropriate
 	// action when handling incidents that are related to mission-critical accounts in production systems.
 	// Do not apply for subaccounts that are used for non-production purposes, such as development, testing, and demos.
@@ -69,7 +76,7 @@ type SubAccount struct {
 	//USED_FOR_PRODUCTION: Subaccount is used for production purposes.
 	//Enum:
 	//	[ UNSET, USED_FOR_PRODUCTION, NOT_USED_FOR_PRODUCTION ]
-	UsedForProduction string `json:"usedForProduction,omitempty"`
+	UsedForProduction UsedForProduction `json:"usedForProduction,omitempty"`
 
 	//The zoneId of the subaccount.
 	ZoneId string `json:"zoneId,omitempty"`
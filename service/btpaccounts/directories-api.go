@@ -2,14 +2,13 @@ package btpaccounts
 
 import (
 	"context"
-	"github.com/nnicora/sap-sdk-go/internal/times"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
 )
 
 type Directory struct {
-	// The response object containing information about the directories.
-	Children []map[string]interface{} `json:"children,omitempty"`
+	// The directories contained in the directory.
+	Children []Directory `json:"children,omitempty"`
 
 	// The status of the customer contract and its associated root global account.
 	//
@@ -25,13 +24,13 @@ type Directory struct {
 	//	has not yet extended the trial period.
 	//Enum:
 	//	[ ACTIVE, PENDING_TERMINATION, SUSPENDED ]
-	ContractStatus string `json:"contractStatus,omitempty"`
+	ContractStatus ContractStatus `json:"contractStatus,omitempty"`
 
 	// Details of the user that created the directory.
 	CreatedBy string `json:"createdBy,omitempty"`
 
 	// The date the directory was created. Dates and times are in UTC format.
-	CreatedDate times.JavaTime `json:"createdDate,omitempty"`
+	CreatedDate types.EpochTime `json:"createdDate,omitempty"`
 
 	// Custom properties assigned to the directory as key-value pairs.
 	CustomProperties []CustomProperties `json:"customProperties,omitempty"`
@@ -84,14 +83,17 @@ type Directory struct {
 	//	[ STARTED, CREATING, UPDATING, MOVING, PROCESSING, DELETING, OK, PENDING_REVIEW, CANCELED, CREATION_FAILED,
 	//	UPDATE_FAILED, UPDATE_ACCOUNT_TYPE_FAILED, UPDATE_DIRECTORY_TYPE_FAILED, PROCESSING_FAILED, DELETION_FAILED,
 	//	MOVE_FAILED, MIGRATING, MIGRATION_FAILED, ROLLBACK_MIGRATION_PROCESSING, MIGRATED ]
-	EntityState string `json:"entityState,omitempty"`
+	EntityState EntityState `json:"entityState,omitempty"`
 
 	// The unique ID of the directory.
-	Guid       string     `json:"guid,omitempty"`
-	LegalLinks LegalLinks `json:"legalLinks,omitempty"`
+	Guid string `json:"guid,omitempty"`
+
+	// Labels assigned to the directory, a label key with its list of values.
+	Labels     map[string][]string `json:"labels,omitempty"`
+	LegalLinks LegalLinks          `json:"legalLinks,omitempty"`
 
 	// The date the directory was last modified. Dates and times are in UTC format.
-	ModifiedDate times.JavaTime `json:"modifiedDate,omitempty"`
+	ModifiedDate types.EpochTime `json:"modifiedDate,omitempty"`
 
 	// The GUID of the directory's parent entity. Typically this is the global account.
 	ParentGuid string `json:"parentGuid,omitempty"`
@@ -111,7 +113,7 @@ type Directory struct {
 // Create a directory
 type CreateDirectoryInput struct {
 	// Additional properties of the directory.
-	CustomProperties []CustomProperties `json:"customProperties,omitempty"`
+	CustomProperties []KeyValue `json:"customProperties,omitempty"`
 
 	// A description of the directory.
 	Description string `json:"description,omitempty"`
@@ -144,13 +146,17 @@ type CreateDirectoryInput struct {
 	DirectoryFeatures []string `json:"directoryFeatures,omitempty"`
 
 	// The display name of the directory.
-	DisplayName string `json:"displayName,omitempty"`
+	DisplayName string `json:"displayName,omitempty" required:"true"`
+
+	// The GUID of the directory in which to create the directory.
+	// When empty, the directory is created directly under the global account.
+	ParentGuid string `json:"parentGUID,omitempty"`
 
 	//Relevant only for directories that are enabled to manage their authorizations. The subdomain that becomes part
 	//of the path used to access the authorization tenant of the directory. Must be unique in the defined region.
 	//Use only letters (a-z), digits (0-9), and hyphens (not at start or end). Maximum length is 63 characters.
 	//Cannot be changed after the directory has been created.
-	Subdomain string `json:"subdomain,omitempty"`
+	Subdomain string `json:"subdomain,omitempty" pattern:"^[a-z0-9-]{1,63}$"`
 }
 type CreateDirectoryOutput struct {
 	Directory
@@ -160,11 +166,12 @@ type CreateDirectoryOutput struct {
 }
 
 func (c *AccountsV1) CreateDirectory(ctx context.Context,
-	input *CreateDirectoryInput) (*CreateDirectoryOutput, error) {
-	req, out := c.createDirectoryRequest(ctx, input)
+	input *CreateDirectoryInput, opts ...request.Option) (*CreateDirectoryOutput, error) {
+	req, out := c.CreateDirectoryRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) createDirectoryRequest(ctx context.Context,
+func (c *AccountsV1) CreateDirectoryRequest(ctx context.Context,
 	input *CreateDirectoryInput) (*request.Request, *CreateDirectoryOutput) {
 	op := &request.Operation{
 		Name: "Create Account Directory",
@@ -186,17 +193,17 @@ func (c *AccountsV1) createDirectoryRequest(ctx context.Context,
 // Get a directory
 type GetDirectoryInput struct {
 	//The GUID of the directory for which to get details.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 
 	// The range of authorizations for which to return information.
 	//
 	//any: Returns a directory for which the user has authorizations on any of the subaccounts
 	//(for example, user is a subaccount admin) or Cloud Foundry roles (for example, user is a Cloud Foundry space manager).
 	//(empty value): Returns a directory for which the user has explicit authorization.
-	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations"`
+	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations" json:"-"`
 
 	//Whether to get the contents of the directory, for example the subaccounts it contains.
-	Expand bool `dest:"querystring" dest-name:"expand"`
+	Expand bool `dest:"querystring" dest-name:"expand" json:"-"`
 }
 type GetDirectoryOutput struct {
 	Directory
@@ -206,11 +213,12 @@ type GetDirectoryOutput struct {
 }
 
 func (c *AccountsV1) GetDirectory(ctx context.Context,
-	input *GetDirectoryInput) (*GetDirectoryOutput, error) {
-	req, out := c.getDirectoryRequest(ctx, input)
+	input *GetDirectoryInput, opts ...request.Option) (*GetDirectoryOutput, error) {
+	req, out := c.GetDirectoryRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getDirectoryRequest(ctx context.Context,
+func (c *AccountsV1) GetDirectoryRequest(ctx context.Context,
 	input *GetDirectoryInput) (*request.Request, *GetDirectoryOutput) {
 	op := &request.Operation{
 		Name: "Get Account Directory",
@@ -232,11 +240,11 @@ func (c *AccountsV1) getDirectoryRequest(ctx context.Context,
 // Delete a directory
 type DeleteDirectoryInput struct {
 	//The GUID of the directory to update.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 
 	//Whether to delete the directory even if it contains data. If not set to true,
 	//the request fails when the directory contains data.
-	ForceDelete bool `dest:"querystring" dest-name:"forceDelete"`
+	ForceDelete bool `dest:"querystring" dest-name:"forceDelete" json:"-"`
 }
 type DeleteDirectoryOutput struct {
 	Directory
@@ -246,11 +254,12 @@ type DeleteDirectoryOutput struct {
 }
 
 func (c *AccountsV1) DeleteDirectory(ctx context.Context,
-	input *DeleteDirectoryInput) (*DeleteDirectoryOutput, error) {
-	req, out := c.deleteDirectoryRequest(ctx, input)
+	input *DeleteDirectoryInput, opts ...request.Option) (*DeleteDirectoryOutput, error) {
+	req, out := c.DeleteDirectoryRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) deleteDirectoryRequest(ctx context.Context,
+func (c *AccountsV1) DeleteDirectoryRequest(ctx context.Context,
 	input *DeleteDirectoryInput) (*request.Request, *DeleteDirectoryOutput) {
 	op := &request.Operation{
 		Name: "Delete Account Directory",
@@ -272,10 +281,10 @@ func (c *AccountsV1) deleteDirectoryRequest(ctx context.Context,
 // Update a directory
 type UpdateDirectoryInput struct {
 	//The GUID of the directory to update.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 
-	//Additional Properties of the directory.
-	CustomProperties []CustomProperties `json:"customProperties,omitempty"`
+	//Custom properties to assign, update, and remove from the directory.
+	CustomProperties []UpdateDirectoryProperties `json:"customProperties,omitempty"`
 
 	//The description of the directory for the customer-facing UIs.
 	Description string `json:"description,omitempty"`
@@ -283,6 +292,14 @@ type UpdateDirectoryInput struct {
 	//The new descriptive name of the directory.
 	DisplayName string `json:"displayName,omitempty"`
 }
+
+// Custom properties as key-value pairs to assign, update, and remove from the directory.
+type UpdateDirectoryProperties struct {
+	KeyValue
+
+	//Whether to delete a property according to the provided key.
+	Delete bool `json:"delete,omitempty"`
+}
 type UpdateDirectoryOutput struct {
 	Directory
 
@@ -291,11 +308,12 @@ type UpdateDirectoryOutput struct {
 }
 
 func (c *AccountsV1) UpdateDirectory(ctx context.Context,
-	input *UpdateDirectoryInput) (*UpdateDirectoryOutput, error) {
-	req, out := c.updateDirectoryRequest(ctx, input)
+	input *UpdateDirectoryInput, opts ...request.Option) (*UpdateDirectoryOutput, error) {
+	req, out := c.UpdateDirectoryRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) updateDirectoryRequest(ctx context.Context,
+func (c *AccountsV1) UpdateDirectoryRequest(ctx context.Context,
 	input *UpdateDirectoryInput) (*request.Request, *UpdateDirectoryOutput) {
 	op := &request.Operation{
 		Name: "Update Account Directory",
@@ -317,7 +335,7 @@ func (c *AccountsV1) updateDirectoryRequest(ctx context.Context,
 // Add features to a directory
 type UpdateDirectoryFeaturesInput struct {
 	//The GUID of the directory to update.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 
 	//Additional admins of the directory. Do not add yourself as you are assigned as a directory admin by default.
 	//Use only with directories that are configured to manage their authorizations.
@@ -340,13 +358,13 @@ type UpdateDirectoryFeaturesInput struct {
 	//	[DEFAULT,ENTITLEMENTS,AUTHORIZATIONS]
 	//Enum:
 	//	[ ENTITLEMENTS, AUTHORIZATIONS ]
-	DirectoryFeatures []string `json:"directoryFeatures,omitempty"`
+	DirectoryFeatures []string `json:"directoryFeatures,omitempty" required:"true"`
 
 	//Relevant only for directories that are enabled to manage their authorizations. The subdomain that becomes
 	//part of the path used to access the authorization tenant of the directory. Must be unique within the defined region.
 	//Use only letters (a-z), digits (0-9), and hyphens (not at start or end). Maximum length is 63 characters.
 	//Cannot be changed after the directory has been created.
-	Subdomain string `json:"subdomain,omitempty"`
+	Subdomain string `json:"subdomain,omitempty" pattern:"^[a-z0-9-]{1,63}$"`
 }
 type UpdateDirectoryFeaturesOutput struct {
 	Directory
@@ -356,11 +374,12 @@ type UpdateDirectoryFeaturesOutput struct {
 }
 
 func (c *AccountsV1) UpdateDirectoryFeatures(ctx context.Context,
-	input *UpdateDirectoryFeaturesInput) (*UpdateDirectoryFeaturesOutput, error) {
-	req, out := c.updateDirectoryFeaturesRequest(ctx, input)
+	input *UpdateDirectoryFeaturesInput, opts ...request.Option) (*UpdateDirectoryFeaturesOutput, error) {
+	req, out := c.UpdateDirectoryFeaturesRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) updateDirectoryFeaturesRequest(ctx context.Context,
+func (c *AccountsV1) UpdateDirectoryFeaturesRequest(ctx context.Context,
 	input *UpdateDirectoryFeaturesInput) (*request.Request, *UpdateDirectoryFeaturesOutput) {
 	op := &request.Operation{
 		Name: "Add Feature To Account Directory",
@@ -382,7 +401,7 @@ func (c *AccountsV1) updateDirectoryFeaturesRequest(ctx context.Context,
 // Get directory custom properties
 type GetDirectoryCustomPropertiesInput struct {
 	//The GUID of the directory to update.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 }
 type GetDirectoryCustomPropertiesOutput struct {
 	Value []CustomProperties `json:"value,omitempty"`
@@ -391,18 +410,25 @@ type GetDirectoryCustomPropertiesOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) GetDirectorCustomProperties(ctx context.Context,
-	input *GetDirectoryCustomPropertiesInput) (*GetDirectoryCustomPropertiesOutput, error) {
-	req, out := c.getDirectorCustomPropertiesRequest(ctx, input)
+func (c *AccountsV1) GetDirectoryCustomProperties(ctx context.Context,
+	input *GetDirectoryCustomPropertiesInput, opts ...request.Option) (*GetDirectoryCustomPropertiesOutput, error) {
+	req, out := c.GetDirectoryCustomPropertiesRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getDirectorCustomPropertiesRequest(ctx context.Context,
+
+// Deprecated: use GetDirectoryCustomProperties.
+func (c *AccountsV1) GetDirectorCustomProperties(ctx context.Context,
+	input *GetDirectoryCustomPropertiesInput, opts ...request.Option) (*GetDirectoryCustomPropertiesOutput, error) {
+	return c.GetDirectoryCustomProperties(ctx, input, opts...)
+}
+func (c *AccountsV1) GetDirectoryCustomPropertiesRequest(ctx context.Context,
 	input *GetDirectoryCustomPropertiesInput) (*request.Request, *GetDirectoryCustomPropertiesOutput) {
 	op := &request.Operation{
-		Name: "Get Account Directory Custom Directory",
+		Name: "Get Account Directory Custom Properties",
 		Http: request.HTTP{
 			Method: request.GET,
-			Path:   "/directories/{directoryGUID}/changeDirectoryFeatures",
+			Path:   "/directories/{directoryGUID}/customProperties",
 		},
 	}
 
@@ -0,0 +1,30 @@
+package btpaccounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestDirectoryCustomProperties(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	d := srv.AddDirectory(btpfake.Directory{
+		DisplayName:      "props",
+		CustomProperties: []btpfake.CustomProperty{{Key: "cost-center", Value: "42"}},
+	})
+
+	sess, err := srv.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := New(sess)
+	out, err := svc.GetDirectoryCustomProperties(context.Background(), &GetDirectoryCustomPropertiesInput{DirectoryGuid: d.Guid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Value) != 1 || out.Value[0].Key != "cost-center" {
+		t.Fatalf("unexpected custom properties %+v", out.Value)
+	}
+}
@@ -0,0 +1,194 @@
+package btpaccounts
+
+// EntityState is the state of a global account, directory or subaccount.
+type EntityState string
+
+const (
+	EntityStateStarted                     EntityState = "STARTED"
+	EntityStateCreating                    EntityState = "CREATING"
+	EntityStateUpdating                    EntityState = "UPDATING"
+	EntityStateMoving                      EntityState = "MOVING"
+	EntityStateProcessing                  EntityState = "PROCESSING"
+	EntityStateDeleting                    EntityState = "DELETING"
+	EntityStateOK                          EntityState = "OK"
+	EntityStatePendingReview               EntityState = "PENDING_REVIEW"
+	EntityStateCanceled                    EntityState = "CANCELED"
+	EntityStateCreationFailed              EntityState = "CREATION_FAILED"
+	EntityStateUpdateFailed                EntityState = "UPDATE_FAILED"
+	EntityStateUpdateAccountTypeFailed     EntityState = "UPDATE_ACCOUNT_TYPE_FAILED"
+	EntityStateUpdateDirectoryTypeFailed   EntityState = "UPDATE_DIRECTORY_TYPE_FAILED"
+	EntityStateProcessingFailed            EntityState = "PROCESSING_FAILED"
+	EntityStateDeletionFailed              EntityState = "DELETION_FAILED"
+	EntityStateMoveFailed                  EntityState = "MOVE_FAILED"
+	EntityStateMigrating                   EntityState = "MIGRATING"
+	EntityStateMigrationFailed             EntityState = "MIGRATION_FAILED"
+	EntityStateRollbackMigrationProcessing EntityState = "ROLLBACK_MIGRATION_PROCESSING"
+	EntityStateMigrated                    EntityState = "MIGRATED"
+)
+
+func (EntityState) Values() []EntityState {
+	return []EntityState{
+		EntityStateStarted,
+		EntityStateCreating,
+		EntityStateUpdating,
+		EntityStateMoving,
+		EntityStateProcessing,
+		EntityStateDeleting,
+		EntityStateOK,
+		EntityStatePendingReview,
+		EntityStateCanceled,
+		EntityStateCreationFailed,
+		EntityStateUpdateFailed,
+		EntityStateUpdateAccountTypeFailed,
+		EntityStateUpdateDirectoryTypeFailed,
+		EntityStateProcessingFailed,
+		EntityStateDeletionFailed,
+		EntityStateMoveFailed,
+		EntityStateMigrating,
+		EntityStateMigrationFailed,
+		EntityStateRollbackMigrationProcessing,
+		EntityStateMigrated,
+	}
+}
+
+func (s EntityState) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the entity has left the transitional states, successfully or not.
+func (s EntityState) IsTerminal() bool {
+	if !s.Valid() {
+		return false
+	}
+	switch s {
+	case EntityStateStarted, EntityStateCreating, EntityStateUpdating, EntityStateMoving,
+		EntityStateProcessing, EntityStateDeleting, EntityStateMigrating,
+		EntityStateRollbackMigrationProcessing:
+		return false
+	}
+	return true
+}
+
+func (s EntityState) IsFailure() bool {
+	switch s {
+	case EntityStateCreationFailed, EntityStateUpdateFailed, EntityStateUpdateAccountTypeFailed,
+		EntityStateUpdateDirectoryTypeFailed, EntityStateProcessingFailed,
+		EntityStateDeletionFailed, EntityStateMoveFailed, EntityStateMigrationFailed,
+		EntityStateCanceled:
+		return true
+	}
+	return false
+}
+
+// UsedForProduction tells whether a subaccount is used for production purposes.
+type UsedForProduction string
+
+const (
+	UsedForProductionUnset   UsedForProduction = "UNSET"
+	UsedForProductionUsed    UsedForProduction = "USED_FOR_PRODUCTION"
+	UsedForProductionNotUsed UsedForProduction = "NOT_USED_FOR_PRODUCTION"
+)
+
+func (UsedForProduction) Values() []UsedForProduction {
+	return []UsedForProduction{
+		UsedForProductionUnset,
+		UsedForProductionUsed,
+		UsedForProductionNotUsed,
+	}
+}
+
+func (u UsedForProduction) Valid() bool {
+	for _, v := range u.Values() {
+		if u == v {
+			return true
+		}
+	}
+	return false
+}
+
+// SubAccountOrigin is the origin of the subaccount creation.
+type SubAccountOrigin string
+
+const (
+	SubAccountOriginRegionSetup              SubAccountOrigin = "REGION_SETUP"
+	SubAccountOriginCockpit                  SubAccountOrigin = "COCKPIT"
+	SubAccountOriginMigratedToCPFoundationV2 SubAccountOrigin = "MIGRATED_TO_CP_FOUNDATION_V2"
+	SubAccountOriginDomainDBSync             SubAccountOrigin = "DOMAINDB_SYNC"
+)
+
+func (SubAccountOrigin) Values() []SubAccountOrigin {
+	return []SubAccountOrigin{
+		SubAccountOriginRegionSetup,
+		SubAccountOriginCockpit,
+		SubAccountOriginMigratedToCPFoundationV2,
+		SubAccountOriginDomainDBSync,
+	}
+}
+
+func (o SubAccountOrigin) Valid() bool {
+	for _, v := range o.Values() {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
+// GlobalAccountOrigin is the origin of the global account creation.
+type GlobalAccountOrigin string
+
+const (
+	GlobalAccountOriginOrder                    GlobalAccountOrigin = "ORDER"
+	GlobalAccountOriginOperator                 GlobalAccountOrigin = "OPERATOR"
+	GlobalAccountOriginRegionSetup              GlobalAccountOrigin = "REGION_SETUP"
+	GlobalAccountOriginMigratedToCPFoundationV2 GlobalAccountOrigin = "MIGRATED_TO_CP_FOUNDATION_V2"
+)
+
+func (GlobalAccountOrigin) Values() []GlobalAccountOrigin {
+	return []GlobalAccountOrigin{
+		GlobalAccountOriginOrder,
+		GlobalAccountOriginOperator,
+		GlobalAccountOriginRegionSetup,
+		GlobalAccountOriginMigratedToCPFoundationV2,
+	}
+}
+
+func (o GlobalAccountOrigin) Valid() bool {
+	for _, v := range o.Values() {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ContractStatus is the status of the customer contract of a global account or directory.
+type ContractStatus string
+
+const (
+	ContractStatusActive             ContractStatus = "ACTIVE"
+	ContractStatusPendingTermination ContractStatus = "PENDING_TERMINATION"
+	ContractStatusSuspended          ContractStatus = "SUSPENDED"
+)
+
+func (ContractStatus) Values() []ContractStatus {
+	return []ContractStatus{
+		ContractStatusActive,
+		ContractStatusPendingTermination,
+		ContractStatusSuspended,
+	}
+}
+
+func (c ContractStatus) Valid() bool {
+	for _, v := range c.Values() {
+		if c == v {
+			return true
+		}
+	}
+	return false
+}
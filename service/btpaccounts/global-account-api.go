@@ -2,7 +2,6 @@ package btpaccounts
 
 import (
 	"context"
-	"github.com/nnicora/sap-sdk-go/internal/times"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
 )
@@ -16,11 +15,11 @@ type GetGlobalAccountInput struct {
 	//entities, such as its directories (for example, directory admin), subaccounts (for example, user is a subaccount admin)
 	//or Cloud Foundry roles (for example, user is a Cloud Foundry space manager).
 	//(empty value): Returns a global account for which the user has explicit authorization
-	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations"`
+	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations" json:"-"`
 
 	//If true, returns the structure of the global account including all its children, such as subaccounts and directories,
 	//in the account model. The structure content may vary from user to user and depends on users’ authorizations.
-	Expand bool `dest:"querystring" dest-name:"expand"`
+	Expand bool `dest:"querystring" dest-name:"expand" json:"-"`
 }
 type GlobalAccountOutput struct {
 	//The list of directories associated with the specified global account.
@@ -49,7 +48,7 @@ type GlobalAccountOutput struct {
 	//	is suspended, and the account owner has not yet extended the trial period.
 	//Enum:
 	//	[ ACTIVE, PENDING_TERMINATION, SUSPENDED ]
-	ContractStatus string `json:"contractStatus,omitempty"`
+	ContractStatus ContractStatus `json:"contractStatus,omitempty"`
 
 	//For internal accounts, the cost center that is associated with the global account owner.
 	//A cost center represents a set of users belonging to the same business unit and is charged for
@@ -57,7 +56,7 @@ type GlobalAccountOutput struct {
 	CostCenter string `json:"costCenter,omitempty"`
 
 	//The date the global account was created. Dates and times are in UTC format.
-	CreatedDate times.JavaTime `json:"createdDate,omitempty"`
+	CreatedDate types.EpochTime `json:"createdDate,omitempty"`
 
 	//The ID of the customer as registered in the CRM system.
 	CrmCustomerId string `json:"crmCustomerId,omitempty"`
@@ -95,13 +94,13 @@ type GlobalAccountOutput struct {
 	//	[ STARTED, CREATING, UPDATING, MOVING, PROCESSING, DELETING, OK, PENDING_REVIEW, CANCELED, CREATION_FAILED,
 	//	UPDATE_FAILED, UPDATE_ACCOUNT_TYPE_FAILED, UPDATE_DIRECTORY_TYPE_FAILED, PROCESSING_FAILED, DELETION_FAILED,
 	//	MOVE_FAILED, MIGRATING, MIGRATION_FAILED, ROLLBACK_MIGRATION_PROCESSING, MIGRATED ]
-	EntityState string `json:"entityState,omitempty"`
+	EntityState EntityState `json:"entityState,omitempty"`
 
 	//The planned date that the global account expires. This is the same date as the Contract End Date,
 	//unless a manual adjustment has been made to the actual expiration date of the global account.
 	//Typically, this property is automatically populated only when a formal termination order is received from the CRM system.
 	//From a customer perspective, this date marks the start of the grace period, which is typically 30 days before the actual deletion of the account.
-	ExpiryDate times.JavaTime `json:"expiryDate,omitempty"`
+	ExpiryDate types.EpochTime `json:"expiryDate,omitempty"`
 
 	//The geographic locations from where the global account can be accessed.
 	//
@@ -130,7 +129,7 @@ type GlobalAccountOutput struct {
 	LicenseType string `json:"licenseType,omitempty"`
 
 	//The date the global account was last modified. Dates and times are in UTC format.
-	ModifiedDate times.JavaTime `json:"modifiedDate,omitempty"`
+	ModifiedDate types.EpochTime `json:"modifiedDate,omitempty"`
 
 	//The origin of the account.
 	//
@@ -139,7 +138,7 @@ type GlobalAccountOutput struct {
 	//REGION_SETUP: Created automatically as part of the region setup.
 	//Enum:
 	//	[ ORDER, OPERATOR, REGION_SETUP, MIGRATED_TO_CP_FOUNDATION_V2 ]
-	Origin string `json:"origin,omitempty"`
+	Origin GlobalAccountOrigin `json:"origin,omitempty"`
 
 	//The GUID of the global account's parent entity. Typically this is the global account.
 	ParentGuid string `json:"parentGuid,omitempty"`
@@ -151,7 +150,7 @@ type GlobalAccountOutput struct {
 	ParentType string `json:"parentType,omitempty"`
 
 	// The date that an expired contract was renewed. Dates and times are in UTC format.
-	RenewalDate times.JavaTime `json:"renewalDate,omitempty"`
+	RenewalDate types.EpochTime `json:"renewalDate,omitempty"`
 
 	// For internal accounts, the service for which the global account was created.
 	ServiceId string `json:"serviceId,omitempty"`
@@ -194,11 +193,12 @@ type LegalLinks struct {
 	Privacy string `json:"privacy,omitempty"`
 }
 
-func (c *AccountsV1) GetGlobalAccount(ctx context.Context, input *GetGlobalAccountInput) (*GlobalAccountOutput, error) {
-	req, out := c.getGlobalAccountRequest(ctx, input)
+func (c *AccountsV1) GetGlobalAccount(ctx context.Context, input *GetGlobalAccountInput, opts ...request.Option) (*GlobalAccountOutput, error) {
+	req, out := c.GetGlobalAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getGlobalAccountRequest(ctx context.Context, input *GetGlobalAccountInput) (*request.Request, *GlobalAccountOutput) {
+func (c *AccountsV1) GetGlobalAccountRequest(ctx context.Context, input *GetGlobalAccountInput) (*request.Request, *GlobalAccountOutput) {
 	op := &request.Operation{
 		Name: "Get Global Account",
 		Http: request.HTTP{
@@ -225,11 +225,12 @@ type UpdateGlobalAccountInput struct {
 	DisplayName string `json:"displayName,omitempty"`
 }
 
-func (c *AccountsV1) UpdateGlobalAccount(ctx context.Context, input *UpdateGlobalAccountInput) (*GlobalAccountOutput, error) {
-	req, out := c.updateGlobalAccountRequest(ctx, input)
+func (c *AccountsV1) UpdateGlobalAccount(ctx context.Context, input *UpdateGlobalAccountInput, opts ...request.Option) (*GlobalAccountOutput, error) {
+	req, out := c.UpdateGlobalAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) updateGlobalAccountRequest(ctx context.Context, input *UpdateGlobalAccountInput) (*request.Request, *GlobalAccountOutput) {
+func (c *AccountsV1) UpdateGlobalAccountRequest(ctx context.Context, input *UpdateGlobalAccountInput) (*request.Request, *GlobalAccountOutput) {
 	op := &request.Operation{
 		Name: "Update Global Account",
 		Http: request.HTTP{
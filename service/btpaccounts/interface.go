@@ -0,0 +1,59 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpaccounts
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// AccountsAPI is the interface implemented by AccountsV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpaccountsmock.
+type AccountsAPI interface {
+	GetAccountTree(ctx context.Context, opts ...request.Option) (*AccountNode, error)
+	CreateDirectory(ctx context.Context, input *CreateDirectoryInput, opts ...request.Option) (*CreateDirectoryOutput, error)
+	CreateDirectoryRequest(ctx context.Context, input *CreateDirectoryInput) (*request.Request, *CreateDirectoryOutput)
+	GetDirectory(ctx context.Context, input *GetDirectoryInput, opts ...request.Option) (*GetDirectoryOutput, error)
+	GetDirectoryRequest(ctx context.Context, input *GetDirectoryInput) (*request.Request, *GetDirectoryOutput)
+	DeleteDirectory(ctx context.Context, input *DeleteDirectoryInput, opts ...request.Option) (*DeleteDirectoryOutput, error)
+	DeleteDirectoryRequest(ctx context.Context, input *DeleteDirectoryInput) (*request.Request, *DeleteDirectoryOutput)
+	UpdateDirectory(ctx context.Context, input *UpdateDirectoryInput, opts ...request.Option) (*UpdateDirectoryOutput, error)
+	UpdateDirectoryRequest(ctx context.Context, input *UpdateDirectoryInput) (*request.Request, *UpdateDirectoryOutput)
+	UpdateDirectoryFeatures(ctx context.Context, input *UpdateDirectoryFeaturesInput, opts ...request.Option) (*UpdateDirectoryFeaturesOutput, error)
+	UpdateDirectoryFeaturesRequest(ctx context.Context, input *UpdateDirectoryFeaturesInput) (*request.Request, *UpdateDirectoryFeaturesOutput)
+	GetDirectoryCustomProperties(ctx context.Context, input *GetDirectoryCustomPropertiesInput, opts ...request.Option) (*GetDirectoryCustomPropertiesOutput, error)
+	GetDirectorCustomProperties(ctx context.Context, input *GetDirectoryCustomPropertiesInput, opts ...request.Option) (*GetDirectoryCustomPropertiesOutput, error)
+	GetDirectoryCustomPropertiesRequest(ctx context.Context, input *GetDirectoryCustomPropertiesInput) (*request.Request, *GetDirectoryCustomPropertiesOutput)
+	GetGlobalAccount(ctx context.Context, input *GetGlobalAccountInput, opts ...request.Option) (*GlobalAccountOutput, error)
+	GetGlobalAccountRequest(ctx context.Context, input *GetGlobalAccountInput) (*request.Request, *GlobalAccountOutput)
+	UpdateGlobalAccount(ctx context.Context, input *UpdateGlobalAccountInput, opts ...request.Option) (*GlobalAccountOutput, error)
+	UpdateGlobalAccountRequest(ctx context.Context, input *UpdateGlobalAccountInput) (*request.Request, *GlobalAccountOutput)
+	GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error)
+	GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput)
+	GetSubAccounts(ctx context.Context, input *GetSubAccountsInput, opts ...request.Option) (*GetSubAccountsOutput, error)
+	GetSubAccountsRequest(ctx context.Context, input *GetSubAccountsInput) (*request.Request, *GetSubAccountsOutput)
+	CreateSubAccount(ctx context.Context, input *CreateSubAccountInput, opts ...request.Option) (*CreateSubAccountOutput, error)
+	CreateSubAccountRequest(ctx context.Context, input *CreateSubAccountInput) (*request.Request, *CreateSubAccountOutput)
+	CloneSubAccount(ctx context.Context, input *CloneSubAccountInput, opts ...request.Option) (*CloneSubAccountOutput, error)
+	CloneSubAccountRequest(ctx context.Context, input *CloneSubAccountInput) (*request.Request, *CloneSubAccountOutput)
+	GetSubAccount(ctx context.Context, input *GetSubAccountInput, opts ...request.Option) (*GetSubAccountOutput, error)
+	GetSubAccountRequest(ctx context.Context, input *GetSubAccountInput) (*request.Request, *GetSubAccountOutput)
+	DeleteSubAccount(ctx context.Context, input *DeleteSubAccountInput, opts ...request.Option) (*DeleteSubAccountOutput, error)
+	DeleteSubAccountRequest(ctx context.Context, input *DeleteSubAccountInput) (*request.Request, *DeleteSubAccountOutput)
+	UpdateSubAccount(ctx context.Context, input *UpdateSubAccountInput, opts ...request.Option) (*UpdateSubAccountOutput, error)
+	UpdateSubAccountRequest(ctx context.Context, input *UpdateSubAccountInput) (*request.Request, *UpdateSubAccountOutput)
+	GetSubAccountCustomProperties(ctx context.Context, input *GetCustomPropertiesInput, opts ...request.Option) (*GetCustomPropertiesOutput, error)
+	GetSubAccountCustomPropertiesRequest(ctx context.Context, input *GetCustomPropertiesInput) (*request.Request, *GetCustomPropertiesOutput)
+	MoveManySubAccounts(ctx context.Context, input *MoveManySubAccountsInput, opts ...request.Option) (*MoveManySubAccountsOutput, error)
+	MoveManySubAccountsRequest(ctx context.Context, input *MoveManySubAccountsInput) (*request.Request, *MoveManySubAccountsOutput)
+	MoveSubAccount(ctx context.Context, input *MoveSubAccountInput, opts ...request.Option) (*MoveSubAccountOutput, error)
+	MoveSubAccountRequest(ctx context.Context, input *MoveSubAccountInput) (*request.Request, *MoveSubAccountOutput)
+	GetSubAccountServiceManagementBinding(ctx context.Context, input *GetServiceManagementBindingInput, opts ...request.Option) (*GetServiceManagementBindingOutput, error)
+	GetSubAccountServiceManagementBindingRequest(ctx context.Context, input *GetServiceManagementBindingInput) (*request.Request, *GetServiceManagementBindingOutput)
+	CreateSubAccountServiceManagementBinding(ctx context.Context, input *CreateServiceManagementBindingInput, opts ...request.Option) (*CreateServiceManagementBindingOutput, error)
+	CreateSubAccountServiceManagementBindingRequest(ctx context.Context, input *CreateServiceManagementBindingInput) (*request.Request, *CreateServiceManagementBindingOutput)
+	DeleteSubAccountServiceManagementBinding(ctx context.Context, input *DeleteServiceManagementBindingInput, opts ...request.Option) (*DeleteServiceManagementBindingOutput, error)
+	DeleteSubAccountServiceManagementBindingRequest(ctx context.Context, input *DeleteServiceManagementBindingInput) (*request.Request, *DeleteServiceManagementBindingOutput)
+}
+
+var _ AccountsAPI = (*AccountsV1)(nil)
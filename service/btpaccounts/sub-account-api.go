@@ -14,10 +14,10 @@ type GetSubAccountsInput struct {
 	//any: Returns all global accounts for which the user has authorizations on any of the accounts' entities,
 	//such as its subaccounts (for example, user is a subaccount admin) or spaces (for example, user is a Cloud Foundry space manager).
 	//(empty value): Returns all subaccounts for which the user has explicit authorization on the global account or directory.
-	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations"`
+	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations" json:"-"`
 
 	//Returns only the subaccounts in a given directory. Provide the unique ID of the directory.
-	DirectoryGuid string `dest:"querystring" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"querystring" dest-name:"directoryGUID" json:"-"`
 }
 type GetSubAccountsOutput struct {
 	Value []SubAccount `json:"value,omitempty"`
@@ -26,11 +26,12 @@ type GetSubAccountsOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) GetSubAccounts(ctx context.Context, input *GetSubAccountsInput) (*GetSubAccountsOutput, error) {
-	req, out := c.getSubAccountsRequest(ctx, input)
+func (c *AccountsV1) GetSubAccounts(ctx context.Context, input *GetSubAccountsInput, opts ...request.Option) (*GetSubAccountsOutput, error) {
+	req, out := c.GetSubAccountsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getSubAccountsRequest(ctx context.Context, input *GetSubAccountsInput) (*request.Request, *GetSubAccountsOutput) {
+func (c *AccountsV1) GetSubAccountsRequest(ctx context.Context, input *GetSubAccountsInput) (*request.Request, *GetSubAccountsOutput) {
 	op := &request.Operation{
 		Name: "Get Sub Accounts",
 		Http: request.HTTP{
@@ -62,7 +63,7 @@ type CreateSubAccountInput struct {
 	Description string `json:"description,omitempty"`
 
 	// The display name of the subaccount for customer-facing UIs.
-	DisplayName string `json:"displayName,omitempty"`
+	DisplayName string `json:"displayName,omitempty" required:"true"`
 
 	//The origin of the subaccount creation.
 	//
@@ -70,13 +71,13 @@ type CreateSubAccountInput struct {
 	//COCKPIT: Created in the cockpit.
 	//Enum:
 	//	[ REGION_SETUP, COCKPIT, MIGRATED_TO_CP_FOUNDATION_V2, DOMAINDB_SYNC ]
-	Origin string `json:"origin,omitempty"`
+	Origin SubAccountOrigin `json:"origin,omitempty" enum:"true"`
 
 	//The unique ID subaccount’s parent entity.
 	ParentGuid string `json:"parentGUID,omitempty"`
 
 	//The region in which the subaccount was created.
-	Region string `json:"region,omitempty"`
+	Region string `json:"region,omitempty" required:"true"`
 
 	//Additional admins of the subaccount. Do not add yourself as you are assigned as a subaccount admin by default.
 	//Enter as a valid JSON array containing the list of admin e-mails (as required by your identity provider).
@@ -87,7 +88,7 @@ type CreateSubAccountInput struct {
 	//The subdomain that becomes part of the path used to access the authorization tenant of the subaccount.
 	//Must be unique within the defined region. Use only letters (a-z), digits (0-9), and hyphens (not at start or end).
 	//Maximum length is 63 characters. Cannot be changed after the subaccount has been created. Does not apply to Neo subaccounts.
-	Subdomain string `json:"subdomain,omitempty"`
+	Subdomain string `json:"subdomain,omitempty" required:"true" pattern:"^[a-z0-9-]{1,63}$"`
 
 	//Whether the subaccount is used for production purposes. This flag can help your cloud operator to take appropriate
 	//action when handling incidents that are related to mission-critical accounts in production systems.
@@ -98,7 +99,7 @@ type CreateSubAccountInput struct {
 	//USED_FOR_PRODUCTION: Subaccount is used for production purposes.
 	//Enum:
 	//	[ USED_FOR_PRODUCTION, NOT_USED_FOR_PRODUCTION ]
-	UsedForProduction string `json:"usedForProduction,omitempty"`
+	UsedForProduction UsedForProduction `json:"usedForProduction,omitempty" enum:"true"`
 }
 type CreateSubAccountOutput struct {
 	SubAccount
@@ -107,11 +108,12 @@ type CreateSubAccountOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) CreateSubAccount(ctx context.Context, input *CreateSubAccountInput) (*CreateSubAccountOutput, error) {
-	req, out := c.createSubAccountRequest(ctx, input)
+func (c *AccountsV1) CreateSubAccount(ctx context.Context, input *CreateSubAccountInput, opts ...request.Option) (*CreateSubAccountOutput, error) {
+	req, out := c.CreateSubAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) createSubAccountRequest(ctx context.Context, input *CreateSubAccountInput) (*request.Request, *CreateSubAccountOutput) {
+func (c *AccountsV1) CreateSubAccountRequest(ctx context.Context, input *CreateSubAccountInput) (*request.Request, *CreateSubAccountOutput) {
 	op := &request.Operation{
 		Name: "Create Sub Account",
 		Http: request.HTTP{
@@ -131,7 +133,7 @@ func (c *AccountsV1) createSubAccountRequest(ctx context.Context, input *CreateS
 // POST /accounts/v1/subaccounts/clone/{sourceSubaccountGUID}
 // Clone a Neo subaccount
 type CloneSubAccountInput struct {
-	SourceSubAccountGuid string `dest:"uri" dest-name:"sourceSubaccountGUID"`
+	SourceSubAccountGuid string `dest:"uri" dest-name:"sourceSubaccountGUID" json:"-"`
 
 	//Enables the subaccount to use beta services and applications. Not to be used in a production environment.
 	//Cannot be reverted once set. Any use of beta functionality is at the customer's own risk, and SAP shall not be
@@ -148,7 +150,7 @@ type CloneSubAccountInput struct {
 	Description string `json:"description,omitempty"`
 
 	// The display name of the subaccount for customer-facing UIs.
-	DisplayName string `json:"displayName,omitempty"`
+	DisplayName string `json:"displayName,omitempty" required:"true"`
 
 	//The origin of the subaccount creation.
 	//
@@ -156,13 +158,13 @@ type CloneSubAccountInput struct {
 	//COCKPIT: Created in the cockpit.
 	//Enum:
 	//	[ REGION_SETUP, COCKPIT, MIGRATED_TO_CP_FOUNDATION_V2, DOMAINDB_SYNC ]
-	Origin string `json:"origin,omitempty"`
+	Origin SubAccountOrigin `json:"origin,omitempty" enum:"true"`
 
 	//The unique ID subaccount’s parent entity.
 	ParentGuid string `json:"parentGUID,omitempty"`
 
 	//The region in which the subaccount was created.
-	Region string `json:"region,omitempty"`
+	Region string `json:"region,omitempty" required:"true"`
 
 	//Additional admins of the subaccount. Do not add yourself as you are assigned as a subaccount admin by default.
 	//Enter as a valid JSON array containing the list of admin e-mails (as required by your identity provider).
@@ -173,7 +175,7 @@ type CloneSubAccountInput struct {
 	//The subdomain that becomes part of the path used to access the authorization tenant of the subaccount.
 	//Must be unique within the defined region. Use only letters (a-z), digits (0-9), and hyphens (not at start or end).
 	//Maximum length is 63 characters. Cannot be changed after the subaccount has been created. Does not apply to Neo subaccounts.
-	Subdomain string `json:"subdomain,omitempty"`
+	Subdomain string `json:"subdomain,omitempty" pattern:"^[a-z0-9-]{1,63}$"`
 
 	//Whether the subaccount is used for production purposes. This flag can help your cloud operator to take appropriate
 	//action when handling incidents that are related to mission-critical accounts in production systems.
@@ -184,7 +186,7 @@ type CloneSubAccountInput struct {
 	//USED_FOR_PRODUCTION: Subaccount is used for production purposes.
 	//Enum:
 	//	[ USED_FOR_PRODUCTION, NOT_USED_FOR_PRODUCTION ]
-	UsedForProduction string `json:"usedForProduction,omitempty"`
+	UsedForProduction UsedForProduction `json:"usedForProduction,omitempty" enum:"true"`
 }
 type CloneSubAccountOutput struct {
 	SubAccount
@@ -193,11 +195,12 @@ type CloneSubAccountOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) CloneSubAccount(ctx context.Context, input *CloneSubAccountInput) (*CloneSubAccountOutput, error) {
-	req, out := c.cloneSubAccountRequest(ctx, input)
+func (c *AccountsV1) CloneSubAccount(ctx context.Context, input *CloneSubAccountInput, opts ...request.Option) (*CloneSubAccountOutput, error) {
+	req, out := c.CloneSubAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) cloneSubAccountRequest(ctx context.Context, input *CloneSubAccountInput) (*request.Request, *CloneSubAccountOutput) {
+func (c *AccountsV1) CloneSubAccountRequest(ctx context.Context, input *CloneSubAccountInput) (*request.Request, *CloneSubAccountOutput) {
 	op := &request.Operation{
 		Name: "Clone Sub Account",
 		Http: request.HTTP{
@@ -218,14 +221,14 @@ func (c *AccountsV1) cloneSubAccountRequest(ctx context.Context, input *CloneSub
 // Get a subaccount
 type GetSubAccountInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 
 	//The range of authorizations for which to return information.
 	//
 	//any: Returns all global accounts for which the user has authorizations on any of the accounts' entities, such as
 	//	its subaccounts (for example, user is a subaccount admin) or spaces (for example, user is a Cloud Foundry space manager).
 	//(empty value): Returns all subaccounts for which the user has explicit authorization on the global account or directory.
-	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations"`
+	DerivedAuthorizations string `dest:"querystring" dest-name:"derivedAuthorizations" json:"-"`
 }
 type GetSubAccountOutput struct {
 	SubAccount
@@ -234,11 +237,12 @@ type GetSubAccountOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) GetSubAccount(ctx context.Context, input *GetSubAccountInput) (*GetSubAccountOutput, error) {
-	req, out := c.getSubAccountRequest(ctx, input)
+func (c *AccountsV1) GetSubAccount(ctx context.Context, input *GetSubAccountInput, opts ...request.Option) (*GetSubAccountOutput, error) {
+	req, out := c.GetSubAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getSubAccountRequest(ctx context.Context, input *GetSubAccountInput) (*request.Request, *GetSubAccountOutput) {
+func (c *AccountsV1) GetSubAccountRequest(ctx context.Context, input *GetSubAccountInput) (*request.Request, *GetSubAccountOutput) {
 	op := &request.Operation{
 		Name: "Get Sub Account",
 		Http: request.HTTP{
@@ -259,7 +263,7 @@ func (c *AccountsV1) getSubAccountRequest(ctx context.Context, input *GetSubAcco
 // Delete a subaccount
 type DeleteSubAccountInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 }
 type DeleteSubAccountOutput struct {
 	SubAccount
@@ -268,11 +272,12 @@ type DeleteSubAccountOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) DeleteSubAccount(ctx context.Context, input *DeleteSubAccountInput) (*DeleteSubAccountOutput, error) {
-	req, out := c.deleteSubAccountRequest(ctx, input)
+func (c *AccountsV1) DeleteSubAccount(ctx context.Context, input *DeleteSubAccountInput, opts ...request.Option) (*DeleteSubAccountOutput, error) {
+	req, out := c.DeleteSubAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) deleteSubAccountRequest(ctx context.Context, input *DeleteSubAccountInput) (*request.Request, *DeleteSubAccountOutput) {
+func (c *AccountsV1) DeleteSubAccountRequest(ctx context.Context, input *DeleteSubAccountInput) (*request.Request, *DeleteSubAccountOutput) {
 	op := &request.Operation{
 		Name: "Delete Sub Account",
 		Http: request.HTTP{
@@ -293,7 +298,7 @@ func (c *AccountsV1) deleteSubAccountRequest(ctx context.Context, input *DeleteS
 // Update a subaccount
 type UpdateSubAccountInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 
 	//Enables the subaccount to use beta services and applications. Not to be used in a production environment.
 	//Cannot be reverted once set. Any use of beta functionality is at the customer's own risk, and SAP shall not be
@@ -304,7 +309,7 @@ type UpdateSubAccountInput struct {
 	CustomProperties  []UpdateSubAccountProperties `json:"customProperties,omitempty"`
 	Description       string                       `json:"description,omitempty"`
 	DisplayName       string                       `json:"displayName,omitempty"`
-	UsedForProduction string                       `json:"usedForProduction,omitempty"`
+	UsedForProduction UsedForProduction            `json:"usedForProduction,omitempty" enum:"true"`
 }
 
 //Custom properties as key-value pairs to assign, update, and remove from the subaccount.
@@ -321,11 +326,12 @@ type UpdateSubAccountOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) UpdateSubAccount(ctx context.Context, input *UpdateSubAccountInput) (*UpdateSubAccountOutput, error) {
-	req, out := c.updateSubAccountRequest(ctx, input)
+func (c *AccountsV1) UpdateSubAccount(ctx context.Context, input *UpdateSubAccountInput, opts ...request.Option) (*UpdateSubAccountOutput, error) {
+	req, out := c.UpdateSubAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) updateSubAccountRequest(ctx context.Context, input *UpdateSubAccountInput) (*request.Request, *UpdateSubAccountOutput) {
+func (c *AccountsV1) UpdateSubAccountRequest(ctx context.Context, input *UpdateSubAccountInput) (*request.Request, *UpdateSubAccountOutput) {
 	op := &request.Operation{
 		Name: "Update Sub Account",
 		Http: request.HTTP{
@@ -346,7 +352,7 @@ func (c *AccountsV1) updateSubAccountRequest(ctx context.Context, input *UpdateS
 // Get custom properties for a subaccount
 type GetCustomPropertiesInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 }
 type GetCustomPropertiesOutput struct {
 	Value []CustomProperties `json:"value,omitempty"`
@@ -355,11 +361,12 @@ type GetCustomPropertiesOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) GetSubAccountCustomProperties(ctx context.Context, input *GetCustomPropertiesInput) (*GetCustomPropertiesOutput, error) {
-	req, out := c.getSubAccountCustomPropertiesRequest(ctx, input)
+func (c *AccountsV1) GetSubAccountCustomProperties(ctx context.Context, input *GetCustomPropertiesInput, opts ...request.Option) (*GetCustomPropertiesOutput, error) {
+	req, out := c.GetSubAccountCustomPropertiesRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getSubAccountCustomPropertiesRequest(ctx context.Context, input *GetCustomPropertiesInput) (*request.Request, *GetCustomPropertiesOutput) {
+func (c *AccountsV1) GetSubAccountCustomPropertiesRequest(ctx context.Context, input *GetCustomPropertiesInput) (*request.Request, *GetCustomPropertiesOutput) {
 	op := &request.Operation{
 		Name: "Get Sub Account Custom Properties",
 		Http: request.HTTP{
@@ -381,7 +388,7 @@ func (c *AccountsV1) getSubAccountCustomPropertiesRequest(ctx context.Context, i
 
 type MoveManySubAccountsInput struct {
 	//Details of which subaccounts to move and where to move them to. All subaccounts must be moved to the same location.
-	SubAccountsToMove []MoveSubAccountsRequestPayload `json:"subaccountsToMoveCollection,omitempty"`
+	SubAccountsToMove []MoveSubAccountsRequestPayload `json:"subaccountsToMoveCollection,omitempty" required:"true"`
 }
 type MoveSubAccountsRequestPayload struct {
 	//The GUID of the current location of the subaccounts. If empty, then GUID of root global account is used.
@@ -401,11 +408,12 @@ type MoveManySubAccountsOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) MoveManySubAccounts(ctx context.Context, input *MoveManySubAccountsInput) (*MoveManySubAccountsOutput, error) {
-	req, out := c.moveManySubAccountsRequest(ctx, input)
+func (c *AccountsV1) MoveManySubAccounts(ctx context.Context, input *MoveManySubAccountsInput, opts ...request.Option) (*MoveManySubAccountsOutput, error) {
+	req, out := c.MoveManySubAccountsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) moveManySubAccountsRequest(ctx context.Context, input *MoveManySubAccountsInput) (*request.Request, *MoveManySubAccountsOutput) {
+func (c *AccountsV1) MoveManySubAccountsRequest(ctx context.Context, input *MoveManySubAccountsInput) (*request.Request, *MoveManySubAccountsOutput) {
 	op := &request.Operation{
 		Name: "Move Many Sub Accounts",
 		Http: request.HTTP{
@@ -426,11 +434,11 @@ func (c *AccountsV1) moveManySubAccountsRequest(ctx context.Context, input *Move
 // Move a subaccount
 type MoveSubAccountInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 
 	//The GUID of the new location of the subaccount. To move to a directory, enter the GUID of the directory.
 	//To move out of a directory to the root global account, enter the GUID of the global account.
-	TargetAccountGuid string `json:"targetAccountGUID,omitempty"`
+	TargetAccountGuid string `json:"targetAccountGUID,omitempty" required:"true"`
 }
 
 type MoveSubAccountOutput struct {
@@ -440,11 +448,12 @@ type MoveSubAccountOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *AccountsV1) MoveSubAccount(ctx context.Context, input *MoveSubAccountInput) (*MoveSubAccountOutput, error) {
-	req, out := c.moveSubAccountRequest(ctx, input)
+func (c *AccountsV1) MoveSubAccount(ctx context.Context, input *MoveSubAccountInput, opts ...request.Option) (*MoveSubAccountOutput, error) {
+	req, out := c.MoveSubAccountRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) moveSubAccountRequest(ctx context.Context, input *MoveSubAccountInput) (*request.Request, *MoveSubAccountOutput) {
+func (c *AccountsV1) MoveSubAccountRequest(ctx context.Context, input *MoveSubAccountInput) (*request.Request, *MoveSubAccountOutput) {
 	op := &request.Operation{
 		Name: "Move Many Sub Accounts",
 		Http: request.HTTP{
@@ -465,7 +474,7 @@ func (c *AccountsV1) moveSubAccountRequest(ctx context.Context, input *MoveSubAc
 // Get a Service Management binding
 type GetServiceManagementBindingInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 }
 
 type GetServiceManagementBindingOutput struct {
@@ -494,11 +503,12 @@ type ServiceManagementBinding struct {
 }
 
 func (c *AccountsV1) GetSubAccountServiceManagementBinding(ctx context.Context,
-	input *GetServiceManagementBindingInput) (*GetServiceManagementBindingOutput, error) {
-	req, out := c.getSubAccountServiceManagementBindingRequest(ctx, input)
+	input *GetServiceManagementBindingInput, opts ...request.Option) (*GetServiceManagementBindingOutput, error) {
+	req, out := c.GetSubAccountServiceManagementBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) getSubAccountServiceManagementBindingRequest(ctx context.Context,
+func (c *AccountsV1) GetSubAccountServiceManagementBindingRequest(ctx context.Context,
 	input *GetServiceManagementBindingInput) (*request.Request, *GetServiceManagementBindingOutput) {
 	op := &request.Operation{
 		Name: "Get Sub Account Service Management Bindings",
@@ -520,7 +530,7 @@ func (c *AccountsV1) getSubAccountServiceManagementBindingRequest(ctx context.Co
 // Create a Service Management binding
 type CreateServiceManagementBindingInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 }
 type CreateServiceManagementBindingOutput struct {
 	ServiceManagementBinding
@@ -530,11 +540,12 @@ type CreateServiceManagementBindingOutput struct {
 }
 
 func (c *AccountsV1) CreateSubAccountServiceManagementBinding(ctx context.Context,
-	input *CreateServiceManagementBindingInput) (*CreateServiceManagementBindingOutput, error) {
-	req, out := c.createSubAccountServiceManagementBindingRequest(ctx, input)
+	input *CreateServiceManagementBindingInput, opts ...request.Option) (*CreateServiceManagementBindingOutput, error) {
+	req, out := c.CreateSubAccountServiceManagementBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) createSubAccountServiceManagementBindingRequest(ctx context.Context,
+func (c *AccountsV1) CreateSubAccountServiceManagementBindingRequest(ctx context.Context,
 	input *CreateServiceManagementBindingInput) (*request.Request, *CreateServiceManagementBindingOutput) {
 	op := &request.Operation{
 		Name: "Create Sub Account Service Management Bindings",
@@ -556,7 +567,7 @@ func (c *AccountsV1) createSubAccountServiceManagementBindingRequest(ctx context
 // Delete a Service Management binding
 type DeleteServiceManagementBindingInput struct {
 	//The GUID of the subaccount for which to get details.
-	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"uri" dest-name:"subaccountGUID" json:"-"`
 }
 
 type DeleteServiceManagementBindingOutput struct {
@@ -565,11 +576,12 @@ type DeleteServiceManagementBindingOutput struct {
 }
 
 func (c *AccountsV1) DeleteSubAccountServiceManagementBinding(ctx context.Context,
-	input *DeleteServiceManagementBindingInput) (*DeleteServiceManagementBindingOutput, error) {
-	req, out := c.deleteSubAccountServiceManagementBindingRequest(ctx, input)
+	input *DeleteServiceManagementBindingInput, opts ...request.Option) (*DeleteServiceManagementBindingOutput, error) {
+	req, out := c.DeleteSubAccountServiceManagementBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *AccountsV1) deleteSubAccountServiceManagementBindingRequest(ctx context.Context,
+func (c *AccountsV1) DeleteSubAccountServiceManagementBindingRequest(ctx context.Context,
 	input *DeleteServiceManagementBindingInput) (*request.Request, *DeleteServiceManagementBindingOutput) {
 	op := &request.Operation{
 		Name: "Delete Sub Account Service Management Bindings",
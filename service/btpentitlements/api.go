@@ -3,7 +3,6 @@ package btpentitlements
 import (
 	"context"
 	"fmt"
-	"github.com/nnicora/sap-sdk-go/internal/times"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
 )
@@ -11,10 +10,10 @@ import (
 // GET /entitlements/v1/globalAccountAllowedDataCenters
 // Get available data centers
 type DataCentersInput struct {
-	AcceptLanguage string `dest:"header" dest-name:"Accept-Language"`
+	AcceptLanguage string `dest:"header" dest-name:"Accept-Language" json:"-"`
 
 	//Region for which to get data centers.
-	Region string `dest:"querystring" dest-name:"region"`
+	Region string `dest:"querystring" dest-name:"region" json:"-"`
 }
 type DataCentersOutput struct {
 	//Contains information about the available data centers for a specified global account.
@@ -59,13 +58,27 @@ type DataCenter struct {
 
 	//The domain of the data center
 	Domain string `json:"domain,omitempty"`
+
+	//The geographic locations from where the global account can be accessed.
+	//
+	//STANDARD: The global account can be accessed from any geographic location.
+	//EU_ACCESS: The global account can be accessed only within locations in the EU.
+	//BACKWARD_COMPLIANT_EU: The global account can be accessed from any geographic location,
+	//	but the data centers follow the EU Access restrictions as well.
+	//Enum:
+	//	[ STANDARD, EU_ACCESS, BACKWARD_COMPLIANT_EU ]
+	GeoAccess string `json:"geoAccess,omitempty"`
+
+	//Whether the data center is restricted to specific global accounts.
+	Restricted bool `json:"restricted,omitempty"`
 }
 
-func (c *EntitlementsV1) GetDataCenters(ctx context.Context) (*DataCentersOutput, error) {
-	req, out := c.getDataCentersRequest(ctx, nil)
+func (c *EntitlementsV1) GetDataCenters(ctx context.Context, opts ...request.Option) (*DataCentersOutput, error) {
+	req, out := c.GetDataCentersRequest(ctx, nil)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) getDataCentersRequest(ctx context.Context, input *DataCentersInput) (*request.Request, *DataCentersOutput) {
+func (c *EntitlementsV1) GetDataCentersRequest(ctx context.Context, input *DataCentersInput) (*request.Request, *DataCentersOutput) {
 	op := &request.Operation{
 		Name: "Regions for Global Account",
 		Http: request.HTTP{
@@ -82,8 +95,8 @@ func (c *EntitlementsV1) getDataCentersRequest(ctx context.Context, input *DataC
 	return c.newRequest(ctx, op, input, output), output
 }
 
-func (e *EntitlementsV1) GetProvidersRegions(ctx context.Context) (map[string][]string, error) {
-	dcs, err := e.GetDataCenters(ctx)
+func (e *EntitlementsV1) GetProvidersRegions(ctx context.Context, opts ...request.Option) (map[string][]string, error) {
+	dcs, err := e.GetDataCenters(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +111,8 @@ func (e *EntitlementsV1) GetProvidersRegions(ctx context.Context) (map[string][]
 	}
 	return providers, nil
 }
-func (e *EntitlementsV1) GetProviderRegions(ctx context.Context, provider string) ([]string, error) {
-	providers, err := e.GetProvidersRegions(ctx)
+func (e *EntitlementsV1) GetProviderRegions(ctx context.Context, provider string, opts ...request.Option) ([]string, error) {
+	providers, err := e.GetProvidersRegions(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -109,15 +122,15 @@ func (e *EntitlementsV1) GetProviderRegions(ctx context.Context, provider string
 // GET /entitlements/v1/globalAccountAssignments
 // Get available data centers
 type GlobalAccountAssignmentsInput struct {
-	AcceptLanguage string `dest:"header" dest-name:"Accept-Language"`
+	AcceptLanguage string `dest:"header" dest-name:"Accept-Language" json:"-"`
 
 	//Specify if to include also services that are automatically assigned to a subaccount when the subaccount is created.
 	//	Default is false.
-	IncludeAutoManagedPlans bool `dest:"querystring" dest-name:"includeAutoManagedPlans"`
+	IncludeAutoManagedPlans bool `dest:"querystring" dest-name:"includeAutoManagedPlans" json:"-"`
 
 	//Use the parameter to specify for which subaccount to view assigned entitlements.
 	//If left empty, the API returns the entitlements for the global account and all its subaccounts.
-	SubAccountGuid string `dest:"querystring" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"querystring" dest-name:"subaccountGUID" json:"-"`
 }
 type GlobalAccountAssignmentsOutput struct {
 	//Services entitled to global account, its directories and subaccounts.
@@ -133,11 +146,12 @@ type GlobalAccountAssignmentsOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *EntitlementsV1) GetGlobalAccountAssignments(ctx context.Context, input *GlobalAccountAssignmentsInput) (*GlobalAccountAssignmentsOutput, error) {
-	req, out := c.getGlobalAccountAssignmentsRequest(ctx, input)
+func (c *EntitlementsV1) GetGlobalAccountAssignments(ctx context.Context, input *GlobalAccountAssignmentsInput, opts ...request.Option) (*GlobalAccountAssignmentsOutput, error) {
+	req, out := c.GetGlobalAccountAssignmentsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) getGlobalAccountAssignmentsRequest(ctx context.Context, input *GlobalAccountAssignmentsInput) (*request.Request, *GlobalAccountAssignmentsOutput) {
+func (c *EntitlementsV1) GetGlobalAccountAssignmentsRequest(ctx context.Context, input *GlobalAccountAssignmentsInput) (*request.Request, *GlobalAccountAssignmentsOutput) {
 	op := &request.Operation{
 		Name: "Get Global Account Assignments",
 		Http: request.HTTP{
@@ -158,14 +172,14 @@ func (c *EntitlementsV1) getGlobalAccountAssignmentsRequest(ctx context.Context,
 // Get all the entitlements and quota assignments
 type GetAssignmentsInput struct {
 	//The ID of the directory for which to show the entitlements and quota assignments.
-	DirectoryGuid string `dest:"querystring" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"querystring" dest-name:"directoryGUID" json:"-"`
 
 	//Specify if to include also services that are automatically entitled to a global account when the global account is created.
 	//Default is false.
-	IncludeAutoManagedPlans bool `dest:"querystring" dest-name:"includeAutoManagedPlans"`
+	IncludeAutoManagedPlans bool `dest:"querystring" dest-name:"includeAutoManagedPlans" json:"-"`
 
 	//The ID of the subaccount for which to show the entitlements and quota assignments.
-	SubAccountGuid string `dest:"querystring" dest-name:"subaccountGUID"`
+	SubAccountGuid string `dest:"querystring" dest-name:"subaccountGUID" json:"-"`
 }
 type GetAssignmentsOutput struct {
 	//Services entitled to global account, its directories and subaccounts.
@@ -267,7 +281,7 @@ type AssignedServicePlan struct {
 	//ENVIRONMENT: An environment service; for example, Cloud Foundry.
 	//Enum:
 	//	[ PLATFORM, SERVICE, ELASTIC_SERVICE, ELASTIC_LIMITED, APPLICATION, QUOTA_BASED_APPLICATION, ENVIRONMENT ]
-	Category string `json:"category,omitempty"`
+	Category types.ServiceCategory `json:"category,omitempty"`
 
 	//Whether the service plan is a beta feature.
 	Beta bool `json:"beta,omitempty"`
@@ -328,7 +342,7 @@ type AssignedServicePlanSubAccount struct {
 	//OK: The CRUD operation or series of operations completed successfully.
 	//Enum:
 	//	[ STARTED, PROCESSING, PROCESSING_FAILED, OK ]
-	EntityState string `json:"entityState,omitempty"`
+	EntityState AssignmentState `json:"entityState,omitempty"`
 
 	//Information about the current state.
 	StateMessage string `json:"stateMessage,omitempty"`
@@ -343,10 +357,10 @@ type AssignedServicePlanSubAccount struct {
 	AutoDistributeAmount int32 `json:"autoDistributeAmount,omitempty"`
 
 	//Date the subaccount has been created. Dates and times are in UTC format.
-	CreatedDate times.JavaTime `json:"createdDate,omitempty"`
+	CreatedDate types.EpochTime `json:"createdDate,omitempty"`
 
 	//Date the subaccount has been modified. Dates and times are in UTC format.
-	ModifiedDate times.JavaTime `json:"modifiedDate,omitempty"`
+	ModifiedDate types.EpochTime `json:"modifiedDate,omitempty"`
 
 	//Global account resource details
 	Resources []Resource `json:"resources,omitempty"`
@@ -453,7 +467,7 @@ type ServicePlan struct {
 	//ENVIRONMENT: An environment service; for example, Cloud Foundry.
 	//Enum:
 	//	[ PLATFORM, SERVICE, ELASTIC_SERVICE, ELASTIC_LIMITED, APPLICATION, QUOTA_BASED_APPLICATION, ENVIRONMENT ]
-	Category string `json:"category,omitempty"`
+	Category types.ServiceCategory `json:"category,omitempty"`
 
 	//Relevant entitlements for the source that added the product.
 	SourceEntitlements []SourceEntitlement `json:"sourceEntitlements,omitempty"`
@@ -510,11 +524,12 @@ type Resource struct {
 	Data interface{} `json:"resourceData,omitempty"`
 }
 
-func (c *EntitlementsV1) GetAssignments(ctx context.Context, input *GetAssignmentsInput) (*GetAssignmentsOutput, error) {
-	req, out := c.getAssignmentsRequest(ctx, input)
+func (c *EntitlementsV1) GetAssignments(ctx context.Context, input *GetAssignmentsInput, opts ...request.Option) (*GetAssignmentsOutput, error) {
+	req, out := c.GetAssignmentsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) getAssignmentsRequest(ctx context.Context, input *GetAssignmentsInput) (*request.Request, *GetAssignmentsOutput) {
+func (c *EntitlementsV1) GetAssignmentsRequest(ctx context.Context, input *GetAssignmentsInput) (*request.Request, *GetAssignmentsOutput) {
 	op := &request.Operation{
 		Name: "Get Entitlements",
 		Http: request.HTTP{
@@ -537,14 +552,14 @@ type UpdateSubAccountServicePlanInput struct {
 	//The details of entitlement's name, plan, amount and subaccount GUIDs to assign to a subaccount. The entitlement
 	//can be a service, multitenant application, or environment. Note that some environments, such as Cloud Foundry,
 	//are available by default to all subaccounts, and therefore are not displayed as entitlements.
-	SubAccountServicePlans []SubAccountServicePlan `json:"subaccountServicePlans,omitempty"`
+	SubAccountServicePlans []SubAccountServicePlan `json:"subaccountServicePlans,omitempty" required:"true"`
 }
 type SubAccountServicePlan struct {
 	//The technical name of the entitlement to assign to a subaccount.
-	ServiceName string `json:"serviceName,omitempty"`
+	ServiceName string `json:"serviceName,omitempty" required:"true"`
 
 	//The technical name of the entitlement's plan.
-	ServicePlanName string `json:"servicePlanName,omitempty"`
+	ServicePlanName string `json:"servicePlanName,omitempty" required:"true"`
 
 	//List of assigned entitlements and their specifications.
 	AssignmentInfo []AssignmentInfo `json:"assignmentInfo,omitempty"`
@@ -571,11 +586,12 @@ type UpdateSubAccountServicePlanOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *EntitlementsV1) UpdateSubAccountServicePlan(ctx context.Context, input *UpdateSubAccountServicePlanInput) (*UpdateSubAccountServicePlanOutput, error) {
-	req, out := c.updateUpdateSubAccountServicePlanRequest(ctx, input)
+func (c *EntitlementsV1) UpdateSubAccountServicePlan(ctx context.Context, input *UpdateSubAccountServicePlanInput, opts ...request.Option) (*UpdateSubAccountServicePlanOutput, error) {
+	req, out := c.UpdateSubAccountServicePlanRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) updateUpdateSubAccountServicePlanRequest(ctx context.Context, input *UpdateSubAccountServicePlanInput) (*request.Request, *UpdateSubAccountServicePlanOutput) {
+func (c *EntitlementsV1) UpdateSubAccountServicePlanRequest(ctx context.Context, input *UpdateSubAccountServicePlanInput) (*request.Request, *UpdateSubAccountServicePlanOutput) {
 	op := &request.Operation{
 		Name: "Update Sub Account ServicePlan",
 		Http: request.HTTP{
@@ -608,12 +624,12 @@ func (c *EntitlementsV1) updateUpdateSubAccountServicePlanRequest(ctx context.Co
 // Assign or update an entitlement in a directory
 type UpdateDirectoryEntitlementsInput struct {
 	//The unique ID of the directory to which the entitlement is assigned.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 
 	//JSON object that contains the specifications of assignment, such as the name of the assigned plan, the quantity
 	//to distribute, and whether to distribute the quota and how much to subaccounts that currently exist in the
 	//directory and to subaccounts that will added to the directory in the future.
-	DirectoryEntitlements []DirectoryEntitlement `json:"entitlements,omitempty"`
+	DirectoryEntitlements []DirectoryEntitlement `json:"entitlements,omitempty" required:"true"`
 }
 type DirectoryEntitlement struct {
 	//The quantity of the plan to assign to the specified directory. Relevant and mandatory only for plans that have a
@@ -621,14 +637,14 @@ type DirectoryEntitlement struct {
 	Amount *uint `json:"amount,omitempty"`
 
 	//The technical name of the entitlement to assign to the directory.
-	Plan string `json:"plan,omitempty"`
+	Plan string `json:"plan,omitempty" required:"true"`
 
 	//Whether to allocate the plan to the to the specified directory without quantity restrictions.
 	//Relevant and mandatory only for plans that don't have a numeric quota. Do not use if amount is specified.
 	Enable *bool `json:"enable,omitempty"`
 
 	//The technical name of the entitlement (service, application, environment) to assign.
-	Service string `json:"service,omitempty"`
+	Service string `json:"service,omitempty" required:"true"`
 
 	//Whether to assign the plan with the quota specified in autoDistributeAmount to subaccounts currently located in
 	//the specified directory. For entitlements without a numeric quota, such as multitenant apps, the plan is assigned
@@ -654,11 +670,12 @@ type UpdateDirectoryEntitlementsOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *EntitlementsV1) UpdateDirectoryEntitlements(ctx context.Context, input *UpdateDirectoryEntitlementsInput) (*UpdateDirectoryEntitlementsOutput, error) {
-	req, out := c.updateDirectoryEntitlementsRequest(ctx, input)
+func (c *EntitlementsV1) UpdateDirectoryEntitlements(ctx context.Context, input *UpdateDirectoryEntitlementsInput, opts ...request.Option) (*UpdateDirectoryEntitlementsOutput, error) {
+	req, out := c.UpdateDirectoryEntitlementsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) updateDirectoryEntitlementsRequest(ctx context.Context, input *UpdateDirectoryEntitlementsInput) (*request.Request, *UpdateDirectoryEntitlementsOutput) {
+func (c *EntitlementsV1) UpdateDirectoryEntitlementsRequest(ctx context.Context, input *UpdateDirectoryEntitlementsInput) (*request.Request, *UpdateDirectoryEntitlementsOutput) {
 	op := &request.Operation{
 		Name: "Update Directory Entitlements",
 		Http: request.HTTP{
@@ -679,7 +696,7 @@ func (c *EntitlementsV1) updateDirectoryEntitlementsRequest(ctx context.Context,
 // Update an existing entitlement in a directory
 /*type PatchDirectoryEntitlementInput struct {
 	//The unique ID of the directory to which the entitlement is assigned.
-	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID"`
+	DirectoryGuid string `dest:"uri" dest-name:"directoryGUID" json:"-"`
 
 	//JSON object that contains the specifications of an assignment, such as the name of the assigned plan and whether
 	//to distribute the quota and how much to subaccounts that currently exist in the directory and to subaccounts that
@@ -717,11 +734,12 @@ type UpdateDirectoryAssignmentOutput struct {
 	types.StatusAndBodyFromResponse
 }
 
-func (c *EntitlementsV1) UpdateDirectoryAssignment(ctx context.Context, input *PatchDirectoryEntitlementInput) (*UpdateDirectoryAssignmentOutput, error) {
-	req, out := c.updateUpdateDirectoryAssignmentRequest(ctx, input)
+func (c *EntitlementsV1) UpdateDirectoryAssignment(ctx context.Context, input *PatchDirectoryEntitlementInput, opts ...request.Option) (*UpdateDirectoryAssignmentOutput, error) {
+	req, out := c.UpdateDirectoryAssignmentRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) updateUpdateDirectoryAssignmentRequest(ctx context.Context, input *PatchDirectoryEntitlementInput) (*request.Request, *UpdateDirectoryAssignmentOutput) {
+func (c *EntitlementsV1) UpdateDirectoryAssignmentRequest(ctx context.Context, input *PatchDirectoryEntitlementInput) (*request.Request, *UpdateDirectoryAssignmentOutput) {
 	op := &request.Operation{
 		Name: "Update Directory Assignment",
 		Http: request.HTTP{
@@ -0,0 +1,134 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpentitlementsmock provides a mock of btpentitlements.EntitlementsAPI.
+package btpentitlementsmock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+)
+
+// EntitlementsAPI implements btpentitlements.EntitlementsAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type EntitlementsAPI struct {
+	GetDataCentersFunc                     func(ctx context.Context, opts ...request.Option) (*btpentitlements.DataCentersOutput, error)
+	GetDataCentersRequestFunc              func(ctx context.Context, input *btpentitlements.DataCentersInput) (*request.Request, *btpentitlements.DataCentersOutput)
+	GetProvidersRegionsFunc                func(ctx context.Context, opts ...request.Option) (map[string][]string, error)
+	GetProviderRegionsFunc                 func(ctx context.Context, provider string, opts ...request.Option) ([]string, error)
+	GetGlobalAccountAssignmentsFunc        func(ctx context.Context, input *btpentitlements.GlobalAccountAssignmentsInput, opts ...request.Option) (*btpentitlements.GlobalAccountAssignmentsOutput, error)
+	GetGlobalAccountAssignmentsRequestFunc func(ctx context.Context, input *btpentitlements.GlobalAccountAssignmentsInput) (*request.Request, *btpentitlements.GlobalAccountAssignmentsOutput)
+	GetAssignmentsFunc                     func(ctx context.Context, input *btpentitlements.GetAssignmentsInput, opts ...request.Option) (*btpentitlements.GetAssignmentsOutput, error)
+	GetAssignmentsRequestFunc              func(ctx context.Context, input *btpentitlements.GetAssignmentsInput) (*request.Request, *btpentitlements.GetAssignmentsOutput)
+	UpdateSubAccountServicePlanFunc        func(ctx context.Context, input *btpentitlements.UpdateSubAccountServicePlanInput, opts ...request.Option) (*btpentitlements.UpdateSubAccountServicePlanOutput, error)
+	UpdateSubAccountServicePlanRequestFunc func(ctx context.Context, input *btpentitlements.UpdateSubAccountServicePlanInput) (*request.Request, *btpentitlements.UpdateSubAccountServicePlanOutput)
+	UpdateDirectoryEntitlementsFunc        func(ctx context.Context, input *btpentitlements.UpdateDirectoryEntitlementsInput, opts ...request.Option) (*btpentitlements.UpdateDirectoryEntitlementsOutput, error)
+	UpdateDirectoryEntitlementsRequestFunc func(ctx context.Context, input *btpentitlements.UpdateDirectoryEntitlementsInput) (*request.Request, *btpentitlements.UpdateDirectoryEntitlementsOutput)
+	GetJobStatusFunc                       func(ctx context.Context, input *btpentitlements.GetJobStatusInput, opts ...request.Option) (*btpentitlements.GetJobStatusOutput, error)
+	GetJobStatusRequestFunc                func(ctx context.Context, input *btpentitlements.GetJobStatusInput) (*request.Request, *btpentitlements.GetJobStatusOutput)
+}
+
+var _ btpentitlements.EntitlementsAPI = (*EntitlementsAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpentitlementsmock: %s is not stubbed", method)
+}
+
+func (m *EntitlementsAPI) GetDataCenters(ctx context.Context, opts ...request.Option) (*btpentitlements.DataCentersOutput, error) {
+	if m.GetDataCentersFunc == nil {
+		return nil, notStubbed("GetDataCenters")
+	}
+	return m.GetDataCentersFunc(ctx, opts...)
+}
+
+func (m *EntitlementsAPI) GetDataCentersRequest(ctx context.Context, input *btpentitlements.DataCentersInput) (*request.Request, *btpentitlements.DataCentersOutput) {
+	if m.GetDataCentersRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetDataCentersRequestFunc(ctx, input)
+}
+
+func (m *EntitlementsAPI) GetProvidersRegions(ctx context.Context, opts ...request.Option) (map[string][]string, error) {
+	if m.GetProvidersRegionsFunc == nil {
+		return nil, notStubbed("GetProvidersRegions")
+	}
+	return m.GetProvidersRegionsFunc(ctx, opts...)
+}
+
+func (m *EntitlementsAPI) GetProviderRegions(ctx context.Context, provider string, opts ...request.Option) ([]string, error) {
+	if m.GetProviderRegionsFunc == nil {
+		return nil, notStubbed("GetProviderRegions")
+	}
+	return m.GetProviderRegionsFunc(ctx, provider, opts...)
+}
+
+func (m *EntitlementsAPI) GetGlobalAccountAssignments(ctx context.Context, input *btpentitlements.GlobalAccountAssignmentsInput, opts ...request.Option) (*btpentitlements.GlobalAccountAssignmentsOutput, error) {
+	if m.GetGlobalAccountAssignmentsFunc == nil {
+		return nil, notStubbed("GetGlobalAccountAssignments")
+	}
+	return m.GetGlobalAccountAssignmentsFunc(ctx, input, opts...)
+}
+
+func (m *EntitlementsAPI) GetGlobalAccountAssignmentsRequest(ctx context.Context, input *btpentitlements.GlobalAccountAssignmentsInput) (*request.Request, *btpentitlements.GlobalAccountAssignmentsOutput) {
+	if m.GetGlobalAccountAssignmentsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetGlobalAccountAssignmentsRequestFunc(ctx, input)
+}
+
+func (m *EntitlementsAPI) GetAssignments(ctx context.Context, input *btpentitlements.GetAssignmentsInput, opts ...request.Option) (*btpentitlements.GetAssignmentsOutput, error) {
+	if m.GetAssignmentsFunc == nil {
+		return nil, notStubbed("GetAssignments")
+	}
+	return m.GetAssignmentsFunc(ctx, input, opts...)
+}
+
+func (m *EntitlementsAPI) GetAssignmentsRequest(ctx context.Context, input *btpentitlements.GetAssignmentsInput) (*request.Request, *btpentitlements.GetAssignmentsOutput) {
+	if m.GetAssignmentsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetAssignmentsRequestFunc(ctx, input)
+}
+
+func (m *EntitlementsAPI) UpdateSubAccountServicePlan(ctx context.Context, input *btpentitlements.UpdateSubAccountServicePlanInput, opts ...request.Option) (*btpentitlements.UpdateSubAccountServicePlanOutput, error) {
+	if m.UpdateSubAccountServicePlanFunc == nil {
+		return nil, notStubbed("UpdateSubAccountServicePlan")
+	}
+	return m.UpdateSubAccountServicePlanFunc(ctx, input, opts...)
+}
+
+func (m *EntitlementsAPI) UpdateSubAccountServicePlanRequest(ctx context.Context, input *btpentitlements.UpdateSubAccountServicePlanInput) (*request.Request, *btpentitlements.UpdateSubAccountServicePlanOutput) {
+	if m.UpdateSubAccountServicePlanRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateSubAccountServicePlanRequestFunc(ctx, input)
+}
+
+func (m *EntitlementsAPI) UpdateDirectoryEntitlements(ctx context.Context, input *btpentitlements.UpdateDirectoryEntitlementsInput, opts ...request.Option) (*btpentitlements.UpdateDirectoryEntitlementsOutput, error) {
+	if m.UpdateDirectoryEntitlementsFunc == nil {
+		return nil, notStubbed("UpdateDirectoryEntitlements")
+	}
+	return m.UpdateDirectoryEntitlementsFunc(ctx, input, opts...)
+}
+
+func (m *EntitlementsAPI) UpdateDirectoryEntitlementsRequest(ctx context.Context, input *btpentitlements.UpdateDirectoryEntitlementsInput) (*request.Request, *btpentitlements.UpdateDirectoryEntitlementsOutput) {
+	if m.UpdateDirectoryEntitlementsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateDirectoryEntitlementsRequestFunc(ctx, input)
+}
+
+func (m *EntitlementsAPI) GetJobStatus(ctx context.Context, input *btpentitlements.GetJobStatusInput, opts ...request.Option) (*btpentitlements.GetJobStatusOutput, error) {
+	if m.GetJobStatusFunc == nil {
+		return nil, notStubbed("GetJobStatus")
+	}
+	return m.GetJobStatusFunc(ctx, input, opts...)
+}
+
+func (m *EntitlementsAPI) GetJobStatusRequest(ctx context.Context, input *btpentitlements.GetJobStatusInput) (*request.Request, *btpentitlements.GetJobStatusOutput) {
+	if m.GetJobStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetJobStatusRequestFunc(ctx, input)
+}
@@ -0,0 +1,49 @@
+package btpentitlements
+
+// AssignmentState is the state of a service plan assignment.
+type AssignmentState string
+
+const (
+	AssignmentStateStarted          AssignmentState = "STARTED"
+	AssignmentStateProcessing       AssignmentState = "PROCESSING"
+	AssignmentStateProcessingFailed AssignmentState = "PROCESSING_FAILED"
+	AssignmentStateOK               AssignmentState = "OK"
+)
+
+func (AssignmentState) Values() []AssignmentState {
+	return []AssignmentState{
+		AssignmentStateStarted,
+		AssignmentStateProcessing,
+		AssignmentStateProcessingFailed,
+		AssignmentStateOK,
+	}
+}
+
+func (s AssignmentState) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the assignment has left the transitional states, successfully or not.
+func (s AssignmentState) IsTerminal() bool {
+	if !s.Valid() {
+		return false
+	}
+	switch s {
+	case AssignmentStateStarted, AssignmentStateProcessing:
+		return false
+	}
+	return true
+}
+
+func (s AssignmentState) IsFailure() bool {
+	switch s {
+	case AssignmentStateProcessingFailed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpentitlements
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// EntitlementsAPI is the interface implemented by EntitlementsV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpentitlementsmock.
+type EntitlementsAPI interface {
+	GetDataCenters(ctx context.Context, opts ...request.Option) (*DataCentersOutput, error)
+	GetDataCentersRequest(ctx context.Context, input *DataCentersInput) (*request.Request, *DataCentersOutput)
+	GetProvidersRegions(ctx context.Context, opts ...request.Option) (map[string][]string, error)
+	GetProviderRegions(ctx context.Context, provider string, opts ...request.Option) ([]string, error)
+	GetGlobalAccountAssignments(ctx context.Context, input *GlobalAccountAssignmentsInput, opts ...request.Option) (*GlobalAccountAssignmentsOutput, error)
+	GetGlobalAccountAssignmentsRequest(ctx context.Context, input *GlobalAccountAssignmentsInput) (*request.Request, *GlobalAccountAssignmentsOutput)
+	GetAssignments(ctx context.Context, input *GetAssignmentsInput, opts ...request.Option) (*GetAssignmentsOutput, error)
+	GetAssignmentsRequest(ctx context.Context, input *GetAssignmentsInput) (*request.Request, *GetAssignmentsOutput)
+	UpdateSubAccountServicePlan(ctx context.Context, input *UpdateSubAccountServicePlanInput, opts ...request.Option) (*UpdateSubAccountServicePlanOutput, error)
+	UpdateSubAccountServicePlanRequest(ctx context.Context, input *UpdateSubAccountServicePlanInput) (*request.Request, *UpdateSubAccountServicePlanOutput)
+	UpdateDirectoryEntitlements(ctx context.Context, input *UpdateDirectoryEntitlementsInput, opts ...request.Option) (*UpdateDirectoryEntitlementsOutput, error)
+	UpdateDirectoryEntitlementsRequest(ctx context.Context, input *UpdateDirectoryEntitlementsInput) (*request.Request, *UpdateDirectoryEntitlementsOutput)
+	GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error)
+	GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput)
+}
+
+var _ EntitlementsAPI = (*EntitlementsV1)(nil)
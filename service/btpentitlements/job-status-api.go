@@ -10,7 +10,7 @@ import (
 // Get available jobs
 type GetJobStatusInput struct {
 	//ID of the job for which to get status
-	JobId string `dest:"uri" dest-name:"jobInstanceIdOrUniqueId"`
+	JobId string `dest:"uri" dest-name:"jobInstanceIdOrUniqueId" json:"-"`
 }
 type GetJobStatusOutput struct {
 	//A description of the exit status of a job when it ends.
@@ -23,17 +23,18 @@ type GetJobStatusOutput struct {
 	//FAILED: The job failed and did not complete. The job can be restarted.
 	//Enum:
 	//	[ IN_PROGRESS, COMPLETED, FAILED ]
-	Status string `json:"status,omitempty"`
+	Status types.JobStatus `json:"status,omitempty"`
 
 	Error *types.Error `json:"error,omitempty"`
 	types.StatusAndBodyFromResponse
 }
 
-func (c *EntitlementsV1) GetJobStatus(ctx context.Context, input *GetJobStatusInput) (*GetJobStatusOutput, error) {
-	req, out := c.getJobStatusRequest(ctx, input)
+func (c *EntitlementsV1) GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error) {
+	req, out := c.GetJobStatusRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EntitlementsV1) getJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput) {
+func (c *EntitlementsV1) GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput) {
 	op := &request.Operation{
 		Name: "Get Job Status",
 		Http: request.HTTP{
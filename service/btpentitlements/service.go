@@ -2,15 +2,17 @@ package btpentitlements
 
 import (
 	"context"
-	"github.com/nnicora/sap-sdk-go/internal/processors"
 	"github.com/nnicora/sap-sdk-go/sap"
 	"github.com/nnicora/sap-sdk-go/sap/endpoints"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/sap/http/request/processors/jsonbuiltin"
 	"github.com/nnicora/sap-sdk-go/sap/metainfo"
+	"github.com/nnicora/sap-sdk-go/sap/processors"
 	"github.com/nnicora/sap-sdk-go/sap/service"
 )
 
+//go:generate go run ../../internal/tools/apigen -type EntitlementsV1
+
 type EntitlementsV1 struct {
 	*service.Requester
 }
@@ -21,7 +23,7 @@ const (
 	ServiceID   = "entitlements"            // ServiceID is a unique identifier of a specific service.
 )
 
-func New(p service.RequesterConfig) *EntitlementsV1 {
+func New(p service.RequesterConfig, opts ...request.Option) *EntitlementsV1 {
 	c, err := p.ServiceConfig(EndpointsID)
 	if err != nil {
 		c.Processors.Using(request.Validate).PushFrontHandler(func(t interface{}) {
@@ -29,10 +31,10 @@ func New(p service.RequesterConfig) *EntitlementsV1 {
 			r.Error = err
 		})
 	}
-	return newRequester(c.RuntimeConfig, c.Processors, c.Endpoint)
+	return newRequester(c.RuntimeConfig, c.Processors, c.Endpoint, opts...)
 }
 
-func newRequester(cfg *sap.RuntimeConfig, p *processors.Processors, endpoint *endpoints.Endpoint) *EntitlementsV1 {
+func newRequester(cfg *sap.RuntimeConfig, p *processors.Processors, endpoint *endpoints.Endpoint, opts ...request.Option) *EntitlementsV1 {
 	svc := &EntitlementsV1{
 		Requester: service.NewRequester(
 			cfg,
@@ -43,6 +45,7 @@ func newRequester(cfg *sap.RuntimeConfig, p *processors.Processors, endpoint *en
 				APIVersion:  "v1",
 			},
 			p,
+			service.WithRequestOptions(opts...),
 		),
 	}
 
@@ -1,8 +1,9 @@
 package btpevents
 
 import (
+	"bytes"
 	"context"
-	"github.com/nnicora/sap-sdk-go/internal/times"
+	"fmt"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
 	"time"
@@ -12,14 +13,14 @@ import (
 // Get events
 type GetEventsInput struct {
 	//The ID of the event.
-	Id []int `dest:"querystring" dest-name:"id"`
+	Id []int `dest:"querystring" dest-name:"id" json:"-"`
 
 	//The ID of the entity associated with the event.
-	EntityId string `dest:"querystring" dest-name:"entityId"`
+	EntityId string `dest:"querystring" dest-name:"entityId" json:"-"`
 
 	//The type of entity associated with the event.
 	//For example: Subaccount, Directory, Tenant.
-	EntityType []string `dest:"querystring" dest-name:"entityType"`
+	EntityType []string `dest:"querystring" dest-name:"entityType" json:"-"`
 
 	//The type of the event that was triggered.
 	//There are two groups of event types, Central Events and Local Events group.
@@ -36,46 +37,46 @@ type GetEventsInput struct {
 	//	AppRegistration_Creation, AppRegistration_Deletion, AppRegistration_Update, SubaccountTenant_Creation,
 	//	SubaccountTenant_Update, SubaccountTenant_Deletion, EnvironmentInstance_Creation, EnvironmentInstance_Deletion,
 	//	EnvironmentInstances_Deletion
-	EventType []string `dest:"querystring" dest-name:"eventType"`
+	EventType []string `dest:"querystring" dest-name:"eventType" json:"-"`
 
 	//Start date and time to query the events by the action that triggered them.
 	//Use the Unix epoch time in milliseconds (you can find an online converter from a regular date-time format to the Unix epoch time format).
 	//For example:
 	//	Monday, June 1, 2020 9:40:22 AM is 1590993622000 in Unix epoch milliseconds time.
-	FromActionTime time.Time `dest:"querystring" dest-name:"fromActionTime" timestampFormat:"unixTimestamp"`
+	FromActionTime time.Time `dest:"querystring" dest-name:"fromActionTime" timestampFormat:"unixTimestamp" json:"-"`
 
 	//Start date and time to query the events by when they were created.
 	//Use the Unix epoch time in milliseconds (you can find an online converter from a regular date-time format to the Unix epoch time format).
 	//For example:
 	//	Monday, June 10, 2020 04:32:22 AM is 1591752742000 in Unix epoch milliseconds time.
-	FromCreationTime time.Time `dest:"querystring" dest-name:"fromCreationTime" timestampFormat:"unixTimestamp"`
+	FromCreationTime time.Time `dest:"querystring" dest-name:"fromCreationTime" timestampFormat:"unixTimestamp" json:"-"`
 
 	//The page number to retrieve.
-	PageNum uint32 `dest:"querystring" dest-name:"pageNum"`
+	PageNum uint32 `dest:"querystring" dest-name:"pageNum" json:"-"`
 
 	//The number of events to retrieve per page (max = 150).
-	PageSize uint32 `dest:"querystring" dest-name:"pageSize"`
+	PageSize uint32 `dest:"querystring" dest-name:"pageSize" json:"-" max:"150"`
 
 	//Field by which to sort the events.
-	SortField string `dest:"querystring" dest-name:"sortField"`
+	SortField string `dest:"querystring" dest-name:"sortField" json:"-"`
 
 	//Sort order for the events.
 	//Can be ascending or descending.
 	//
 	//Available values : ASC, DESC
-	SortOrder string `dest:"querystring" dest-name:"sortOrder"`
+	SortOrder string `dest:"querystring" dest-name:"sortOrder" json:"-"`
 
 	//End date and time to query the events by the action that triggered them.
 	//Use the Unix epoch time in milliseconds (you can find an online converter from a regular date-time format to the Unix epoch time format).
 	//For example:
 	//	Monday, June 4, 2020 11:40:22 AM is 1591260022000 in Unix epoch milliseconds time.
-	ToActionTime time.Time `dest:"querystring" dest-name:"toActionTime" timestampFormat:"unixTimestamp"`
+	ToActionTime time.Time `dest:"querystring" dest-name:"toActionTime" timestampFormat:"unixTimestamp" json:"-"`
 
 	//End date and time to query the events by when they were created.
 	//Use the Unix epoch time in milliseconds (you can find an online converter from a regular date-time format to the Unix epoch time format).
 	//For example:
 	//	Monday, June 6, 2020 12:32:22 AM is 1591392742000 in Unix epoch milliseconds time.
-	ToCreationTime time.Time `dest:"querystring" dest-name:"toCreationTime" timestampFormat:"unixTimestamp"`
+	ToCreationTime time.Time `dest:"querystring" dest-name:"toCreationTime" timestampFormat:"unixTimestamp" json:"-"`
 }
 type GetEventsOutput struct {
 	//Lists of the events associated with the API call and used scopes.
@@ -102,11 +103,11 @@ type Event struct {
 
 	//The time the action triggered the event.
 	//The format is Unix epoch time in milliseconds.
-	ActionTime times.JavaTime `json:"actionTime,omitempty"`
+	ActionTime types.EpochTime `json:"actionTime,omitempty"`
 
 	//The time when the event record was created.
 	//The format is Unix epoch time in milliseconds.
-	CreationTime times.JavaTime `json:"creationTime,omitempty"`
+	CreationTime types.EpochTime `json:"creationTime,omitempty"`
 
 	//JSON object that contains description and details about the requested events.
 	Details map[string]interface{} `json:"details,omitempty"`
@@ -139,11 +140,12 @@ type Event struct {
 	GlobalAccountGuid string `json:"globalAccountGUID,omitempty"`
 }
 
-func (c *EventsV1) GetEvents(ctx context.Context, input *GetEventsInput) (*GetEventsOutput, error) {
-	req, out := c.getEventsRequest(ctx, input)
+func (c *EventsV1) GetEvents(ctx context.Context, input *GetEventsInput, opts ...request.Option) (*GetEventsOutput, error) {
+	req, out := c.GetEventsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EventsV1) getEventsRequest(ctx context.Context, input *GetEventsInput) (*request.Request, *GetEventsOutput) {
+func (c *EventsV1) GetEventsRequest(ctx context.Context, input *GetEventsInput) (*request.Request, *GetEventsOutput) {
 	op := &request.Operation{
 		Name: "Get All Events",
 		Http: request.HTTP{
@@ -165,6 +167,13 @@ func (c *EventsV1) getEventsRequest(ctx context.Context, input *GetEventsInput)
 type GetEventsTypesInput struct {
 }
 type GetEventsTypesOutput struct {
+	//The event types, the service answers with a list of them.
+	Values []EventType `json:"values,omitempty"`
+
+	Error *types.Error `json:"error,omitempty"`
+	types.StatusAndBodyFromResponse
+}
+type EventType struct {
 	//Category to which the event type belongs.
 	//
 	//LOCAL: The event is associated with the local region within a multi-region universe.
@@ -195,16 +204,14 @@ type GetEventsTypesOutput struct {
 	//	SubaccountTenant_Update, SubaccountTenant_Deletion, EnvironmentInstance_Creation, EnvironmentInstance_Deletion,
 	//	EnvironmentInstances_Deletion
 	Type string `json:"type,omitempty"`
-
-	Error *types.Error `json:"error,omitempty"`
-	types.StatusAndBodyFromResponse
 }
 
-func (c *EventsV1) GetEventsTypes(ctx context.Context) (*GetEventsTypesOutput, error) {
-	req, out := c.getEventsTypesRequest(ctx, nil)
+func (c *EventsV1) GetEventsTypes(ctx context.Context, opts ...request.Option) (*GetEventsTypesOutput, error) {
+	req, out := c.GetEventsTypesRequest(ctx, nil)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *EventsV1) getEventsTypesRequest(ctx context.Context, input *GetEventsTypesInput) (*request.Request, *GetEventsTypesOutput) {
+func (c *EventsV1) GetEventsTypesRequest(ctx context.Context, input *GetEventsTypesInput) (*request.Request, *GetEventsTypesOutput) {
 	op := &request.Operation{
 		Name: "Get Events Types",
 		Http: request.HTTP{
@@ -218,5 +225,14 @@ func (c *EventsV1) getEventsTypesRequest(ctx context.Context, input *GetEventsTy
 	}
 
 	output := &GetEventsTypesOutput{}
-	return c.newRequest(ctx, op, input, output), output
+	req := c.newRequest(ctx, op, input, output)
+
+	// the service answers with a JSON array, wrapped to decode into Values
+	req.ResponseBodyHandler = func(statusCode int, body []byte) ([]byte, error) {
+		if statusCode >= 200 && statusCode < 300 && len(bytes.TrimSpace(body)) > 0 {
+			return []byte(fmt.Sprintf("{ \"values\": %s }", body)), nil
+		}
+		return body, nil
+	}
+	return req, output
 }
@@ -0,0 +1,70 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpeventsmock provides a mock of btpevents.EventsAPI.
+package btpeventsmock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpevents"
+)
+
+// EventsAPI implements btpevents.EventsAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type EventsAPI struct {
+	GetEventsFunc             func(ctx context.Context, input *btpevents.GetEventsInput, opts ...request.Option) (*btpevents.GetEventsOutput, error)
+	GetEventsRequestFunc      func(ctx context.Context, input *btpevents.GetEventsInput) (*request.Request, *btpevents.GetEventsOutput)
+	GetEventsTypesFunc        func(ctx context.Context, opts ...request.Option) (*btpevents.GetEventsTypesOutput, error)
+	GetEventsTypesRequestFunc func(ctx context.Context, input *btpevents.GetEventsTypesInput) (*request.Request, *btpevents.GetEventsTypesOutput)
+	GetJobStatusFunc          func(ctx context.Context, input *btpevents.GetJobStatusInput, opts ...request.Option) (*btpevents.GetJobStatusOutput, error)
+	GetJobStatusRequestFunc   func(ctx context.Context, input *btpevents.GetJobStatusInput) (*request.Request, *btpevents.GetJobStatusOutput)
+}
+
+var _ btpevents.EventsAPI = (*EventsAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpeventsmock: %s is not stubbed", method)
+}
+
+func (m *EventsAPI) GetEvents(ctx context.Context, input *btpevents.GetEventsInput, opts ...request.Option) (*btpevents.GetEventsOutput, error) {
+	if m.GetEventsFunc == nil {
+		return nil, notStubbed("GetEvents")
+	}
+	return m.GetEventsFunc(ctx, input, opts...)
+}
+
+func (m *EventsAPI) GetEventsRequest(ctx context.Context, input *btpevents.GetEventsInput) (*request.Request, *btpevents.GetEventsOutput) {
+	if m.GetEventsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetEventsRequestFunc(ctx, input)
+}
+
+func (m *EventsAPI) GetEventsTypes(ctx context.Context, opts ...request.Option) (*btpevents.GetEventsTypesOutput, error) {
+	if m.GetEventsTypesFunc == nil {
+		return nil, notStubbed("GetEventsTypes")
+	}
+	return m.GetEventsTypesFunc(ctx, opts...)
+}
+
+func (m *EventsAPI) GetEventsTypesRequest(ctx context.Context, input *btpevents.GetEventsTypesInput) (*request.Request, *btpevents.GetEventsTypesOutput) {
+	if m.GetEventsTypesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetEventsTypesRequestFunc(ctx, input)
+}
+
+func (m *EventsAPI) GetJobStatus(ctx context.Context, input *btpevents.GetJobStatusInput, opts ...request.Option) (*btpevents.GetJobStatusOutput, error) {
+	if m.GetJobStatusFunc == nil {
+		return nil, notStubbed("GetJobStatus")
+	}
+	return m.GetJobStatusFunc(ctx, input, opts...)
+}
+
+func (m *EventsAPI) GetJobStatusRequest(ctx context.Context, input *btpevents.GetJobStatusInput) (*request.Request, *btpevents.GetJobStatusOutput) {
+	if m.GetJobStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetJobStatusRequestFunc(ctx, input)
+}
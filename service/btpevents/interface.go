@@ -0,0 +1,21 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpevents
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// EventsAPI is the interface implemented by EventsV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpeventsmock.
+type EventsAPI interface {
+	GetEvents(ctx context.Context, input *GetEventsInput, opts ...request.Option) (*GetEventsOutput, error)
+	GetEventsRequest(ctx context.Context, input *GetEventsInput) (*request.Request, *GetEventsOutput)
+	GetEventsTypes(ctx context.Context, opts ...request.Option) (*GetEventsTypesOutput, error)
+	GetEventsTypesRequest(ctx context.Context, input *GetEventsTypesInput) (*request.Request, *GetEventsTypesOutput)
+	GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error)
+	GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput)
+}
+
+var _ EventsAPI = (*EventsV1)(nil)
@@ -0,0 +1,189 @@
+package btpmanagment
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/internal/sapcontext"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// RotateServiceBindingInput describes the rotation of the credentials of a service binding.
+type RotateServiceBindingInput struct {
+	//The ID of the binding whose credentials to rotate.
+	ServiceBindingID string
+
+	//The name of the new binding.
+	//By default the name of the old binding with its version incremented, e.g. my-binding-v2
+	//replaces my-binding and my-binding-v3 replaces my-binding-v2.
+	Name string
+
+	//Configuration parameters of the new binding.
+	//The parameters of the old binding are not copied, as services do not always return them.
+	Parameters map[string]string
+
+	//Switches the consumers of the old binding to the new one, whose credentials and parameters
+	//are in the rotation. The old binding is deleted only when it succeeds; when it fails, the new
+	//binding is deleted instead.
+	Switch func(ctx context.Context, rotation *ServiceBindingRotation) error
+
+	//How long the old binding is kept after the switch, for the consumers still using it.
+	GracePeriod time.Duration
+
+	//The time waited between two polls of the asynchronous operations.
+	//DefaultPollInterval when zero.
+	PollInterval time.Duration
+
+	//How long deleting the new binding of a failed rotation may take.
+	//DefaultRollbackTimeout when zero.
+	RollbackTimeout time.Duration
+}
+
+// DefaultRollbackTimeout is how long deleting the new binding of a failed rotation may take when no
+// timeout is given.
+const DefaultRollbackTimeout = 5 * time.Minute
+
+// ServiceBindingRotation is the state of a rotation passed to the Switch callback and returned by
+// RotateServiceBinding.
+type ServiceBindingRotation struct {
+	//The binding whose credentials are rotated.
+	Old BindingItem
+
+	//The binding replacing the old one, with its credentials.
+	New BindingItem
+
+	//The parameters of the new binding, as returned by GetServiceBindingParameters.
+	Parameters map[string]interface{}
+
+	//Whether the old binding has been deleted.
+	OldDeleted bool
+}
+
+// RotateServiceBinding rotates the credentials of a service binding: it creates a new binding of the
+// same service instance, with the bind resource and labels of the old one, waits for it to be ready
+// and fetches its credentials and parameters. It then calls Switch and, after the grace period,
+// deletes the old binding.
+//
+// Until Switch succeeds, a failure rolls the rotation back by deleting the new binding. Once the
+// consumers are switched the new binding is kept: if the old one cannot be deleted, the rotation is
+// returned with OldDeleted false along with the error.
+func (c *ServiceManagementV1) RotateServiceBinding(ctx context.Context, input *RotateServiceBindingInput,
+	opts ...request.Option) (*ServiceBindingRotation, error) {
+	if input == nil || input.ServiceBindingID == "" {
+		return nil, fmt.Errorf("the ID of the service binding to rotate is required")
+	}
+	if input.Switch == nil {
+		return nil, fmt.Errorf("a Switch function is required to rotate service binding %s", input.ServiceBindingID)
+	}
+
+	old, err := c.GetServiceBinding(ctx, &GetServiceBindingInput{ServiceBindingID: input.ServiceBindingID}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	rotation := &ServiceBindingRotation{Old: old.BindingItem}
+
+	name := input.Name
+	if name == "" {
+		name = NextBindingName(old.Name)
+	}
+	created, err := c.CreateServiceBinding(ctx, &CreateServiceBindingInput{
+		Async:             true,
+		Name:              name,
+		ServiceInstanceId: old.ServiceInstanceId,
+		Parameters:        input.Parameters,
+		BindResource:      old.BindResource,
+		Labels:            old.Labels,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.prepareRotation(ctx, input, rotation, created, opts...); err != nil {
+		return nil, c.rollbackRotation(ctx, input, rotation, created.Id, err, opts...)
+	}
+	if err := input.Switch(ctx, rotation); err != nil {
+		return nil, c.rollbackRotation(ctx, input, rotation, created.Id,
+			fmt.Errorf("switch to service binding %s failed; %v", created.Id, err), opts...)
+	}
+
+	if input.GracePeriod > 0 {
+		timer := time.NewTimer(input.GracePeriod)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return rotation, ctx.Err()
+		}
+	}
+
+	deleted, err := c.DeleteServiceBinding(ctx, &DeleteServiceBindingInput{Async: true, ServiceBindingID: old.Id}, opts...)
+	if err == nil {
+		_, err = c.WaitForOperation(ctx, deleted.AsyncOperation, input.PollInterval, opts...)
+	}
+	if err != nil {
+		return rotation, fmt.Errorf("could not delete the rotated service binding %s; %v", old.Id, err)
+	}
+	rotation.OldDeleted = true
+	return rotation, nil
+}
+
+// Waits for the new binding and gets its credentials and parameters.
+func (c *ServiceManagementV1) prepareRotation(ctx context.Context, input *RotateServiceBindingInput,
+	rotation *ServiceBindingRotation, created *CreateServiceBindingOutput, opts ...request.Option) error {
+	if _, err := c.WaitForOperation(ctx, created.AsyncOperation, input.PollInterval, opts...); err != nil {
+		return err
+	}
+	binding, err := c.GetServiceBinding(ctx, &GetServiceBindingInput{ServiceBindingID: created.Id}, opts...)
+	if err != nil {
+		return err
+	}
+	if !binding.Ready {
+		return fmt.Errorf("service binding %s is not ready", created.Id)
+	}
+	rotation.New = binding.BindingItem
+
+	params, err := c.GetServiceBindingParameters(ctx, &GetServiceBindingParametersInput{ServiceBindingID: created.Id}, opts...)
+	if err != nil {
+		return err
+	}
+	rotation.Parameters = params.Parameters
+	return nil
+}
+
+// Deletes the new binding of a rotation which failed, returning the cause with the rollback error, if any.
+// The binding is deleted on a context of its own, as the rotation may have failed because its context
+// was cancelled.
+func (c *ServiceManagementV1) rollbackRotation(ctx context.Context, input *RotateServiceBindingInput,
+	rotation *ServiceBindingRotation, id string, cause error, opts ...request.Option) error {
+	timeout := input.RollbackTimeout
+	if timeout <= 0 {
+		timeout = DefaultRollbackTimeout
+	}
+	ctx, cancel := context.WithTimeout(sapcontext.Detached(ctx), timeout)
+	defer cancel()
+
+	deleted, err := c.DeleteServiceBinding(ctx, &DeleteServiceBindingInput{Async: true, ServiceBindingID: id}, opts...)
+	if err == nil {
+		_, err = c.WaitForOperation(ctx, deleted.AsyncOperation, input.PollInterval, opts...)
+	}
+	if err != nil {
+		return fmt.Errorf("rotation of service binding %s failed; %v; rollback failed, service binding %s "+
+			"is left behind; %v", rotation.Old.Id, cause, id, err)
+	}
+	return fmt.Errorf("rotation of service binding %s failed; %v", rotation.Old.Id, cause)
+}
+
+var bindingVersion = regexp.MustCompile(`-v([0-9]+)$`)
+
+// NextBindingName returns the name of the binding replacing the given one in a rotation: the name
+// with its version suffix incremented, or with the -v2 suffix when it has none.
+func NextBindingName(name string) string {
+	if m := bindingVersion.FindStringSubmatchIndex(name); m != nil {
+		if v, err := strconv.Atoi(name[m[2]:m[3]]); err == nil {
+			return name[:m[0]] + "-v" + strconv.Itoa(v+1)
+		}
+	}
+	return name + "-v2"
+}
@@ -0,0 +1,134 @@
+package btpmanagment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestNextBindingName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"my-binding":     "my-binding-v2",
+		"my-binding-v2":  "my-binding-v3",
+		"my-binding-v9":  "my-binding-v10",
+		"my-binding-v":   "my-binding-v-v2",
+		"my-binding-v2a": "my-binding-v2a-v2",
+	} {
+		if got := NextBindingName(name); got != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, got)
+		}
+	}
+}
+
+func TestRotateServiceBinding(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sm := newClient(t, srv)
+	ctx := context.Background()
+
+	instance, err := sm.CreateServiceInstance(ctx, &CreateServiceInstanceInput{
+		Name:                "rotated",
+		ServiceOfferingName: "xsuaa",
+		ServicePlanName:     "application",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := sm.CreateServiceBinding(ctx, &CreateServiceBindingInput{
+		Name:              "app",
+		ServiceInstanceId: instance.Id,
+		Labels:            map[string][]string{"app": {"web"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var switched string
+	rotation, err := sm.RotateServiceBinding(ctx, &RotateServiceBindingInput{
+		ServiceBindingID: old.Id,
+		PollInterval:     time.Millisecond,
+		Switch: func(ctx context.Context, rotation *ServiceBindingRotation) error {
+			switched, _ = rotation.New.Credentials["clientid"].(string)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotation.New.Name != "app-v2" || rotation.New.Labels["app"][0] != "web" || !rotation.OldDeleted {
+		t.Fatalf("unexpected rotation %+v", rotation)
+	}
+	if switched != "sb-"+rotation.New.Id {
+		t.Fatalf("expected the credentials of the new binding, got '%s'", switched)
+	}
+	if _, err := sm.GetServiceBinding(ctx, &GetServiceBindingInput{ServiceBindingID: old.Id}); err == nil {
+		t.Fatal("expected the old binding to be deleted")
+	}
+
+	// a failed switch deletes the new binding and keeps the current one
+	_, err = sm.RotateServiceBinding(ctx, &RotateServiceBindingInput{
+		ServiceBindingID: rotation.New.Id,
+		PollInterval:     time.Millisecond,
+		Switch: func(ctx context.Context, rotation *ServiceBindingRotation) error {
+			return errors.New("consumers unreachable")
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "consumers unreachable") {
+		t.Fatalf("expected the switch error, got %v", err)
+	}
+	bindings, err := sm.GetServiceBindings(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bindings.Items) != 1 || bindings.Items[0].Id != rotation.New.Id {
+		t.Fatalf("expected only binding %s, got %+v", rotation.New.Id, bindings.Items)
+	}
+}
+
+func TestRollbackCancelledRotation(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sm := newClient(t, srv)
+
+	instance, err := sm.CreateServiceInstance(context.Background(), &CreateServiceInstanceInput{
+		Name:                "rotated",
+		ServiceOfferingName: "xsuaa",
+		ServicePlanName:     "application",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := sm.CreateServiceBinding(context.Background(), &CreateServiceBindingInput{
+		Name:              "app",
+		ServiceInstanceId: instance.Id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the rotation is cancelled while switching, the new binding is deleted all the same
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = sm.RotateServiceBinding(ctx, &RotateServiceBindingInput{
+		ServiceBindingID: old.Id,
+		PollInterval:     time.Millisecond,
+		Switch: func(ctx context.Context, rotation *ServiceBindingRotation) error {
+			cancel()
+			return ctx.Err()
+		},
+	})
+	if err == nil || strings.Contains(err.Error(), "rollback failed") {
+		t.Fatalf("expected the rotation to be rolled back, got %v", err)
+	}
+	bindings, err := sm.GetServiceBindings(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bindings.Items) != 1 || bindings.Items[0].Id != old.Id {
+		t.Fatalf("expected only binding %s, got %+v", old.Id, bindings.Items)
+	}
+}
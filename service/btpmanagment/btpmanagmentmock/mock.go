@@ -0,0 +1,583 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpmanagmentmock provides a mock of btpmanagment.ServiceManagementAPI.
+package btpmanagmentmock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"time"
+)
+
+// ServiceManagementAPI implements btpmanagment.ServiceManagementAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type ServiceManagementAPI struct {
+	RotateServiceBindingFunc                func(ctx context.Context, input *btpmanagment.RotateServiceBindingInput, opts ...request.Option) (*btpmanagment.ServiceBindingRotation, error)
+	SyncLabelsFunc                          func(ctx context.Context, resource btpmanagment.LabeledResource, desired map[string][]string, opts ...request.Option) ([]btpmanagment.Label, error)
+	GetOperationStatusFunc                  func(ctx context.Context, input *btpmanagment.GetOperationStatusInput, opts ...request.Option) (*btpmanagment.GetOperationStatusOutput, error)
+	GetOperationStatusRequestFunc           func(ctx context.Context, input *btpmanagment.GetOperationStatusInput) (*request.Request, *btpmanagment.GetOperationStatusOutput)
+	GetOperationsFunc                       func(ctx context.Context, input *btpmanagment.GetOperationsInput, opts ...request.Option) (*btpmanagment.GetOperationsOutput, error)
+	GetOperationsRequestFunc                func(ctx context.Context, input *btpmanagment.GetOperationsInput) (*request.Request, *btpmanagment.GetOperationsOutput)
+	WaitForOperationFunc                    func(ctx context.Context, op btpmanagment.AsyncOperation, interval time.Duration, opts ...request.Option) (*btpmanagment.GetOperationStatusOutput, error)
+	GetPlatformsFunc                        func(ctx context.Context, input *btpmanagment.GetPlatformsInput, opts ...request.Option) (*btpmanagment.GetPlatformsOutput, error)
+	GetPlatformsRequestFunc                 func(ctx context.Context, input *btpmanagment.GetPlatformsInput) (*request.Request, *btpmanagment.GetPlatformsOutput)
+	CreatePlatformFunc                      func(ctx context.Context, input *btpmanagment.CreatePlatformInput, opts ...request.Option) (*btpmanagment.CreatePlatformOutput, error)
+	CreatePlatformRequestFunc               func(ctx context.Context, input *btpmanagment.CreatePlatformInput) (*request.Request, *btpmanagment.CreatePlatformOutput)
+	GetPlatformFunc                         func(ctx context.Context, input *btpmanagment.GetPlatformInput, opts ...request.Option) (*btpmanagment.GetPlatformOutput, error)
+	GetPlatformRequestFunc                  func(ctx context.Context, input *btpmanagment.GetPlatformInput) (*request.Request, *btpmanagment.GetPlatformOutput)
+	DeletePlatformFunc                      func(ctx context.Context, input *btpmanagment.DeletePlatformInput, opts ...request.Option) (*btpmanagment.DeletePlatformOutput, error)
+	DeletePlatformRequestFunc               func(ctx context.Context, input *btpmanagment.DeletePlatformInput) (*request.Request, *btpmanagment.DeletePlatformOutput)
+	UpdatePlatformFunc                      func(ctx context.Context, input *btpmanagment.UpdatePlatformInput, opts ...request.Option) (*btpmanagment.UpdatePlatformOutput, error)
+	UpdatePlatformRequestFunc               func(ctx context.Context, input *btpmanagment.UpdatePlatformInput) (*request.Request, *btpmanagment.UpdatePlatformOutput)
+	GetServiceBindingsFunc                  func(ctx context.Context, input *btpmanagment.GetServiceBindingsInput, opts ...request.Option) (*btpmanagment.GetServiceBindingsOutput, error)
+	GetServiceBindingsRequestFunc           func(ctx context.Context, input *btpmanagment.GetServiceBindingsInput) (*request.Request, *btpmanagment.GetServiceBindingsOutput)
+	CreateServiceBindingFunc                func(ctx context.Context, input *btpmanagment.CreateServiceBindingInput, opts ...request.Option) (*btpmanagment.CreateServiceBindingOutput, error)
+	CreateServiceBindingRequestFunc         func(ctx context.Context, input *btpmanagment.CreateServiceBindingInput) (*request.Request, *btpmanagment.CreateServiceBindingOutput)
+	GetServiceBindingFunc                   func(ctx context.Context, input *btpmanagment.GetServiceBindingInput, opts ...request.Option) (*btpmanagment.GetServiceBindingOutput, error)
+	GetServiceBindingRequestFunc            func(ctx context.Context, input *btpmanagment.GetServiceBindingInput) (*request.Request, *btpmanagment.GetServiceBindingOutput)
+	DeleteServiceBindingFunc                func(ctx context.Context, input *btpmanagment.DeleteServiceBindingInput, opts ...request.Option) (*btpmanagment.DeleteServiceBindingOutput, error)
+	DeleteServiceBindingRequestFunc         func(ctx context.Context, input *btpmanagment.DeleteServiceBindingInput) (*request.Request, *btpmanagment.DeleteServiceBindingOutput)
+	UpdateServiceBindingFunc                func(ctx context.Context, input *btpmanagment.UpdateServiceBindingInput, opts ...request.Option) (*btpmanagment.UpdateServiceBindingOutput, error)
+	UpdateServiceBindingRequestFunc         func(ctx context.Context, input *btpmanagment.UpdateServiceBindingInput) (*request.Request, *btpmanagment.UpdateServiceBindingOutput)
+	GetServiceBindingParametersFunc         func(ctx context.Context, input *btpmanagment.GetServiceBindingParametersInput, opts ...request.Option) (*btpmanagment.GetServiceBindingParametersOutput, error)
+	GetServiceBindingParametersRequestFunc  func(ctx context.Context, input *btpmanagment.GetServiceBindingParametersInput) (*request.Request, *btpmanagment.GetServiceBindingParametersOutput)
+	GetServiceBrokersFunc                   func(ctx context.Context, input *btpmanagment.GetServiceBrokersInput, opts ...request.Option) (*btpmanagment.GetServiceBrokersOutput, error)
+	GetServiceBrokersRequestFunc            func(ctx context.Context, input *btpmanagment.GetServiceBrokersInput) (*request.Request, *btpmanagment.GetServiceBrokersOutput)
+	GetServiceBrokerFunc                    func(ctx context.Context, input *btpmanagment.GetServiceBrokerInput, opts ...request.Option) (*btpmanagment.GetServiceBrokerOutput, error)
+	GetServiceBrokerRequestFunc             func(ctx context.Context, input *btpmanagment.GetServiceBrokerInput) (*request.Request, *btpmanagment.GetServiceBrokerOutput)
+	RegisterServiceBrokerFunc               func(ctx context.Context, input *btpmanagment.RegisterServiceBrokerInput, opts ...request.Option) (*btpmanagment.RegisterServiceBrokerOutput, error)
+	RegisterServiceBrokerRequestFunc        func(ctx context.Context, input *btpmanagment.RegisterServiceBrokerInput) (*request.Request, *btpmanagment.RegisterServiceBrokerOutput)
+	UpdateServiceBrokerFunc                 func(ctx context.Context, input *btpmanagment.UpdateServiceBrokerInput, opts ...request.Option) (*btpmanagment.UpdateServiceBrokerOutput, error)
+	UpdateServiceBrokerRequestFunc          func(ctx context.Context, input *btpmanagment.UpdateServiceBrokerInput) (*request.Request, *btpmanagment.UpdateServiceBrokerOutput)
+	RefreshServiceBrokerCatalogFunc         func(ctx context.Context, input *btpmanagment.RefreshServiceBrokerCatalogInput, opts ...request.Option) (*btpmanagment.UpdateServiceBrokerOutput, error)
+	DeleteServiceBrokerFunc                 func(ctx context.Context, input *btpmanagment.DeleteServiceBrokerInput, opts ...request.Option) (*btpmanagment.DeleteServiceBrokerOutput, error)
+	DeleteServiceBrokerRequestFunc          func(ctx context.Context, input *btpmanagment.DeleteServiceBrokerInput) (*request.Request, *btpmanagment.DeleteServiceBrokerOutput)
+	GetServiceInstancesFunc                 func(ctx context.Context, input *btpmanagment.GetServiceInstancesInput, opts ...request.Option) (*btpmanagment.GetServiceInstancesOutput, error)
+	GetServiceInstancesRequestFunc          func(ctx context.Context, input *btpmanagment.GetServiceInstancesInput) (*request.Request, *btpmanagment.GetServiceInstancesOutput)
+	CreateServiceInstanceFunc               func(ctx context.Context, input *btpmanagment.CreateServiceInstanceInput, opts ...request.Option) (*btpmanagment.CreateServiceInstanceOutput, error)
+	CreateServiceInstanceRequestFunc        func(ctx context.Context, input *btpmanagment.CreateServiceInstanceInput) (*request.Request, *btpmanagment.CreateServiceInstanceOutput)
+	GetServiceInstanceFunc                  func(ctx context.Context, input *btpmanagment.GetServiceInstanceInput, opts ...request.Option) (*btpmanagment.GetServiceInstanceOutput, error)
+	GetServiceInstanceRequestFunc           func(ctx context.Context, input *btpmanagment.GetServiceInstanceInput) (*request.Request, *btpmanagment.GetServiceInstanceOutput)
+	DeleteServiceInstanceFunc               func(ctx context.Context, input *btpmanagment.DeleteServiceInstanceInput, opts ...request.Option) (*btpmanagment.DeleteServiceInstanceOutput, error)
+	DeleteServiceInstanceRequestFunc        func(ctx context.Context, input *btpmanagment.DeleteServiceInstanceInput) (*request.Request, *btpmanagment.DeleteServiceInstanceOutput)
+	UpdateServiceInstanceFunc               func(ctx context.Context, input *btpmanagment.UpdateServiceInstanceInput, opts ...request.Option) (*btpmanagment.UpdateServiceInstanceOutput, error)
+	UpdateServiceInstanceRequestFunc        func(ctx context.Context, input *btpmanagment.UpdateServiceInstanceInput) (*request.Request, *btpmanagment.UpdateServiceInstanceOutput)
+	GetServiceInstanceParametersFunc        func(ctx context.Context, input *btpmanagment.GetServiceInstanceParametersInput, opts ...request.Option) (*btpmanagment.GetServiceInstanceParametersOutput, error)
+	GetServiceInstanceParametersRequestFunc func(ctx context.Context, input *btpmanagment.GetServiceInstanceParametersInput) (*request.Request, *btpmanagment.GetServiceInstanceParametersOutput)
+	GetServiceOfferingsFunc                 func(ctx context.Context, input *btpmanagment.GetServiceOfferingsInput, opts ...request.Option) (*btpmanagment.GetServiceOfferingsOutput, error)
+	GetServiceOfferingsRequestFunc          func(ctx context.Context, input *btpmanagment.GetServiceOfferingsInput) (*request.Request, *btpmanagment.GetServiceOfferingsOutput)
+	GetServiceOfferingFunc                  func(ctx context.Context, input *btpmanagment.GetServiceOfferingInput, opts ...request.Option) (*btpmanagment.GetServiceOfferingOutput, error)
+	GetServiceOfferingRequestFunc           func(ctx context.Context, input *btpmanagment.GetServiceOfferingInput) (*request.Request, *btpmanagment.GetServiceOfferingOutput)
+	GetServicePlansFunc                     func(ctx context.Context, input *btpmanagment.GetServicePlansInput, opts ...request.Option) (*btpmanagment.GetServicePlansOutput, error)
+	GetServicePlansRequestFunc              func(ctx context.Context, input *btpmanagment.GetServicePlansInput) (*request.Request, *btpmanagment.GetServicePlansOutput)
+	GetServicePlanFunc                      func(ctx context.Context, input *btpmanagment.GetServicePlanInput, opts ...request.Option) (*btpmanagment.GetServicePlanOutput, error)
+	GetServicePlanRequestFunc               func(ctx context.Context, input *btpmanagment.GetServicePlanInput) (*request.Request, *btpmanagment.GetServicePlanOutput)
+	GetVisibilitiesFunc                     func(ctx context.Context, input *btpmanagment.GetVisibilitiesInput, opts ...request.Option) (*btpmanagment.GetVisibilitiesOutput, error)
+	GetVisibilitiesRequestFunc              func(ctx context.Context, input *btpmanagment.GetVisibilitiesInput) (*request.Request, *btpmanagment.GetVisibilitiesOutput)
+	CreateVisibilityFunc                    func(ctx context.Context, input *btpmanagment.CreateVisibilityInput, opts ...request.Option) (*btpmanagment.CreateVisibilityOutput, error)
+	CreateVisibilityRequestFunc             func(ctx context.Context, input *btpmanagment.CreateVisibilityInput) (*request.Request, *btpmanagment.CreateVisibilityOutput)
+	GetVisibilityFunc                       func(ctx context.Context, input *btpmanagment.GetVisibilityInput, opts ...request.Option) (*btpmanagment.GetVisibilityOutput, error)
+	GetVisibilityRequestFunc                func(ctx context.Context, input *btpmanagment.GetVisibilityInput) (*request.Request, *btpmanagment.GetVisibilityOutput)
+	UpdateVisibilityFunc                    func(ctx context.Context, input *btpmanagment.UpdateVisibilityInput, opts ...request.Option) (*btpmanagment.UpdateVisibilityOutput, error)
+	UpdateVisibilityRequestFunc             func(ctx context.Context, input *btpmanagment.UpdateVisibilityInput) (*request.Request, *btpmanagment.UpdateVisibilityOutput)
+	DeleteVisibilityFunc                    func(ctx context.Context, input *btpmanagment.DeleteVisibilityInput, opts ...request.Option) (*btpmanagment.DeleteVisibilityOutput, error)
+	DeleteVisibilityRequestFunc             func(ctx context.Context, input *btpmanagment.DeleteVisibilityInput) (*request.Request, *btpmanagment.DeleteVisibilityOutput)
+}
+
+var _ btpmanagment.ServiceManagementAPI = (*ServiceManagementAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpmanagmentmock: %s is not stubbed", method)
+}
+
+func (m *ServiceManagementAPI) RotateServiceBinding(ctx context.Context, input *btpmanagment.RotateServiceBindingInput, opts ...request.Option) (*btpmanagment.ServiceBindingRotation, error) {
+	if m.RotateServiceBindingFunc == nil {
+		return nil, notStubbed("RotateServiceBinding")
+	}
+	return m.RotateServiceBindingFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) SyncLabels(ctx context.Context, resource btpmanagment.LabeledResource, desired map[string][]string, opts ...request.Option) ([]btpmanagment.Label, error) {
+	if m.SyncLabelsFunc == nil {
+		return nil, notStubbed("SyncLabels")
+	}
+	return m.SyncLabelsFunc(ctx, resource, desired, opts...)
+}
+
+func (m *ServiceManagementAPI) GetOperationStatus(ctx context.Context, input *btpmanagment.GetOperationStatusInput, opts ...request.Option) (*btpmanagment.GetOperationStatusOutput, error) {
+	if m.GetOperationStatusFunc == nil {
+		return nil, notStubbed("GetOperationStatus")
+	}
+	return m.GetOperationStatusFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetOperationStatusRequest(ctx context.Context, input *btpmanagment.GetOperationStatusInput) (*request.Request, *btpmanagment.GetOperationStatusOutput) {
+	if m.GetOperationStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetOperationStatusRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetOperations(ctx context.Context, input *btpmanagment.GetOperationsInput, opts ...request.Option) (*btpmanagment.GetOperationsOutput, error) {
+	if m.GetOperationsFunc == nil {
+		return nil, notStubbed("GetOperations")
+	}
+	return m.GetOperationsFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetOperationsRequest(ctx context.Context, input *btpmanagment.GetOperationsInput) (*request.Request, *btpmanagment.GetOperationsOutput) {
+	if m.GetOperationsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetOperationsRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) WaitForOperation(ctx context.Context, op btpmanagment.AsyncOperation, interval time.Duration, opts ...request.Option) (*btpmanagment.GetOperationStatusOutput, error) {
+	if m.WaitForOperationFunc == nil {
+		return nil, notStubbed("WaitForOperation")
+	}
+	return m.WaitForOperationFunc(ctx, op, interval, opts...)
+}
+
+func (m *ServiceManagementAPI) GetPlatforms(ctx context.Context, input *btpmanagment.GetPlatformsInput, opts ...request.Option) (*btpmanagment.GetPlatformsOutput, error) {
+	if m.GetPlatformsFunc == nil {
+		return nil, notStubbed("GetPlatforms")
+	}
+	return m.GetPlatformsFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetPlatformsRequest(ctx context.Context, input *btpmanagment.GetPlatformsInput) (*request.Request, *btpmanagment.GetPlatformsOutput) {
+	if m.GetPlatformsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetPlatformsRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) CreatePlatform(ctx context.Context, input *btpmanagment.CreatePlatformInput, opts ...request.Option) (*btpmanagment.CreatePlatformOutput, error) {
+	if m.CreatePlatformFunc == nil {
+		return nil, notStubbed("CreatePlatform")
+	}
+	return m.CreatePlatformFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) CreatePlatformRequest(ctx context.Context, input *btpmanagment.CreatePlatformInput) (*request.Request, *btpmanagment.CreatePlatformOutput) {
+	if m.CreatePlatformRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreatePlatformRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetPlatform(ctx context.Context, input *btpmanagment.GetPlatformInput, opts ...request.Option) (*btpmanagment.GetPlatformOutput, error) {
+	if m.GetPlatformFunc == nil {
+		return nil, notStubbed("GetPlatform")
+	}
+	return m.GetPlatformFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetPlatformRequest(ctx context.Context, input *btpmanagment.GetPlatformInput) (*request.Request, *btpmanagment.GetPlatformOutput) {
+	if m.GetPlatformRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetPlatformRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) DeletePlatform(ctx context.Context, input *btpmanagment.DeletePlatformInput, opts ...request.Option) (*btpmanagment.DeletePlatformOutput, error) {
+	if m.DeletePlatformFunc == nil {
+		return nil, notStubbed("DeletePlatform")
+	}
+	return m.DeletePlatformFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) DeletePlatformRequest(ctx context.Context, input *btpmanagment.DeletePlatformInput) (*request.Request, *btpmanagment.DeletePlatformOutput) {
+	if m.DeletePlatformRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeletePlatformRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) UpdatePlatform(ctx context.Context, input *btpmanagment.UpdatePlatformInput, opts ...request.Option) (*btpmanagment.UpdatePlatformOutput, error) {
+	if m.UpdatePlatformFunc == nil {
+		return nil, notStubbed("UpdatePlatform")
+	}
+	return m.UpdatePlatformFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) UpdatePlatformRequest(ctx context.Context, input *btpmanagment.UpdatePlatformInput) (*request.Request, *btpmanagment.UpdatePlatformOutput) {
+	if m.UpdatePlatformRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdatePlatformRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceBindings(ctx context.Context, input *btpmanagment.GetServiceBindingsInput, opts ...request.Option) (*btpmanagment.GetServiceBindingsOutput, error) {
+	if m.GetServiceBindingsFunc == nil {
+		return nil, notStubbed("GetServiceBindings")
+	}
+	return m.GetServiceBindingsFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceBindingsRequest(ctx context.Context, input *btpmanagment.GetServiceBindingsInput) (*request.Request, *btpmanagment.GetServiceBindingsOutput) {
+	if m.GetServiceBindingsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceBindingsRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) CreateServiceBinding(ctx context.Context, input *btpmanagment.CreateServiceBindingInput, opts ...request.Option) (*btpmanagment.CreateServiceBindingOutput, error) {
+	if m.CreateServiceBindingFunc == nil {
+		return nil, notStubbed("CreateServiceBinding")
+	}
+	return m.CreateServiceBindingFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) CreateServiceBindingRequest(ctx context.Context, input *btpmanagment.CreateServiceBindingInput) (*request.Request, *btpmanagment.CreateServiceBindingOutput) {
+	if m.CreateServiceBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateServiceBindingRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceBinding(ctx context.Context, input *btpmanagment.GetServiceBindingInput, opts ...request.Option) (*btpmanagment.GetServiceBindingOutput, error) {
+	if m.GetServiceBindingFunc == nil {
+		return nil, notStubbed("GetServiceBinding")
+	}
+	return m.GetServiceBindingFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceBindingRequest(ctx context.Context, input *btpmanagment.GetServiceBindingInput) (*request.Request, *btpmanagment.GetServiceBindingOutput) {
+	if m.GetServiceBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceBindingRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) DeleteServiceBinding(ctx context.Context, input *btpmanagment.DeleteServiceBindingInput, opts ...request.Option) (*btpmanagment.DeleteServiceBindingOutput, error) {
+	if m.DeleteServiceBindingFunc == nil {
+		return nil, notStubbed("DeleteServiceBinding")
+	}
+	return m.DeleteServiceBindingFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) DeleteServiceBindingRequest(ctx context.Context, input *btpmanagment.DeleteServiceBindingInput) (*request.Request, *btpmanagment.DeleteServiceBindingOutput) {
+	if m.DeleteServiceBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteServiceBindingRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) UpdateServiceBinding(ctx context.Context, input *btpmanagment.UpdateServiceBindingInput, opts ...request.Option) (*btpmanagment.UpdateServiceBindingOutput, error) {
+	if m.UpdateServiceBindingFunc == nil {
+		return nil, notStubbed("UpdateServiceBinding")
+	}
+	return m.UpdateServiceBindingFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) UpdateServiceBindingRequest(ctx context.Context, input *btpmanagment.UpdateServiceBindingInput) (*request.Request, *btpmanagment.UpdateServiceBindingOutput) {
+	if m.UpdateServiceBindingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateServiceBindingRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceBindingParameters(ctx context.Context, input *btpmanagment.GetServiceBindingParametersInput, opts ...request.Option) (*btpmanagment.GetServiceBindingParametersOutput, error) {
+	if m.GetServiceBindingParametersFunc == nil {
+		return nil, notStubbed("GetServiceBindingParameters")
+	}
+	return m.GetServiceBindingParametersFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceBindingParametersRequest(ctx context.Context, input *btpmanagment.GetServiceBindingParametersInput) (*request.Request, *btpmanagment.GetServiceBindingParametersOutput) {
+	if m.GetServiceBindingParametersRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceBindingParametersRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceBrokers(ctx context.Context, input *btpmanagment.GetServiceBrokersInput, opts ...request.Option) (*btpmanagment.GetServiceBrokersOutput, error) {
+	if m.GetServiceBrokersFunc == nil {
+		return nil, notStubbed("GetServiceBrokers")
+	}
+	return m.GetServiceBrokersFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceBrokersRequest(ctx context.Context, input *btpmanagment.GetServiceBrokersInput) (*request.Request, *btpmanagment.GetServiceBrokersOutput) {
+	if m.GetServiceBrokersRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceBrokersRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceBroker(ctx context.Context, input *btpmanagment.GetServiceBrokerInput, opts ...request.Option) (*btpmanagment.GetServiceBrokerOutput, error) {
+	if m.GetServiceBrokerFunc == nil {
+		return nil, notStubbed("GetServiceBroker")
+	}
+	return m.GetServiceBrokerFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceBrokerRequest(ctx context.Context, input *btpmanagment.GetServiceBrokerInput) (*request.Request, *btpmanagment.GetServiceBrokerOutput) {
+	if m.GetServiceBrokerRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceBrokerRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) RegisterServiceBroker(ctx context.Context, input *btpmanagment.RegisterServiceBrokerInput, opts ...request.Option) (*btpmanagment.RegisterServiceBrokerOutput, error) {
+	if m.RegisterServiceBrokerFunc == nil {
+		return nil, notStubbed("RegisterServiceBroker")
+	}
+	return m.RegisterServiceBrokerFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) RegisterServiceBrokerRequest(ctx context.Context, input *btpmanagment.RegisterServiceBrokerInput) (*request.Request, *btpmanagment.RegisterServiceBrokerOutput) {
+	if m.RegisterServiceBrokerRequestFunc == nil {
+		return nil, nil
+	}
+	return m.RegisterServiceBrokerRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) UpdateServiceBroker(ctx context.Context, input *btpmanagment.UpdateServiceBrokerInput, opts ...request.Option) (*btpmanagment.UpdateServiceBrokerOutput, error) {
+	if m.UpdateServiceBrokerFunc == nil {
+		return nil, notStubbed("UpdateServiceBroker")
+	}
+	return m.UpdateServiceBrokerFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) UpdateServiceBrokerRequest(ctx context.Context, input *btpmanagment.UpdateServiceBrokerInput) (*request.Request, *btpmanagment.UpdateServiceBrokerOutput) {
+	if m.UpdateServiceBrokerRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateServiceBrokerRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) RefreshServiceBrokerCatalog(ctx context.Context, input *btpmanagment.RefreshServiceBrokerCatalogInput, opts ...request.Option) (*btpmanagment.UpdateServiceBrokerOutput, error) {
+	if m.RefreshServiceBrokerCatalogFunc == nil {
+		return nil, notStubbed("RefreshServiceBrokerCatalog")
+	}
+	return m.RefreshServiceBrokerCatalogFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) DeleteServiceBroker(ctx context.Context, input *btpmanagment.DeleteServiceBrokerInput, opts ...request.Option) (*btpmanagment.DeleteServiceBrokerOutput, error) {
+	if m.DeleteServiceBrokerFunc == nil {
+		return nil, notStubbed("DeleteServiceBroker")
+	}
+	return m.DeleteServiceBrokerFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) DeleteServiceBrokerRequest(ctx context.Context, input *btpmanagment.DeleteServiceBrokerInput) (*request.Request, *btpmanagment.DeleteServiceBrokerOutput) {
+	if m.DeleteServiceBrokerRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteServiceBrokerRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceInstances(ctx context.Context, input *btpmanagment.GetServiceInstancesInput, opts ...request.Option) (*btpmanagment.GetServiceInstancesOutput, error) {
+	if m.GetServiceInstancesFunc == nil {
+		return nil, notStubbed("GetServiceInstances")
+	}
+	return m.GetServiceInstancesFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceInstancesRequest(ctx context.Context, input *btpmanagment.GetServiceInstancesInput) (*request.Request, *btpmanagment.GetServiceInstancesOutput) {
+	if m.GetServiceInstancesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceInstancesRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) CreateServiceInstance(ctx context.Context, input *btpmanagment.CreateServiceInstanceInput, opts ...request.Option) (*btpmanagment.CreateServiceInstanceOutput, error) {
+	if m.CreateServiceInstanceFunc == nil {
+		return nil, notStubbed("CreateServiceInstance")
+	}
+	return m.CreateServiceInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) CreateServiceInstanceRequest(ctx context.Context, input *btpmanagment.CreateServiceInstanceInput) (*request.Request, *btpmanagment.CreateServiceInstanceOutput) {
+	if m.CreateServiceInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateServiceInstanceRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceInstance(ctx context.Context, input *btpmanagment.GetServiceInstanceInput, opts ...request.Option) (*btpmanagment.GetServiceInstanceOutput, error) {
+	if m.GetServiceInstanceFunc == nil {
+		return nil, notStubbed("GetServiceInstance")
+	}
+	return m.GetServiceInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceInstanceRequest(ctx context.Context, input *btpmanagment.GetServiceInstanceInput) (*request.Request, *btpmanagment.GetServiceInstanceOutput) {
+	if m.GetServiceInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceInstanceRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) DeleteServiceInstance(ctx context.Context, input *btpmanagment.DeleteServiceInstanceInput, opts ...request.Option) (*btpmanagment.DeleteServiceInstanceOutput, error) {
+	if m.DeleteServiceInstanceFunc == nil {
+		return nil, notStubbed("DeleteServiceInstance")
+	}
+	return m.DeleteServiceInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) DeleteServiceInstanceRequest(ctx context.Context, input *btpmanagment.DeleteServiceInstanceInput) (*request.Request, *btpmanagment.DeleteServiceInstanceOutput) {
+	if m.DeleteServiceInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteServiceInstanceRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) UpdateServiceInstance(ctx context.Context, input *btpmanagment.UpdateServiceInstanceInput, opts ...request.Option) (*btpmanagment.UpdateServiceInstanceOutput, error) {
+	if m.UpdateServiceInstanceFunc == nil {
+		return nil, notStubbed("UpdateServiceInstance")
+	}
+	return m.UpdateServiceInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) UpdateServiceInstanceRequest(ctx context.Context, input *btpmanagment.UpdateServiceInstanceInput) (*request.Request, *btpmanagment.UpdateServiceInstanceOutput) {
+	if m.UpdateServiceInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateServiceInstanceRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceInstanceParameters(ctx context.Context, input *btpmanagment.GetServiceInstanceParametersInput, opts ...request.Option) (*btpmanagment.GetServiceInstanceParametersOutput, error) {
+	if m.GetServiceInstanceParametersFunc == nil {
+		return nil, notStubbed("GetServiceInstanceParameters")
+	}
+	return m.GetServiceInstanceParametersFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceInstanceParametersRequest(ctx context.Context, input *btpmanagment.GetServiceInstanceParametersInput) (*request.Request, *btpmanagment.GetServiceInstanceParametersOutput) {
+	if m.GetServiceInstanceParametersRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceInstanceParametersRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceOfferings(ctx context.Context, input *btpmanagment.GetServiceOfferingsInput, opts ...request.Option) (*btpmanagment.GetServiceOfferingsOutput, error) {
+	if m.GetServiceOfferingsFunc == nil {
+		return nil, notStubbed("GetServiceOfferings")
+	}
+	return m.GetServiceOfferingsFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceOfferingsRequest(ctx context.Context, input *btpmanagment.GetServiceOfferingsInput) (*request.Request, *btpmanagment.GetServiceOfferingsOutput) {
+	if m.GetServiceOfferingsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceOfferingsRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServiceOffering(ctx context.Context, input *btpmanagment.GetServiceOfferingInput, opts ...request.Option) (*btpmanagment.GetServiceOfferingOutput, error) {
+	if m.GetServiceOfferingFunc == nil {
+		return nil, notStubbed("GetServiceOffering")
+	}
+	return m.GetServiceOfferingFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServiceOfferingRequest(ctx context.Context, input *btpmanagment.GetServiceOfferingInput) (*request.Request, *btpmanagment.GetServiceOfferingOutput) {
+	if m.GetServiceOfferingRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServiceOfferingRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServicePlans(ctx context.Context, input *btpmanagment.GetServicePlansInput, opts ...request.Option) (*btpmanagment.GetServicePlansOutput, error) {
+	if m.GetServicePlansFunc == nil {
+		return nil, notStubbed("GetServicePlans")
+	}
+	return m.GetServicePlansFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServicePlansRequest(ctx context.Context, input *btpmanagment.GetServicePlansInput) (*request.Request, *btpmanagment.GetServicePlansOutput) {
+	if m.GetServicePlansRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServicePlansRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetServicePlan(ctx context.Context, input *btpmanagment.GetServicePlanInput, opts ...request.Option) (*btpmanagment.GetServicePlanOutput, error) {
+	if m.GetServicePlanFunc == nil {
+		return nil, notStubbed("GetServicePlan")
+	}
+	return m.GetServicePlanFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetServicePlanRequest(ctx context.Context, input *btpmanagment.GetServicePlanInput) (*request.Request, *btpmanagment.GetServicePlanOutput) {
+	if m.GetServicePlanRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServicePlanRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetVisibilities(ctx context.Context, input *btpmanagment.GetVisibilitiesInput, opts ...request.Option) (*btpmanagment.GetVisibilitiesOutput, error) {
+	if m.GetVisibilitiesFunc == nil {
+		return nil, notStubbed("GetVisibilities")
+	}
+	return m.GetVisibilitiesFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetVisibilitiesRequest(ctx context.Context, input *btpmanagment.GetVisibilitiesInput) (*request.Request, *btpmanagment.GetVisibilitiesOutput) {
+	if m.GetVisibilitiesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetVisibilitiesRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) CreateVisibility(ctx context.Context, input *btpmanagment.CreateVisibilityInput, opts ...request.Option) (*btpmanagment.CreateVisibilityOutput, error) {
+	if m.CreateVisibilityFunc == nil {
+		return nil, notStubbed("CreateVisibility")
+	}
+	return m.CreateVisibilityFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) CreateVisibilityRequest(ctx context.Context, input *btpmanagment.CreateVisibilityInput) (*request.Request, *btpmanagment.CreateVisibilityOutput) {
+	if m.CreateVisibilityRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateVisibilityRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) GetVisibility(ctx context.Context, input *btpmanagment.GetVisibilityInput, opts ...request.Option) (*btpmanagment.GetVisibilityOutput, error) {
+	if m.GetVisibilityFunc == nil {
+		return nil, notStubbed("GetVisibility")
+	}
+	return m.GetVisibilityFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) GetVisibilityRequest(ctx context.Context, input *btpmanagment.GetVisibilityInput) (*request.Request, *btpmanagment.GetVisibilityOutput) {
+	if m.GetVisibilityRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetVisibilityRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) UpdateVisibility(ctx context.Context, input *btpmanagment.UpdateVisibilityInput, opts ...request.Option) (*btpmanagment.UpdateVisibilityOutput, error) {
+	if m.UpdateVisibilityFunc == nil {
+		return nil, notStubbed("UpdateVisibility")
+	}
+	return m.UpdateVisibilityFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) UpdateVisibilityRequest(ctx context.Context, input *btpmanagment.UpdateVisibilityInput) (*request.Request, *btpmanagment.UpdateVisibilityOutput) {
+	if m.UpdateVisibilityRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateVisibilityRequestFunc(ctx, input)
+}
+
+func (m *ServiceManagementAPI) DeleteVisibility(ctx context.Context, input *btpmanagment.DeleteVisibilityInput, opts ...request.Option) (*btpmanagment.DeleteVisibilityOutput, error) {
+	if m.DeleteVisibilityFunc == nil {
+		return nil, notStubbed("DeleteVisibility")
+	}
+	return m.DeleteVisibilityFunc(ctx, input, opts...)
+}
+
+func (m *ServiceManagementAPI) DeleteVisibilityRequest(ctx context.Context, input *btpmanagment.DeleteVisibilityInput) (*request.Request, *btpmanagment.DeleteVisibilityOutput) {
+	if m.DeleteVisibilityRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteVisibilityRequestFunc(ctx, input)
+}
@@ -1,5 +1,7 @@
 package btpmanagment
 
+import "github.com/nnicora/sap-sdk-go/service/types"
+
 type Operation struct {
 	//The ID of the operation.
 	Id string `json:"id,omitempty"`
@@ -11,14 +13,14 @@ type Operation struct {
 	//Possible values:
 	//Enum:
 	//	[ CREATE, UPDATE, DELETE ]
-	Type string `json:"type,omitempty"`
+	Type OperationType `json:"type,omitempty"`
 
 	//Valid values are: in progress, succeeded, and failed.
 	//While the state is "in progress", the platform should continue polling.
 	//The responses: "state": "succeeded" or "state": "failed" must cause the platform to stop polling.
 	//Enum:
 	//	[ in progress, succeeded, failed ]
-	State string `json:"state,omitempty"`
+	State OperationState `json:"state,omitempty"`
 
 	//Details about the operation for customer-facing UI.
 	Description string `json:"description,omitempty"`
@@ -44,16 +46,16 @@ type Operation struct {
 	//The time the resource is scheduled for deletion.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	DeletionScheduled string `json:"deletion_scheduled,omitempty"`
+	DeletionScheduled types.ISOTime `json:"deletion_scheduled,omitempty"`
 
 	//The time the resource was created.
 	//In ISO 8601 format.
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 
 	//The last time the resource was updated.
 	//In ISO 8601 format.
 	//Recommended field if "state": "succeeded" or "state": "failed".
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 
 	//The list of the errors if the operation has failed.
 	Errors []Error `json:"errors,omitempty"`
@@ -69,7 +71,7 @@ type TransitiveResource struct {
 	Type string `json:"type,omitempty"`
 
 	//The type of the operation associated with the resource.
-	OperationType string `json:"operation_type,omitempty"`
+	OperationType OperationType `json:"operation_type,omitempty"`
 
 	//The minimum criteria required to use the resource in the context of the platform.
 	Criteria string `json:"criteria,omitempty"`
@@ -84,6 +86,14 @@ type Error struct {
 	ErrorDescription string `json:"description,omitempty"`
 }
 
+// NestedError is the Error of the outputs embedding a resource with a description of its own: it is
+// one level deeper than the resource, so that the description of the resource is decoded rather than
+// dropped along with the one of the error. The description of a failed request is decoded into the
+// description of the resource.
+type NestedError struct {
+	Error
+}
+
 type Credentials struct {
 	Basic Basic `json:"basic,omitempty"`
 }
@@ -0,0 +1,131 @@
+package btpmanagment
+
+// OperationState is the state of an operation on a Service Manager resource.
+type OperationState string
+
+const (
+	OperationStateInProgress OperationState = "in progress"
+	OperationStateSucceeded  OperationState = "succeeded"
+	OperationStateFailed     OperationState = "failed"
+)
+
+func (OperationState) Values() []OperationState {
+	return []OperationState{
+		OperationStateInProgress,
+		OperationStateSucceeded,
+		OperationStateFailed,
+	}
+}
+
+func (s OperationState) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the operation has ended and polling should stop.
+func (s OperationState) IsTerminal() bool {
+	if !s.Valid() {
+		return false
+	}
+	switch s {
+	case OperationStateInProgress:
+		return false
+	}
+	return true
+}
+
+func (s OperationState) IsFailure() bool {
+	switch s {
+	case OperationStateFailed:
+		return true
+	}
+	return false
+}
+
+// OperationType is the type of an operation on a Service Manager resource.
+type OperationType string
+
+const (
+	OperationTypeCreate OperationType = "CREATE"
+	OperationTypeUpdate OperationType = "UPDATE"
+	OperationTypeDelete OperationType = "DELETE"
+)
+
+func (OperationType) Values() []OperationType {
+	return []OperationType{
+		OperationTypeCreate,
+		OperationTypeUpdate,
+		OperationTypeDelete,
+	}
+}
+
+func (t OperationType) Valid() bool {
+	for _, v := range t.Values() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelOperation is the change a Label makes to the labels of a resource.
+type LabelOperation string
+
+const (
+	LabelOperationAdd          LabelOperation = "add"
+	LabelOperationAddValues    LabelOperation = "add_values"
+	LabelOperationRemove       LabelOperation = "remove"
+	LabelOperationRemoveValues LabelOperation = "remove_values"
+)
+
+func (LabelOperation) Values() []LabelOperation {
+	return []LabelOperation{
+		LabelOperationAdd,
+		LabelOperationAddValues,
+		LabelOperationRemove,
+		LabelOperationRemoveValues,
+	}
+}
+
+func (o LabelOperation) Valid() bool {
+	for _, v := range o.Values() {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceType is the kind of a Service Manager resource, as written in the paths of the API.
+type ResourceType string
+
+const (
+	ResourceTypePlatforms        ResourceType = "platforms"
+	ResourceTypeServiceBrokers   ResourceType = "service_brokers"
+	ResourceTypeServiceInstances ResourceType = "service_instances"
+	ResourceTypeServiceBindings  ResourceType = "service_bindings"
+	ResourceTypeVisibilities     ResourceType = "visibilities"
+)
+
+func (ResourceType) Values() []ResourceType {
+	return []ResourceType{
+		ResourceTypePlatforms,
+		ResourceTypeServiceBrokers,
+		ResourceTypeServiceInstances,
+		ResourceTypeServiceBindings,
+		ResourceTypeVisibilities,
+	}
+}
+
+func (t ResourceType) Valid() bool {
+	for _, v := range t.Values() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpmanagment
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"time"
+)
+
+// ServiceManagementAPI is the interface implemented by ServiceManagementV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpmanagmentmock.
+type ServiceManagementAPI interface {
+	RotateServiceBinding(ctx context.Context, input *RotateServiceBindingInput, opts ...request.Option) (*ServiceBindingRotation, error)
+	SyncLabels(ctx context.Context, resource LabeledResource, desired map[string][]string, opts ...request.Option) ([]Label, error)
+	GetOperationStatus(ctx context.Context, input *GetOperationStatusInput, opts ...request.Option) (*GetOperationStatusOutput, error)
+	GetOperationStatusRequest(ctx context.Context, input *GetOperationStatusInput) (*request.Request, *GetOperationStatusOutput)
+	GetOperations(ctx context.Context, input *GetOperationsInput, opts ...request.Option) (*GetOperationsOutput, error)
+	GetOperationsRequest(ctx context.Context, input *GetOperationsInput) (*request.Request, *GetOperationsOutput)
+	WaitForOperation(ctx context.Context, op AsyncOperation, interval time.Duration, opts ...request.Option) (*GetOperationStatusOutput, error)
+	GetPlatforms(ctx context.Context, input *GetPlatformsInput, opts ...request.Option) (*GetPlatformsOutput, error)
+	GetPlatformsRequest(ctx context.Context, input *GetPlatformsInput) (*request.Request, *GetPlatformsOutput)
+	CreatePlatform(ctx context.Context, input *CreatePlatformInput, opts ...request.Option) (*CreatePlatformOutput, error)
+	CreatePlatformRequest(ctx context.Context, input *CreatePlatformInput) (*request.Request, *CreatePlatformOutput)
+	GetPlatform(ctx context.Context, input *GetPlatformInput, opts ...request.Option) (*GetPlatformOutput, error)
+	GetPlatformRequest(ctx context.Context, input *GetPlatformInput) (*request.Request, *GetPlatformOutput)
+	DeletePlatform(ctx context.Context, input *DeletePlatformInput, opts ...request.Option) (*DeletePlatformOutput, error)
+	DeletePlatformRequest(ctx context.Context, input *DeletePlatformInput) (*request.Request, *DeletePlatformOutput)
+	UpdatePlatform(ctx context.Context, input *UpdatePlatformInput, opts ...request.Option) (*UpdatePlatformOutput, error)
+	UpdatePlatformRequest(ctx context.Context, input *UpdatePlatformInput) (*request.Request, *UpdatePlatformOutput)
+	GetServiceBindings(ctx context.Context, input *GetServiceBindingsInput, opts ...request.Option) (*GetServiceBindingsOutput, error)
+	GetServiceBindingsRequest(ctx context.Context, input *GetServiceBindingsInput) (*request.Request, *GetServiceBindingsOutput)
+	CreateServiceBinding(ctx context.Context, input *CreateServiceBindingInput, opts ...request.Option) (*CreateServiceBindingOutput, error)
+	CreateServiceBindingRequest(ctx context.Context, input *CreateServiceBindingInput) (*request.Request, *CreateServiceBindingOutput)
+	GetServiceBinding(ctx context.Context, input *GetServiceBindingInput, opts ...request.Option) (*GetServiceBindingOutput, error)
+	GetServiceBindingRequest(ctx context.Context, input *GetServiceBindingInput) (*request.Request, *GetServiceBindingOutput)
+	DeleteServiceBinding(ctx context.Context, input *DeleteServiceBindingInput, opts ...request.Option) (*DeleteServiceBindingOutput, error)
+	DeleteServiceBindingRequest(ctx context.Context, input *DeleteServiceBindingInput) (*request.Request, *DeleteServiceBindingOutput)
+	UpdateServiceBinding(ctx context.Context, input *UpdateServiceBindingInput, opts ...request.Option) (*UpdateServiceBindingOutput, error)
+	UpdateServiceBindingRequest(ctx context.Context, input *UpdateServiceBindingInput) (*request.Request, *UpdateServiceBindingOutput)
+	GetServiceBindingParameters(ctx context.Context, input *GetServiceBindingParametersInput, opts ...request.Option) (*GetServiceBindingParametersOutput, error)
+	GetServiceBindingParametersRequest(ctx context.Context, input *GetServiceBindingParametersInput) (*request.Request, *GetServiceBindingParametersOutput)
+	GetServiceBrokers(ctx context.Context, input *GetServiceBrokersInput, opts ...request.Option) (*GetServiceBrokersOutput, error)
+	GetServiceBrokersRequest(ctx context.Context, input *GetServiceBrokersInput) (*request.Request, *GetServiceBrokersOutput)
+	GetServiceBroker(ctx context.Context, input *GetServiceBrokerInput, opts ...request.Option) (*GetServiceBrokerOutput, error)
+	GetServiceBrokerRequest(ctx context.Context, input *GetServiceBrokerInput) (*request.Request, *GetServiceBrokerOutput)
+	RegisterServiceBroker(ctx context.Context, input *RegisterServiceBrokerInput, opts ...request.Option) (*RegisterServiceBrokerOutput, error)
+	RegisterServiceBrokerRequest(ctx context.Context, input *RegisterServiceBrokerInput) (*request.Request, *RegisterServiceBrokerOutput)
+	UpdateServiceBroker(ctx context.Context, input *UpdateServiceBrokerInput, opts ...request.Option) (*UpdateServiceBrokerOutput, error)
+	UpdateServiceBrokerRequest(ctx context.Context, input *UpdateServiceBrokerInput) (*request.Request, *UpdateServiceBrokerOutput)
+	RefreshServiceBrokerCatalog(ctx context.Context, input *RefreshServiceBrokerCatalogInput, opts ...request.Option) (*UpdateServiceBrokerOutput, error)
+	DeleteServiceBroker(ctx context.Context, input *DeleteServiceBrokerInput, opts ...request.Option) (*DeleteServiceBrokerOutput, error)
+	DeleteServiceBrokerRequest(ctx context.Context, input *DeleteServiceBrokerInput) (*request.Request, *DeleteServiceBrokerOutput)
+	GetServiceInstances(ctx context.Context, input *GetServiceInstancesInput, opts ...request.Option) (*GetServiceInstancesOutput, error)
+	GetServiceInstancesRequest(ctx context.Context, input *GetServiceInstancesInput) (*request.Request, *GetServiceInstancesOutput)
+	CreateServiceInstance(ctx context.Context, input *CreateServiceInstanceInput, opts ...request.Option) (*CreateServiceInstanceOutput, error)
+	CreateServiceInstanceRequest(ctx context.Context, input *CreateServiceInstanceInput) (*request.Request, *CreateServiceInstanceOutput)
+	GetServiceInstance(ctx context.Context, input *GetServiceInstanceInput, opts ...request.Option) (*GetServiceInstanceOutput, error)
+	GetServiceInstanceRequest(ctx context.Context, input *GetServiceInstanceInput) (*request.Request, *GetServiceInstanceOutput)
+	DeleteServiceInstance(ctx context.Context, input *DeleteServiceInstanceInput, opts ...request.Option) (*DeleteServiceInstanceOutput, error)
+	DeleteServiceInstanceRequest(ctx context.Context, input *DeleteServiceInstanceInput) (*request.Request, *DeleteServiceInstanceOutput)
+	UpdateServiceInstance(ctx context.Context, input *UpdateServiceInstanceInput, opts ...request.Option) (*UpdateServiceInstanceOutput, error)
+	UpdateServiceInstanceRequest(ctx context.Context, input *UpdateServiceInstanceInput) (*request.Request, *UpdateServiceInstanceOutput)
+	GetServiceInstanceParameters(ctx context.Context, input *GetServiceInstanceParametersInput, opts ...request.Option) (*GetServiceInstanceParametersOutput, error)
+	GetServiceInstanceParametersRequest(ctx context.Context, input *GetServiceInstanceParametersInput) (*request.Request, *GetServiceInstanceParametersOutput)
+	GetServiceOfferings(ctx context.Context, input *GetServiceOfferingsInput, opts ...request.Option) (*GetServiceOfferingsOutput, error)
+	GetServiceOfferingsRequest(ctx context.Context, input *GetServiceOfferingsInput) (*request.Request, *GetServiceOfferingsOutput)
+	GetServiceOffering(ctx context.Context, input *GetServiceOfferingInput, opts ...request.Option) (*GetServiceOfferingOutput, error)
+	GetServiceOfferingRequest(ctx context.Context, input *GetServiceOfferingInput) (*request.Request, *GetServiceOfferingOutput)
+	GetServicePlans(ctx context.Context, input *GetServicePlansInput, opts ...request.Option) (*GetServicePlansOutput, error)
+	GetServicePlansRequest(ctx context.Context, input *GetServicePlansInput) (*request.Request, *GetServicePlansOutput)
+	GetServicePlan(ctx context.Context, input *GetServicePlanInput, opts ...request.Option) (*GetServicePlanOutput, error)
+	GetServicePlanRequest(ctx context.Context, input *GetServicePlanInput) (*request.Request, *GetServicePlanOutput)
+	GetVisibilities(ctx context.Context, input *GetVisibilitiesInput, opts ...request.Option) (*GetVisibilitiesOutput, error)
+	GetVisibilitiesRequest(ctx context.Context, input *GetVisibilitiesInput) (*request.Request, *GetVisibilitiesOutput)
+	CreateVisibility(ctx context.Context, input *CreateVisibilityInput, opts ...request.Option) (*CreateVisibilityOutput, error)
+	CreateVisibilityRequest(ctx context.Context, input *CreateVisibilityInput) (*request.Request, *CreateVisibilityOutput)
+	GetVisibility(ctx context.Context, input *GetVisibilityInput, opts ...request.Option) (*GetVisibilityOutput, error)
+	GetVisibilityRequest(ctx context.Context, input *GetVisibilityInput) (*request.Request, *GetVisibilityOutput)
+	UpdateVisibility(ctx context.Context, input *UpdateVisibilityInput, opts ...request.Option) (*UpdateVisibilityOutput, error)
+	UpdateVisibilityRequest(ctx context.Context, input *UpdateVisibilityInput) (*request.Request, *UpdateVisibilityOutput)
+	DeleteVisibility(ctx context.Context, input *DeleteVisibilityInput, opts ...request.Option) (*DeleteVisibilityOutput, error)
+	DeleteVisibilityRequest(ctx context.Context, input *DeleteVisibilityInput) (*request.Request, *DeleteVisibilityOutput)
+}
+
+var _ ServiceManagementAPI = (*ServiceManagementV1)(nil)
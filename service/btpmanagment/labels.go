@@ -0,0 +1,168 @@
+package btpmanagment
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"sort"
+)
+
+// AddLabel adds a label which the resource does not have yet.
+func AddLabel(key string, values ...string) Label {
+	return Label{Op: LabelOperationAdd, Key: key, Values: values}
+}
+
+// AddLabelValues adds values to a label of the resource.
+func AddLabelValues(key string, values ...string) Label {
+	return Label{Op: LabelOperationAddValues, Key: key, Values: values}
+}
+
+// RemoveLabel removes a label, with all its values, from the resource.
+func RemoveLabel(key string) Label {
+	return Label{Op: LabelOperationRemove, Key: key}
+}
+
+// RemoveLabelValues removes values from a label of the resource.
+func RemoveLabelValues(key string, values ...string) Label {
+	return Label{Op: LabelOperationRemoveValues, Key: key, Values: values}
+}
+
+// DiffLabels returns the changes turning the current labels into the desired ones: labels
+// missing from current are added, labels missing from desired are removed, and for the
+// others only the values which differ are added or removed. Keys are sorted, so the result
+// is stable; it is empty when both sets of labels hold the same values.
+func DiffLabels(current, desired map[string][]string) []Label {
+	keys := make([]string, 0, len(current)+len(desired))
+	for k := range current {
+		keys = append(keys, k)
+	}
+	for k := range desired {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Label
+	for _, k := range keys {
+		have, had := current[k]
+		want, wanted := desired[k]
+		switch {
+		case !wanted || len(want) == 0:
+			if had {
+				changes = append(changes, RemoveLabel(k))
+			}
+		case !had || len(have) == 0:
+			changes = append(changes, AddLabel(k, distinct(want)...))
+		default:
+			// values are added first, so the label is never left without values
+			if added := subtract(want, have); len(added) > 0 {
+				changes = append(changes, AddLabelValues(k, added...))
+			}
+			if removed := subtract(have, want); len(removed) > 0 {
+				changes = append(changes, RemoveLabelValues(k, removed...))
+			}
+		}
+	}
+	return changes
+}
+
+// Values of a which are not in b, in the order of a and without duplicates.
+func subtract(a, b []string) []string {
+	skip := make(map[string]bool, len(b))
+	for _, v := range b {
+		skip[v] = true
+	}
+	var out []string
+	for _, v := range a {
+		if !skip[v] {
+			skip[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func distinct(values []string) []string {
+	return subtract(values, nil)
+}
+
+// LabeledResource identifies a Service Manager resource whose labels can be changed:
+// a platform, a service broker, a service instance, a service binding or a visibility.
+type LabeledResource struct {
+	Type ResourceType
+	ID   string
+}
+
+func (r LabeledResource) String() string {
+	return fmt.Sprintf("%s/%s", r.Type, r.ID)
+}
+
+// SyncLabels gets the labels of the resource and updates them to the desired ones, sending only
+// the changes computed by DiffLabels; no update is sent when the labels are already as desired.
+// It returns the changes applied.
+func (c *ServiceManagementV1) SyncLabels(ctx context.Context, resource LabeledResource,
+	desired map[string][]string, opts ...request.Option) ([]Label, error) {
+	current, err := c.currentLabels(ctx, resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	changes := DiffLabels(current, desired)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	switch resource.Type {
+	case ResourceTypePlatforms:
+		_, err = c.UpdatePlatform(ctx, &UpdatePlatformInput{PlatformID: resource.ID, Labels: changes}, opts...)
+	case ResourceTypeServiceInstances:
+		_, err = c.UpdateServiceInstance(ctx, &UpdateServiceInstanceInput{ServiceInstanceID: resource.ID, Labels: changes}, opts...)
+	case ResourceTypeServiceBindings:
+		_, err = c.UpdateServiceBinding(ctx, &UpdateServiceBindingInput{ServiceBindingID: resource.ID, Labels: changes}, opts...)
+	case ResourceTypeServiceBrokers:
+		_, err = c.UpdateServiceBroker(ctx, &UpdateServiceBrokerInput{ServiceBrokerID: resource.ID, Labels: changes}, opts...)
+	case ResourceTypeVisibilities:
+		_, err = c.UpdateVisibility(ctx, &UpdateVisibilityInput{VisibilityID: resource.ID, Labels: changes}, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (c *ServiceManagementV1) currentLabels(ctx context.Context, resource LabeledResource,
+	opts ...request.Option) (map[string][]string, error) {
+	switch resource.Type {
+	case ResourceTypePlatforms:
+		out, err := c.GetPlatform(ctx, &GetPlatformInput{PlatformID: resource.ID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return out.Labels, nil
+	case ResourceTypeServiceInstances:
+		out, err := c.GetServiceInstance(ctx, &GetServiceInstanceInput{ServiceInstanceID: resource.ID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return out.Labels, nil
+	case ResourceTypeServiceBindings:
+		out, err := c.GetServiceBinding(ctx, &GetServiceBindingInput{ServiceBindingID: resource.ID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return out.Labels, nil
+	case ResourceTypeServiceBrokers:
+		out, err := c.GetServiceBroker(ctx, &GetServiceBrokerInput{ServiceBrokerID: resource.ID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return out.Labels, nil
+	case ResourceTypeVisibilities:
+		out, err := c.GetVisibility(ctx, &GetVisibilityInput{VisibilityID: resource.ID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return out.Labels, nil
+	}
+	return nil, fmt.Errorf("labels of %s cannot be changed", resource)
+}
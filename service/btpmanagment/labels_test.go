@@ -0,0 +1,88 @@
+package btpmanagment
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+// Returns a client of the Service Manager API of the fake.
+func newClient(t *testing.T, srv *btpfake.Server) *ServiceManagementV1 {
+	sess, err := srv.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(sess)
+}
+
+func TestDiffLabels(t *testing.T) {
+	current := map[string][]string{
+		"team":  {"core", "platform"},
+		"env":   {"dev"},
+		"owner": {"alice"},
+		"empty": {},
+	}
+	desired := map[string][]string{
+		"team":   {"core", "billing", "billing"},
+		"env":    {"dev"},
+		"region": {"eu10"},
+		"empty":  {"x"},
+	}
+
+	expected := []Label{
+		AddLabel("empty", "x"),
+		RemoveLabel("owner"),
+		AddLabel("region", "eu10"),
+		AddLabelValues("team", "billing"),
+		RemoveLabelValues("team", "platform"),
+	}
+	if changes := DiffLabels(current, desired); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, changes)
+	}
+
+	if changes := DiffLabels(desired, desired); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestSyncLabels(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sm := newClient(t, srv)
+	ctx := context.Background()
+
+	created, err := sm.CreateServiceInstance(ctx, &CreateServiceInstanceInput{
+		Name:                "labeled",
+		ServiceOfferingName: "xsuaa",
+		ServicePlanName:     "application",
+		Labels:              map[string][]string{"team": {"core", "platform"}, "owner": {"alice"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance := LabeledResource{Type: ResourceTypeServiceInstances, ID: created.Id}
+
+	desired := map[string][]string{"team": {"core", "billing"}, "env": {"dev"}}
+	changes, err := sm.SyncLabels(ctx, instance, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 4 {
+		t.Fatalf("expected 4 changes, got %+v", changes)
+	}
+
+	out, err := sm.GetServiceInstance(ctx, &GetServiceInstanceInput{ServiceInstanceID: created.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Labels) != 2 || strings.Join(out.Labels["team"], ",") != "core,billing" || out.Labels["env"][0] != "dev" {
+		t.Fatalf("unexpected labels %v", out.Labels)
+	}
+
+	if changes, err = sm.SyncLabels(ctx, instance, desired); err != nil || len(changes) != 0 {
+		t.Fatalf("expected the labels to be in sync, got %+v, %v", changes, err)
+	}
+}
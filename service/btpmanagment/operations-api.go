@@ -2,8 +2,11 @@ package btpmanagment
 
 import (
 	"context"
+	"fmt"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
+	"strings"
+	"time"
 )
 
 const operations = "Service Management - Operations"
@@ -13,25 +16,26 @@ const operations = "Service Management - Operations"
 type GetOperationStatusInput struct {
 	//The type of the SAP Cloud Service Management service resource.
 	//Available values : platforms, service_brokers, service_bindings, service_instances
-	ResourceType string `dest:"uri" dest-name:"resourceType"`
+	ResourceType string `dest:"uri" dest-name:"resourceType" json:"-"`
 	//The ID of the previously created entity of the specified resource type.
-	ResourceID string `dest:"uri" dest-name:"resourceID"`
+	ResourceID string `dest:"uri" dest-name:"resourceID" json:"-"`
 	//The ID of the operation for which to get status.
-	OperationID string `dest:"uri" dest-name:"operationID"`
+	OperationID string `dest:"uri" dest-name:"operationID" json:"-"`
 }
 type GetOperationStatusOutput struct {
 	Operation
 
-	Error
+	NestedError
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) GetOperationStatus(ctx context.Context,
-	input *GetOperationStatusInput) (*GetOperationStatusOutput, error) {
-	req, out := c.getOperationStatusRequest(ctx, input)
+	input *GetOperationStatusInput, opts ...request.Option) (*GetOperationStatusOutput, error) {
+	req, out := c.GetOperationStatusRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getOperationStatusRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetOperationStatusRequest(ctx context.Context,
 	input *GetOperationStatusInput) (*request.Request, *GetOperationStatusOutput) {
 	op := &request.Operation{
 		Name: operations,
@@ -48,3 +52,131 @@ func (c *ServiceManagementV1) getOperationStatusRequest(ctx context.Context,
 	output := &GetOperationStatusOutput{}
 	return c.newRequest(ctx, op, input, output), output
 }
+
+// GET /v1/{resourceType}/{resourceID}/operations
+// Get all operations of a resource
+type GetOperationsInput struct {
+	//The type of the SAP Cloud Service Management service resource.
+	//Available values : platforms, service_brokers, service_bindings, service_instances
+	ResourceType string `dest:"uri" dest-name:"resourceType" json:"-"`
+	//The ID of the entity of the specified resource type whose operations to get.
+	ResourceID string `dest:"uri" dest-name:"resourceID" json:"-"`
+
+	//Filters the response based on the field query.
+	//For example:
+	//state eq 'failed'.
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
+
+	//The token returned by the previous call, to get the next page of operations.
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
+
+	//The maximum number of operations to return in the response.
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
+}
+type GetOperationsOutput struct {
+	//Use this token when you call the API again to get more operations of the resource.
+	Token string `json:"token,omitempty"`
+
+	//The number of the operations of the resource.
+	NumItems int64 `json:"num_items,omitempty"`
+
+	//The operations of the resource, the oldest first.
+	Items []Operation `json:"items,omitempty"`
+
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) GetOperations(ctx context.Context,
+	input *GetOperationsInput, opts ...request.Option) (*GetOperationsOutput, error) {
+	req, out := c.GetOperationsRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) GetOperationsRequest(ctx context.Context,
+	input *GetOperationsInput) (*request.Request, *GetOperationsOutput) {
+	op := &request.Operation{
+		Name: operations,
+		Http: request.HTTP{
+			Method: request.GET,
+			Path:   "/{resourceType}/{resourceID}/operations",
+		},
+	}
+
+	if input == nil {
+		input = &GetOperationsInput{}
+	}
+
+	output := &GetOperationsOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// AsyncOperation is part of the outputs of the operations which can run asynchronously. When the
+// service accepts such a request, it answers 202 Accepted with the operation to poll in the Location
+// header, e.g. /v1/service_instances/{resourceID}/operations/{operationID}.
+type AsyncOperation struct {
+	Location string `src:"header" src-name:"Location" json:"-"`
+}
+
+// OperationStatusInput returns the input of GetOperationStatus to poll the operation, and false
+// when the request was not accepted to run asynchronously.
+func (o AsyncOperation) OperationStatusInput() (*GetOperationStatusInput, bool) {
+	path := o.Location
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	if i := strings.Index(path, "/v1/"); i >= 0 {
+		path = path[i+len("/v1/"):]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 4 || parts[2] != "operations" || parts[0] == "" || parts[1] == "" || parts[3] == "" {
+		return nil, false
+	}
+	return &GetOperationStatusInput{ResourceType: parts[0], ResourceID: parts[1], OperationID: parts[3]}, true
+}
+
+// DefaultPollInterval is the time waited between two polls of an operation when no interval is given.
+const DefaultPollInterval = 2 * time.Second
+
+// WaitForOperation polls an operation accepted to run asynchronously until it succeeds or fails,
+// waiting interval (DefaultPollInterval when zero) between two polls. It returns nil when the request
+// was not accepted to run asynchronously, i.e. it has already completed, and an error when the
+// operation failed or ctx is done first.
+func (c *ServiceManagementV1) WaitForOperation(ctx context.Context, op AsyncOperation,
+	interval time.Duration, opts ...request.Option) (*GetOperationStatusOutput, error) {
+	input, ok := op.OperationStatusInput()
+	if !ok {
+		return nil, nil
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		out, err := c.GetOperationStatus(ctx, input, opts...)
+		if err != nil {
+			return nil, err
+		}
+		switch out.State {
+		case OperationStateSucceeded:
+			return out, nil
+		case OperationStateFailed:
+			return out, operationError(out)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return out, ctx.Err()
+		}
+	}
+}
+
+func operationError(out *GetOperationStatusOutput) error {
+	reason := out.Description
+	if len(out.Errors) > 0 {
+		reason = out.Errors[0].ErrorDescription
+	}
+	return fmt.Errorf("%s operation %s of %s %s failed; %s", out.Type, out.Id, out.ResourceType, out.ResourceId, reason)
+}
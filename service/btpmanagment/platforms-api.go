@@ -15,23 +15,23 @@ type GetPlatformsInput struct {
 	//If used, must be a nonempty string.
 	//For example:
 	//	type eq 'kubernetes'
-	FieldQuery string `dest:"querystring" dest-name:"fieldQuery"`
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
 
 	//Filters the response based on the label query.
 	//If used, must be a nonempty string.
 	//For example:
 	//	environment eq 'dev'
-	LabelQuery string `dest:"querystring" dest-name:"labelQuery"`
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
 
 	//You get this parameter in the response list of the API if the total number of items to return (num_items) is larger
 	//than the number of items returned in a single API call (max_items).
 	//You get a different token in each response to be used in each consecutive call as long as there are more items to list.
 	//Use the returned tokens to get the full list of items associated with your subaccount.
 	//If this is the first time you are calling the API, leave this field empty.
-	Token string `dest:"querystring" dest-name:"token"`
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
 
 	//The maximum number of platforms to return in the response.
-	MaxItems int64 `dest:"querystring" dest-name:"max_items"`
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
 }
 type GetPlatformsOutput struct {
 	//Use this token when you call the API again to get more platforms associated with your subaccount.
@@ -74,21 +74,22 @@ type PlatformItem struct {
 	//The time the platform was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 
 	//The last time the platform was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 
 	//Additional data associated with the resource entity.
 	Labels map[string][]string `json:"labels,omitempty"`
 }
 
-func (c *ServiceManagementV1) GetPlatforms(ctx context.Context, input *GetPlatformsInput) (*GetPlatformsOutput, error) {
-	req, out := c.getPlatformsRequest(ctx, input)
+func (c *ServiceManagementV1) GetPlatforms(ctx context.Context, input *GetPlatformsInput, opts ...request.Option) (*GetPlatformsOutput, error) {
+	req, out := c.GetPlatformsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getPlatformsRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetPlatformsRequest(ctx context.Context,
 	input *GetPlatformsInput) (*request.Request, *GetPlatformsOutput) {
 	op := &request.Operation{
 		Name: platforms,
@@ -114,13 +115,13 @@ type CreatePlatformInput struct {
 	//It can't contain white spaces.
 	//The name must not exceed 255 characters, but it is recommended to keep it much shorter, for the convenience
 	//of using short names in CLI commands.
-	Name string `json:"name,omitempty"`
+	Name string `json:"name,omitempty" required:"true"`
 
 	//The type of the platform.
 	//Possible values:
 	//Enum:
 	//	[ kubernetes ]
-	Type string `json:"type,omitempty"`
+	Type string `json:"type,omitempty" required:"true"`
 
 	//The description of the platform for customer-facing UIs.
 	Description string `json:"description,omitempty"`
@@ -155,11 +156,11 @@ type CreatePlatformOutput struct {
 	//The time the platform was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 
 	//The last time the platform was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 
 	//Additional data associated with the resource entity.
 	Labels map[string][]string `json:"labels,omitempty"`
@@ -169,11 +170,12 @@ type CreatePlatformOutput struct {
 }
 
 func (c *ServiceManagementV1) CreatePlatform(ctx context.Context,
-	input *CreatePlatformInput) (*CreatePlatformOutput, error) {
-	req, out := c.createPlatformRequest(ctx, input)
+	input *CreatePlatformInput, opts ...request.Option) (*CreatePlatformOutput, error) {
+	req, out := c.CreatePlatformRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) createPlatformRequest(ctx context.Context,
+func (c *ServiceManagementV1) CreatePlatformRequest(ctx context.Context,
 	input *CreatePlatformInput) (*request.Request, *CreatePlatformOutput) {
 	op := &request.Operation{
 		Name: platforms,
@@ -195,7 +197,7 @@ func (c *ServiceManagementV1) createPlatformRequest(ctx context.Context,
 // Get all platforms
 type GetPlatformInput struct {
 	//The ID of the registered platform for which to get details.
-	PlatformID string `dest:"uri" dest-name:"platformID"`
+	PlatformID string `dest:"uri" dest-name:"platformID" json:"-"`
 }
 type GetPlatformOutput struct {
 	//The ID of the platform.
@@ -223,11 +225,11 @@ type GetPlatformOutput struct {
 	//The time the platform was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 
 	//The last time the platform was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 
 	//Additional data associated with the resource entity.
 	Labels map[string][]string `json:"labels,omitempty"`
@@ -237,11 +239,12 @@ type GetPlatformOutput struct {
 }
 
 func (c *ServiceManagementV1) GetPlatform(ctx context.Context,
-	input *GetPlatformInput) (*GetPlatformOutput, error) {
-	req, out := c.getPlatformRequest(ctx, input)
+	input *GetPlatformInput, opts ...request.Option) (*GetPlatformOutput, error) {
+	req, out := c.GetPlatformRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getPlatformRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetPlatformRequest(ctx context.Context,
 	input *GetPlatformInput) (*request.Request, *GetPlatformOutput) {
 	op := &request.Operation{
 		Name: platforms,
@@ -263,10 +266,10 @@ func (c *ServiceManagementV1) getPlatformRequest(ctx context.Context,
 // Unregister a platform
 type DeletePlatformInput struct {
 	//The ID of the platform to unregister.
-	PlatformID string `dest:"uri" dest-name:"platformID"`
+	PlatformID string `dest:"uri" dest-name:"platformID" json:"-"`
 
 	//Whether to cascade-delete all the services and bindings that are related to the platform.
-	Cascade bool `dest:"querystring" dest-name:"cascade"`
+	Cascade bool `dest:"querystring" dest-name:"cascade" json:"-"`
 }
 type DeletePlatformOutput struct {
 	Error
@@ -274,11 +277,12 @@ type DeletePlatformOutput struct {
 }
 
 func (c *ServiceManagementV1) DeletePlatform(ctx context.Context,
-	input *DeletePlatformInput) (*DeletePlatformOutput, error) {
-	req, out := c.deletePlatformRequest(ctx, input)
+	input *DeletePlatformInput, opts ...request.Option) (*DeletePlatformOutput, error) {
+	req, out := c.DeletePlatformRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) deletePlatformRequest(ctx context.Context,
+func (c *ServiceManagementV1) DeletePlatformRequest(ctx context.Context,
 	input *DeletePlatformInput) (*request.Request, *DeletePlatformOutput) {
 	op := &request.Operation{
 		Name: platforms,
@@ -300,7 +304,7 @@ func (c *ServiceManagementV1) deletePlatformRequest(ctx context.Context,
 // Update a platform
 type UpdatePlatformInput struct {
 	//The ID of the registered platform to update.
-	PlatformID string `dest:"uri" dest-name:"platformID"`
+	PlatformID string `dest:"uri" dest-name:"platformID" json:"-"`
 
 	//The ID of the platform to update.
 	//Platform ID is a globally unique identifier (GUID).
@@ -329,8 +333,8 @@ type Label struct {
 	//The operation to perform on a label.
 	//Possible values:
 	//Enum:
-	//	[ add, remove ]
-	Op string `json:"op,omitempty"`
+	//	[ add, add_values, remove, remove_values ]
+	Op LabelOperation `json:"op,omitempty"`
 
 	//The name of the label.
 	Key string `json:"key,omitempty"`
@@ -345,7 +349,7 @@ type UpdatePlatformOutput struct {
 	Id string `json:"id,omitempty"`
 
 	//Whether the resource is ready for consumption.
-	Ready string `json:"ready,omitempty"`
+	Ready bool `json:"ready,omitempty"`
 
 	//The type of the platform.
 	//Possible values:
@@ -362,11 +366,11 @@ type UpdatePlatformOutput struct {
 	//The time the platform was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 
 	//The last time the platform was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 
 	//The list of labels to update for the resource.
 	Labels map[string][]string `json:"labels,omitempty"`
@@ -376,11 +380,12 @@ type UpdatePlatformOutput struct {
 }
 
 func (c *ServiceManagementV1) UpdatePlatform(ctx context.Context,
-	input *UpdatePlatformInput) (*UpdatePlatformOutput, error) {
-	req, out := c.updatePlatformRequest(ctx, input)
+	input *UpdatePlatformInput, opts ...request.Option) (*UpdatePlatformOutput, error) {
+	req, out := c.UpdatePlatformRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) updatePlatformRequest(ctx context.Context,
+func (c *ServiceManagementV1) UpdatePlatformRequest(ctx context.Context,
 	input *UpdatePlatformInput) (*request.Request, *UpdatePlatformOutput) {
 	op := &request.Operation{
 		Name: platforms,
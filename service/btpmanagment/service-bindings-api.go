@@ -1,7 +1,9 @@
 package btpmanagment
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
 )
@@ -15,20 +17,20 @@ type GetServiceBindingsInput struct {
 	//If used, must be a nonempty string.
 	//For example:
 	//	ready eq 'true'
-	FieldQuery string `dest:"querystring" dest-name:"fieldQuery"`
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
 	//Filters the response based on the label query.
 	//If used, must be a nonempty string.
 	//For example:
 	//	environment eq 'dev'
-	LabelQuery string `dest:"querystring" dest-name:"labelQuery"`
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
 	//You get this parameter in the response list of the API if the total number of items to return (num_items) is larger
 	//than the number of items returned in a single API call (max_items).
 	//You get a different token in each response to be used in each consecutive call as long as there are more items to list.
 	//Use the returned tokens to get the full list of resources associated with your subaccount.
 	//If this is the first time you are calling the API, leave this field empty.
-	Token string `dest:"querystring" dest-name:"token"`
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
 	//The maximum number of service bindings to return in the response.
-	MaxItems int64 `dest:"querystring" dest-name:"max_items"`
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
 }
 type GetServiceBindingsOutput struct {
 	//Use this token when you call the API again to get more service bindings associated with your subaccount.
@@ -63,20 +65,21 @@ type BindingItem struct {
 	//The time the binding was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 	//The last time the binding was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 	//Additional data associated with the resource entity.
 	Labels map[string][]string `json:"labels,omitempty"`
 }
 
 func (c *ServiceManagementV1) GetServiceBindings(ctx context.Context,
-	input *GetServiceBindingsInput) (*GetServiceBindingsOutput, error) {
-	req, out := c.getServiceBindingsRequest(ctx, input)
+	input *GetServiceBindingsInput, opts ...request.Option) (*GetServiceBindingsOutput, error) {
+	req, out := c.GetServiceBindingsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceBindingsRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceBindingsRequest(ctx context.Context,
 	input *GetServiceBindingsInput) (*request.Request, *GetServiceBindingsOutput) {
 	op := &request.Operation{
 		Name: serviceBindings,
@@ -98,12 +101,12 @@ func (c *ServiceManagementV1) getServiceBindingsRequest(ctx context.Context,
 // Create a service binding
 type CreateServiceBindingInput struct {
 	//Whether to perform this operation asynchronously.
-	Async bool `dest:"querystring" dest-name:"async"`
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
 
 	//The name of the service binding.
-	Name string `json:"name,omitempty"`
+	Name string `json:"name,omitempty" required:"true"`
 	//The id of the service instance associated with the binding.
-	ServiceInstanceId string `json:"service_instance_id,omitempty"`
+	ServiceInstanceId string `json:"service_instance_id,omitempty" required:"true"`
 	//Some services support providing of additional configuration parameters during binding creation.
 	//Pass these parameters as key-value pairs.
 	//For the list of supported configuration parameters, see the documentation of a particular service offering.
@@ -124,17 +127,19 @@ type CreateServiceBindingInput struct {
 }
 type CreateServiceBindingOutput struct {
 	BindingItem
+	AsyncOperation
 
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) CreateServiceBinding(ctx context.Context,
-	input *CreateServiceBindingInput) (*CreateServiceBindingOutput, error) {
-	req, out := c.createServiceBindingRequest(ctx, input)
+	input *CreateServiceBindingInput, opts ...request.Option) (*CreateServiceBindingOutput, error) {
+	req, out := c.CreateServiceBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) createServiceBindingRequest(ctx context.Context,
+func (c *ServiceManagementV1) CreateServiceBindingRequest(ctx context.Context,
 	input *CreateServiceBindingInput) (*request.Request, *CreateServiceBindingOutput) {
 	op := &request.Operation{
 		Name: serviceBindings,
@@ -156,7 +161,7 @@ func (c *ServiceManagementV1) createServiceBindingRequest(ctx context.Context,
 // Get service Binding details
 type GetServiceBindingInput struct {
 	//The ID of the service binding for which to get details.
-	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID"`
+	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID" json:"-"`
 }
 type GetServiceBindingOutput struct {
 	BindingItem
@@ -167,11 +172,12 @@ type GetServiceBindingOutput struct {
 }
 
 func (c *ServiceManagementV1) GetServiceBinding(ctx context.Context,
-	input *GetServiceBindingInput) (*GetServiceBindingOutput, error) {
-	req, out := c.getServiceBindingRequest(ctx, input)
+	input *GetServiceBindingInput, opts ...request.Option) (*GetServiceBindingOutput, error) {
+	req, out := c.GetServiceBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceBindingRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceBindingRequest(ctx context.Context,
 	input *GetServiceBindingInput) (*request.Request, *GetServiceBindingOutput) {
 	op := &request.Operation{
 		Name: serviceBindings,
@@ -193,21 +199,24 @@ func (c *ServiceManagementV1) getServiceBindingRequest(ctx context.Context,
 // Delete a service Binding
 type DeleteServiceBindingInput struct {
 	//The ID of the service binding to delete.
-	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID"`
+	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID" json:"-"`
 	//Whether to perform this operation asynchronously.
-	Async bool `dest:"querystring" dest-name:"async"`
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
 }
 type DeleteServiceBindingOutput struct {
+	AsyncOperation
+
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) DeleteServiceBinding(ctx context.Context,
-	input *DeleteServiceBindingInput) (*DeleteServiceBindingOutput, error) {
-	req, out := c.deleteServiceBindingRequest(ctx, input)
+	input *DeleteServiceBindingInput, opts ...request.Option) (*DeleteServiceBindingOutput, error) {
+	req, out := c.DeleteServiceBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) deleteServiceBindingRequest(ctx context.Context,
+func (c *ServiceManagementV1) DeleteServiceBindingRequest(ctx context.Context,
 	input *DeleteServiceBindingInput) (*request.Request, *DeleteServiceBindingOutput) {
 	op := &request.Operation{
 		Name: serviceBindings,
@@ -226,28 +235,31 @@ func (c *ServiceManagementV1) deleteServiceBindingRequest(ctx context.Context,
 }
 
 // PATCH /v1/service_bindings/{serviceBindingID}
-// Update a service Binding
-/*type UpdateServiceBindingInput struct {
-	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID"`
-
-	Label          string              `json:"name,omitempty"`
-	ServicePlanId string              `json:"service_plan_id,omitempty"`
-	Parameters    map[string]string   `json:"parameters,omitempty"`
-	Labels        map[string][]string `json:"labels,omitempty"`
+// Update a service binding
+type UpdateServiceBindingInput struct {
+	//The ID of the service binding to update.
+	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID" json:"-"`
+
+	//The list of labels to update for the resource; labels are the only property of a binding which can be updated.
+	Labels []Label `json:"labels,omitempty"`
 }
 type UpdateServiceBindingOutput struct {
+	BindingItem
+
 	Error
+	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) UpdateServiceBinding(ctx context.Context,
-	input *UpdateServiceBindingInput) (*UpdateServiceBindingOutput, error) {
-	req, out := c.updateServiceBindingRequest(ctx, input)
+	input *UpdateServiceBindingInput, opts ...request.Option) (*UpdateServiceBindingOutput, error) {
+	req, out := c.UpdateServiceBindingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) updateServiceBindingRequest(ctx context.Context,
+func (c *ServiceManagementV1) UpdateServiceBindingRequest(ctx context.Context,
 	input *UpdateServiceBindingInput) (*request.Request, *UpdateServiceBindingOutput) {
 	op := &request.Operation{
-		Label: serviceBindings,
+		Name: serviceBindings,
 		Http: request.HTTP{
 			Method: request.PATCH,
 			Path:   "/service_bindings/{serviceBindingID}",
@@ -260,27 +272,28 @@ func (c *ServiceManagementV1) updateServiceBindingRequest(ctx context.Context,
 
 	output := &UpdateServiceBindingOutput{}
 	return c.newRequest(ctx, op, input, output), output
-}*/
+}
 
 // GET /v1/service_bindings/{serviceBindingID}/parameters
 // Get service Binding parameters
 type GetServiceBindingParametersInput struct {
 	//The ID of the service binding for which to get parameters.
-	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID"`
+	ServiceBindingID string `dest:"uri" dest-name:"serviceBindingID" json:"-"`
 }
 type GetServiceBindingParametersOutput struct {
-	Parameters map[string]string `json:"-,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) GetServiceBindingParameters(ctx context.Context,
-	input *GetServiceBindingParametersInput) (*GetServiceBindingParametersOutput, error) {
-	req, out := c.getServiceBindingParametersRequest(ctx, input)
+	input *GetServiceBindingParametersInput, opts ...request.Option) (*GetServiceBindingParametersOutput, error) {
+	req, out := c.GetServiceBindingParametersRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceBindingParametersRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceBindingParametersRequest(ctx context.Context,
 	input *GetServiceBindingParametersInput) (*request.Request, *GetServiceBindingParametersOutput) {
 	op := &request.Operation{
 		Name: serviceBindings,
@@ -295,5 +308,14 @@ func (c *ServiceManagementV1) getServiceBindingParametersRequest(ctx context.Con
 	}
 
 	output := &GetServiceBindingParametersOutput{}
-	return c.newRequest(ctx, op, input, output), output
+	req := c.newRequest(ctx, op, input, output)
+
+	// the service answers with the parameters object itself, wrapped to decode into Parameters
+	req.ResponseBodyHandler = func(statusCode int, body []byte) ([]byte, error) {
+		if statusCode >= 200 && statusCode < 300 && len(bytes.TrimSpace(body)) > 0 {
+			return []byte(fmt.Sprintf("{ \"parameters\": %s }", body)), nil
+		}
+		return body, nil
+	}
+	return req, output
 }
@@ -15,23 +15,23 @@ type GetServiceBrokersInput struct {
 	//If used, must be a nonempty string.
 	//For example:
 	//name eq 'my service broker'.
-	FieldQuery string `dest:"querystring" dest-name:"fieldQuery"`
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
 
 	//Filters the response based on the label query.
 	//If used, must be a nonempty string.
 	//For example:
 	//environment eq 'dev'.
-	LabelQuery string `dest:"querystring" dest-name:"labelQuery"`
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
 
 	//You get this parameter in the response list of the API if the total number of items to return (num_items) is
 	//larger than the number of items returned in a single API call (max_items).
 	//You get a different token in each response to be used in each consecutive call as long as there are more items to list.
 	//Use the returned tokens to get the full list of resources associated with your subaccount.
 	//Leave the field empty if this is the first time you are calling the API.
-	Token string `dest:"querystring" dest-name:"token"`
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
 
 	//The maximum number of service brokers to return in the response.
-	MaxItems int64 `dest:"querystring" dest-name:"max_items"`
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
 }
 type GetServiceBrokersOutput struct {
 	//Use this token when you call the API again to get more service brokers associated with your subaccount.
@@ -65,22 +65,23 @@ type BrokerItem struct {
 	//The time the service broker was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 
 	//The last time the service broker was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 
 	//Additional data associated with the resource entity.
 	Labels map[string][]string `json:"labels,omitempty"`
 }
 
 func (c *ServiceManagementV1) GetServiceBrokers(ctx context.Context,
-	input *GetServiceBrokersInput) (*GetServiceBrokersOutput, error) {
-	req, out := c.getServiceBrokersRequest(ctx, input)
+	input *GetServiceBrokersInput, opts ...request.Option) (*GetServiceBrokersOutput, error) {
+	req, out := c.GetServiceBrokersRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceBrokersRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceBrokersRequest(ctx context.Context,
 	input *GetServiceBrokersInput) (*request.Request, *GetServiceBrokersOutput) {
 	op := &request.Operation{
 		Name: serviceBrokers,
@@ -102,22 +103,23 @@ func (c *ServiceManagementV1) getServiceBrokersRequest(ctx context.Context,
 // Get service broker details
 type GetServiceBrokerInput struct {
 	//The ID of the service broker for which to get details.
-	ServiceBrokerID string `dest:"uri" dest-name:"serviceBrokerID"`
+	ServiceBrokerID string `dest:"uri" dest-name:"serviceBrokerID" json:"-"`
 }
 type GetServiceBrokerOutput struct {
 	BrokerItem
 	LastOperation Operation `json:"last_operation,omitempty"`
 
-	Error
+	NestedError
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) GetServiceBroker(ctx context.Context,
-	input *GetServiceBrokerInput) (*GetServiceBrokerOutput, error) {
-	req, out := c.getServiceBrokerRequest(ctx, input)
+	input *GetServiceBrokerInput, opts ...request.Option) (*GetServiceBrokerOutput, error) {
+	req, out := c.GetServiceBrokerRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceBrokerRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceBrokerRequest(ctx context.Context,
 	input *GetServiceBrokerInput) (*request.Request, *GetServiceBrokerOutput) {
 	op := &request.Operation{
 		Name: serviceBrokers,
@@ -134,3 +136,175 @@ func (c *ServiceManagementV1) getServiceBrokerRequest(ctx context.Context,
 	output := &GetServiceBrokerOutput{}
 	return c.newRequest(ctx, op, input, output), output
 }
+
+// POST /v1/service_brokers
+// Register a service broker
+type RegisterServiceBrokerInput struct {
+	//Whether to perform this operation asynchronously.
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
+
+	//The name of the service broker to register.
+	Name string `json:"name,omitempty" required:"true"`
+
+	//The description of the service broker for customer-facing UIs.
+	Description string `json:"description,omitempty"`
+
+	//The URL of the service broker, implementing the Open Service Broker API.
+	BrokerUrl string `json:"broker_url,omitempty" required:"true"`
+
+	//The credentials the SAP Cloud Service Management service uses to call the service broker.
+	Credentials *Credentials `json:"credentials,omitempty" required:"true"`
+
+	//Additional data associated with the resource entity.
+	Labels map[string][]string `json:"labels,omitempty"`
+}
+type RegisterServiceBrokerOutput struct {
+	BrokerItem
+	AsyncOperation
+
+	NestedError
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) RegisterServiceBroker(ctx context.Context,
+	input *RegisterServiceBrokerInput, opts ...request.Option) (*RegisterServiceBrokerOutput, error) {
+	req, out := c.RegisterServiceBrokerRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) RegisterServiceBrokerRequest(ctx context.Context,
+	input *RegisterServiceBrokerInput) (*request.Request, *RegisterServiceBrokerOutput) {
+	op := &request.Operation{
+		Name: serviceBrokers,
+		Http: request.HTTP{
+			Method: request.POST,
+			Path:   "/service_brokers",
+		},
+	}
+
+	if input == nil {
+		input = &RegisterServiceBrokerInput{}
+	}
+
+	output := &RegisterServiceBrokerOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// PATCH /v1/service_brokers/{serviceBrokerID}
+// Update a service broker
+type UpdateServiceBrokerInput struct {
+	//The ID of the service broker to update.
+	ServiceBrokerID string `dest:"uri" dest-name:"serviceBrokerID" json:"-"`
+
+	//Whether to perform this operation asynchronously.
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
+
+	//The new name of the service broker.
+	Name string `json:"name,omitempty"`
+
+	//The new description of the service broker.
+	Description string `json:"description,omitempty"`
+
+	//The new URL of the service broker.
+	BrokerUrl string `json:"broker_url,omitempty"`
+
+	//The new credentials of the service broker.
+	Credentials *Credentials `json:"credentials,omitempty"`
+
+	//The list of labels to update for the resource.
+	Labels []Label `json:"labels,omitempty"`
+}
+type UpdateServiceBrokerOutput struct {
+	BrokerItem
+	AsyncOperation
+
+	NestedError
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) UpdateServiceBroker(ctx context.Context,
+	input *UpdateServiceBrokerInput, opts ...request.Option) (*UpdateServiceBrokerOutput, error) {
+	req, out := c.UpdateServiceBrokerRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) UpdateServiceBrokerRequest(ctx context.Context,
+	input *UpdateServiceBrokerInput) (*request.Request, *UpdateServiceBrokerOutput) {
+	op := &request.Operation{
+		Name: serviceBrokers,
+		Http: request.HTTP{
+			Method: request.PATCH,
+			Path:   "/service_brokers/{serviceBrokerID}",
+		},
+	}
+
+	if input == nil {
+		input = &UpdateServiceBrokerInput{}
+	}
+
+	output := &UpdateServiceBrokerOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// PATCH /v1/service_brokers/{serviceBrokerID}
+// Refresh the catalog of a service broker
+type RefreshServiceBrokerCatalogInput struct {
+	//The ID of the service broker whose catalog to fetch again.
+	ServiceBrokerID string `dest:"uri" dest-name:"serviceBrokerID" json:"-"`
+
+	//Whether to perform this operation asynchronously.
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
+}
+
+// RefreshServiceBrokerCatalog makes the SAP Cloud Service Management service fetch the catalog of
+// the service broker again, by updating the broker without changing any of its properties.
+func (c *ServiceManagementV1) RefreshServiceBrokerCatalog(ctx context.Context,
+	input *RefreshServiceBrokerCatalogInput, opts ...request.Option) (*UpdateServiceBrokerOutput, error) {
+	if input == nil {
+		input = &RefreshServiceBrokerCatalogInput{}
+	}
+	return c.UpdateServiceBroker(ctx, &UpdateServiceBrokerInput{
+		ServiceBrokerID: input.ServiceBrokerID,
+		Async:           input.Async,
+	}, opts...)
+}
+
+// DELETE /v1/service_brokers/{serviceBrokerID}
+// Delete a service broker
+type DeleteServiceBrokerInput struct {
+	//The ID of the service broker to delete.
+	ServiceBrokerID string `dest:"uri" dest-name:"serviceBrokerID" json:"-"`
+
+	//Whether to perform this operation asynchronously.
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
+}
+type DeleteServiceBrokerOutput struct {
+	AsyncOperation
+
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) DeleteServiceBroker(ctx context.Context,
+	input *DeleteServiceBrokerInput, opts ...request.Option) (*DeleteServiceBrokerOutput, error) {
+	req, out := c.DeleteServiceBrokerRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) DeleteServiceBrokerRequest(ctx context.Context,
+	input *DeleteServiceBrokerInput) (*request.Request, *DeleteServiceBrokerOutput) {
+	op := &request.Operation{
+		Name: serviceBrokers,
+		Http: request.HTTP{
+			Method: request.DELETE,
+			Path:   "/service_brokers/{serviceBrokerID}",
+		},
+	}
+
+	if input == nil {
+		input = &DeleteServiceBrokerInput{}
+	}
+
+	output := &DeleteServiceBrokerOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
@@ -0,0 +1,129 @@
+package btpmanagment
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestDecodeDescription(t *testing.T) {
+	body := []byte(`{"id": "b1", "name": "broker", "description": "the broker of the team"}`)
+	for name, out := range map[string]interface{}{
+		"get":      &GetServiceBrokerOutput{},
+		"register": &RegisterServiceBrokerOutput{},
+		"update":   &UpdateServiceBrokerOutput{},
+	} {
+		if err := json.Unmarshal(body, out); err != nil {
+			t.Fatal(err)
+		}
+		var item BrokerItem
+		switch out := out.(type) {
+		case *GetServiceBrokerOutput:
+			item = out.BrokerItem
+		case *RegisterServiceBrokerOutput:
+			item = out.BrokerItem
+		case *UpdateServiceBrokerOutput:
+			item = out.BrokerItem
+		}
+		if item.Description != "the broker of the team" {
+			t.Fatalf("%s: expected the description to be decoded, got %+v", name, out)
+		}
+	}
+
+	var failed RegisterServiceBrokerOutput
+	if err := json.Unmarshal([]byte(`{"error": "Conflict", "description": "broker exists"}`), &failed); err != nil {
+		t.Fatal(err)
+	}
+	if failed.ErrorMessage != "Conflict" || failed.Description != "broker exists" {
+		t.Fatalf("expected the error to be decoded, got %+v", failed)
+	}
+
+	var op GetOperationStatusOutput
+	if err := json.Unmarshal([]byte(`{"id": "op1", "type": "create", "state": "failed",
+		"description": "broker unreachable", "resource_type": "/v1/service_brokers"}`), &op); err != nil {
+		t.Fatal(err)
+	}
+	if err := operationError(&op); !strings.HasSuffix(err.Error(), "failed; broker unreachable") {
+		t.Fatalf("expected the description of the operation in the error, got %v", err)
+	}
+}
+
+func TestServiceBrokersAndVisibilities(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sm := newClient(t, srv)
+	ctx := context.Background()
+
+	registered, err := sm.RegisterServiceBroker(ctx, &RegisterServiceBrokerInput{
+		Async:       true,
+		Name:        "my-broker",
+		BrokerUrl:   "https://my-broker.example.com",
+		Credentials: &Credentials{Basic: Basic{Username: "user", Password: "secret"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	poll, ok := registered.OperationStatusInput()
+	if !ok || poll.ResourceType != "service_brokers" || poll.ResourceID != registered.Id {
+		t.Fatalf("unexpected operation location '%s'", registered.Location)
+	}
+	for i := 0; ; i++ {
+		status, err := sm.GetOperationStatus(ctx, poll)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.State == OperationStateSucceeded {
+			break
+		}
+		if i == 10 {
+			t.Fatalf("operation still %s", status.State)
+		}
+	}
+
+	if _, err := sm.RefreshServiceBrokerCatalog(ctx, &RefreshServiceBrokerCatalogInput{ServiceBrokerID: registered.Id}); err != nil {
+		t.Fatal(err)
+	}
+	ops, err := sm.GetOperations(ctx, &GetOperationsInput{ResourceType: "service_brokers", ResourceID: registered.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops.Items) != 2 || ops.Items[0].Type != OperationTypeCreate || ops.Items[1].Type != OperationTypeUpdate {
+		t.Fatalf("unexpected operations %+v", ops.Items)
+	}
+
+	plans, err := sm.GetServicePlans(ctx, &GetServicePlansInput{FieldQuery: "name eq 'lite'"})
+	if err != nil || len(plans.Items) != 1 {
+		t.Fatalf("expected the lite plan, got %v", err)
+	}
+	visibility, err := sm.CreateVisibility(ctx, &CreateVisibilityInput{
+		ServicePlanId: plans.Items[0].Id,
+		Labels:        map[string][]string{"env": {"dev"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sm.SyncLabels(ctx, LabeledResource{Type: ResourceTypeVisibilities, ID: visibility.Id},
+		map[string][]string{"env": {"prod"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := sm.GetVisibility(ctx, &GetVisibilityInput{VisibilityID: visibility.Id})
+	if err != nil || got.ServicePlanId != plans.Items[0].Id || got.Labels["env"][0] != "prod" {
+		t.Fatalf("unexpected visibility %+v, %v", got, err)
+	}
+	if _, err := sm.DeleteVisibility(ctx, &DeleteVisibilityInput{VisibilityID: visibility.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := sm.GetVisibilities(ctx, nil); err != nil || len(list.Items) != 0 {
+		t.Fatalf("expected no visibilities, got %+v, %v", list, err)
+	}
+
+	if _, err := sm.DeleteServiceBroker(ctx, &DeleteServiceBrokerInput{ServiceBrokerID: registered.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sm.GetServiceBroker(ctx, &GetServiceBrokerInput{ServiceBrokerID: registered.Id}); err == nil {
+		t.Fatal("expected the broker to be deleted")
+	}
+}
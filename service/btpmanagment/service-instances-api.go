@@ -1,7 +1,9 @@
 package btpmanagment
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"github.com/nnicora/sap-sdk-go/sap/http/request"
 	"github.com/nnicora/sap-sdk-go/service/types"
 )
@@ -15,20 +17,20 @@ type GetServiceInstancesInput struct {
 	//If used, must be a nonempty string.
 	//For example:
 	//	usable eq 'true'
-	FieldQuery string `dest:"querystring" dest-name:"fieldQuery"`
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
 	//Filters the response based on the label query.
 	//If used, must be a nonempty string.
 	//For example:
 	//	environment eq 'dev'
-	LabelQuery string `dest:"querystring" dest-name:"labelQuery"`
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
 	//You get this parameter in the response list of the API if the total number of items to return (num_items) is larger
 	//than the number of items returned in a single API call (max_items).
 	//You get a different token in each response to be used in each consecutive call as long as there are more items to list.
 	//Use the returned tokens to get the full list of resources associated with your subaccount.
 	//If this is the first time you are calling the API, leave this field empty.
-	Token string `dest:"querystring" dest-name:"token"`
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
 	//The maximum number of service instances to return in the response.
-	MaxItems int64 `dest:"querystring" dest-name:"max_items"`
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
 }
 type GetServiceInstancesOutput struct {
 	//Use this token when you call the API again to get more service instances associated with your subaccount.
@@ -67,20 +69,21 @@ type InstanceItem struct {
 	//The time the service instance was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 	//The last time the service instance was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 	//Additional data associated with the resource entity.
 	Labels map[string][]string `json:"labels,omitempty"`
 }
 
 func (c *ServiceManagementV1) GetServiceInstances(ctx context.Context,
-	input *GetServiceInstancesInput) (*GetServiceInstancesOutput, error) {
-	req, out := c.getServiceInstancesRequest(ctx, input)
+	input *GetServiceInstancesInput, opts ...request.Option) (*GetServiceInstancesOutput, error) {
+	req, out := c.GetServiceInstancesRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceInstancesRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceInstancesRequest(ctx context.Context,
 	input *GetServiceInstancesInput) (*request.Request, *GetServiceInstancesOutput) {
 	op := &request.Operation{
 		Name: serviceInstances,
@@ -102,11 +105,11 @@ func (c *ServiceManagementV1) getServiceInstancesRequest(ctx context.Context,
 // Create a service instance
 type CreateServiceInstanceInput struct {
 	//Whether to perform this operation asynchronously.
-	Async bool `dest:"querystring" dest-name:"async"`
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
 
 	//The name of the new service instance.
 	//Can't be an empty object.
-	Name string `json:"name,omitempty"`
+	Name string `json:"name,omitempty" required:"true"`
 
 	//The ID of the service plan to use for the service instance.
 	ServicePlanId string `json:"service_plan_id,omitempty"`
@@ -127,17 +130,19 @@ type CreateServiceInstanceInput struct {
 }
 type CreateServiceInstanceOutput struct {
 	InstanceItem
+	AsyncOperation
 
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) CreateServiceInstance(ctx context.Context,
-	input *CreateServiceInstanceInput) (*CreateServiceInstanceOutput, error) {
-	req, out := c.createServiceInstanceRequest(ctx, input)
+	input *CreateServiceInstanceInput, opts ...request.Option) (*CreateServiceInstanceOutput, error) {
+	req, out := c.CreateServiceInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) createServiceInstanceRequest(ctx context.Context,
+func (c *ServiceManagementV1) CreateServiceInstanceRequest(ctx context.Context,
 	input *CreateServiceInstanceInput) (*request.Request, *CreateServiceInstanceOutput) {
 	op := &request.Operation{
 		Name: serviceInstances,
@@ -159,7 +164,7 @@ func (c *ServiceManagementV1) createServiceInstanceRequest(ctx context.Context,
 // Get service instance details
 type GetServiceInstanceInput struct {
 	//The ID of the provisioned service instance for which to get details.
-	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID"`
+	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID" json:"-"`
 }
 type GetServiceInstanceOutput struct {
 	InstanceItem
@@ -170,11 +175,12 @@ type GetServiceInstanceOutput struct {
 }
 
 func (c *ServiceManagementV1) GetServiceInstance(ctx context.Context,
-	input *GetServiceInstanceInput) (*GetServiceInstanceOutput, error) {
-	req, out := c.getServiceInstanceRequest(ctx, input)
+	input *GetServiceInstanceInput, opts ...request.Option) (*GetServiceInstanceOutput, error) {
+	req, out := c.GetServiceInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceInstanceRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceInstanceRequest(ctx context.Context,
 	input *GetServiceInstanceInput) (*request.Request, *GetServiceInstanceOutput) {
 	op := &request.Operation{
 		Name: serviceInstances,
@@ -196,22 +202,25 @@ func (c *ServiceManagementV1) getServiceInstanceRequest(ctx context.Context,
 // Delete a service instance
 type DeleteServiceInstanceInput struct {
 	//The ID of the provisioned service instance to delete.
-	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID"`
+	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID" json:"-"`
 
 	//Whether to perform this operation asynchronously.
-	Async bool `dest:"querystring" dest-name:"async"`
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
 }
 type DeleteServiceInstanceOutput struct {
+	AsyncOperation
+
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) DeleteServiceInstance(ctx context.Context,
-	input *DeleteServiceInstanceInput) (*DeleteServiceInstanceOutput, error) {
-	req, out := c.deleteServiceInstanceRequest(ctx, input)
+	input *DeleteServiceInstanceInput, opts ...request.Option) (*DeleteServiceInstanceOutput, error) {
+	req, out := c.DeleteServiceInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) deleteServiceInstanceRequest(ctx context.Context,
+func (c *ServiceManagementV1) DeleteServiceInstanceRequest(ctx context.Context,
 	input *DeleteServiceInstanceInput) (*request.Request, *DeleteServiceInstanceOutput) {
 	op := &request.Operation{
 		Name: serviceInstances,
@@ -233,9 +242,9 @@ func (c *ServiceManagementV1) deleteServiceInstanceRequest(ctx context.Context,
 // Update a service instance
 type UpdateServiceInstanceInput struct {
 	//The ID of the provisioned service instance to update.
-	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID"`
+	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID" json:"-"`
 	//Whether to perform this operation asynchronously.
-	Async bool `dest:"querystring" dest-name:"async"`
+	Async bool `dest:"querystring" dest-name:"async" json:"-"`
 
 	//The name of the service instance to update.
 	Name string `json:"name,omitempty"`
@@ -248,21 +257,23 @@ type UpdateServiceInstanceInput struct {
 	//defined during this step
 	Parameters map[string]string `json:"parameters,omitempty"`
 	//The list of labels to update for the resource.
-	Labels map[string][]string `json:"labels,omitempty"`
+	Labels []Label `json:"labels,omitempty"`
 }
 type UpdateServiceInstanceOutput struct {
 	InstanceItem
+	AsyncOperation
 
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) UpdateServiceInstance(ctx context.Context,
-	input *UpdateServiceInstanceInput) (*UpdateServiceInstanceOutput, error) {
-	req, out := c.updateServiceInstanceRequest(ctx, input)
+	input *UpdateServiceInstanceInput, opts ...request.Option) (*UpdateServiceInstanceOutput, error) {
+	req, out := c.UpdateServiceInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) updateServiceInstanceRequest(ctx context.Context,
+func (c *ServiceManagementV1) UpdateServiceInstanceRequest(ctx context.Context,
 	input *UpdateServiceInstanceInput) (*request.Request, *UpdateServiceInstanceOutput) {
 	op := &request.Operation{
 		Name: serviceInstances,
@@ -284,21 +295,22 @@ func (c *ServiceManagementV1) updateServiceInstanceRequest(ctx context.Context,
 // Get service instance parameters
 type GetServiceInstanceParametersInput struct {
 	//The ID of the provisioned service instance for which to get parameters.
-	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID"`
+	ServiceInstanceID string `dest:"uri" dest-name:"serviceInstanceID" json:"-"`
 }
 type GetServiceInstanceParametersOutput struct {
-	Parameters map[string]string `json:"-,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 
 	Error
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) GetServiceInstanceParameters(ctx context.Context,
-	input *GetServiceInstanceParametersInput) (*GetServiceInstanceParametersOutput, error) {
-	req, out := c.getServiceInstanceParametersRequest(ctx, input)
+	input *GetServiceInstanceParametersInput, opts ...request.Option) (*GetServiceInstanceParametersOutput, error) {
+	req, out := c.GetServiceInstanceParametersRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceInstanceParametersRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceInstanceParametersRequest(ctx context.Context,
 	input *GetServiceInstanceParametersInput) (*request.Request, *GetServiceInstanceParametersOutput) {
 	op := &request.Operation{
 		Name: serviceInstances,
@@ -313,5 +325,14 @@ func (c *ServiceManagementV1) getServiceInstanceParametersRequest(ctx context.Co
 	}
 
 	output := &GetServiceInstanceParametersOutput{}
-	return c.newRequest(ctx, op, input, output), output
+	req := c.newRequest(ctx, op, input, output)
+
+	// the service answers with the parameters object itself, wrapped to decode into Parameters
+	req.ResponseBodyHandler = func(statusCode int, body []byte) ([]byte, error) {
+		if statusCode >= 200 && statusCode < 300 && len(bytes.TrimSpace(body)) > 0 {
+			return []byte(fmt.Sprintf("{ \"parameters\": %s }", body)), nil
+		}
+		return body, nil
+	}
+	return req, output
 }
@@ -15,20 +15,20 @@ type GetServiceOfferingsInput struct {
 	//If used, must be a nonempty string.
 	//For example:
 	//	ready eq 'true'
-	FieldQuery string `dest:"querystring" dest-name:"fieldQuery"`
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
 	//Filters the response based on the label query.
 	//If used, must be a nonempty string.
 	//For example:
 	//	environment eq 'dev'
-	LabelQuery string `dest:"querystring" dest-name:"labelQuery"`
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
 	//You get this parameter in the response list of the API if the total number of items to return (num_items) is larger
 	//than the number of items returned in a single API call (max_items).
 	//You get a different token in each response to be used in each consecutive call as long as there are more items to list.
 	//Use the returned tokens to get the full list of resources associated with your subaccount.
 	//If this is the first time you are calling the API, leave this field empty.
-	Token string `dest:"querystring" dest-name:"token"`
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
 	//The maximum number of service offerings to return in the response.
-	MaxItems int64 `dest:"querystring" dest-name:"max_items"`
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
 }
 type GetServiceOfferingsOutput struct {
 	//Use this token when you call the API again to get more service offerings associated with your subaccount.
@@ -76,10 +76,10 @@ type OfferingItem struct {
 	//The time the service offering was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 	//The last time the service offering was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 }
 type OfferingMetadata struct {
 	//The description of the service offering.
@@ -95,11 +95,12 @@ type OfferingMetadata struct {
 }
 
 func (c *ServiceManagementV1) GetServiceOfferings(ctx context.Context,
-	input *GetServiceOfferingsInput) (*GetServiceOfferingsOutput, error) {
-	req, out := c.getServiceOfferingsRequest(ctx, input)
+	input *GetServiceOfferingsInput, opts ...request.Option) (*GetServiceOfferingsOutput, error) {
+	req, out := c.GetServiceOfferingsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceOfferingsRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceOfferingsRequest(ctx context.Context,
 	input *GetServiceOfferingsInput) (*request.Request, *GetServiceOfferingsOutput) {
 	op := &request.Operation{
 		Name: serviceOfferings,
@@ -121,21 +122,22 @@ func (c *ServiceManagementV1) getServiceOfferingsRequest(ctx context.Context,
 // Get service offering details
 type GetServiceOfferingInput struct {
 	//The ID of the service offering for which to get details.
-	ServiceOfferingID string `dest:"uri" dest-name:"serviceOfferingID"`
+	ServiceOfferingID string `dest:"uri" dest-name:"serviceOfferingID" json:"-"`
 }
 type GetServiceOfferingOutput struct {
 	OfferingItem
 
-	Error
+	NestedError
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) GetServiceOffering(ctx context.Context,
-	input *GetServiceOfferingInput) (*GetServiceOfferingOutput, error) {
-	req, out := c.getServiceOfferingRequest(ctx, input)
+	input *GetServiceOfferingInput, opts ...request.Option) (*GetServiceOfferingOutput, error) {
+	req, out := c.GetServiceOfferingRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServiceOfferingRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServiceOfferingRequest(ctx context.Context,
 	input *GetServiceOfferingInput) (*request.Request, *GetServiceOfferingOutput) {
 	op := &request.Operation{
 		Name: serviceOfferings,
@@ -15,20 +15,20 @@ type GetServicePlansInput struct {
 	//If used, must be a nonempty string.
 	//For example:
 	//	ready eq 'true'
-	FieldQuery string `dest:"querystring" dest-name:"fieldQuery"`
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
 	//Filters the response based on the label query.
 	//If used, must be a nonempty string.
 	//For example:
 	//	environment eq 'dev'
-	LabelQuery string `dest:"querystring" dest-name:"labelQuery"`
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
 	//You get this parameter in the response list of the API if the total number of items to return (num_items) is
 	//larger than the number of items returned in a single API call (max_items).
 	//You get a different token in each response to be used in each consecutive call as long as there are more items to list.
 	//Use the returned tokens to get the full list of resources associated with your subaccount.
 	//If this is the first time you are calling this API, leave this field empty.
-	Token string `dest:"querystring" dest-name:"token"`
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
 	//The maximum number of service plans to return in the response.
-	MaxItems int64 `dest:"querystring" dest-name:"max_items"`
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
 }
 type GetServicePlansOutput struct {
 	//Use this token when you call the API again to get more service plans associated with your subaccount.
@@ -68,10 +68,10 @@ type PlanItem struct {
 	//The time the service plan was created.
 	//In ISO 8601 format:
 	//	YYYY-MM-DDThh:mm:ssTZD
-	CreatedAt string `json:"created_at,omitempty"`
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
 	//The last time the service plan was updated.
 	//In ISO 8601 format.
-	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
 }
 type PlanMetadata struct {
 	//Platforms supported by the service plan.
@@ -85,11 +85,12 @@ type PlanMetadata struct {
 }
 
 func (c *ServiceManagementV1) GetServicePlans(ctx context.Context,
-	input *GetServicePlansInput) (*GetServicePlansOutput, error) {
-	req, out := c.getServicePlansRequest(ctx, input)
+	input *GetServicePlansInput, opts ...request.Option) (*GetServicePlansOutput, error) {
+	req, out := c.GetServicePlansRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServicePlansRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServicePlansRequest(ctx context.Context,
 	input *GetServicePlansInput) (*request.Request, *GetServicePlansOutput) {
 	op := &request.Operation{
 		Name: servicePlans,
@@ -111,21 +112,22 @@ func (c *ServiceManagementV1) getServicePlansRequest(ctx context.Context,
 // Get service plan details
 type GetServicePlanInput struct {
 	//The ID of the service plan for which to get details.
-	ServicePlanID string `dest:"uri" dest-name:"servicePlanID"`
+	ServicePlanID string `dest:"uri" dest-name:"servicePlanID" json:"-"`
 }
 type GetServicePlanOutput struct {
 	PlanItem
 
-	Error
+	NestedError
 	types.StatusAndBodyFromResponse
 }
 
 func (c *ServiceManagementV1) GetServicePlan(ctx context.Context,
-	input *GetServicePlanInput) (*GetServicePlanOutput, error) {
-	req, out := c.getServicePlanRequest(ctx, input)
+	input *GetServicePlanInput, opts ...request.Option) (*GetServicePlanOutput, error) {
+	req, out := c.GetServicePlanRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ServiceManagementV1) getServicePlanRequest(ctx context.Context,
+func (c *ServiceManagementV1) GetServicePlanRequest(ctx context.Context,
 	input *GetServicePlanInput) (*request.Request, *GetServicePlanOutput) {
 	op := &request.Operation{
 		Name: servicePlans,
@@ -0,0 +1,147 @@
+package smquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// characters ending an operand which is not quoted
+const separators = " \t\n(),'"
+
+// Parse parses a fieldQuery or labelQuery expression; values may be quoted or not.
+// The empty string parses into the empty query.
+func Parse(query string) (Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Query
+	for i := 0; i < len(tokens); {
+		if len(out) > 0 {
+			if !strings.EqualFold(tokens[i].text, "and") || tokens[i].quoted {
+				return nil, fmt.Errorf("expected 'and' but found '%s'", tokens[i].text)
+			}
+			i++
+		}
+		if i+2 >= len(tokens) {
+			return nil, fmt.Errorf("incomplete criterion at '%s'", join(tokens[i:]))
+		}
+		if tokens[i].quoted || isPunct(tokens[i]) {
+			return nil, fmt.Errorf("expected a field or label but found '%s'", tokens[i].text)
+		}
+		c := Criterion{Left: tokens[i].text, Operator: Operator(strings.ToLower(tokens[i+1].text))}
+		if !c.Operator.Valid() || tokens[i+1].quoted {
+			return nil, fmt.Errorf("unsupported operator '%s'", tokens[i+1].text)
+		}
+		i += 2
+
+		if c.Operator.IsMultiValue() {
+			if !tokens[i].is("(") {
+				return nil, fmt.Errorf("operator '%s' expects a list of values", c.Operator)
+			}
+			i++
+			for expectValue := true; ; expectValue = !expectValue {
+				if i >= len(tokens) {
+					return nil, fmt.Errorf("unterminated list of values of '%s'", c.Left)
+				}
+				t := tokens[i]
+				i++
+				if t.is(")") && (!expectValue || len(c.Values) == 0) {
+					break
+				}
+				if expectValue == isPunct(t) || !expectValue && !t.is(",") {
+					return nil, fmt.Errorf("unexpected '%s' in the list of values of '%s'", t.text, c.Left)
+				}
+				if expectValue {
+					c.Values = append(c.Values, t.text)
+				}
+			}
+			if len(c.Values) == 0 {
+				return nil, fmt.Errorf("operator '%s' expects at least one value", c.Operator)
+			}
+		} else {
+			if isPunct(tokens[i]) {
+				return nil, fmt.Errorf("operator '%s' expects a single value", c.Operator)
+			}
+			c.Values = []string{tokens[i].text}
+			i++
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// MustParse is like Parse but panics on an invalid query; meant for constant queries.
+func MustParse(query string) Query {
+	q, err := Parse(query)
+	if err != nil {
+		panic(fmt.Sprintf("smquery: %v", err))
+	}
+	return q
+}
+
+type token struct {
+	// the text of the token, unquoted
+	text   string
+	quoted bool
+}
+
+func (t token) is(punct string) bool {
+	return !t.quoted && t.text == punct
+}
+
+func isPunct(t token) bool {
+	return t.is("(") || t.is(")") || t.is(",")
+}
+
+func join(tokens []token) string {
+	parts := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		parts = append(parts, t.text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Splits a query into words, quoted literals, parentheses and commas.
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case ch == '(' || ch == ')' || ch == ',':
+			tokens = append(tokens, token{text: string(ch)})
+			i++
+		case ch == '\'':
+			var b strings.Builder
+			j := i + 1
+			for {
+				if j >= len(query) {
+					return nil, fmt.Errorf("unterminated literal at position %d", i)
+				}
+				if query[j] == '\'' {
+					if j+1 < len(query) && query[j+1] == '\'' {
+						b.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				b.WriteByte(query[j])
+				j++
+			}
+			tokens = append(tokens, token{text: b.String(), quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(query) && !strings.ContainsRune(separators, rune(query[j])) {
+				j++
+			}
+			tokens = append(tokens, token{text: query[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,184 @@
+// Package smquery builds and parses the expressions of the Service Manager query language,
+// as taken by the FieldQuery and LabelQuery parameters of the list operations:
+//
+//	q := smquery.Eq("environment", "dev").And(smquery.NotIn("region", "eu10", "us10"))
+//	input := &btpmanagment.GetServiceInstancesInput{LabelQuery: q.String()}
+//
+// Values are always quoted and escaped, so that they can hold any text.
+package smquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator compares the left operand of a criterion, a field or a label key, with its values.
+type Operator string
+
+const (
+	// Equal to the value.
+	OperatorEq Operator = "eq"
+	// Not equal to the value.
+	OperatorNe Operator = "ne"
+	// Equal to one of the values.
+	OperatorIn Operator = "in"
+	// Equal to none of the values.
+	OperatorNotIn Operator = "notin"
+	// Greater than the value.
+	OperatorGt Operator = "gt"
+	// Less than the value.
+	OperatorLt Operator = "lt"
+	// Greater than or equal to the value.
+	OperatorGe Operator = "ge"
+	// Less than or equal to the value.
+	OperatorLe Operator = "le"
+	// Equal to the value, or not set at all.
+	OperatorEn Operator = "en"
+)
+
+func (Operator) Values() []Operator {
+	return []Operator{
+		OperatorEq,
+		OperatorNe,
+		OperatorIn,
+		OperatorNotIn,
+		OperatorGt,
+		OperatorLt,
+		OperatorGe,
+		OperatorLe,
+		OperatorEn,
+	}
+}
+
+func (o Operator) Valid() bool {
+	for _, v := range o.Values() {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the operator takes a list of values rather than a single one.
+func (o Operator) IsMultiValue() bool {
+	return o == OperatorIn || o == OperatorNotIn
+}
+
+// Criterion is a single comparison of a query, e.g. environment eq 'dev'.
+type Criterion struct {
+	Left     string
+	Operator Operator
+	Values   []string
+}
+
+// Query is a list of criteria joined with the and operator; the zero value is the empty query.
+type Query []Criterion
+
+func Eq(left, value string) Query {
+	return single(left, OperatorEq, value)
+}
+
+func Ne(left, value string) Query {
+	return single(left, OperatorNe, value)
+}
+
+func In(left string, values ...string) Query {
+	return single(left, OperatorIn, values...)
+}
+
+func NotIn(left string, values ...string) Query {
+	return single(left, OperatorNotIn, values...)
+}
+
+func Gt(left, value string) Query {
+	return single(left, OperatorGt, value)
+}
+
+func Lt(left, value string) Query {
+	return single(left, OperatorLt, value)
+}
+
+func Ge(left, value string) Query {
+	return single(left, OperatorGe, value)
+}
+
+func Le(left, value string) Query {
+	return single(left, OperatorLe, value)
+}
+
+func En(left, value string) Query {
+	return single(left, OperatorEn, value)
+}
+
+func single(left string, op Operator, values ...string) Query {
+	return Query{{Left: left, Operator: op, Values: values}}
+}
+
+// And returns a query matching both q and all the others.
+func (q Query) And(others ...Query) Query {
+	out := make(Query, 0, len(q))
+	out = append(out, q...)
+	for _, other := range others {
+		out = append(out, other...)
+	}
+	return out
+}
+
+// String renders the query as sent to the service; the empty query renders as "".
+func (q Query) String() string {
+	parts := make([]string, 0, len(q))
+	for _, c := range q {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, " and ")
+}
+
+func (c Criterion) String() string {
+	if c.Operator.IsMultiValue() {
+		values := make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			values = append(values, Quote(v))
+		}
+		return fmt.Sprintf("%s %s (%s)", c.Left, c.Operator, strings.Join(values, ", "))
+	}
+	value := ""
+	if len(c.Values) > 0 {
+		value = c.Values[0]
+	}
+	return fmt.Sprintf("%s %s %s", c.Left, c.Operator, Quote(value))
+}
+
+// Validate checks that every criterion can be rendered into a query the service accepts.
+func (q Query) Validate() error {
+	for i, c := range q {
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("criterion %d (%s): %v", i+1, c.Left, err)
+		}
+	}
+	return nil
+}
+
+func (c Criterion) validate() error {
+	if c.Left == "" {
+		return fmt.Errorf("missing left operand")
+	}
+	if strings.ContainsAny(c.Left, separators) {
+		return fmt.Errorf("left operand contains one of the characters %q", separators)
+	}
+	if !c.Operator.Valid() {
+		return fmt.Errorf("unsupported operator '%s'", c.Operator)
+	}
+	if c.Operator.IsMultiValue() {
+		if len(c.Values) == 0 {
+			return fmt.Errorf("operator '%s' expects at least one value", c.Operator)
+		}
+	} else if len(c.Values) != 1 {
+		return fmt.Errorf("operator '%s' expects a single value", c.Operator)
+	}
+	return nil
+}
+
+// Quote quotes a value, doubling the single quotes it contains.
+func Quote(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
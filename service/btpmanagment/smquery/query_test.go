@@ -0,0 +1,79 @@
+package smquery
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	q := Eq("environment", "dev").
+		And(NotIn("region", "eu10", "us10"), Gt("created_at", "2021-06-01T00:00:00Z")).
+		And(En("owner", "O'Brien"))
+
+	expected := "environment eq 'dev' and region notin ('eu10', 'us10') and " +
+		"created_at gt '2021-06-01T00:00:00Z' and owner en 'O''Brien'"
+	if got := q.String(); got != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, got)
+	}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := (Query{}).String(); got != "" {
+		t.Fatalf("expected the empty query, got %q", got)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	q := Eq("name", "a, (b) and 'c'").And(In("plan", "x y", "z"), Ne("id", ""))
+	parsed, err := Parse(q.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, q) {
+		t.Fatalf("expected %#v, got %#v", q, parsed)
+	}
+}
+
+func TestParseUnquoted(t *testing.T) {
+	parsed, err := Parse("num_items GT 5 AND name in (a,b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Gt("num_items", "5").And(In("name", "a", "b"))
+	if !reflect.DeepEqual(parsed, expected) {
+		t.Fatalf("expected %v, got %v", expected, parsed)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, query := range []string{
+		"name eq",
+		"name is 'a'",
+		"name eq 'a",
+		"name eq 'a' or id eq 'b'",
+		"name in 'a'",
+		"name in ()",
+		"name in ('a',)",
+		"name in ('a' 'b')",
+		"name eq ('a')",
+		"'name' eq 'a'",
+	} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, q := range []Query{
+		Eq("", "a"),
+		Eq("my label", "a"),
+		In("name"),
+		{{Left: "name", Operator: "like", Values: []string{"a"}}},
+		{{Left: "name", Operator: OperatorEq, Values: []string{"a", "b"}}},
+	} {
+		if err := q.Validate(); err == nil {
+			t.Errorf("%v: expected an error", q)
+		}
+	}
+}
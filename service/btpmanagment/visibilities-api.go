@@ -0,0 +1,261 @@
+package btpmanagment
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/types"
+)
+
+const visibilities = "Service Management - Visibilities"
+
+// GET /v1/visibilities
+// Get all visibilities
+type GetVisibilitiesInput struct {
+	//Filters the response based on the field query.
+	//If used, must be a nonempty string.
+	//For example:
+	//service_plan_id eq 'my-plan-id'.
+	FieldQuery string `dest:"querystring" dest-name:"fieldQuery" json:"-"`
+
+	//Filters the response based on the label query.
+	//If used, must be a nonempty string.
+	//For example:
+	//environment eq 'dev'.
+	LabelQuery string `dest:"querystring" dest-name:"labelQuery" json:"-"`
+
+	//You get this parameter in the response list of the API if the total number of items to return (num_items) is
+	//larger than the number of items returned in a single API call (max_items).
+	//Leave the field empty if this is the first time you are calling the API.
+	Token string `dest:"querystring" dest-name:"token" json:"-"`
+
+	//The maximum number of visibilities to return in the response.
+	MaxItems int64 `dest:"querystring" dest-name:"max_items" json:"-"`
+}
+type GetVisibilitiesOutput struct {
+	//Use this token when you call the API again to get more visibilities.
+	//If the field is not present, you have reached the end of the list.
+	Token string `json:"token,omitempty"`
+
+	//The number of the visibilities.
+	NumItems int64 `json:"num_items,omitempty"`
+
+	//The list of response objects that contains details about the visibilities.
+	Items []VisibilityItem `json:"items,omitempty"`
+
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+// VisibilityItem makes a service plan available in a platform; a visibility without platform
+// makes the plan available in all the platforms.
+type VisibilityItem struct {
+	//The ID of the visibility.
+	Id string `json:"id,omitempty"`
+
+	//Whether the visibility is ready.
+	Ready bool `json:"ready,omitempty"`
+
+	//The ID of the platform for which the plan is visible.
+	//Empty when the plan is visible in all the platforms.
+	PlatformId string `json:"platform_id,omitempty"`
+
+	//The ID of the service plan made visible.
+	ServicePlanId string `json:"service_plan_id,omitempty"`
+
+	//The time the visibility was created.
+	//In ISO 8601 format.
+	CreatedAt types.ISOTime `json:"created_at,omitempty"`
+
+	//The last time the visibility was updated.
+	//In ISO 8601 format.
+	UpdatedAt types.ISOTime `json:"updated_at,omitempty"`
+
+	//Additional data associated with the resource entity.
+	Labels map[string][]string `json:"labels,omitempty"`
+}
+
+func (c *ServiceManagementV1) GetVisibilities(ctx context.Context,
+	input *GetVisibilitiesInput, opts ...request.Option) (*GetVisibilitiesOutput, error) {
+	req, out := c.GetVisibilitiesRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) GetVisibilitiesRequest(ctx context.Context,
+	input *GetVisibilitiesInput) (*request.Request, *GetVisibilitiesOutput) {
+	op := &request.Operation{
+		Name: visibilities,
+		Http: request.HTTP{
+			Method: request.GET,
+			Path:   "/visibilities",
+		},
+	}
+
+	if input == nil {
+		input = &GetVisibilitiesInput{}
+	}
+
+	output := &GetVisibilitiesOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// POST /v1/visibilities
+// Create a visibility
+type CreateVisibilityInput struct {
+	//The ID of the service plan to make visible.
+	ServicePlanId string `json:"service_plan_id,omitempty" required:"true"`
+
+	//The ID of the platform in which to make the plan visible.
+	//Leave it empty to make the plan visible in all the platforms.
+	PlatformId string `json:"platform_id,omitempty"`
+
+	//Additional data associated with the resource entity.
+	Labels map[string][]string `json:"labels,omitempty"`
+}
+type CreateVisibilityOutput struct {
+	VisibilityItem
+
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) CreateVisibility(ctx context.Context,
+	input *CreateVisibilityInput, opts ...request.Option) (*CreateVisibilityOutput, error) {
+	req, out := c.CreateVisibilityRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) CreateVisibilityRequest(ctx context.Context,
+	input *CreateVisibilityInput) (*request.Request, *CreateVisibilityOutput) {
+	op := &request.Operation{
+		Name: visibilities,
+		Http: request.HTTP{
+			Method: request.POST,
+			Path:   "/visibilities",
+		},
+	}
+
+	if input == nil {
+		input = &CreateVisibilityInput{}
+	}
+
+	output := &CreateVisibilityOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// GET /v1/visibilities/{visibilityID}
+// Get visibility details
+type GetVisibilityInput struct {
+	//The ID of the visibility for which to get details.
+	VisibilityID string `dest:"uri" dest-name:"visibilityID" json:"-"`
+}
+type GetVisibilityOutput struct {
+	VisibilityItem
+
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) GetVisibility(ctx context.Context,
+	input *GetVisibilityInput, opts ...request.Option) (*GetVisibilityOutput, error) {
+	req, out := c.GetVisibilityRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) GetVisibilityRequest(ctx context.Context,
+	input *GetVisibilityInput) (*request.Request, *GetVisibilityOutput) {
+	op := &request.Operation{
+		Name: visibilities,
+		Http: request.HTTP{
+			Method: request.GET,
+			Path:   "/visibilities/{visibilityID}",
+		},
+	}
+
+	if input == nil {
+		input = &GetVisibilityInput{}
+	}
+
+	output := &GetVisibilityOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// PATCH /v1/visibilities/{visibilityID}
+// Update a visibility
+type UpdateVisibilityInput struct {
+	//The ID of the visibility to update.
+	VisibilityID string `dest:"uri" dest-name:"visibilityID" json:"-"`
+
+	//The ID of the service plan to make visible instead.
+	ServicePlanId string `json:"service_plan_id,omitempty"`
+
+	//The ID of the platform in which to make the plan visible instead.
+	PlatformId string `json:"platform_id,omitempty"`
+
+	//The list of labels to update for the resource.
+	Labels []Label `json:"labels,omitempty"`
+}
+type UpdateVisibilityOutput struct {
+	VisibilityItem
+
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) UpdateVisibility(ctx context.Context,
+	input *UpdateVisibilityInput, opts ...request.Option) (*UpdateVisibilityOutput, error) {
+	req, out := c.UpdateVisibilityRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) UpdateVisibilityRequest(ctx context.Context,
+	input *UpdateVisibilityInput) (*request.Request, *UpdateVisibilityOutput) {
+	op := &request.Operation{
+		Name: visibilities,
+		Http: request.HTTP{
+			Method: request.PATCH,
+			Path:   "/visibilities/{visibilityID}",
+		},
+	}
+
+	if input == nil {
+		input = &UpdateVisibilityInput{}
+	}
+
+	output := &UpdateVisibilityOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
+
+// DELETE /v1/visibilities/{visibilityID}
+// Delete a visibility
+type DeleteVisibilityInput struct {
+	//The ID of the visibility to delete.
+	VisibilityID string `dest:"uri" dest-name:"visibilityID" json:"-"`
+}
+type DeleteVisibilityOutput struct {
+	Error
+	types.StatusAndBodyFromResponse
+}
+
+func (c *ServiceManagementV1) DeleteVisibility(ctx context.Context,
+	input *DeleteVisibilityInput, opts ...request.Option) (*DeleteVisibilityOutput, error) {
+	req, out := c.DeleteVisibilityRequest(ctx, input)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+func (c *ServiceManagementV1) DeleteVisibilityRequest(ctx context.Context,
+	input *DeleteVisibilityInput) (*request.Request, *DeleteVisibilityOutput) {
+	op := &request.Operation{
+		Name: visibilities,
+		Http: request.HTTP{
+			Method: request.DELETE,
+			Path:   "/visibilities/{visibilityID}",
+		},
+	}
+
+	if input == nil {
+		input = &DeleteVisibilityInput{}
+	}
+
+	output := &DeleteVisibilityOutput{}
+	return c.newRequest(ctx, op, input, output), output
+}
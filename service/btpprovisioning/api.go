@@ -40,7 +40,7 @@ type ServicePlanQuotaAssignment struct {
 	Service string `json:"service,omitempty"`
 	//Enum:
 	//	[ PLATFORM, SERVICE, ELASTIC_SERVICE, ELASTIC_LIMITED, APPLICATION, QUOTA_BASED_APPLICATION, ENVIRONMENT ]
-	ServiceCategory string `json:"serviceCategory,omitempty"`
+	ServiceCategory types.ServiceCategory `json:"serviceCategory,omitempty"`
 	//Unique ID of the subaccount for which to get quota.
 	SubAccountGuid string `json:"subaccountGUID,omitempty"`
 	//The ID of the tenant for the subaccount.
@@ -65,11 +65,12 @@ type Resource struct {
 	Type string `json:"resourceType,omitempty"`
 }
 
-func (c *ProvisioningV1) GetServicePlanQuotaAssignments(ctx context.Context) (*GetServicePlanAssignmentsOutput, error) {
-	req, out := c.getServicePlanQuotaAssignmentsRequest(ctx, nil)
+func (c *ProvisioningV1) GetServicePlanQuotaAssignments(ctx context.Context, opts ...request.Option) (*GetServicePlanAssignmentsOutput, error) {
+	req, out := c.GetServicePlanQuotaAssignmentsRequest(ctx, nil)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) getServicePlanQuotaAssignmentsRequest(ctx context.Context, input *GetServicePlanAssignmentsInput) (*request.Request, *GetServicePlanAssignmentsOutput) {
+func (c *ProvisioningV1) GetServicePlanQuotaAssignmentsRequest(ctx context.Context, input *GetServicePlanAssignmentsInput) (*request.Request, *GetServicePlanAssignmentsOutput) {
 	op := &request.Operation{
 		Name: quotaAssignments,
 		Http: request.HTTP{
@@ -91,7 +92,7 @@ func (c *ProvisioningV1) getServicePlanQuotaAssignmentsRequest(ctx context.Conte
 type GetAvailableEnvironmentsInput struct {
 	//Security token that contains authentication declarations of an end user by the authorization server
 	//(SAP Identity and Authentication Service).
-	XIDToken string `dest:"header" dest-name:"X-ID-Token"`
+	XIDToken string `dest:"header" dest-name:"X-ID-Token" json:"-"`
 }
 type GetAvailableEnvironmentsOutput struct {
 	Environments []AvailableEnvironment `json:"availableEnvironments,omitempty"`
@@ -107,7 +108,7 @@ type AvailableEnvironment struct {
 	//Description of the service plan for the available environment.
 	Description string `json:"description,omitempty"`
 	//The type of environment that is available (for example: cloudfoundry).
-	EnvironmentType string `json:"environmentType,omitempty"`
+	EnvironmentType EnvironmentType `json:"environmentType,omitempty"`
 	//The landscape label of the environment broker.
 	LandscapeLabel string `json:"landscapeLabel,omitempty"`
 	//Name of the service plan for the available environment.
@@ -134,11 +135,12 @@ type AvailableEnvironment struct {
 	UpdateSchema string `json:"updateSchema,omitempty"`
 }
 
-func (c *ProvisioningV1) GetAvailableEnvironments(ctx context.Context) (*GetAvailableEnvironmentsOutput, error) {
-	req, out := c.getAvailableEnvironmentsRequest(ctx, nil)
+func (c *ProvisioningV1) GetAvailableEnvironments(ctx context.Context, opts ...request.Option) (*GetAvailableEnvironmentsOutput, error) {
+	req, out := c.GetAvailableEnvironmentsRequest(ctx, nil)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) getAvailableEnvironmentsRequest(ctx context.Context, input *GetAvailableEnvironmentsInput) (*request.Request, *GetAvailableEnvironmentsOutput) {
+func (c *ProvisioningV1) GetAvailableEnvironmentsRequest(ctx context.Context, input *GetAvailableEnvironmentsInput) (*request.Request, *GetAvailableEnvironmentsOutput) {
 	op := &request.Operation{
 		Name: environments,
 		Http: request.HTTP{
@@ -160,7 +162,7 @@ func (c *ProvisioningV1) getAvailableEnvironmentsRequest(ctx context.Context, in
 type GetEnvironmentInstancesInput struct {
 	//Security token that contains authentication declarations of an end user by the authorization server
 	//(SAP Identity and Authentication Service).
-	XIDToken string `dest:"header" dest-name:"X-ID-Token"`
+	XIDToken string `dest:"header" dest-name:"X-ID-Token" json:"-"`
 }
 type GetEnvironmentInstancesOutput struct {
 	//The list of all the environment instances to delete
@@ -175,7 +177,7 @@ type EnvironmentInstance struct {
 	//The commercial type of the environment broker.
 	CommercialType string `json:"commercialType,omitempty"`
 	//The date the environment instance was created. Dates and times are in UTC format.
-	CreatedDate int64 `json:"createdDate,omitempty"`
+	CreatedDate types.EpochTime `json:"createdDate,omitempty"`
 	//The URL of the service dashboard, which is a web-based management user interface for the service instances.
 	DashboardUrl string `json:"dashboardUrl,omitempty"`
 	//The description of the environment instance.
@@ -183,17 +185,17 @@ type EnvironmentInstance struct {
 	//Type of the environment instance that is used.
 	//Enum:
 	//	[ cloudfoundry, kubernetes, neo ]
-	EnvironmentType string `json:"environmentType,omitempty"`
+	EnvironmentType EnvironmentType `json:"environmentType,omitempty"`
 	//The GUID of the global account that is associated with the environment instance.
 	GlobalAccountGuid string `json:"globalAccountGUID,omitempty"`
 	//Automatically generated unique identifier for the environment instance.
 	Id string `json:"id,omitempty"`
 	//Broker-specified key-value pairs that specify attributes of a service instance.
-	Labels string `json:"labels,omitempty"`
+	Labels EnvironmentLabels `json:"labels,omitempty"`
 	//The name of the landscape within the logged-in region on which the environment instance is created.
 	LandscapeLabel string `json:"landscapeLabel,omitempty"`
 	//The last date the environment instance was last modified. Dates and times are in UTC format.
-	ModifiedDate int64 `json:"modifiedDate,omitempty"`
+	ModifiedDate types.EpochTime `json:"modifiedDate,omitempty"`
 	//Name of the environment instance.
 	Name string `json:"name,omitempty"`
 	//An identifier that represents the last operation. This ID is returned by the environment brokers.
@@ -213,7 +215,7 @@ type EnvironmentInstance struct {
 	//Current state of the environment instance.
 	//Enum:
 	//	[ CREATING, UPDATING, DELETING, OK, CREATION_FAILED, DELETION_FAILED, UPDATE_FAILED ]
-	State string `json:"state,omitempty"`
+	State EnvironmentState `json:"state,omitempty"`
 	//Information about the current state of the environment instance.
 	StateMessage string `json:"stateMessage,omitempty"`
 	//The GUID of the subaccount associated with the environment instance.
@@ -226,14 +228,15 @@ type EnvironmentInstance struct {
 	//Deprovision: Environment instance deleted.
 	//Enum:
 	//	[ Provision, Update, Deprovision ]
-	Type string `json:"type,omitempty"`
+	Type OperationType `json:"type,omitempty"`
 }
 
-func (c *ProvisioningV1) GetEnvironmentInstances(ctx context.Context) (*GetEnvironmentInstancesOutput, error) {
-	req, out := c.getEnvironmentInstancesRequest(ctx, nil)
+func (c *ProvisioningV1) GetEnvironmentInstances(ctx context.Context, opts ...request.Option) (*GetEnvironmentInstancesOutput, error) {
+	req, out := c.GetEnvironmentInstancesRequest(ctx, nil)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) getEnvironmentInstancesRequest(ctx context.Context, input *GetEnvironmentInstancesInput) (*request.Request, *GetEnvironmentInstancesOutput) {
+func (c *ProvisioningV1) GetEnvironmentInstancesRequest(ctx context.Context, input *GetEnvironmentInstancesInput) (*request.Request, *GetEnvironmentInstancesOutput) {
 	op := &request.Operation{
 		Name: environments,
 		Http: request.HTTP{
@@ -257,7 +260,7 @@ type CreateEnvironmentInstanceInput struct {
 	Description string `json:"description,omitempty"`
 	//Type of the environment instance that is used. Must match the type of the environment instance broker
 	//(for example: cloudfoundry). Use GET /provisioning/v1/availableEnvironments to view the valid values.
-	EnvironmentType string `json:"environmentType,omitempty"`
+	EnvironmentType EnvironmentType `json:"environmentType,omitempty" required:"true" enum:"true"`
 	//The name of the landscape within the logged-in region on which to create the environment instance. Only required
 	//only if the region has multiple landscapes. To see which landscapes are available for this environment, use the
 	//GET /provisioning/v1/availableEnvironments API.
@@ -278,9 +281,9 @@ type CreateEnvironmentInstanceInput struct {
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
 	//Name of the service plan for the environment instance. Must match the name in the corresponding service broker's
 	//catalog. (for example: standard)
-	PlanName string `json:"planName,omitempty"`
+	PlanName string `json:"planName,omitempty" required:"true"`
 	//The name of service offered in the catalog of the corresponding environment broker. (for example: cloudfoundry)
-	ServiceName string `json:"serviceName,omitempty"`
+	ServiceName string `json:"serviceName,omitempty" required:"true"`
 	//Technical key of the corresponding environment broker.
 	TechnicalKey string `json:"technicalKey,omitempty"`
 	//The e-mail of the user that owns the environment instance. In some environments, this user might be assigned as
@@ -298,11 +301,12 @@ type CreateEnvironmentInstancesOutput struct {
 }
 
 func (c *ProvisioningV1) CreateEnvironmentInstance(ctx context.Context,
-	input *CreateEnvironmentInstanceInput) (*CreateEnvironmentInstancesOutput, error) {
-	req, out := c.createEnvironmentInstanceRequest(ctx, input)
+	input *CreateEnvironmentInstanceInput, opts ...request.Option) (*CreateEnvironmentInstancesOutput, error) {
+	req, out := c.CreateEnvironmentInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) createEnvironmentInstanceRequest(ctx context.Context,
+func (c *ProvisioningV1) CreateEnvironmentInstanceRequest(ctx context.Context,
 	input *CreateEnvironmentInstanceInput) (*request.Request, *CreateEnvironmentInstancesOutput) {
 	op := &request.Operation{
 		Name: environments,
@@ -333,11 +337,12 @@ type DeleteEnvironmentInstancesOutput struct {
 }
 
 func (c *ProvisioningV1) DeleteEnvironmentInstances(ctx context.Context,
-	input *DeleteEnvironmentInstancesInput) (*DeleteEnvironmentInstancesOutput, error) {
-	req, out := c.deleteEnvironmentInstancesRequest(ctx, input)
+	input *DeleteEnvironmentInstancesInput, opts ...request.Option) (*DeleteEnvironmentInstancesOutput, error) {
+	req, out := c.DeleteEnvironmentInstancesRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) deleteEnvironmentInstancesRequest(ctx context.Context,
+func (c *ProvisioningV1) DeleteEnvironmentInstancesRequest(ctx context.Context,
 	input *DeleteEnvironmentInstancesInput) (*request.Request, *DeleteEnvironmentInstancesOutput) {
 	op := &request.Operation{
 		Name: environments,
@@ -359,7 +364,7 @@ func (c *ProvisioningV1) deleteEnvironmentInstancesRequest(ctx context.Context,
 // Get an environment instance
 type GetEnvironmentInstanceInput struct {
 	//The ID of the environment instance to view.
-	EnvironmentInstanceId string `dest:"uri" dest-name:"environmentInstanceId"`
+	EnvironmentInstanceId string `dest:"uri" dest-name:"environmentInstanceId" json:"-"`
 }
 type GetEnvironmentInstanceOutput struct {
 	EnvironmentInstance
@@ -369,11 +374,12 @@ type GetEnvironmentInstanceOutput struct {
 }
 
 func (c *ProvisioningV1) GetEnvironmentInstance(ctx context.Context,
-	input *GetEnvironmentInstanceInput) (*GetEnvironmentInstanceOutput, error) {
-	req, out := c.getEnvironmentInstanceRequest(ctx, input)
+	input *GetEnvironmentInstanceInput, opts ...request.Option) (*GetEnvironmentInstanceOutput, error) {
+	req, out := c.GetEnvironmentInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) getEnvironmentInstanceRequest(ctx context.Context,
+func (c *ProvisioningV1) GetEnvironmentInstanceRequest(ctx context.Context,
 	input *GetEnvironmentInstanceInput) (*request.Request, *GetEnvironmentInstanceOutput) {
 	op := &request.Operation{
 		Name: environments,
@@ -395,7 +401,7 @@ func (c *ProvisioningV1) getEnvironmentInstanceRequest(ctx context.Context,
 // Delete an environment instance
 type DeleteEnvironmentInstanceInput struct {
 	//ID of the environment instance to delete
-	EnvironmentInstanceId string `dest:"uri" dest-name:"environmentInstanceId"`
+	EnvironmentInstanceId string `dest:"uri" dest-name:"environmentInstanceId" json:"-"`
 }
 type DeleteEnvironmentInstanceOutput struct {
 	EnvironmentInstance
@@ -405,11 +411,12 @@ type DeleteEnvironmentInstanceOutput struct {
 }
 
 func (c *ProvisioningV1) DeleteEnvironmentInstance(ctx context.Context,
-	input *DeleteEnvironmentInstanceInput) (*DeleteEnvironmentInstanceOutput, error) {
-	req, out := c.deleteEnvironmentInstanceRequest(ctx, input)
+	input *DeleteEnvironmentInstanceInput, opts ...request.Option) (*DeleteEnvironmentInstanceOutput, error) {
+	req, out := c.DeleteEnvironmentInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) deleteEnvironmentInstanceRequest(ctx context.Context,
+func (c *ProvisioningV1) DeleteEnvironmentInstanceRequest(ctx context.Context,
 	input *DeleteEnvironmentInstanceInput) (*request.Request, *DeleteEnvironmentInstanceOutput) {
 	op := &request.Operation{
 		Name: environments,
@@ -431,7 +438,7 @@ func (c *ProvisioningV1) deleteEnvironmentInstanceRequest(ctx context.Context,
 // Update an environment instance
 type UpdateEnvironmentInstanceInput struct {
 	//ID of the environment instance to delete
-	EnvironmentInstanceId string `dest:"uri" dest-name:"environmentInstanceId"`
+	EnvironmentInstanceId string `dest:"uri" dest-name:"environmentInstanceId" json:"-"`
 
 	//Name of the service plan for the environment instance. Must match the name in the corresponding service broker's
 	//catalog. (for example: Subscription)
@@ -452,11 +459,12 @@ type UpdateEnvironmentInstanceOutput struct {
 }
 
 func (c *ProvisioningV1) UpdateEnvironmentInstance(ctx context.Context,
-	input *UpdateEnvironmentInstanceInput) (*UpdateEnvironmentInstanceOutput, error) {
-	req, out := c.updateEnvironmentInstanceRequest(ctx, input)
+	input *UpdateEnvironmentInstanceInput, opts ...request.Option) (*UpdateEnvironmentInstanceOutput, error) {
+	req, out := c.UpdateEnvironmentInstanceRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *ProvisioningV1) updateEnvironmentInstanceRequest(ctx context.Context,
+func (c *ProvisioningV1) UpdateEnvironmentInstanceRequest(ctx context.Context,
 	input *UpdateEnvironmentInstanceInput) (*request.Request, *UpdateEnvironmentInstanceOutput) {
 	op := &request.Operation{
 		Name: environments,
@@ -0,0 +1,166 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpprovisioningmock provides a mock of btpprovisioning.ProvisioningAPI.
+package btpprovisioningmock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+)
+
+// ProvisioningAPI implements btpprovisioning.ProvisioningAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type ProvisioningAPI struct {
+	GetServicePlanQuotaAssignmentsFunc        func(ctx context.Context, opts ...request.Option) (*btpprovisioning.GetServicePlanAssignmentsOutput, error)
+	GetServicePlanQuotaAssignmentsRequestFunc func(ctx context.Context, input *btpprovisioning.GetServicePlanAssignmentsInput) (*request.Request, *btpprovisioning.GetServicePlanAssignmentsOutput)
+	GetAvailableEnvironmentsFunc              func(ctx context.Context, opts ...request.Option) (*btpprovisioning.GetAvailableEnvironmentsOutput, error)
+	GetAvailableEnvironmentsRequestFunc       func(ctx context.Context, input *btpprovisioning.GetAvailableEnvironmentsInput) (*request.Request, *btpprovisioning.GetAvailableEnvironmentsOutput)
+	GetEnvironmentInstancesFunc               func(ctx context.Context, opts ...request.Option) (*btpprovisioning.GetEnvironmentInstancesOutput, error)
+	GetEnvironmentInstancesRequestFunc        func(ctx context.Context, input *btpprovisioning.GetEnvironmentInstancesInput) (*request.Request, *btpprovisioning.GetEnvironmentInstancesOutput)
+	CreateEnvironmentInstanceFunc             func(ctx context.Context, input *btpprovisioning.CreateEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.CreateEnvironmentInstancesOutput, error)
+	CreateEnvironmentInstanceRequestFunc      func(ctx context.Context, input *btpprovisioning.CreateEnvironmentInstanceInput) (*request.Request, *btpprovisioning.CreateEnvironmentInstancesOutput)
+	DeleteEnvironmentInstancesFunc            func(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstancesInput, opts ...request.Option) (*btpprovisioning.DeleteEnvironmentInstancesOutput, error)
+	DeleteEnvironmentInstancesRequestFunc     func(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstancesInput) (*request.Request, *btpprovisioning.DeleteEnvironmentInstancesOutput)
+	GetEnvironmentInstanceFunc                func(ctx context.Context, input *btpprovisioning.GetEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.GetEnvironmentInstanceOutput, error)
+	GetEnvironmentInstanceRequestFunc         func(ctx context.Context, input *btpprovisioning.GetEnvironmentInstanceInput) (*request.Request, *btpprovisioning.GetEnvironmentInstanceOutput)
+	DeleteEnvironmentInstanceFunc             func(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.DeleteEnvironmentInstanceOutput, error)
+	DeleteEnvironmentInstanceRequestFunc      func(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstanceInput) (*request.Request, *btpprovisioning.DeleteEnvironmentInstanceOutput)
+	UpdateEnvironmentInstanceFunc             func(ctx context.Context, input *btpprovisioning.UpdateEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.UpdateEnvironmentInstanceOutput, error)
+	UpdateEnvironmentInstanceRequestFunc      func(ctx context.Context, input *btpprovisioning.UpdateEnvironmentInstanceInput) (*request.Request, *btpprovisioning.UpdateEnvironmentInstanceOutput)
+	GetJobStatusFunc                          func(ctx context.Context, input *btpprovisioning.GetJobStatusInput, opts ...request.Option) (*btpprovisioning.GetJobStatusOutput, error)
+	GetJobStatusRequestFunc                   func(ctx context.Context, input *btpprovisioning.GetJobStatusInput) (*request.Request, *btpprovisioning.GetJobStatusOutput)
+}
+
+var _ btpprovisioning.ProvisioningAPI = (*ProvisioningAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpprovisioningmock: %s is not stubbed", method)
+}
+
+func (m *ProvisioningAPI) GetServicePlanQuotaAssignments(ctx context.Context, opts ...request.Option) (*btpprovisioning.GetServicePlanAssignmentsOutput, error) {
+	if m.GetServicePlanQuotaAssignmentsFunc == nil {
+		return nil, notStubbed("GetServicePlanQuotaAssignments")
+	}
+	return m.GetServicePlanQuotaAssignmentsFunc(ctx, opts...)
+}
+
+func (m *ProvisioningAPI) GetServicePlanQuotaAssignmentsRequest(ctx context.Context, input *btpprovisioning.GetServicePlanAssignmentsInput) (*request.Request, *btpprovisioning.GetServicePlanAssignmentsOutput) {
+	if m.GetServicePlanQuotaAssignmentsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetServicePlanQuotaAssignmentsRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) GetAvailableEnvironments(ctx context.Context, opts ...request.Option) (*btpprovisioning.GetAvailableEnvironmentsOutput, error) {
+	if m.GetAvailableEnvironmentsFunc == nil {
+		return nil, notStubbed("GetAvailableEnvironments")
+	}
+	return m.GetAvailableEnvironmentsFunc(ctx, opts...)
+}
+
+func (m *ProvisioningAPI) GetAvailableEnvironmentsRequest(ctx context.Context, input *btpprovisioning.GetAvailableEnvironmentsInput) (*request.Request, *btpprovisioning.GetAvailableEnvironmentsOutput) {
+	if m.GetAvailableEnvironmentsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetAvailableEnvironmentsRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) GetEnvironmentInstances(ctx context.Context, opts ...request.Option) (*btpprovisioning.GetEnvironmentInstancesOutput, error) {
+	if m.GetEnvironmentInstancesFunc == nil {
+		return nil, notStubbed("GetEnvironmentInstances")
+	}
+	return m.GetEnvironmentInstancesFunc(ctx, opts...)
+}
+
+func (m *ProvisioningAPI) GetEnvironmentInstancesRequest(ctx context.Context, input *btpprovisioning.GetEnvironmentInstancesInput) (*request.Request, *btpprovisioning.GetEnvironmentInstancesOutput) {
+	if m.GetEnvironmentInstancesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetEnvironmentInstancesRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) CreateEnvironmentInstance(ctx context.Context, input *btpprovisioning.CreateEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.CreateEnvironmentInstancesOutput, error) {
+	if m.CreateEnvironmentInstanceFunc == nil {
+		return nil, notStubbed("CreateEnvironmentInstance")
+	}
+	return m.CreateEnvironmentInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ProvisioningAPI) CreateEnvironmentInstanceRequest(ctx context.Context, input *btpprovisioning.CreateEnvironmentInstanceInput) (*request.Request, *btpprovisioning.CreateEnvironmentInstancesOutput) {
+	if m.CreateEnvironmentInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.CreateEnvironmentInstanceRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) DeleteEnvironmentInstances(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstancesInput, opts ...request.Option) (*btpprovisioning.DeleteEnvironmentInstancesOutput, error) {
+	if m.DeleteEnvironmentInstancesFunc == nil {
+		return nil, notStubbed("DeleteEnvironmentInstances")
+	}
+	return m.DeleteEnvironmentInstancesFunc(ctx, input, opts...)
+}
+
+func (m *ProvisioningAPI) DeleteEnvironmentInstancesRequest(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstancesInput) (*request.Request, *btpprovisioning.DeleteEnvironmentInstancesOutput) {
+	if m.DeleteEnvironmentInstancesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteEnvironmentInstancesRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) GetEnvironmentInstance(ctx context.Context, input *btpprovisioning.GetEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.GetEnvironmentInstanceOutput, error) {
+	if m.GetEnvironmentInstanceFunc == nil {
+		return nil, notStubbed("GetEnvironmentInstance")
+	}
+	return m.GetEnvironmentInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ProvisioningAPI) GetEnvironmentInstanceRequest(ctx context.Context, input *btpprovisioning.GetEnvironmentInstanceInput) (*request.Request, *btpprovisioning.GetEnvironmentInstanceOutput) {
+	if m.GetEnvironmentInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetEnvironmentInstanceRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) DeleteEnvironmentInstance(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.DeleteEnvironmentInstanceOutput, error) {
+	if m.DeleteEnvironmentInstanceFunc == nil {
+		return nil, notStubbed("DeleteEnvironmentInstance")
+	}
+	return m.DeleteEnvironmentInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ProvisioningAPI) DeleteEnvironmentInstanceRequest(ctx context.Context, input *btpprovisioning.DeleteEnvironmentInstanceInput) (*request.Request, *btpprovisioning.DeleteEnvironmentInstanceOutput) {
+	if m.DeleteEnvironmentInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.DeleteEnvironmentInstanceRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) UpdateEnvironmentInstance(ctx context.Context, input *btpprovisioning.UpdateEnvironmentInstanceInput, opts ...request.Option) (*btpprovisioning.UpdateEnvironmentInstanceOutput, error) {
+	if m.UpdateEnvironmentInstanceFunc == nil {
+		return nil, notStubbed("UpdateEnvironmentInstance")
+	}
+	return m.UpdateEnvironmentInstanceFunc(ctx, input, opts...)
+}
+
+func (m *ProvisioningAPI) UpdateEnvironmentInstanceRequest(ctx context.Context, input *btpprovisioning.UpdateEnvironmentInstanceInput) (*request.Request, *btpprovisioning.UpdateEnvironmentInstanceOutput) {
+	if m.UpdateEnvironmentInstanceRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateEnvironmentInstanceRequestFunc(ctx, input)
+}
+
+func (m *ProvisioningAPI) GetJobStatus(ctx context.Context, input *btpprovisioning.GetJobStatusInput, opts ...request.Option) (*btpprovisioning.GetJobStatusOutput, error) {
+	if m.GetJobStatusFunc == nil {
+		return nil, notStubbed("GetJobStatus")
+	}
+	return m.GetJobStatusFunc(ctx, input, opts...)
+}
+
+func (m *ProvisioningAPI) GetJobStatusRequest(ctx context.Context, input *btpprovisioning.GetJobStatusInput) (*request.Request, *btpprovisioning.GetJobStatusOutput) {
+	if m.GetJobStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetJobStatusRequestFunc(ctx, input)
+}
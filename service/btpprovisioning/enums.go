@@ -0,0 +1,108 @@
+package btpprovisioning
+
+// EnvironmentState is the state of an environment instance.
+type EnvironmentState string
+
+const (
+	EnvironmentStateCreating       EnvironmentState = "CREATING"
+	EnvironmentStateUpdating       EnvironmentState = "UPDATING"
+	EnvironmentStateDeleting       EnvironmentState = "DELETING"
+	EnvironmentStateOK             EnvironmentState = "OK"
+	EnvironmentStateCreationFailed EnvironmentState = "CREATION_FAILED"
+	EnvironmentStateDeletionFailed EnvironmentState = "DELETION_FAILED"
+	EnvironmentStateUpdateFailed   EnvironmentState = "UPDATE_FAILED"
+)
+
+func (EnvironmentState) Values() []EnvironmentState {
+	return []EnvironmentState{
+		EnvironmentStateCreating,
+		EnvironmentStateUpdating,
+		EnvironmentStateDeleting,
+		EnvironmentStateOK,
+		EnvironmentStateCreationFailed,
+		EnvironmentStateDeletionFailed,
+		EnvironmentStateUpdateFailed,
+	}
+}
+
+func (s EnvironmentState) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the environment instance has left the transitional states, successfully or not.
+func (s EnvironmentState) IsTerminal() bool {
+	if !s.Valid() {
+		return false
+	}
+	switch s {
+	case EnvironmentStateCreating, EnvironmentStateUpdating, EnvironmentStateDeleting:
+		return false
+	}
+	return true
+}
+
+func (s EnvironmentState) IsFailure() bool {
+	switch s {
+	case EnvironmentStateCreationFailed, EnvironmentStateDeletionFailed,
+		EnvironmentStateUpdateFailed:
+		return true
+	}
+	return false
+}
+
+// EnvironmentType is the type of an environment.
+type EnvironmentType string
+
+const (
+	EnvironmentTypeCloudFoundry EnvironmentType = "cloudfoundry"
+	EnvironmentTypeKubernetes   EnvironmentType = "kubernetes"
+	EnvironmentTypeNeo          EnvironmentType = "neo"
+)
+
+func (EnvironmentType) Values() []EnvironmentType {
+	return []EnvironmentType{
+		EnvironmentTypeCloudFoundry,
+		EnvironmentTypeKubernetes,
+		EnvironmentTypeNeo,
+	}
+}
+
+func (t EnvironmentType) Valid() bool {
+	for _, v := range t.Values() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// OperationType is the type of the last operation run on an environment instance.
+type OperationType string
+
+const (
+	OperationTypeProvision   OperationType = "Provision"
+	OperationTypeUpdate      OperationType = "Update"
+	OperationTypeDeprovision OperationType = "Deprovision"
+)
+
+func (OperationType) Values() []OperationType {
+	return []OperationType{
+		OperationTypeProvision,
+		OperationTypeUpdate,
+		OperationTypeDeprovision,
+	}
+}
+
+func (t OperationType) Valid() bool {
+	for _, v := range t.Values() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
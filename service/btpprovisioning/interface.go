@@ -0,0 +1,33 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpprovisioning
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// ProvisioningAPI is the interface implemented by ProvisioningV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpprovisioningmock.
+type ProvisioningAPI interface {
+	GetServicePlanQuotaAssignments(ctx context.Context, opts ...request.Option) (*GetServicePlanAssignmentsOutput, error)
+	GetServicePlanQuotaAssignmentsRequest(ctx context.Context, input *GetServicePlanAssignmentsInput) (*request.Request, *GetServicePlanAssignmentsOutput)
+	GetAvailableEnvironments(ctx context.Context, opts ...request.Option) (*GetAvailableEnvironmentsOutput, error)
+	GetAvailableEnvironmentsRequest(ctx context.Context, input *GetAvailableEnvironmentsInput) (*request.Request, *GetAvailableEnvironmentsOutput)
+	GetEnvironmentInstances(ctx context.Context, opts ...request.Option) (*GetEnvironmentInstancesOutput, error)
+	GetEnvironmentInstancesRequest(ctx context.Context, input *GetEnvironmentInstancesInput) (*request.Request, *GetEnvironmentInstancesOutput)
+	CreateEnvironmentInstance(ctx context.Context, input *CreateEnvironmentInstanceInput, opts ...request.Option) (*CreateEnvironmentInstancesOutput, error)
+	CreateEnvironmentInstanceRequest(ctx context.Context, input *CreateEnvironmentInstanceInput) (*request.Request, *CreateEnvironmentInstancesOutput)
+	DeleteEnvironmentInstances(ctx context.Context, input *DeleteEnvironmentInstancesInput, opts ...request.Option) (*DeleteEnvironmentInstancesOutput, error)
+	DeleteEnvironmentInstancesRequest(ctx context.Context, input *DeleteEnvironmentInstancesInput) (*request.Request, *DeleteEnvironmentInstancesOutput)
+	GetEnvironmentInstance(ctx context.Context, input *GetEnvironmentInstanceInput, opts ...request.Option) (*GetEnvironmentInstanceOutput, error)
+	GetEnvironmentInstanceRequest(ctx context.Context, input *GetEnvironmentInstanceInput) (*request.Request, *GetEnvironmentInstanceOutput)
+	DeleteEnvironmentInstance(ctx context.Context, input *DeleteEnvironmentInstanceInput, opts ...request.Option) (*DeleteEnvironmentInstanceOutput, error)
+	DeleteEnvironmentInstanceRequest(ctx context.Context, input *DeleteEnvironmentInstanceInput) (*request.Request, *DeleteEnvironmentInstanceOutput)
+	UpdateEnvironmentInstance(ctx context.Context, input *UpdateEnvironmentInstanceInput, opts ...request.Option) (*UpdateEnvironmentInstanceOutput, error)
+	UpdateEnvironmentInstanceRequest(ctx context.Context, input *UpdateEnvironmentInstanceInput) (*request.Request, *UpdateEnvironmentInstanceOutput)
+	GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error)
+	GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput)
+}
+
+var _ ProvisioningAPI = (*ProvisioningV1)(nil)
@@ -0,0 +1,33 @@
+package btpprovisioning
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// EnvironmentLabels are the broker-specified labels of an environment instance, such as
+// the API endpoint and org of a Cloud Foundry environment. The service sends them either
+// as an object or as a JSON document inside a string; both forms decode.
+type EnvironmentLabels map[string]string
+
+func (l *EnvironmentLabels) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			*l = nil
+			return nil
+		}
+		data = []byte(text)
+	}
+
+	var labels map[string]string
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return err
+	}
+	*l = labels
+	return nil
+}
@@ -0,0 +1,31 @@
+package btpresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func TestMonthlyUsageDateRange(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.AddUsage(
+		btpfake.UsageRecord{ServiceName: "hana", ReportYearMonth: 202101, Usage: 1},
+		btpfake.UsageRecord{ServiceName: "hana", ReportYearMonth: 202102, Usage: 2},
+		btpfake.UsageRecord{ServiceName: "hana", ReportYearMonth: 202103, Usage: 3},
+	)
+
+	sess, err := srv.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := New(sess)
+	out, err := svc.GetMonthlyUsage(context.Background(), &GetMonthlyUsageInput{FromDate: 202101, ToDate: 202102})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Content) != 2 {
+		t.Fatalf("expected the usage of two months, got %d", len(out.Content))
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpresourcesmock provides a mock of btpresources.ResourceAPI.
+package btpresourcesmock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpresources"
+)
+
+// ResourceAPI implements btpresources.ResourceAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type ResourceAPI struct {
+	GetCloudCreditsDetailsFunc           func(ctx context.Context, input *btpresources.GetCloudCreditsDetailsInput, opts ...request.Option) (*btpresources.GetCloudCreditsDetailsOutput, error)
+	GetCloudCreditsDetailsRequestFunc    func(ctx context.Context, input *btpresources.GetCloudCreditsDetailsInput) (*request.Request, *btpresources.GetCloudCreditsDetailsOutput)
+	GetMonthlySubAccountsCostFunc        func(ctx context.Context, input *btpresources.GetMonthlySubAccountsCostInput, opts ...request.Option) (*btpresources.GetMonthlySubAccountsCostOutput, error)
+	GetMonthlySubAccountsCostRequestFunc func(ctx context.Context, input *btpresources.GetMonthlySubAccountsCostInput) (*request.Request, *btpresources.GetMonthlySubAccountsCostOutput)
+	GetMonthlyUsageFunc                  func(ctx context.Context, input *btpresources.GetMonthlyUsageInput, opts ...request.Option) (*btpresources.GetMonthlyUsageOutput, error)
+	GetMonthlyUsageRequestFunc           func(ctx context.Context, input *btpresources.GetMonthlyUsageInput) (*request.Request, *btpresources.GetMonthlyUsageOutput)
+	GetSubAccountUsageFunc               func(ctx context.Context, input *btpresources.GetSubAccountUsageInput, opts ...request.Option) (*btpresources.GetSubAccountUsageOutput, error)
+	GetSubAccountUsageRequestFunc        func(ctx context.Context, input *btpresources.GetSubAccountUsageInput) (*request.Request, *btpresources.GetSubAccountUsageOutput)
+}
+
+var _ btpresources.ResourceAPI = (*ResourceAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpresourcesmock: %s is not stubbed", method)
+}
+
+func (m *ResourceAPI) GetCloudCreditsDetails(ctx context.Context, input *btpresources.GetCloudCreditsDetailsInput, opts ...request.Option) (*btpresources.GetCloudCreditsDetailsOutput, error) {
+	if m.GetCloudCreditsDetailsFunc == nil {
+		return nil, notStubbed("GetCloudCreditsDetails")
+	}
+	return m.GetCloudCreditsDetailsFunc(ctx, input, opts...)
+}
+
+func (m *ResourceAPI) GetCloudCreditsDetailsRequest(ctx context.Context, input *btpresources.GetCloudCreditsDetailsInput) (*request.Request, *btpresources.GetCloudCreditsDetailsOutput) {
+	if m.GetCloudCreditsDetailsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetCloudCreditsDetailsRequestFunc(ctx, input)
+}
+
+func (m *ResourceAPI) GetMonthlySubAccountsCost(ctx context.Context, input *btpresources.GetMonthlySubAccountsCostInput, opts ...request.Option) (*btpresources.GetMonthlySubAccountsCostOutput, error) {
+	if m.GetMonthlySubAccountsCostFunc == nil {
+		return nil, notStubbed("GetMonthlySubAccountsCost")
+	}
+	return m.GetMonthlySubAccountsCostFunc(ctx, input, opts...)
+}
+
+func (m *ResourceAPI) GetMonthlySubAccountsCostRequest(ctx context.Context, input *btpresources.GetMonthlySubAccountsCostInput) (*request.Request, *btpresources.GetMonthlySubAccountsCostOutput) {
+	if m.GetMonthlySubAccountsCostRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetMonthlySubAccountsCostRequestFunc(ctx, input)
+}
+
+func (m *ResourceAPI) GetMonthlyUsage(ctx context.Context, input *btpresources.GetMonthlyUsageInput, opts ...request.Option) (*btpresources.GetMonthlyUsageOutput, error) {
+	if m.GetMonthlyUsageFunc == nil {
+		return nil, notStubbed("GetMonthlyUsage")
+	}
+	return m.GetMonthlyUsageFunc(ctx, input, opts...)
+}
+
+func (m *ResourceAPI) GetMonthlyUsageRequest(ctx context.Context, input *btpresources.GetMonthlyUsageInput) (*request.Request, *btpresources.GetMonthlyUsageOutput) {
+	if m.GetMonthlyUsageRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetMonthlyUsageRequestFunc(ctx, input)
+}
+
+func (m *ResourceAPI) GetSubAccountUsage(ctx context.Context, input *btpresources.GetSubAccountUsageInput, opts ...request.Option) (*btpresources.GetSubAccountUsageOutput, error) {
+	if m.GetSubAccountUsageFunc == nil {
+		return nil, notStubbed("GetSubAccountUsage")
+	}
+	return m.GetSubAccountUsageFunc(ctx, input, opts...)
+}
+
+func (m *ResourceAPI) GetSubAccountUsageRequest(ctx context.Context, input *btpresources.GetSubAccountUsageInput) (*request.Request, *btpresources.GetSubAccountUsageOutput) {
+	if m.GetSubAccountUsageRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetSubAccountUsageRequestFunc(ctx, input)
+}
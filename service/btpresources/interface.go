@@ -0,0 +1,23 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpresources
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// ResourceAPI is the interface implemented by ResourceV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpresourcesmock.
+type ResourceAPI interface {
+	GetCloudCreditsDetails(ctx context.Context, input *GetCloudCreditsDetailsInput, opts ...request.Option) (*GetCloudCreditsDetailsOutput, error)
+	GetCloudCreditsDetailsRequest(ctx context.Context, input *GetCloudCreditsDetailsInput) (*request.Request, *GetCloudCreditsDetailsOutput)
+	GetMonthlySubAccountsCost(ctx context.Context, input *GetMonthlySubAccountsCostInput, opts ...request.Option) (*GetMonthlySubAccountsCostOutput, error)
+	GetMonthlySubAccountsCostRequest(ctx context.Context, input *GetMonthlySubAccountsCostInput) (*request.Request, *GetMonthlySubAccountsCostOutput)
+	GetMonthlyUsage(ctx context.Context, input *GetMonthlyUsageInput, opts ...request.Option) (*GetMonthlyUsageOutput, error)
+	GetMonthlyUsageRequest(ctx context.Context, input *GetMonthlyUsageInput) (*request.Request, *GetMonthlyUsageOutput)
+	GetSubAccountUsage(ctx context.Context, input *GetSubAccountUsageInput, opts ...request.Option) (*GetSubAccountUsageOutput, error)
+	GetSubAccountUsageRequest(ctx context.Context, input *GetSubAccountUsageInput) (*request.Request, *GetSubAccountUsageOutput)
+}
+
+var _ ResourceAPI = (*ResourceV1)(nil)
@@ -57,11 +57,12 @@ type GetApplicationRegistrationOutput struct {
 }
 
 func (c *SaaSProvisioningV1) GetApplicationRegistration(ctx context.Context,
-	input *GetApplicationRegistrationInput) (*GetApplicationRegistrationOutput, error) {
-	req, out := c.getApplicationRegistrationRequest(ctx, input)
+	input *GetApplicationRegistrationInput, opts ...request.Option) (*GetApplicationRegistrationOutput, error) {
+	req, out := c.GetApplicationRegistrationRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getApplicationRegistrationRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) GetApplicationRegistrationRequest(ctx context.Context,
 	input *GetApplicationRegistrationInput) (*request.Request, *GetApplicationRegistrationOutput) {
 	op := &request.Operation{
 		Name: "Get Application Registration",
@@ -83,14 +84,14 @@ func (c *SaaSProvisioningV1) getApplicationRegistrationRequest(ctx context.Conte
 // Get application subscriptions
 type GetApplicationSubscriptionsInput struct {
 	//Get subscriptions by associated global account ID.
-	GlobalAccountId string `dest:"querystring" dest-name:"globalAccountId"`
+	GlobalAccountId string `dest:"querystring" dest-name:"globalAccountId" json:"-"`
 	//Get subscriptions by state.
 	//Available values : IN_PROCESS, SUBSCRIBED, SUBSCRIBE_FAILED, UNSUBSCRIBE_FAILED, UPDATE_FAILED, NOT_SUBSCRIBED
-	State string `dest:"querystring" dest-name:"state"`
+	State SubscriptionState `dest:"querystring" dest-name:"state" json:"-"`
 	//Get subscriptions by the associated subaccount ID.
-	SubAccountId string `dest:"querystring" dest-name:"subaccountId"`
+	SubAccountId string `dest:"querystring" dest-name:"subaccountId" json:"-"`
 	//Get subscriptions by tenant ID.
-	TenantId string `dest:"querystring" dest-name:"tenantId"`
+	TenantId string `dest:"querystring" dest-name:"tenantId" json:"-"`
 }
 type GetApplicationSubscriptionsOutput struct {
 	Values []ApplicationSubscription `json:"values,omitempty"`
@@ -107,13 +108,13 @@ type ApplicationSubscription struct {
 	//The unique registration name of the deployed multitenant application, as defined by the app developer.
 	AppName string `json:"appName,omitempty"`
 	//The date and time the subscription was last modified. Dates and times are in UTC format.
-	ChangedOn string `json:"changedOn,omitempty"`
+	ChangedOn types.ISOTime `json:"changedOn,omitempty"`
 	//A subscription code for the application.
 	Code string `json:"code,omitempty"`
 	//Tenant ID of the global account or subaccount of the consumer that has subscribed to the multitenant application.
 	ConsumerTenantId string `json:"consumerTenantId,omitempty"`
 	//The date and time the subscription was created. Dates and times are in UTC format.
-	CreatedOn string `json:"createdOn,omitempty"`
+	CreatedOn types.ISOTime `json:"createdOn,omitempty"`
 	//Any reuse services used or required by a subscribed application and its services.
 	Dependencies []Dependency `json:"dependencies,omitempty"`
 	//Error description for the following statuses: SUBSCRIBE_FAILED, UNSUBSCRIBE_FAILED, UPDATE_FAILED.
@@ -128,7 +129,7 @@ type ApplicationSubscription struct {
 	//The ID of the multitenant application that is registered to the SAP SaaS Provisioning registry.
 	ServiceInstanceId string `json:"serviceInstanceId,omitempty"`
 	//State of the subscriptions. Possible states: IN_PROCESS, SUBSCRIBED, SUBSCRIBE_FAILED, UPDATE_FAILED.
-	State string `json:"state,omitempty"`
+	State SubscriptionState `json:"state,omitempty"`
 	//ID of the associated subaccount.
 	SubAccountId string `json:"subaccountId,omitempty"`
 	//Consumer Subdomain
@@ -148,11 +149,12 @@ type Dependency struct {
 }
 
 func (c *SaaSProvisioningV1) GetApplicationSubscriptions(ctx context.Context,
-	input *GetApplicationSubscriptionsInput) (*GetApplicationSubscriptionsOutput, error) {
-	req, out := c.getApplicationSubscriptionsRequest(ctx, input)
+	input *GetApplicationSubscriptionsInput, opts ...request.Option) (*GetApplicationSubscriptionsOutput, error) {
+	req, out := c.GetApplicationSubscriptionsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getApplicationSubscriptionsRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) GetApplicationSubscriptionsRequest(ctx context.Context,
 	input *GetApplicationSubscriptionsInput) (*request.Request, *GetApplicationSubscriptionsOutput) {
 	op := &request.Operation{
 		Name: "Get Application Subscriptions",
@@ -185,7 +187,7 @@ func (c *SaaSProvisioningV1) getApplicationSubscriptionsRequest(ctx context.Cont
 // Subscribe tenant to an application
 type SubscribeTenantToApplicationInput struct {
 	//The ID of the tenant to subscribe.
-	TenantId string `dest:"uri" dest-name:"tenantId"`
+	TenantId string `dest:"uri" dest-name:"tenantId" json:"-"`
 }
 type SubscribeTenantToApplicationOutput struct {
 	Location string `src:"header" src-name:"Location"`
@@ -199,11 +201,12 @@ type SubscribeTenantToApplicationOutput struct {
 }
 
 func (c *SaaSProvisioningV1) SubscribeTenantToApplication(ctx context.Context,
-	input *SubscribeTenantToApplicationInput) (*SubscribeTenantToApplicationOutput, error) {
-	req, out := c.subscribeTenantToApplicationRequest(ctx, input)
+	input *SubscribeTenantToApplicationInput, opts ...request.Option) (*SubscribeTenantToApplicationOutput, error) {
+	req, out := c.SubscribeTenantToApplicationRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) subscribeTenantToApplicationRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) SubscribeTenantToApplicationRequest(ctx context.Context,
 	input *SubscribeTenantToApplicationInput) (*request.Request, *SubscribeTenantToApplicationOutput) {
 	op := &request.Operation{
 		Name: "Subscribe Tenant To Application",
@@ -236,7 +239,7 @@ func (c *SaaSProvisioningV1) subscribeTenantToApplicationRequest(ctx context.Con
 // Unsubscribe tenant from an application
 type UnSubscribeTenantFromApplicationInput struct {
 	//The ID of the tenant to unsubscribe
-	TenantId string `dest:"uri" dest-name:"tenantId"`
+	TenantId string `dest:"uri" dest-name:"tenantId" json:"-"`
 }
 type UnSubscribeTenantFromApplicationOutput struct {
 	Location string `src:"header" src-name:"Location"`
@@ -250,11 +253,12 @@ type UnSubscribeTenantFromApplicationOutput struct {
 }
 
 func (c *SaaSProvisioningV1) UnSubscribeTenantFromApplication(ctx context.Context,
-	input *UnSubscribeTenantFromApplicationInput) (*UnSubscribeTenantFromApplicationOutput, error) {
-	req, out := c.unsubscribeTenantFromApplicationRequest(ctx, input)
+	input *UnSubscribeTenantFromApplicationInput, opts ...request.Option) (*UnSubscribeTenantFromApplicationOutput, error) {
+	req, out := c.UnSubscribeTenantFromApplicationRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) unsubscribeTenantFromApplicationRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) UnSubscribeTenantFromApplicationRequest(ctx context.Context,
 	input *UnSubscribeTenantFromApplicationInput) (*request.Request, *UnSubscribeTenantFromApplicationOutput) {
 	op := &request.Operation{
 		Name: "UnSubscribe Tenant From Application",
@@ -287,16 +291,16 @@ func (c *SaaSProvisioningV1) unsubscribeTenantFromApplicationRequest(ctx context
 // Update subscription dependencies
 type UpdateSubscriptionDependenciesInput struct {
 	//The ID of the tenant for which to update dependencies
-	TenantId string `dest:"uri" dest-name:"tenantId"`
+	TenantId string `dest:"uri" dest-name:"tenantId" json:"-"`
 
 	//Whether to skip updating the dependencies that haven’t changed.
-	SkipUnchangedDependencies bool `dest:"querystring" dest-name:"skipUnchangedDependencies"`
+	SkipUnchangedDependencies bool `dest:"querystring" dest-name:"skipUnchangedDependencies" json:"-"`
 	//Whether to skip updating dependencies. If set to true, updateApplicationURL must also be set to true.
 	//This way, you can update the application URL without updating its dependencies.
-	SkipUpdatingDependencies bool `dest:"querystring" dest-name:"skipUpdatingDependencies"`
+	SkipUpdatingDependencies bool `dest:"querystring" dest-name:"skipUpdatingDependencies" json:"-"`
 	//Whether to update the application URL returned from the app callback. If set to true together with
 	//skipUpdatingDependencies, the API call becomes synchronous.
-	UpdateApplicationURL bool `dest:"querystring" dest-name:"updateApplicationURL"`
+	UpdateApplicationURL bool `dest:"querystring" dest-name:"updateApplicationURL" json:"-"`
 
 	//Send custom property values in the form of key-value pairs to dependent services (provider applications) during
 	//the update to notify them about a change related to an existing subscription.
@@ -314,11 +318,12 @@ type UpdateSubscriptionDependenciesOutput struct {
 }
 
 func (c *SaaSProvisioningV1) UpdateSubscriptionDependencies(ctx context.Context,
-	input *UpdateSubscriptionDependenciesInput) (*UpdateSubscriptionDependenciesOutput, error) {
-	req, out := c.updateSubscriptionDependenciesRequest(ctx, input)
+	input *UpdateSubscriptionDependenciesInput, opts ...request.Option) (*UpdateSubscriptionDependenciesOutput, error) {
+	req, out := c.UpdateSubscriptionDependenciesRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) updateSubscriptionDependenciesRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) UpdateSubscriptionDependenciesRequest(ctx context.Context,
 	input *UpdateSubscriptionDependenciesInput) (*request.Request, *UpdateSubscriptionDependenciesOutput) {
 	op := &request.Operation{
 		Name: "Update Subscription Dependencies",
@@ -350,7 +355,7 @@ func (c *SaaSProvisioningV1) updateSubscriptionDependenciesRequest(ctx context.C
 // GET /saas-manager/v1/applications
 // Get all entitled multitenant applications
 type GetEntitledApplicationsInput struct {
-	AcceptLanguage string `dest:"header" dest-name:"Accept-Language"`
+	AcceptLanguage string `dest:"header" dest-name:"Accept-Language" json:"-"`
 }
 type GetEntitledApplicationsOutput struct {
 	//The response list of all the multitenant applications to which a specified subaccount is entitled to subscribe.
@@ -374,7 +379,7 @@ type Application struct {
 	//IAS is Identity Authentication Service that defines scopes and permissions for users in zones (common data isolation systems across systems, SaaS tenants, and services).
 	//Enum:
 	//	[ XSUAA, IAS ]
-	AuthenticationProvider string `json:"authenticationProvider,omitempty"`
+	AuthenticationProvider AuthenticationProvider `json:"authenticationProvider,omitempty"`
 	//The technical name of the category defined by the app developer to which the multitenant
 	//application is grouped in customer-facing UIs.
 	Category string `json:"category,omitempty"`
@@ -398,9 +403,9 @@ type Application struct {
 	//The application's incident-tracking component provided in metadata for customer-facing UIs.
 	IncidentTrackingComponent string `json:"incidentTrackingComponent,omitempty"`
 	//The date the subscription was last modified. Dates and times are in UTC format.
-	ModifiedDate int64 `json:"modifiedDate,omitempty"`
+	ModifiedDate types.EpochTime `json:"modifiedDate,omitempty"`
 	//The date the subscription was created. Dates and times are in UTC format.
-	CreatedDate int64 `json:"createdDate,omitempty"`
+	CreatedDate types.EpochTime `json:"createdDate,omitempty"`
 	//The plan name of the application to which the consumer has subscribed.
 	PlanName string `json:"planName,omitempty"`
 	//ID of the landscape-specific environment.
@@ -412,7 +417,7 @@ type Application struct {
 	//The subscription state of the subaccount regarding the multitenant application.
 	//Enum:
 	//	[ IN_PROCESS, SUBSCRIBED, SUBSCRIBE_FAILED, UNSUBSCRIBE_FAILED, UPDATE_FAILED, NOT_SUBSCRIBED ]
-	State string `json:"state,omitempty"`
+	State SubscriptionState `json:"state,omitempty"`
 	//The ID of the subaccount which is subscribed to the multitenant application.
 	SubscribedSubAccountId string `json:"subscribedSubaccountId,omitempty"`
 	//The ID of the tenant which is subscribed to a multitenant application.
@@ -436,11 +441,12 @@ type SubscriptionError struct {
 }
 
 func (c *SaaSProvisioningV1) GetEntitledApplications(ctx context.Context,
-	input *GetEntitledApplicationsInput) (*GetEntitledApplicationsOutput, error) {
-	req, out := c.getEntitledApplicationsRequest(ctx, input)
+	input *GetEntitledApplicationsInput, opts ...request.Option) (*GetEntitledApplicationsOutput, error) {
+	req, out := c.GetEntitledApplicationsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getEntitledApplicationsRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) GetEntitledApplicationsRequest(ctx context.Context,
 	input *GetEntitledApplicationsInput) (*request.Request, *GetEntitledApplicationsOutput) {
 	op := &request.Operation{
 		Name: "Get Entitled Applications",
@@ -461,12 +467,12 @@ func (c *SaaSProvisioningV1) getEntitledApplicationsRequest(ctx context.Context,
 // GET /saas-manager/v1/applications/{appName}
 // Get details of a multitenant application
 type GetDetailsApplicationsInput struct {
-	AcceptLanguage string `dest:"header" dest-name:"Accept-Language"`
+	AcceptLanguage string `dest:"header" dest-name:"Accept-Language" json:"-"`
 
 	//The name of the multitenant application to which a subaccount is entitled to subscribe.
-	AppName string `dest:"uri" dest-name:"appName"`
+	AppName string `dest:"uri" dest-name:"appName" json:"-"`
 	//The name of the subscription plan to the multitenant application.
-	PlanName string `dest:"querystring" dest-name:"planName"`
+	PlanName string `dest:"querystring" dest-name:"planName" json:"-"`
 }
 type GetDetailsApplicationsOutput struct {
 	Application
@@ -478,11 +484,12 @@ type GetDetailsApplicationsOutput struct {
 }
 
 func (c *SaaSProvisioningV1) GetDetailsApplications(ctx context.Context,
-	input *GetDetailsApplicationsInput) (*GetDetailsApplicationsOutput, error) {
-	req, out := c.getDetailsApplicationsRequest(ctx, input)
+	input *GetDetailsApplicationsInput, opts ...request.Option) (*GetDetailsApplicationsOutput, error) {
+	req, out := c.GetDetailsApplicationsRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getDetailsApplicationsRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) GetDetailsApplicationsRequest(ctx context.Context,
 	input *GetDetailsApplicationsInput) (*request.Request, *GetDetailsApplicationsOutput) {
 	op := &request.Operation{
 		Name: "Get Details Applications",
@@ -504,7 +511,7 @@ func (c *SaaSProvisioningV1) getDetailsApplicationsRequest(ctx context.Context,
 // Subscribe to an application from a subaccount
 type SubscribeToApplicationInput struct {
 	//The name of the multitenant application to subscribe to.
-	AppName string `dest:"uri" dest-name:"appName"`
+	AppName string `dest:"uri" dest-name:"appName" json:"-"`
 
 	//The name of the subscription plan to a multitenant application
 	PlanName string `json:"planName,omitempty"`
@@ -515,11 +522,12 @@ type SubscribeToApplicationOutput struct {
 }
 
 func (c *SaaSProvisioningV1) SubscribeToApplication(ctx context.Context,
-	input *SubscribeToApplicationInput) (*SubscribeToApplicationOutput, error) {
-	req, out := c.getSubscribeToApplicationRequest(ctx, input)
+	input *SubscribeToApplicationInput, opts ...request.Option) (*SubscribeToApplicationOutput, error) {
+	req, out := c.SubscribeToApplicationRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getSubscribeToApplicationRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) SubscribeToApplicationRequest(ctx context.Context,
 	input *SubscribeToApplicationInput) (*request.Request, *SubscribeToApplicationOutput) {
 	op := &request.Operation{
 		Name: "Subscribe To Application",
@@ -541,7 +549,7 @@ func (c *SaaSProvisioningV1) getSubscribeToApplicationRequest(ctx context.Contex
 // Unsubscribe an application from a subaccount
 type UnSubscribeFromApplicationInput struct {
 	//The name of the multitenant application from which to unsubscribe the subaccount.
-	AppName string `dest:"uri" dest-name:"appName"`
+	AppName string `dest:"uri" dest-name:"appName" json:"-"`
 }
 type UnSubscribeFromApplicationOutput struct {
 	//Error *types.Error `json:"error,omitempty"`
@@ -551,11 +559,12 @@ type UnSubscribeFromApplicationOutput struct {
 }
 
 func (c *SaaSProvisioningV1) UnSubscribeFromApplication(ctx context.Context,
-	input *UnSubscribeFromApplicationInput) error {
-	req, _ := c.unSubscribeFromApplicationRequest(ctx, input)
+	input *UnSubscribeFromApplicationInput, opts ...request.Option) error {
+	req, _ := c.UnSubscribeFromApplicationRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return req.Send()
 }
-func (c *SaaSProvisioningV1) unSubscribeFromApplicationRequest(ctx context.Context,
+func (c *SaaSProvisioningV1) UnSubscribeFromApplicationRequest(ctx context.Context,
 	input *UnSubscribeFromApplicationInput) (*request.Request, *UnSubscribeFromApplicationOutput) {
 	op := &request.Operation{
 		Name: "UnSubscribe From Application",
@@ -577,7 +586,7 @@ func (c *SaaSProvisioningV1) unSubscribeFromApplicationRequest(ctx context.Conte
 // Subscribe a subaccount tenant to an application
 type SubscribeSubAccountTenantToApplicationInput struct {
 	//Unique identifier of the current subscription job.
-	Identifier string `dest:"uri" dest-name:"identifier"`
+	Identifier string `dest:"uri" dest-name:"identifier" json:"-"`
 
 	//Additional details accompanying the subscription process. Relates mostly to the
 	//cases when the subscription process status is FAILED.
@@ -585,7 +594,7 @@ type SubscribeSubAccountTenantToApplicationInput struct {
 	//Status of the subscription job.
 	//Enum:
 	//	[ SUCCEEDED, FAILED ]
-	Status string `json:"status,omitempty"`
+	Status CallbackStatus `json:"status,omitempty" enum:"true"`
 	//The URL the multitenant application is exposing for a subscription.
 	SubscriptionUrl string `json:"subscriptionUrl,omitempty"`
 }
@@ -599,17 +608,18 @@ type SubscribeSubAccountTenantToApplicationOutput struct {
 }
 
 func (c *SaaSProvisioningV1) SubscribeSubAccountTenantToApplication(ctx context.Context,
-	input *SubscribeSubAccountTenantToApplicationInput) error {
-	req, _ := c.subscribeSubAccountTenantToApplicationRequest(ctx, input)
+	input *SubscribeSubAccountTenantToApplicationInput, opts ...request.Option) error {
+	req, _ := c.SubscribeSubAccountTenantToApplicationRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return req.Send()
 }
-func (c *SaaSProvisioningV1) subscribeSubAccountTenantToApplicationRequest(ctx context.Context,
-	input *SubscribeSubAccountTenantToApplicationInput) (*request.Request, *UnSubscribeFromApplicationOutput) {
+func (c *SaaSProvisioningV1) SubscribeSubAccountTenantToApplicationRequest(ctx context.Context,
+	input *SubscribeSubAccountTenantToApplicationInput) (*request.Request, *SubscribeSubAccountTenantToApplicationOutput) {
 	op := &request.Operation{
 		Name: "Subscribe SubAccount Tenant To Application",
 		Http: request.HTTP{
 			Method: request.PUT,
-			Path:   "/applications/{appName}/subscription",
+			Path:   "/subscription-callback/{identifier}/result",
 		},
 	}
 
@@ -617,7 +627,7 @@ func (c *SaaSProvisioningV1) subscribeSubAccountTenantToApplicationRequest(ctx c
 		input = &SubscribeSubAccountTenantToApplicationInput{}
 	}
 
-	output := &UnSubscribeFromApplicationOutput{}
+	output := &SubscribeSubAccountTenantToApplicationOutput{}
 	request := c.newRequest(ctx, op, input, output)
 
 	// TODO: This is a hack should not be use on good designed API
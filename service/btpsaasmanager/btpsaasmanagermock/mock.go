@@ -0,0 +1,214 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package btpsaasmanagermock provides a mock of btpsaasmanager.SaaSProvisioningAPI.
+package btpsaasmanagermock
+
+import (
+	"context"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+)
+
+// SaaSProvisioningAPI implements btpsaasmanager.SaaSProvisioningAPI by delegating every call to the function
+// field of the same name; calling a method whose function is not set fails with an error.
+type SaaSProvisioningAPI struct {
+	GetApplicationRegistrationFunc                    func(ctx context.Context, input *btpsaasmanager.GetApplicationRegistrationInput, opts ...request.Option) (*btpsaasmanager.GetApplicationRegistrationOutput, error)
+	GetApplicationRegistrationRequestFunc             func(ctx context.Context, input *btpsaasmanager.GetApplicationRegistrationInput) (*request.Request, *btpsaasmanager.GetApplicationRegistrationOutput)
+	GetApplicationSubscriptionsFunc                   func(ctx context.Context, input *btpsaasmanager.GetApplicationSubscriptionsInput, opts ...request.Option) (*btpsaasmanager.GetApplicationSubscriptionsOutput, error)
+	GetApplicationSubscriptionsRequestFunc            func(ctx context.Context, input *btpsaasmanager.GetApplicationSubscriptionsInput) (*request.Request, *btpsaasmanager.GetApplicationSubscriptionsOutput)
+	SubscribeTenantToApplicationFunc                  func(ctx context.Context, input *btpsaasmanager.SubscribeTenantToApplicationInput, opts ...request.Option) (*btpsaasmanager.SubscribeTenantToApplicationOutput, error)
+	SubscribeTenantToApplicationRequestFunc           func(ctx context.Context, input *btpsaasmanager.SubscribeTenantToApplicationInput) (*request.Request, *btpsaasmanager.SubscribeTenantToApplicationOutput)
+	UnSubscribeTenantFromApplicationFunc              func(ctx context.Context, input *btpsaasmanager.UnSubscribeTenantFromApplicationInput, opts ...request.Option) (*btpsaasmanager.UnSubscribeTenantFromApplicationOutput, error)
+	UnSubscribeTenantFromApplicationRequestFunc       func(ctx context.Context, input *btpsaasmanager.UnSubscribeTenantFromApplicationInput) (*request.Request, *btpsaasmanager.UnSubscribeTenantFromApplicationOutput)
+	UpdateSubscriptionDependenciesFunc                func(ctx context.Context, input *btpsaasmanager.UpdateSubscriptionDependenciesInput, opts ...request.Option) (*btpsaasmanager.UpdateSubscriptionDependenciesOutput, error)
+	UpdateSubscriptionDependenciesRequestFunc         func(ctx context.Context, input *btpsaasmanager.UpdateSubscriptionDependenciesInput) (*request.Request, *btpsaasmanager.UpdateSubscriptionDependenciesOutput)
+	GetEntitledApplicationsFunc                       func(ctx context.Context, input *btpsaasmanager.GetEntitledApplicationsInput, opts ...request.Option) (*btpsaasmanager.GetEntitledApplicationsOutput, error)
+	GetEntitledApplicationsRequestFunc                func(ctx context.Context, input *btpsaasmanager.GetEntitledApplicationsInput) (*request.Request, *btpsaasmanager.GetEntitledApplicationsOutput)
+	GetDetailsApplicationsFunc                        func(ctx context.Context, input *btpsaasmanager.GetDetailsApplicationsInput, opts ...request.Option) (*btpsaasmanager.GetDetailsApplicationsOutput, error)
+	GetDetailsApplicationsRequestFunc                 func(ctx context.Context, input *btpsaasmanager.GetDetailsApplicationsInput) (*request.Request, *btpsaasmanager.GetDetailsApplicationsOutput)
+	SubscribeToApplicationFunc                        func(ctx context.Context, input *btpsaasmanager.SubscribeToApplicationInput, opts ...request.Option) (*btpsaasmanager.SubscribeToApplicationOutput, error)
+	SubscribeToApplicationRequestFunc                 func(ctx context.Context, input *btpsaasmanager.SubscribeToApplicationInput) (*request.Request, *btpsaasmanager.SubscribeToApplicationOutput)
+	UnSubscribeFromApplicationFunc                    func(ctx context.Context, input *btpsaasmanager.UnSubscribeFromApplicationInput, opts ...request.Option) error
+	UnSubscribeFromApplicationRequestFunc             func(ctx context.Context, input *btpsaasmanager.UnSubscribeFromApplicationInput) (*request.Request, *btpsaasmanager.UnSubscribeFromApplicationOutput)
+	SubscribeSubAccountTenantToApplicationFunc        func(ctx context.Context, input *btpsaasmanager.SubscribeSubAccountTenantToApplicationInput, opts ...request.Option) error
+	SubscribeSubAccountTenantToApplicationRequestFunc func(ctx context.Context, input *btpsaasmanager.SubscribeSubAccountTenantToApplicationInput) (*request.Request, *btpsaasmanager.SubscribeSubAccountTenantToApplicationOutput)
+	GetJobStatusFunc                                  func(ctx context.Context, input *btpsaasmanager.GetJobStatusInput, opts ...request.Option) (*btpsaasmanager.GetJobStatusOutput, error)
+	GetJobStatusRequestFunc                           func(ctx context.Context, input *btpsaasmanager.GetJobStatusInput) (*request.Request, *btpsaasmanager.GetJobStatusOutput)
+	GetErrorJobStatusFunc                             func(ctx context.Context, input *btpsaasmanager.GetErrorJobStatusInput, opts ...request.Option) (*btpsaasmanager.GetErrorJobStatusOutput, error)
+	GetErrorJobStatusRequestFunc                      func(ctx context.Context, input *btpsaasmanager.GetErrorJobStatusInput) (*request.Request, *btpsaasmanager.GetErrorJobStatusOutput)
+}
+
+var _ btpsaasmanager.SaaSProvisioningAPI = (*SaaSProvisioningAPI)(nil)
+
+func notStubbed(method string) error {
+	return fmt.Errorf("btpsaasmanagermock: %s is not stubbed", method)
+}
+
+func (m *SaaSProvisioningAPI) GetApplicationRegistration(ctx context.Context, input *btpsaasmanager.GetApplicationRegistrationInput, opts ...request.Option) (*btpsaasmanager.GetApplicationRegistrationOutput, error) {
+	if m.GetApplicationRegistrationFunc == nil {
+		return nil, notStubbed("GetApplicationRegistration")
+	}
+	return m.GetApplicationRegistrationFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) GetApplicationRegistrationRequest(ctx context.Context, input *btpsaasmanager.GetApplicationRegistrationInput) (*request.Request, *btpsaasmanager.GetApplicationRegistrationOutput) {
+	if m.GetApplicationRegistrationRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetApplicationRegistrationRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) GetApplicationSubscriptions(ctx context.Context, input *btpsaasmanager.GetApplicationSubscriptionsInput, opts ...request.Option) (*btpsaasmanager.GetApplicationSubscriptionsOutput, error) {
+	if m.GetApplicationSubscriptionsFunc == nil {
+		return nil, notStubbed("GetApplicationSubscriptions")
+	}
+	return m.GetApplicationSubscriptionsFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) GetApplicationSubscriptionsRequest(ctx context.Context, input *btpsaasmanager.GetApplicationSubscriptionsInput) (*request.Request, *btpsaasmanager.GetApplicationSubscriptionsOutput) {
+	if m.GetApplicationSubscriptionsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetApplicationSubscriptionsRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) SubscribeTenantToApplication(ctx context.Context, input *btpsaasmanager.SubscribeTenantToApplicationInput, opts ...request.Option) (*btpsaasmanager.SubscribeTenantToApplicationOutput, error) {
+	if m.SubscribeTenantToApplicationFunc == nil {
+		return nil, notStubbed("SubscribeTenantToApplication")
+	}
+	return m.SubscribeTenantToApplicationFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) SubscribeTenantToApplicationRequest(ctx context.Context, input *btpsaasmanager.SubscribeTenantToApplicationInput) (*request.Request, *btpsaasmanager.SubscribeTenantToApplicationOutput) {
+	if m.SubscribeTenantToApplicationRequestFunc == nil {
+		return nil, nil
+	}
+	return m.SubscribeTenantToApplicationRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) UnSubscribeTenantFromApplication(ctx context.Context, input *btpsaasmanager.UnSubscribeTenantFromApplicationInput, opts ...request.Option) (*btpsaasmanager.UnSubscribeTenantFromApplicationOutput, error) {
+	if m.UnSubscribeTenantFromApplicationFunc == nil {
+		return nil, notStubbed("UnSubscribeTenantFromApplication")
+	}
+	return m.UnSubscribeTenantFromApplicationFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) UnSubscribeTenantFromApplicationRequest(ctx context.Context, input *btpsaasmanager.UnSubscribeTenantFromApplicationInput) (*request.Request, *btpsaasmanager.UnSubscribeTenantFromApplicationOutput) {
+	if m.UnSubscribeTenantFromApplicationRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UnSubscribeTenantFromApplicationRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) UpdateSubscriptionDependencies(ctx context.Context, input *btpsaasmanager.UpdateSubscriptionDependenciesInput, opts ...request.Option) (*btpsaasmanager.UpdateSubscriptionDependenciesOutput, error) {
+	if m.UpdateSubscriptionDependenciesFunc == nil {
+		return nil, notStubbed("UpdateSubscriptionDependencies")
+	}
+	return m.UpdateSubscriptionDependenciesFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) UpdateSubscriptionDependenciesRequest(ctx context.Context, input *btpsaasmanager.UpdateSubscriptionDependenciesInput) (*request.Request, *btpsaasmanager.UpdateSubscriptionDependenciesOutput) {
+	if m.UpdateSubscriptionDependenciesRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UpdateSubscriptionDependenciesRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) GetEntitledApplications(ctx context.Context, input *btpsaasmanager.GetEntitledApplicationsInput, opts ...request.Option) (*btpsaasmanager.GetEntitledApplicationsOutput, error) {
+	if m.GetEntitledApplicationsFunc == nil {
+		return nil, notStubbed("GetEntitledApplications")
+	}
+	return m.GetEntitledApplicationsFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) GetEntitledApplicationsRequest(ctx context.Context, input *btpsaasmanager.GetEntitledApplicationsInput) (*request.Request, *btpsaasmanager.GetEntitledApplicationsOutput) {
+	if m.GetEntitledApplicationsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetEntitledApplicationsRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) GetDetailsApplications(ctx context.Context, input *btpsaasmanager.GetDetailsApplicationsInput, opts ...request.Option) (*btpsaasmanager.GetDetailsApplicationsOutput, error) {
+	if m.GetDetailsApplicationsFunc == nil {
+		return nil, notStubbed("GetDetailsApplications")
+	}
+	return m.GetDetailsApplicationsFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) GetDetailsApplicationsRequest(ctx context.Context, input *btpsaasmanager.GetDetailsApplicationsInput) (*request.Request, *btpsaasmanager.GetDetailsApplicationsOutput) {
+	if m.GetDetailsApplicationsRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetDetailsApplicationsRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) SubscribeToApplication(ctx context.Context, input *btpsaasmanager.SubscribeToApplicationInput, opts ...request.Option) (*btpsaasmanager.SubscribeToApplicationOutput, error) {
+	if m.SubscribeToApplicationFunc == nil {
+		return nil, notStubbed("SubscribeToApplication")
+	}
+	return m.SubscribeToApplicationFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) SubscribeToApplicationRequest(ctx context.Context, input *btpsaasmanager.SubscribeToApplicationInput) (*request.Request, *btpsaasmanager.SubscribeToApplicationOutput) {
+	if m.SubscribeToApplicationRequestFunc == nil {
+		return nil, nil
+	}
+	return m.SubscribeToApplicationRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) UnSubscribeFromApplication(ctx context.Context, input *btpsaasmanager.UnSubscribeFromApplicationInput, opts ...request.Option) error {
+	if m.UnSubscribeFromApplicationFunc == nil {
+		return notStubbed("UnSubscribeFromApplication")
+	}
+	return m.UnSubscribeFromApplicationFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) UnSubscribeFromApplicationRequest(ctx context.Context, input *btpsaasmanager.UnSubscribeFromApplicationInput) (*request.Request, *btpsaasmanager.UnSubscribeFromApplicationOutput) {
+	if m.UnSubscribeFromApplicationRequestFunc == nil {
+		return nil, nil
+	}
+	return m.UnSubscribeFromApplicationRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) SubscribeSubAccountTenantToApplication(ctx context.Context, input *btpsaasmanager.SubscribeSubAccountTenantToApplicationInput, opts ...request.Option) error {
+	if m.SubscribeSubAccountTenantToApplicationFunc == nil {
+		return notStubbed("SubscribeSubAccountTenantToApplication")
+	}
+	return m.SubscribeSubAccountTenantToApplicationFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) SubscribeSubAccountTenantToApplicationRequest(ctx context.Context, input *btpsaasmanager.SubscribeSubAccountTenantToApplicationInput) (*request.Request, *btpsaasmanager.SubscribeSubAccountTenantToApplicationOutput) {
+	if m.SubscribeSubAccountTenantToApplicationRequestFunc == nil {
+		return nil, nil
+	}
+	return m.SubscribeSubAccountTenantToApplicationRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) GetJobStatus(ctx context.Context, input *btpsaasmanager.GetJobStatusInput, opts ...request.Option) (*btpsaasmanager.GetJobStatusOutput, error) {
+	if m.GetJobStatusFunc == nil {
+		return nil, notStubbed("GetJobStatus")
+	}
+	return m.GetJobStatusFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) GetJobStatusRequest(ctx context.Context, input *btpsaasmanager.GetJobStatusInput) (*request.Request, *btpsaasmanager.GetJobStatusOutput) {
+	if m.GetJobStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetJobStatusRequestFunc(ctx, input)
+}
+
+func (m *SaaSProvisioningAPI) GetErrorJobStatus(ctx context.Context, input *btpsaasmanager.GetErrorJobStatusInput, opts ...request.Option) (*btpsaasmanager.GetErrorJobStatusOutput, error) {
+	if m.GetErrorJobStatusFunc == nil {
+		return nil, notStubbed("GetErrorJobStatus")
+	}
+	return m.GetErrorJobStatusFunc(ctx, input, opts...)
+}
+
+func (m *SaaSProvisioningAPI) GetErrorJobStatusRequest(ctx context.Context, input *btpsaasmanager.GetErrorJobStatusInput) (*request.Request, *btpsaasmanager.GetErrorJobStatusOutput) {
+	if m.GetErrorJobStatusRequestFunc == nil {
+		return nil, nil
+	}
+	return m.GetErrorJobStatusRequestFunc(ctx, input)
+}
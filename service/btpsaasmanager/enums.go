@@ -0,0 +1,152 @@
+package btpsaasmanager
+
+// SubscriptionState is the subscription state of a subaccount to a multitenant application.
+type SubscriptionState string
+
+const (
+	SubscriptionStateInProcess         SubscriptionState = "IN_PROCESS"
+	SubscriptionStateSubscribed        SubscriptionState = "SUBSCRIBED"
+	SubscriptionStateSubscribeFailed   SubscriptionState = "SUBSCRIBE_FAILED"
+	SubscriptionStateUnsubscribeFailed SubscriptionState = "UNSUBSCRIBE_FAILED"
+	SubscriptionStateUpdateFailed      SubscriptionState = "UPDATE_FAILED"
+	SubscriptionStateNotSubscribed     SubscriptionState = "NOT_SUBSCRIBED"
+)
+
+func (SubscriptionState) Values() []SubscriptionState {
+	return []SubscriptionState{
+		SubscriptionStateInProcess,
+		SubscriptionStateSubscribed,
+		SubscriptionStateSubscribeFailed,
+		SubscriptionStateUnsubscribeFailed,
+		SubscriptionStateUpdateFailed,
+		SubscriptionStateNotSubscribed,
+	}
+}
+
+func (s SubscriptionState) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the subscription has left the transitional states, successfully or not.
+func (s SubscriptionState) IsTerminal() bool {
+	if !s.Valid() {
+		return false
+	}
+	switch s {
+	case SubscriptionStateInProcess:
+		return false
+	}
+	return true
+}
+
+func (s SubscriptionState) IsFailure() bool {
+	switch s {
+	case SubscriptionStateSubscribeFailed, SubscriptionStateUnsubscribeFailed,
+		SubscriptionStateUpdateFailed:
+		return true
+	}
+	return false
+}
+
+// ErrorJobState is the state of a subscription job.
+type ErrorJobState string
+
+const (
+	ErrorJobStateCreated   ErrorJobState = "CREATED"
+	ErrorJobStateStarted   ErrorJobState = "STARTED"
+	ErrorJobStateSucceeded ErrorJobState = "SUCCEEDED"
+	ErrorJobStateFailed    ErrorJobState = "FAILED"
+	ErrorJobStateRetry     ErrorJobState = "RETRY"
+)
+
+func (ErrorJobState) Values() []ErrorJobState {
+	return []ErrorJobState{
+		ErrorJobStateCreated,
+		ErrorJobStateStarted,
+		ErrorJobStateSucceeded,
+		ErrorJobStateFailed,
+		ErrorJobStateRetry,
+	}
+}
+
+func (s ErrorJobState) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the job has ended, successfully or not.
+func (s ErrorJobState) IsTerminal() bool {
+	if !s.Valid() {
+		return false
+	}
+	switch s {
+	case ErrorJobStateCreated, ErrorJobStateStarted, ErrorJobStateRetry:
+		return false
+	}
+	return true
+}
+
+func (s ErrorJobState) IsFailure() bool {
+	switch s {
+	case ErrorJobStateFailed:
+		return true
+	}
+	return false
+}
+
+// CallbackStatus is the result of a subscription reported by the application.
+type CallbackStatus string
+
+const (
+	CallbackStatusSucceeded CallbackStatus = "SUCCEEDED"
+	CallbackStatusFailed    CallbackStatus = "FAILED"
+)
+
+func (CallbackStatus) Values() []CallbackStatus {
+	return []CallbackStatus{
+		CallbackStatusSucceeded,
+		CallbackStatusFailed,
+	}
+}
+
+func (s CallbackStatus) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthenticationProvider is the authentication provider of a multitenant application.
+type AuthenticationProvider string
+
+const (
+	AuthenticationProviderXSUAA AuthenticationProvider = "XSUAA"
+	AuthenticationProviderIAS   AuthenticationProvider = "IAS"
+)
+
+func (AuthenticationProvider) Values() []AuthenticationProvider {
+	return []AuthenticationProvider{
+		AuthenticationProviderXSUAA,
+		AuthenticationProviderIAS,
+	}
+}
+
+func (p AuthenticationProvider) Valid() bool {
+	for _, v := range p.Values() {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package btpsaasmanager
+
+import (
+	"context"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+)
+
+// SaaSProvisioningAPI is the interface implemented by SaaSProvisioningV1, to be used in place of the
+// concrete client where callers need to stub the service, see package btpsaasmanagermock.
+type SaaSProvisioningAPI interface {
+	GetApplicationRegistration(ctx context.Context, input *GetApplicationRegistrationInput, opts ...request.Option) (*GetApplicationRegistrationOutput, error)
+	GetApplicationRegistrationRequest(ctx context.Context, input *GetApplicationRegistrationInput) (*request.Request, *GetApplicationRegistrationOutput)
+	GetApplicationSubscriptions(ctx context.Context, input *GetApplicationSubscriptionsInput, opts ...request.Option) (*GetApplicationSubscriptionsOutput, error)
+	GetApplicationSubscriptionsRequest(ctx context.Context, input *GetApplicationSubscriptionsInput) (*request.Request, *GetApplicationSubscriptionsOutput)
+	SubscribeTenantToApplication(ctx context.Context, input *SubscribeTenantToApplicationInput, opts ...request.Option) (*SubscribeTenantToApplicationOutput, error)
+	SubscribeTenantToApplicationRequest(ctx context.Context, input *SubscribeTenantToApplicationInput) (*request.Request, *SubscribeTenantToApplicationOutput)
+	UnSubscribeTenantFromApplication(ctx context.Context, input *UnSubscribeTenantFromApplicationInput, opts ...request.Option) (*UnSubscribeTenantFromApplicationOutput, error)
+	UnSubscribeTenantFromApplicationRequest(ctx context.Context, input *UnSubscribeTenantFromApplicationInput) (*request.Request, *UnSubscribeTenantFromApplicationOutput)
+	UpdateSubscriptionDependencies(ctx context.Context, input *UpdateSubscriptionDependenciesInput, opts ...request.Option) (*UpdateSubscriptionDependenciesOutput, error)
+	UpdateSubscriptionDependenciesRequest(ctx context.Context, input *UpdateSubscriptionDependenciesInput) (*request.Request, *UpdateSubscriptionDependenciesOutput)
+	GetEntitledApplications(ctx context.Context, input *GetEntitledApplicationsInput, opts ...request.Option) (*GetEntitledApplicationsOutput, error)
+	GetEntitledApplicationsRequest(ctx context.Context, input *GetEntitledApplicationsInput) (*request.Request, *GetEntitledApplicationsOutput)
+	GetDetailsApplications(ctx context.Context, input *GetDetailsApplicationsInput, opts ...request.Option) (*GetDetailsApplicationsOutput, error)
+	GetDetailsApplicationsRequest(ctx context.Context, input *GetDetailsApplicationsInput) (*request.Request, *GetDetailsApplicationsOutput)
+	SubscribeToApplication(ctx context.Context, input *SubscribeToApplicationInput, opts ...request.Option) (*SubscribeToApplicationOutput, error)
+	SubscribeToApplicationRequest(ctx context.Context, input *SubscribeToApplicationInput) (*request.Request, *SubscribeToApplicationOutput)
+	UnSubscribeFromApplication(ctx context.Context, input *UnSubscribeFromApplicationInput, opts ...request.Option) error
+	UnSubscribeFromApplicationRequest(ctx context.Context, input *UnSubscribeFromApplicationInput) (*request.Request, *UnSubscribeFromApplicationOutput)
+	SubscribeSubAccountTenantToApplication(ctx context.Context, input *SubscribeSubAccountTenantToApplicationInput, opts ...request.Option) error
+	SubscribeSubAccountTenantToApplicationRequest(ctx context.Context, input *SubscribeSubAccountTenantToApplicationInput) (*request.Request, *SubscribeSubAccountTenantToApplicationOutput)
+	GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error)
+	GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput)
+	GetErrorJobStatus(ctx context.Context, input *GetErrorJobStatusInput, opts ...request.Option) (*GetErrorJobStatusOutput, error)
+	GetErrorJobStatusRequest(ctx context.Context, input *GetErrorJobStatusInput) (*request.Request, *GetErrorJobStatusOutput)
+}
+
+var _ SaaSProvisioningAPI = (*SaaSProvisioningV1)(nil)
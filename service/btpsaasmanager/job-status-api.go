@@ -10,7 +10,7 @@ import (
 // Get available jobs
 type GetJobStatusInput struct {
 	//ID of the job for which to get status
-	JobId string `dest:"uri" dest-name:"jobInstanceIdOrUniqueId"`
+	JobId string `dest:"uri" dest-name:"jobInstanceIdOrUniqueId" json:"-"`
 }
 type GetJobStatusOutput struct {
 	//A description of the exit status of a job when it ends.
@@ -23,17 +23,18 @@ type GetJobStatusOutput struct {
 	//FAILED: The job failed and did not complete. The job can be restarted.
 	//Enum:
 	//	[ IN_PROGRESS, COMPLETED, FAILED ]
-	Status string `json:"status,omitempty"`
+	Status types.JobStatus `json:"status,omitempty"`
 
 	Error *types.Error `json:"error,omitempty"`
 	types.StatusAndBodyFromResponse
 }
 
-func (c *SaaSProvisioningV1) GetJobStatus(ctx context.Context, input *GetJobStatusInput) (*GetJobStatusOutput, error) {
-	req, out := c.getJobStatusRequest(ctx, input)
+func (c *SaaSProvisioningV1) GetJobStatus(ctx context.Context, input *GetJobStatusInput, opts ...request.Option) (*GetJobStatusOutput, error) {
+	req, out := c.GetJobStatusRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput) {
+func (c *SaaSProvisioningV1) GetJobStatusRequest(ctx context.Context, input *GetJobStatusInput) (*request.Request, *GetJobStatusOutput) {
 	op := &request.Operation{
 		Name: "Job Status Management",
 		Http: request.HTTP{
@@ -55,11 +56,11 @@ func (c *SaaSProvisioningV1) getJobStatusRequest(ctx context.Context, input *Get
 // Get job errorStatusCode
 type GetErrorJobStatusInput struct {
 	//The unique ID of a job for which to get information.
-	JobUuid string `dest:"uri" dest-name:"jobUuid"`
+	JobUuid string `dest:"uri" dest-name:"jobUuid" json:"-"`
 }
 type GetErrorJobStatusOutput struct {
 	//The service instance ID of the SAP SaaS Provisioning service (saas-registry) that the application is using.
-	CreatedBy string `json:"description,omitempty"`
+	CreatedBy string `json:"createdBy,omitempty"`
 	//ID of the corresponding job.
 	Id string `json:"id,omitempty"`
 
@@ -71,7 +72,7 @@ type GetErrorJobStatusOutput struct {
 	//RETRY: Subscription has timed out and job processing is pending a retry.
 	//Enum:
 	//	[ CREATED, STARTED, SUCCEEDED, FAILED, RETRY ]
-	State string `json:"state,omitempty"`
+	State ErrorJobState `json:"state,omitempty"`
 
 	ErrorJob *ErrorJob `json:"error,omitempty"`
 
@@ -91,24 +92,27 @@ type ErrorJob struct {
 	Status    int32     `json:"status,omitempty"`
 	Timestamp Timestamp `json:"timestamp,omitempty"`
 }
+
+// Timestamp of a job error, a serialized java.util.Date; Time holds the instant it describes.
 type Timestamp struct {
-	Date           int32 `json:"date,omitempty"`
-	Day            int32 `json:"day,omitempty"`
-	Hours          int32 `json:"hours,omitempty"`
-	Minutes        int32 `json:"minutes,omitempty"`
-	Month          int32 `json:"month,omitempty"`
-	Nanos          int32 `json:"nanos,omitempty"`
-	Seconds        int32 `json:"seconds,omitempty"`
-	Time           int64 `json:"time,omitempty"`
-	TimezoneOffset int32 `json:"timezoneOffset,omitempty"`
-	Year           int32 `json:"year,omitempty"`
+	Date           int32           `json:"date,omitempty"`
+	Day            int32           `json:"day,omitempty"`
+	Hours          int32           `json:"hours,omitempty"`
+	Minutes        int32           `json:"minutes,omitempty"`
+	Month          int32           `json:"month,omitempty"`
+	Nanos          int32           `json:"nanos,omitempty"`
+	Seconds        int32           `json:"seconds,omitempty"`
+	Time           types.EpochTime `json:"time,omitempty"`
+	TimezoneOffset int32           `json:"timezoneOffset,omitempty"`
+	Year           int32           `json:"year,omitempty"`
 }
 
-func (c *SaaSProvisioningV1) GetErrorJobStatus(ctx context.Context, input *GetErrorJobStatusInput) (*GetErrorJobStatusOutput, error) {
-	req, out := c.getErrorJobStatusRequest(ctx, input)
+func (c *SaaSProvisioningV1) GetErrorJobStatus(ctx context.Context, input *GetErrorJobStatusInput, opts ...request.Option) (*GetErrorJobStatusOutput, error) {
+	req, out := c.GetErrorJobStatusRequest(ctx, input)
+	req.ApplyOptions(opts...)
 	return out, req.Send()
 }
-func (c *SaaSProvisioningV1) getErrorJobStatusRequest(ctx context.Context, input *GetErrorJobStatusInput) (*request.Request, *GetErrorJobStatusOutput) {
+func (c *SaaSProvisioningV1) GetErrorJobStatusRequest(ctx context.Context, input *GetErrorJobStatusInput) (*request.Request, *GetErrorJobStatusOutput) {
 	op := &request.Operation{
 		Name: "Job Management",
 		Http: request.HTTP{
@@ -0,0 +1,82 @@
+package types
+
+// Enumerations shared by the services. Values the SDK does not know decode as they are;
+// Valid reports whether a value is one of the documented ones.
+
+// JobStatus is the status of an asynchronous job of the jobs management API.
+type JobStatus string
+
+const (
+	JobStatusInProgress JobStatus = "IN_PROGRESS"
+	JobStatusCompleted  JobStatus = "COMPLETED"
+	JobStatusFailed     JobStatus = "FAILED"
+)
+
+func (JobStatus) Values() []JobStatus {
+	return []JobStatus{JobStatusInProgress, JobStatusCompleted, JobStatusFailed}
+}
+
+func (s JobStatus) Valid() bool {
+	for _, v := range s.Values() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the job has ended, successfully or not.
+func (s JobStatus) IsTerminal() bool {
+	return s == JobStatusCompleted || s == JobStatusFailed
+}
+
+func (s JobStatus) IsFailure() bool {
+	return s == JobStatusFailed
+}
+
+// ServiceCategory is the type of a service in the entitlements and provisioning services.
+type ServiceCategory string
+
+const (
+	ServiceCategoryPlatform              ServiceCategory = "PLATFORM"
+	ServiceCategoryService               ServiceCategory = "SERVICE"
+	ServiceCategoryElasticService        ServiceCategory = "ELASTIC_SERVICE"
+	ServiceCategoryElasticLimited        ServiceCategory = "ELASTIC_LIMITED"
+	ServiceCategoryApplication           ServiceCategory = "APPLICATION"
+	ServiceCategoryQuotaBasedApplication ServiceCategory = "QUOTA_BASED_APPLICATION"
+	ServiceCategoryEnvironment           ServiceCategory = "ENVIRONMENT"
+)
+
+func (ServiceCategory) Values() []ServiceCategory {
+	return []ServiceCategory{
+		ServiceCategoryPlatform,
+		ServiceCategoryService,
+		ServiceCategoryElasticService,
+		ServiceCategoryElasticLimited,
+		ServiceCategoryApplication,
+		ServiceCategoryQuotaBasedApplication,
+		ServiceCategoryEnvironment,
+	}
+}
+
+func (c ServiceCategory) Valid() bool {
+	for _, v := range c.Values() {
+		if c == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the plans of the category are assigned with an amount rather than enabled, which the
+// unlimited ones never are.
+func (c ServiceCategory) QuotaBased(unlimited bool) bool {
+	if unlimited {
+		return false
+	}
+	switch c {
+	case ServiceCategoryApplication, ServiceCategoryElasticService:
+		return false
+	}
+	return true
+}
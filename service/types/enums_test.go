@@ -0,0 +1,63 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJobStatus(t *testing.T) {
+	for _, c := range []struct {
+		status                   JobStatus
+		valid, terminal, failure bool
+	}{
+		{JobStatusInProgress, true, false, false},
+		{JobStatusCompleted, true, true, false},
+		{JobStatusFailed, true, true, true},
+		{"PAUSED", false, false, false},
+	} {
+		if got := c.status.Valid(); got != c.valid {
+			t.Errorf("%s: Valid() = %v", c.status, got)
+		}
+		if got := c.status.IsTerminal(); got != c.terminal {
+			t.Errorf("%s: IsTerminal() = %v", c.status, got)
+		}
+		if got := c.status.IsFailure(); got != c.failure {
+			t.Errorf("%s: IsFailure() = %v", c.status, got)
+		}
+	}
+}
+
+func TestServiceCategoryQuotaBased(t *testing.T) {
+	for _, c := range []struct {
+		category   ServiceCategory
+		unlimited  bool
+		quotaBased bool
+	}{
+		{ServiceCategoryService, false, true},
+		{ServiceCategoryService, true, false},
+		{ServiceCategoryElasticLimited, false, true},
+		{ServiceCategoryQuotaBasedApplication, false, true},
+		{ServiceCategoryApplication, false, false},
+		{ServiceCategoryElasticService, false, false},
+	} {
+		if got := c.category.QuotaBased(c.unlimited); got != c.quotaBased {
+			t.Errorf("%s, unlimited %v: QuotaBased() = %v", c.category, c.unlimited, got)
+		}
+	}
+}
+
+func TestUnknownEnumDecodes(t *testing.T) {
+	var v struct {
+		Status   JobStatus       `json:"status"`
+		Category ServiceCategory `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(`{"status":"PAUSED","category":"NEW_CATEGORY"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Status != "PAUSED" || v.Category != "NEW_CATEGORY" {
+		t.Errorf("decoded into %+v", v)
+	}
+	if v.Status.Valid() || v.Category.Valid() {
+		t.Error("unknown values reported as valid")
+	}
+}
@@ -0,0 +1,266 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Timestamps of the service models. Every type keeps the wire format of the fields it is
+// used for, marshals back into that same format, and decodes null and empty values into
+// the zero time, which is marshaled as null.
+
+// EpochTime is a point in time sent as Unix epoch milliseconds.
+type EpochTime time.Time
+
+// ISOTime is a point in time sent as an ISO-8601 string, e.g. 2021-06-01T12:00:00Z.
+type ISOTime time.Time
+
+// Date is a calendar day sent as a YYYY-MM-DD string.
+type Date time.Time
+
+// NumericDate is a calendar day sent as a YYYYMMDD number.
+type NumericDate time.Time
+
+// YearMonth is a calendar month sent as a YYYYMM number.
+type YearMonth time.Time
+
+// Layouts accepted when decoding an ISOTime; the first one is also used to encode it.
+var isoLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+const (
+	dateLayout        = "2006-01-02"
+	numericDateLayout = "20060102"
+	yearMonthLayout   = "200601"
+)
+
+// NewDate returns the Date of a calendar day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// NewYearMonth returns the YearMonth of a calendar month.
+func NewYearMonth(year int, month time.Month) YearMonth {
+	return YearMonth(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (t EpochTime) Time() time.Time {
+	return time.Time(t)
+}
+
+func (t EpochTime) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+func (t EpochTime) String() string {
+	return format(time.Time(t), time.RFC3339Nano)
+}
+
+func (t EpochTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(time.Time(t).UnixNano()/int64(time.Millisecond), 10)), nil
+}
+
+func (t *EpochTime) UnmarshalJSON(data []byte) error {
+	text, err := scalar(data)
+	if err != nil || text == "" {
+		*t = EpochTime{}
+		return err
+	}
+	millis, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		// some services send the milliseconds in exponent notation
+		f, ferr := strconv.ParseFloat(text, 64)
+		if ferr != nil {
+			return &time.ParseError{Layout: "epoch milliseconds", Value: text, Message: ": " + err.Error()}
+		}
+		millis = int64(f)
+	}
+	*t = EpochTime(time.Unix(0, millis*int64(time.Millisecond)).UTC())
+	return nil
+}
+
+func (t ISOTime) Time() time.Time {
+	return time.Time(t)
+}
+
+func (t ISOTime) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+func (t ISOTime) String() string {
+	return format(time.Time(t), isoLayouts[0])
+}
+
+func (t ISOTime) MarshalJSON() ([]byte, error) {
+	return marshalText(time.Time(t), isoLayouts[0])
+}
+
+func (t *ISOTime) UnmarshalJSON(data []byte) error {
+	text, err := scalar(data)
+	if err != nil || text == "" {
+		*t = ISOTime{}
+		return err
+	}
+	for _, layout := range isoLayouts {
+		if tm, perr := time.Parse(layout, text); perr == nil {
+			*t = ISOTime(tm)
+			return nil
+		}
+	}
+	_, err = time.Parse(isoLayouts[0], text)
+	return err
+}
+
+func (d Date) Time() time.Time {
+	return time.Time(d)
+}
+
+func (d Date) IsZero() bool {
+	return time.Time(d).IsZero()
+}
+
+func (d Date) String() string {
+	return format(time.Time(d), dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return marshalText(time.Time(d), dateLayout)
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	text, err := scalar(data)
+	if err != nil || text == "" {
+		*d = Date{}
+		return err
+	}
+	tm, err := time.Parse(dateLayout, text)
+	if err != nil {
+		// a timestamp is cut to its day
+		full, ferr := time.Parse(time.RFC3339Nano, text)
+		if ferr != nil {
+			return err
+		}
+		tm = time.Date(full.Year(), full.Month(), full.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	*d = Date(tm)
+	return nil
+}
+
+func (d NumericDate) Time() time.Time {
+	return time.Time(d)
+}
+
+func (d NumericDate) IsZero() bool {
+	return time.Time(d).IsZero()
+}
+
+func (d NumericDate) String() string {
+	return format(time.Time(d), dateLayout)
+}
+
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return marshalNumber(time.Time(d), numericDateLayout)
+}
+
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	tm, err := parseNumber(data, numericDateLayout)
+	if err == nil {
+		*d = NumericDate(tm)
+	}
+	return err
+}
+
+func (m YearMonth) Time() time.Time {
+	return time.Time(m)
+}
+
+func (m YearMonth) IsZero() bool {
+	return time.Time(m).IsZero()
+}
+
+func (m YearMonth) String() string {
+	return format(time.Time(m), "2006-01")
+}
+
+// Number of the month in its YYYYMM form, as taken by the fromDate and toDate parameters.
+func (m YearMonth) Number() uint32 {
+	if m.IsZero() {
+		return 0
+	}
+	t := time.Time(m)
+	return uint32(t.Year()*100 + int(t.Month()))
+}
+
+// AddMonths returns the month n months after m, or before it when n is negative.
+func (m YearMonth) AddMonths(n int) YearMonth {
+	t := time.Time(m)
+	return NewYearMonth(t.Year(), t.Month()+time.Month(n))
+}
+
+func (m YearMonth) MarshalJSON() ([]byte, error) {
+	return marshalNumber(time.Time(m), yearMonthLayout)
+}
+
+func (m *YearMonth) UnmarshalJSON(data []byte) error {
+	tm, err := parseNumber(data, yearMonthLayout)
+	if err == nil {
+		*m = YearMonth(tm)
+	}
+	return err
+}
+
+// Text of a JSON string or number; empty for null and empty strings.
+func scalar(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+	if data[0] != '"' {
+		return string(data), nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+func format(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func marshalText(t time.Time, layout string) ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(layout))
+}
+
+func marshalNumber(t time.Time, layout string) ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(t.Format(layout)), nil
+}
+
+// Parses a date written as a number, or as a string holding that number; null and 0 are the zero time.
+func parseNumber(data []byte, layout string) (time.Time, error) {
+	text, err := scalar(data)
+	if err != nil || text == "" || text == "0" {
+		return time.Time{}, err
+	}
+	return time.Parse(layout, text)
+}
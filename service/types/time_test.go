@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type timestamps struct {
+	Epoch     EpochTime   `json:"epoch"`
+	ISO       ISOTime     `json:"iso"`
+	Date      Date        `json:"date"`
+	Numeric   NumericDate `json:"numeric"`
+	YearMonth YearMonth   `json:"yearMonth"`
+}
+
+func TestTimesRoundTrip(t *testing.T) {
+	in := `{"epoch":1622548800123,"iso":"2021-06-01T12:00:00.5+02:00","date":"2021-06-01","numeric":20210601,"yearMonth":202106}`
+
+	var v timestamps
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v.Epoch.Time(), time.Date(2021, time.June, 1, 12, 0, 0, 123e6, time.UTC); !got.Equal(want) {
+		t.Errorf("epoch: got %v, want %v", got, want)
+	}
+	if got, want := v.ISO.Time(), time.Date(2021, time.June, 1, 10, 0, 0, 5e8, time.UTC); !got.Equal(want) {
+		t.Errorf("iso: got %v, want %v", got, want)
+	}
+	if got := v.Date.String(); got != "2021-06-01" {
+		t.Errorf("date: got %s", got)
+	}
+	if got := v.Numeric.String(); got != "2021-06-01" {
+		t.Errorf("numeric date: got %s", got)
+	}
+	if got := v.YearMonth.Number(); got != 202106 {
+		t.Errorf("year month: got %d", got)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("marshaled\n%s\nexpected\n%s", out, in)
+	}
+}
+
+func TestTimesNull(t *testing.T) {
+	for _, in := range []string{
+		`{"epoch":null,"iso":null,"date":null,"numeric":null,"yearMonth":null}`,
+		`{"epoch":"","iso":"","date":"","numeric":0,"yearMonth":0}`,
+		`{}`,
+	} {
+		var v timestamps
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		if !v.Epoch.IsZero() || !v.ISO.IsZero() || !v.Date.IsZero() || !v.Numeric.IsZero() || !v.YearMonth.IsZero() {
+			t.Errorf("%s: decoded into %+v", in, v)
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"epoch":null,"iso":null,"date":null,"numeric":null,"yearMonth":null}`; string(out) != want {
+			t.Errorf("%s: marshaled into %s", in, out)
+		}
+	}
+}
+
+func TestISOTimeLayouts(t *testing.T) {
+	want := time.Date(2021, time.April, 29, 10, 44, 1, 371e6, time.UTC)
+	for _, in := range []string{
+		`"2021-04-29T10:44:01.371Z"`,
+		`"2021-04-29T10:44:01.371+0000"`,
+		`"2021-04-29T10:44:01.371"`,
+		`"2021-04-29 10:44:01.371"`,
+	} {
+		var v ISOTime
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if !v.Time().Equal(want) {
+			t.Errorf("%s: got %v", in, v.Time())
+		}
+	}
+
+	var v ISOTime
+	if err := json.Unmarshal([]byte(`"yesterday"`), &v); err == nil {
+		t.Error("expected an error for a malformed timestamp")
+	}
+}
+
+func TestYearMonthAddMonths(t *testing.T) {
+	m := NewYearMonth(2021, time.November)
+	if got := m.AddMonths(3).Number(); got != 202202 {
+		t.Errorf("got %d", got)
+	}
+	if got := m.AddMonths(-11).Number(); got != 202012 {
+		t.Errorf("got %d", got)
+	}
+}
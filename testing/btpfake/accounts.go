@@ -0,0 +1,921 @@
+package btpfake
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+var subdomainPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type CustomProperty struct {
+	AccountGuid string `json:"accountGUID,omitempty"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+}
+
+type GlobalAccount struct {
+	Guid             string           `json:"guid"`
+	DisplayName      string           `json:"displayName"`
+	Description      string           `json:"description,omitempty"`
+	Subdomain        string           `json:"subdomain,omitempty"`
+	EntityState      string           `json:"entityState"`
+	LicenseType      string           `json:"licenseType,omitempty"`
+	CommercialModel  string           `json:"commercialModel,omitempty"`
+	ConsumptionBased bool             `json:"consumptionBased"`
+	ContractStatus   string           `json:"contractStatus,omitempty"`
+	GeoAccess        string           `json:"geoAccess,omitempty"`
+	Origin           string           `json:"origin,omitempty"`
+	CreatedDate      int64            `json:"createdDate,omitempty"`
+	ModifiedDate     int64            `json:"modifiedDate,omitempty"`
+	CustomProperties []CustomProperty `json:"customProperties,omitempty"`
+
+	Children    []Directory  `json:"children,omitempty"`
+	Subaccounts []SubAccount `json:"subaccounts,omitempty"`
+}
+
+type Directory struct {
+	Guid              string           `json:"guid"`
+	ParentGuid        string           `json:"parentGuid,omitempty"`
+	DisplayName       string           `json:"displayName"`
+	Description       string           `json:"description,omitempty"`
+	Subdomain         string           `json:"subdomain,omitempty"`
+	EntityState       string           `json:"entityState"`
+	StateMessage      string           `json:"stateMessage,omitempty"`
+	CreatedBy         string           `json:"createdBy,omitempty"`
+	DirectoryFeatures []string         `json:"directoryFeatures,omitempty"`
+	CustomProperties  []CustomProperty `json:"customProperties,omitempty"`
+	CreatedDate       int64            `json:"createdDate,omitempty"`
+	ModifiedDate      int64            `json:"modifiedDate,omitempty"`
+
+	Children    []Directory  `json:"children,omitempty"`
+	SubAccounts []SubAccount `json:"subaccounts,omitempty"`
+}
+
+type SubAccount struct {
+	Guid              string           `json:"guid"`
+	GlobalAccountGuid string           `json:"globalAccountGUID"`
+	ParentGuid        string           `json:"parentGUID"`
+	DisplayName       string           `json:"displayName"`
+	Description       string           `json:"description,omitempty"`
+	Subdomain         string           `json:"subdomain"`
+	Region            string           `json:"region"`
+	State             string           `json:"state"`
+	StateMessage      string           `json:"stateMessage,omitempty"`
+	UsedForProduction string           `json:"usedForProduction,omitempty"`
+	BetaEnabled       bool             `json:"betaEnabled"`
+	CreatedBy         string           `json:"createdBy,omitempty"`
+	CustomProperties  []CustomProperty `json:"customProperties,omitempty"`
+	ParentFeatures    []string         `json:"parentFeatures,omitempty"`
+	CreatedDate       int64            `json:"createdDate,omitempty"`
+	ModifiedDate      int64            `json:"modifiedDate,omitempty"`
+}
+
+type ServiceManagementBinding struct {
+	ClientId     string `json:"clientid"`
+	ClientSecret string `json:"clientsecret"`
+	SMUrl        string `json:"sm_url"`
+	Url          string `json:"url"`
+	XsAppName    string `json:"xsappname"`
+}
+
+type accountsState struct {
+	global GlobalAccount
+
+	directories    map[string]*Directory
+	directoryOrder []string
+
+	subaccounts     map[string]*SubAccount
+	subaccountOrder []string
+
+	// Service Management bindings per subaccount GUID
+	smBindings map[string]*ServiceManagementBinding
+}
+
+func newAccountsState(s *Server) *accountsState {
+	now := s.nowMillis()
+	return &accountsState{
+		global: GlobalAccount{
+			Guid:             s.opts.GlobalAccountGuid,
+			DisplayName:      s.opts.GlobalAccountName,
+			Subdomain:        s.opts.GlobalAccountSubdomain,
+			EntityState:      "OK",
+			LicenseType:      "CUSTOMER",
+			CommercialModel:  "Subscription",
+			ContractStatus:   "ACTIVE",
+			GeoAccess:        "STANDARD",
+			Origin:           "OPERATOR",
+			CreatedDate:      now,
+			ModifiedDate:     now,
+			ConsumptionBased: false,
+		},
+		directories: make(map[string]*Directory),
+		subaccounts: make(map[string]*SubAccount),
+		smBindings:  make(map[string]*ServiceManagementBinding),
+	}
+}
+
+// Global account served by the fake.
+func (s *Server) GlobalAccount() GlobalAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts.global
+}
+
+// Seeds a directory; missing GUID, parent and state are defaulted.
+func (s *Server) AddDirectory(d Directory) Directory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.Guid == "" {
+		d.Guid = s.newGuid()
+	}
+	if d.ParentGuid == "" {
+		d.ParentGuid = s.accounts.global.Guid
+	}
+	if d.EntityState == "" {
+		d.EntityState = "OK"
+	}
+	if len(d.DirectoryFeatures) == 0 {
+		d.DirectoryFeatures = []string{"DEFAULT"}
+	}
+	if d.CreatedDate == 0 {
+		d.CreatedDate = s.nowMillis()
+		d.ModifiedDate = d.CreatedDate
+	}
+	d.Children, d.SubAccounts = nil, nil
+
+	s.accounts.addDirectory(&d)
+	return d
+}
+
+// Seeds a subaccount; missing GUID, parent, region and state are defaulted.
+func (s *Server) AddSubAccount(sa SubAccount) SubAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sa.Guid == "" {
+		sa.Guid = s.newGuid()
+	}
+	if sa.ParentGuid == "" {
+		sa.ParentGuid = s.accounts.global.Guid
+	}
+	if sa.Region == "" {
+		sa.Region = s.opts.Region
+	}
+	if sa.State == "" {
+		sa.State = "OK"
+	}
+	if sa.UsedForProduction == "" {
+		sa.UsedForProduction = "UNSET"
+	}
+	if sa.CreatedDate == 0 {
+		sa.CreatedDate = s.nowMillis()
+		sa.ModifiedDate = sa.CreatedDate
+	}
+	sa.GlobalAccountGuid = s.accounts.global.Guid
+	sa.ParentFeatures = s.accounts.parentFeatures(sa.ParentGuid)
+
+	s.accounts.addSubAccount(&sa)
+	return sa
+}
+
+// Current state of a subaccount.
+func (s *Server) SubAccount(guid string) (SubAccount, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sa, ok := s.accounts.subaccounts[guid]; ok {
+		return *sa, true
+	}
+	return SubAccount{}, false
+}
+
+// Current state of a directory.
+func (s *Server) Directory(guid string) (Directory, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.accounts.directories[guid]; ok {
+		return *d, true
+	}
+	return Directory{}, false
+}
+
+func (a *accountsState) addDirectory(d *Directory) {
+	a.directories[d.Guid] = d
+	a.directoryOrder = append(a.directoryOrder, d.Guid)
+}
+
+func (a *accountsState) addSubAccount(sa *SubAccount) {
+	a.subaccounts[sa.Guid] = sa
+	a.subaccountOrder = append(a.subaccountOrder, sa.Guid)
+}
+
+func (a *accountsState) removeDirectory(guid string) {
+	delete(a.directories, guid)
+	a.directoryOrder = removeString(a.directoryOrder, guid)
+}
+
+func (a *accountsState) removeSubAccount(guid string) {
+	delete(a.subaccounts, guid)
+	delete(a.smBindings, guid)
+	a.subaccountOrder = removeString(a.subaccountOrder, guid)
+}
+
+func (a *accountsState) isContainer(guid string) bool {
+	if guid == a.global.Guid {
+		return true
+	}
+	_, ok := a.directories[guid]
+	return ok
+}
+
+func (a *accountsState) parentFeatures(parentGuid string) []string {
+	if d, ok := a.directories[parentGuid]; ok {
+		return append([]string(nil), d.DirectoryFeatures...)
+	}
+	return nil
+}
+
+func (a *accountsState) subdomainTaken(subdomain string) bool {
+	if subdomain == "" {
+		return false
+	}
+	if a.global.Subdomain == subdomain {
+		return true
+	}
+	for _, sa := range a.subaccounts {
+		if sa.Subdomain == subdomain {
+			return true
+		}
+	}
+	for _, d := range a.directories {
+		if d.Subdomain == subdomain {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *accountsState) childDirectories(parent string) []*Directory {
+	out := make([]*Directory, 0)
+	for _, guid := range a.directoryOrder {
+		if d := a.directories[guid]; d.ParentGuid == parent {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func (a *accountsState) childSubAccounts(parent string) []*SubAccount {
+	out := make([]*SubAccount, 0)
+	for _, guid := range a.subaccountOrder {
+		if sa := a.subaccounts[guid]; sa.ParentGuid == parent {
+			out = append(out, sa)
+		}
+	}
+	return out
+}
+
+func (a *accountsState) directoryView(d *Directory, expand bool) Directory {
+	out := *d
+	out.CustomProperties = append([]CustomProperty(nil), d.CustomProperties...)
+	out.Children, out.SubAccounts = nil, nil
+	if expand {
+		for _, child := range a.childDirectories(d.Guid) {
+			out.Children = append(out.Children, a.directoryView(child, true))
+		}
+		for _, sa := range a.childSubAccounts(d.Guid) {
+			out.SubAccounts = append(out.SubAccounts, *sa)
+		}
+	}
+	return out
+}
+
+func (a *accountsState) globalView(expand bool) GlobalAccount {
+	out := a.global
+	out.Children, out.Subaccounts = nil, nil
+	if expand {
+		for _, child := range a.childDirectories(a.global.Guid) {
+			out.Children = append(out.Children, a.directoryView(child, true))
+		}
+		for _, sa := range a.childSubAccounts(a.global.Guid) {
+			out.Subaccounts = append(out.Subaccounts, *sa)
+		}
+	}
+	return out
+}
+
+type createDirectoryBody struct {
+	CustomProperties  []CustomProperty `json:"customProperties"`
+	Description       string           `json:"description"`
+	DirectoryAdmins   []string         `json:"directoryAdmins"`
+	DirectoryFeatures []string         `json:"directoryFeatures"`
+	DisplayName       string           `json:"displayName"`
+	ParentGuid        string           `json:"parentGUID"`
+	Subdomain         string           `json:"subdomain"`
+}
+
+type updatePropertiesBody struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Delete bool   `json:"delete"`
+}
+
+type updateEntityBody struct {
+	BetaEnabled       bool                   `json:"betaEnabled"`
+	CustomProperties  []updatePropertiesBody `json:"customProperties"`
+	Description       *string                `json:"description"`
+	DisplayName       *string                `json:"displayName"`
+	UsedForProduction string                 `json:"usedForProduction"`
+}
+
+type createSubAccountBody struct {
+	BetaEnabled       bool             `json:"betaEnabled"`
+	CustomProperties  []CustomProperty `json:"customProperties"`
+	Description       string           `json:"description"`
+	DisplayName       string           `json:"displayName"`
+	Origin            string           `json:"origin"`
+	ParentGuid        string           `json:"parentGUID"`
+	Region            string           `json:"region"`
+	SubaccountAdmins  []string         `json:"subaccountAdmins"`
+	Subdomain         string           `json:"subdomain"`
+	UsedForProduction string           `json:"usedForProduction"`
+}
+
+func applyProperties(accountGuid string, current []CustomProperty, updates []updatePropertiesBody) []CustomProperty {
+	for _, u := range updates {
+		idx := -1
+		for i, p := range current {
+			if p.Key == u.Key {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case u.Delete && idx >= 0:
+			current = append(current[:idx], current[idx+1:]...)
+		case u.Delete:
+		case idx >= 0:
+			current[idx].Value = u.Value
+		default:
+			current = append(current, CustomProperty{AccountGuid: accountGuid, Key: u.Key, Value: u.Value})
+		}
+	}
+	return current
+}
+
+func withAccountGuid(accountGuid string, props []CustomProperty) []CustomProperty {
+	out := make([]CustomProperty, 0, len(props))
+	for _, p := range props {
+		out = append(out, CustomProperty{AccountGuid: accountGuid, Key: p.Key, Value: p.Value})
+	}
+	return out
+}
+
+func (s *Server) registerAccounts() {
+	a := s.accounts
+
+	s.handle(http.MethodGet, "/accounts/v1/globalAccount", func(c *call) {
+		writeJSON(c, http.StatusOK, a.globalView(c.queryBool("expand")))
+	})
+
+	s.handle(http.MethodPatch, "/accounts/v1/globalAccount", func(c *call) {
+		var body updateEntityBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.DisplayName != nil {
+			a.global.DisplayName = *body.DisplayName
+		}
+		if body.Description != nil {
+			a.global.Description = *body.Description
+		}
+		a.global.ModifiedDate = s.nowMillis()
+		s.emit("GlobalAccount_Update", "GlobalAccount", a.global.Guid, nil)
+		writeJSON(c, http.StatusOK, a.globalView(false))
+	})
+
+	s.handle(http.MethodPost, "/accounts/v1/directories", func(c *call) {
+		var body createDirectoryBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.DisplayName == "" {
+			writeError(c, http.StatusBadRequest, "displayName must not be empty")
+			return
+		}
+		if body.ParentGuid == "" {
+			body.ParentGuid = a.global.Guid
+		}
+		if !a.isContainer(body.ParentGuid) {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("parent '%s' not found", body.ParentGuid))
+			return
+		}
+		features := body.DirectoryFeatures
+		if len(features) == 0 {
+			features = []string{"DEFAULT"}
+		}
+		if err := validateDirectoryFeatures(features); err != "" {
+			writeError(c, http.StatusBadRequest, err)
+			return
+		}
+		if hasString(features, "AUTHORIZATIONS") {
+			if !subdomainPattern.MatchString(body.Subdomain) {
+				writeError(c, http.StatusBadRequest, "a valid subdomain is required for directories managing authorizations")
+				return
+			}
+		}
+		if a.subdomainTaken(body.Subdomain) {
+			writeError(c, http.StatusConflict, fmt.Sprintf("subdomain '%s' is already in use", body.Subdomain))
+			return
+		}
+
+		now := s.nowMillis()
+		d := &Directory{
+			Guid:              s.newGuid(),
+			ParentGuid:        body.ParentGuid,
+			DisplayName:       body.DisplayName,
+			Description:       body.Description,
+			Subdomain:         body.Subdomain,
+			EntityState:       "CREATING",
+			CreatedBy:         "btpfake",
+			DirectoryFeatures: features,
+			CreatedDate:       now,
+			ModifiedDate:      now,
+		}
+		d.CustomProperties = withAccountGuid(d.Guid, body.CustomProperties)
+		a.addDirectory(d)
+
+		s.startJob(d.Guid, "Create directory", func(failed bool) {
+			if failed {
+				d.EntityState, d.StateMessage = "CREATION_FAILED", "Directory creation failed"
+				return
+			}
+			d.EntityState, d.StateMessage = "OK", "Directory created."
+		})
+		s.emit("AccountDirectory_Creation", "Directory", d.Guid, map[string]interface{}{"displayName": d.DisplayName})
+		writeJSON(c, http.StatusCreated, a.directoryView(d, false))
+	})
+
+	s.handle(http.MethodGet, "/accounts/v1/directories/{directoryGUID}", func(c *call) {
+		guid := c.param("directoryGUID")
+		s.pollJob(guid)
+		d, ok := a.directories[guid]
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("directory '%s' not found", guid))
+			return
+		}
+		writeJSON(c, http.StatusOK, a.directoryView(d, c.queryBool("expand")))
+	})
+
+	s.handle(http.MethodPatch, "/accounts/v1/directories/{directoryGUID}", func(c *call) {
+		d, ok := a.directories[c.param("directoryGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "directory not found")
+			return
+		}
+		var body updateEntityBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.DisplayName != nil {
+			d.DisplayName = *body.DisplayName
+		}
+		if body.Description != nil {
+			d.Description = *body.Description
+		}
+		d.CustomProperties = applyProperties(d.Guid, d.CustomProperties, body.CustomProperties)
+		d.ModifiedDate = s.nowMillis()
+		s.emit("AccountDirectory_Update", "Directory", d.Guid, nil)
+		writeJSON(c, http.StatusOK, a.directoryView(d, false))
+	})
+
+	s.handle(http.MethodDelete, "/accounts/v1/directories/{directoryGUID}", func(c *call) {
+		guid := c.param("directoryGUID")
+		d, ok := a.directories[guid]
+		if !ok {
+			writeError(c, http.StatusNotFound, "directory not found")
+			return
+		}
+		force := c.queryBool("forceDelete")
+		if !force && (len(a.childDirectories(guid)) > 0 || len(a.childSubAccounts(guid)) > 0) {
+			writeError(c, http.StatusConflict, "directory is not empty; use forceDelete to delete its content")
+			return
+		}
+
+		d.EntityState = "DELETING"
+		s.startJob(guid, "Delete directory", func(failed bool) {
+			if failed {
+				d.EntityState, d.StateMessage = "DELETION_FAILED", "Directory deletion failed"
+				return
+			}
+			s.deleteDirectoryTree(guid)
+		})
+		s.emit("AccountDirectory_Deletion", "Directory", guid, nil)
+		writeJSON(c, http.StatusOK, a.directoryView(d, false))
+	})
+
+	s.handle(http.MethodPatch, "/accounts/v1/directories/{directoryGUID}/changeDirectoryFeatures", func(c *call) {
+		d, ok := a.directories[c.param("directoryGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "directory not found")
+			return
+		}
+		var body createDirectoryBody
+		if !c.decode(&body) {
+			return
+		}
+		features := body.DirectoryFeatures
+		if !hasString(features, "DEFAULT") {
+			features = append([]string{"DEFAULT"}, features...)
+		}
+		for _, f := range d.DirectoryFeatures {
+			if !hasString(features, f) {
+				writeError(c, http.StatusBadRequest, fmt.Sprintf("feature '%s' cannot be disabled", f))
+				return
+			}
+		}
+		if err := validateDirectoryFeatures(features); err != "" {
+			writeError(c, http.StatusBadRequest, err)
+			return
+		}
+		if hasString(features, "AUTHORIZATIONS") && d.Subdomain == "" {
+			if !subdomainPattern.MatchString(body.Subdomain) || a.subdomainTaken(body.Subdomain) {
+				writeError(c, http.StatusBadRequest, "a valid, unused subdomain is required for directories managing authorizations")
+				return
+			}
+			d.Subdomain = body.Subdomain
+		}
+		d.DirectoryFeatures = features
+		d.ModifiedDate = s.nowMillis()
+		for _, sa := range a.childSubAccounts(d.Guid) {
+			sa.ParentFeatures = append([]string(nil), features...)
+		}
+		s.emit("AccountDirectory_Update_Type", "Directory", d.Guid, map[string]interface{}{"directoryFeatures": features})
+		writeJSON(c, http.StatusOK, a.directoryView(d, false))
+	})
+
+	s.handle(http.MethodGet, "/accounts/v1/directories/{directoryGUID}/customProperties", func(c *call) {
+		d, ok := a.directories[c.param("directoryGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "directory not found")
+			return
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"value": nonNilProperties(d.CustomProperties)})
+	})
+
+	s.handle(http.MethodGet, "/accounts/v1/subaccounts", func(c *call) {
+		directory := c.query("directoryGUID")
+		out := make([]SubAccount, 0)
+		for _, guid := range a.subaccountOrder {
+			sa := a.subaccounts[guid]
+			if directory != "" && sa.ParentGuid != directory {
+				continue
+			}
+			out = append(out, *sa)
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"value": out})
+	})
+
+	s.handle(http.MethodPost, "/accounts/v1/subaccounts", func(c *call) {
+		var body createSubAccountBody
+		if !c.decode(&body) {
+			return
+		}
+		sa, status, msg := s.createSubAccount(body)
+		if sa == nil {
+			writeError(c, status, msg)
+			return
+		}
+		writeJSON(c, http.StatusCreated, *sa)
+	})
+
+	s.handle(http.MethodPost, "/accounts/v1/subaccounts/clone/{sourceSubaccountGUID}", func(c *call) {
+		source, ok := a.subaccounts[c.param("sourceSubaccountGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "source subaccount not found")
+			return
+		}
+		var body createSubAccountBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.Region == "" {
+			body.Region = source.Region
+		}
+		if body.ParentGuid == "" {
+			body.ParentGuid = source.ParentGuid
+		}
+		if len(body.CustomProperties) == 0 {
+			body.CustomProperties = source.CustomProperties
+		}
+		sa, status, msg := s.createSubAccount(body)
+		if sa == nil {
+			writeError(c, status, msg)
+			return
+		}
+		writeJSON(c, http.StatusCreated, *sa)
+	})
+
+	s.handle(http.MethodPost, "/accounts/v1/subaccounts/move", func(c *call) {
+		var body struct {
+			Collection []struct {
+				SourceGuid      string   `json:"sourceGuid"`
+				SubaccountGuids []string `json:"subaccountGuids"`
+				TargetGuid      string   `json:"targetGuid"`
+			} `json:"subaccountsToMoveCollection"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		for _, m := range body.Collection {
+			if !a.isContainer(m.TargetGuid) {
+				writeError(c, http.StatusNotFound, fmt.Sprintf("target '%s' not found", m.TargetGuid))
+				return
+			}
+			for _, guid := range m.SubaccountGuids {
+				sa, ok := a.subaccounts[guid]
+				if !ok {
+					writeError(c, http.StatusNotFound, fmt.Sprintf("subaccount '%s' not found", guid))
+					return
+				}
+				if m.SourceGuid != "" && sa.ParentGuid != m.SourceGuid {
+					writeError(c, http.StatusBadRequest, fmt.Sprintf("subaccount '%s' is not located in '%s'", guid, m.SourceGuid))
+					return
+				}
+			}
+		}
+		for _, m := range body.Collection {
+			for _, guid := range m.SubaccountGuids {
+				s.moveSubAccount(a.subaccounts[guid], m.TargetGuid)
+			}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+
+	s.handle(http.MethodGet, "/accounts/v1/subaccounts/{subaccountGUID}", func(c *call) {
+		guid := c.param("subaccountGUID")
+		s.pollJob(guid)
+		sa, ok := a.subaccounts[guid]
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("subaccount '%s' not found", guid))
+			return
+		}
+		writeJSON(c, http.StatusOK, *sa)
+	})
+
+	s.handle(http.MethodPatch, "/accounts/v1/subaccounts/{subaccountGUID}", func(c *call) {
+		sa, ok := a.subaccounts[c.param("subaccountGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "subaccount not found")
+			return
+		}
+		var body updateEntityBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.DisplayName != nil {
+			sa.DisplayName = *body.DisplayName
+		}
+		if body.Description != nil {
+			sa.Description = *body.Description
+		}
+		if body.BetaEnabled {
+			sa.BetaEnabled = true
+		}
+		if body.UsedForProduction != "" {
+			sa.UsedForProduction = body.UsedForProduction
+		}
+		sa.CustomProperties = applyProperties(sa.Guid, sa.CustomProperties, body.CustomProperties)
+		sa.ModifiedDate = s.nowMillis()
+		s.emit("Subaccount_Update", "Subaccount", sa.Guid, nil)
+		writeJSON(c, http.StatusOK, *sa)
+	})
+
+	s.handle(http.MethodDelete, "/accounts/v1/subaccounts/{subaccountGUID}", func(c *call) {
+		guid := c.param("subaccountGUID")
+		sa, ok := a.subaccounts[guid]
+		if !ok {
+			writeError(c, http.StatusNotFound, "subaccount not found")
+			return
+		}
+		s.deleteSubAccount(sa)
+		writeJSON(c, http.StatusOK, *sa)
+	})
+
+	s.handle(http.MethodGet, "/accounts/v1/subaccounts/{subaccountGUID}/customProperties", func(c *call) {
+		sa, ok := a.subaccounts[c.param("subaccountGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "subaccount not found")
+			return
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"value": nonNilProperties(sa.CustomProperties)})
+	})
+
+	s.handle(http.MethodPost, "/accounts/v1/subaccounts/{subaccountGUID}/move", func(c *call) {
+		sa, ok := a.subaccounts[c.param("subaccountGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "subaccount not found")
+			return
+		}
+		var body struct {
+			TargetAccountGuid string `json:"targetAccountGUID"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if !a.isContainer(body.TargetAccountGuid) {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("target '%s' not found", body.TargetAccountGuid))
+			return
+		}
+		s.moveSubAccount(sa, body.TargetAccountGuid)
+		writeJSON(c, http.StatusOK, *sa)
+	})
+
+	s.handle(http.MethodGet, "/accounts/v1/subaccounts/{subaccountGUID}/serviceManagementBinding", func(c *call) {
+		guid := c.param("subaccountGUID")
+		if _, ok := a.subaccounts[guid]; !ok {
+			writeError(c, http.StatusNotFound, "subaccount not found")
+			return
+		}
+		b, ok := a.smBindings[guid]
+		if !ok {
+			writeError(c, http.StatusNotFound, "service management binding not found")
+			return
+		}
+		writeJSON(c, http.StatusOK, b)
+	})
+
+	s.handle(http.MethodPost, "/accounts/v1/subaccounts/{subaccountGUID}/serviceManagementBinding", func(c *call) {
+		guid := c.param("subaccountGUID")
+		if _, ok := a.subaccounts[guid]; !ok {
+			writeError(c, http.StatusNotFound, "subaccount not found")
+			return
+		}
+		b, ok := a.smBindings[guid]
+		if !ok {
+			b = &ServiceManagementBinding{
+				ClientId:     "sb-" + guid,
+				ClientSecret: fmt.Sprintf("secret-%d", s.nextID()),
+				SMUrl:        s.URL,
+				Url:          s.URL,
+				XsAppName:    "btpfake-sm-" + guid,
+			}
+			a.smBindings[guid] = b
+		}
+		writeJSON(c, http.StatusCreated, b)
+	})
+
+	s.handle(http.MethodDelete, "/accounts/v1/subaccounts/{subaccountGUID}/serviceManagementBinding", func(c *call) {
+		guid := c.param("subaccountGUID")
+		if _, ok := a.smBindings[guid]; !ok {
+			writeError(c, http.StatusNotFound, "service management binding not found")
+			return
+		}
+		delete(a.smBindings, guid)
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+}
+
+func (s *Server) createSubAccount(body createSubAccountBody) (*SubAccount, int, string) {
+	a := s.accounts
+	if body.DisplayName == "" {
+		return nil, http.StatusBadRequest, "displayName must not be empty"
+	}
+	if body.Region == "" {
+		return nil, http.StatusBadRequest, "region must not be empty"
+	}
+	if !subdomainPattern.MatchString(body.Subdomain) {
+		return nil, http.StatusBadRequest, fmt.Sprintf("invalid subdomain '%s'", body.Subdomain)
+	}
+	if a.subdomainTaken(body.Subdomain) {
+		return nil, http.StatusConflict, fmt.Sprintf("subdomain '%s' is already in use", body.Subdomain)
+	}
+	if body.ParentGuid == "" {
+		body.ParentGuid = a.global.Guid
+	}
+	if !a.isContainer(body.ParentGuid) {
+		return nil, http.StatusNotFound, fmt.Sprintf("parent '%s' not found", body.ParentGuid)
+	}
+	switch body.UsedForProduction {
+	case "":
+		body.UsedForProduction = "UNSET"
+	case "UNSET", "USED_FOR_PRODUCTION", "NOT_USED_FOR_PRODUCTION":
+	default:
+		return nil, http.StatusBadRequest, fmt.Sprintf("invalid usedForProduction '%s'", body.UsedForProduction)
+	}
+
+	now := s.nowMillis()
+	sa := &SubAccount{
+		Guid:              s.newGuid(),
+		GlobalAccountGuid: a.global.Guid,
+		ParentGuid:        body.ParentGuid,
+		DisplayName:       body.DisplayName,
+		Description:       body.Description,
+		Subdomain:         body.Subdomain,
+		Region:            body.Region,
+		State:             "CREATING",
+		StateMessage:      "Subaccount is being created.",
+		UsedForProduction: body.UsedForProduction,
+		BetaEnabled:       body.BetaEnabled,
+		CreatedBy:         "btpfake",
+		ParentFeatures:    a.parentFeatures(body.ParentGuid),
+		CreatedDate:       now,
+		ModifiedDate:      now,
+	}
+	sa.CustomProperties = withAccountGuid(sa.Guid, body.CustomProperties)
+	a.addSubAccount(sa)
+
+	s.startJob(sa.Guid, "Create subaccount", func(failed bool) {
+		if failed {
+			sa.State, sa.StateMessage = "CREATION_FAILED", "Subaccount creation failed."
+			return
+		}
+		sa.State, sa.StateMessage = "OK", "Subaccount created."
+		s.entitlements.autoAssign(s, sa.Guid)
+	})
+	s.emit("Subaccount_Creation", "Subaccount", sa.Guid, map[string]interface{}{"displayName": sa.DisplayName, "region": sa.Region})
+	return sa, http.StatusCreated, ""
+}
+
+func (s *Server) moveSubAccount(sa *SubAccount, target string) {
+	source := sa.ParentGuid
+	sa.ParentGuid = target
+	sa.ParentFeatures = s.accounts.parentFeatures(target)
+	sa.ModifiedDate = s.nowMillis()
+	s.emit("Subaccount_Move", "Subaccount", sa.Guid, map[string]interface{}{"sourceGuid": source, "targetGuid": target})
+}
+
+func (s *Server) deleteSubAccount(sa *SubAccount) {
+	sa.State, sa.StateMessage = "DELETING", "Subaccount is being deleted."
+	guid := sa.Guid
+	s.startJob(guid, "Delete subaccount", func(failed bool) {
+		if failed {
+			sa.State, sa.StateMessage = "DELETION_FAILED", "Subaccount deletion failed."
+			return
+		}
+		s.accounts.removeSubAccount(guid)
+		s.entitlements.removeEntity(guid)
+		s.provisioning.removeSubAccount(guid)
+		s.saas.removeSubAccount(guid)
+	})
+	s.emit("Subaccount_Deletion", "Subaccount", guid, nil)
+}
+
+func (s *Server) deleteDirectoryTree(guid string) {
+	a := s.accounts
+	for _, child := range a.childDirectories(guid) {
+		s.deleteDirectoryTree(child.Guid)
+	}
+	for _, sa := range a.childSubAccounts(guid) {
+		a.removeSubAccount(sa.Guid)
+		s.entitlements.removeEntity(sa.Guid)
+		s.provisioning.removeSubAccount(sa.Guid)
+		s.saas.removeSubAccount(sa.Guid)
+	}
+	a.removeDirectory(guid)
+	s.entitlements.removeEntity(guid)
+}
+
+func validateDirectoryFeatures(features []string) string {
+	for _, f := range features {
+		switch f {
+		case "DEFAULT", "ENTITLEMENTS", "AUTHORIZATIONS":
+		default:
+			return fmt.Sprintf("unknown directory feature '%s'", f)
+		}
+	}
+	if !hasString(features, "DEFAULT") {
+		return "directory feature DEFAULT is mandatory"
+	}
+	if hasString(features, "AUTHORIZATIONS") && !hasString(features, "ENTITLEMENTS") {
+		return "directory feature AUTHORIZATIONS requires ENTITLEMENTS"
+	}
+	return ""
+}
+
+func nonNilProperties(props []CustomProperty) []CustomProperty {
+	if props == nil {
+		return []CustomProperty{}
+	}
+	return props
+}
+
+func hasString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, v string) []string {
+	out := values[:0]
+	for _, value := range values {
+		if value != v {
+			out = append(out, value)
+		}
+	}
+	return out
+}
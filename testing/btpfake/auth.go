@@ -0,0 +1,132 @@
+package btpfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// XSUAA-like token endpoint, supporting the client_credentials and password grant types.
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+
+	switch grant := r.PostForm.Get("grant_type"); grant {
+	case "client_credentials", "password":
+	default:
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, known := s.clientGrant(clientID, clientSecret)
+	if !known {
+		tokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	token := fmt.Sprintf("btpfake-token-%d", s.nextID())
+	s.tokens[token] = g
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tokenResponse{
+		AccessToken: token,
+		TokenType:   "bearer",
+		ExpiresIn:   3600,
+	})
+}
+
+// The kinds of client credentials the token endpoint accepts.
+type grantKind int
+
+const (
+	// The client of the global account, accepted by every API.
+	grantGlobal grantKind = iota
+
+	// The Service Manager binding of a subaccount, accepted by the Service Manager API only.
+	grantServiceManager
+
+	// The service key of a subaccount, accepted by the provisioning and SaaS Manager APIs only.
+	grantSubAccount
+)
+
+// What a token was issued for.
+type grant struct {
+	kind grantKind
+
+	// Subaccount the token is scoped to; empty for the global account.
+	subaccount string
+}
+
+// Whether the token is accepted by the API serving the path.
+func (g grant) accepts(path string) bool {
+	switch g.kind {
+	case grantServiceManager:
+		return strings.HasPrefix(path, "/v1/")
+	case grantSubAccount:
+		return strings.HasPrefix(path, "/provisioning/") || strings.HasPrefix(path, "/saas-manager/") ||
+			strings.HasPrefix(path, "/api/v2.0/")
+	}
+	return true
+}
+
+// Prefix of the client ID of the service key of a subaccount, followed by its GUID.
+const subAccountClientPrefix = "sb-cis-local-"
+
+// Resolves what a client is granted; the default client is scoped to the global account.
+func (s *Server) clientGrant(clientID, clientSecret string) (grant, bool) {
+	if clientID == s.opts.ClientID && clientSecret == s.opts.ClientSecret {
+		return grant{kind: grantGlobal}, true
+	}
+	if guid := strings.TrimPrefix(clientID, subAccountClientPrefix); guid != clientID {
+		_, ok := s.accounts.subaccounts[guid]
+		return grant{kind: grantSubAccount, subaccount: guid}, ok && clientSecret == s.subAccountSecret(guid)
+	}
+	for guid, b := range s.accounts.smBindings {
+		if b.ClientId == clientID && b.ClientSecret == clientSecret {
+			return grant{kind: grantServiceManager, subaccount: guid}, true
+		}
+	}
+	return grant{}, false
+}
+
+func (s *Server) subAccountSecret(guid string) string {
+	return s.opts.ClientSecret + "-" + guid
+}
+
+func (s *Server) authorize(r *http.Request) (grant, bool) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(strings.ToLower(header), "bearer ") {
+		return grant{}, false
+	}
+	g, ok := s.tokens[strings.TrimSpace(header[len("bearer "):])]
+	return g, ok
+}
+
+func tokenError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
@@ -0,0 +1,579 @@
+package btpfake
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Plan entitled to the global account, seeded with AddEntitlement.
+type Entitlement struct {
+	Service            string
+	ServiceDisplayName string
+	Plan               string
+	PlanDisplayName    string
+
+	//Enum:
+	//	[ APPLICATION, ELASTIC_SERVICE, ELASTIC_LIMITED, PLATFORM, QUOTA_BASED_APPLICATION, SERVICE ]
+	Category string
+
+	// Quota entitled to the global account; ignored for unlimited plans.
+	Amount    float64
+	Unlimited bool
+
+	// Assigned automatically to every new subaccount.
+	AutoAssign           bool
+	AutoDistributeAmount int
+
+	// Upper limit of quota a single subaccount may receive; zero means no limit.
+	MaxAllowedSubaccountQuota int
+}
+
+// Whether assignments of the plan are expressed as an amount rather than enabled/disabled.
+func (e Entitlement) quotaBased() bool {
+	if e.Unlimited {
+		return false
+	}
+	switch e.Category {
+	case "APPLICATION", "ELASTIC_SERVICE":
+		return false
+	}
+	return true
+}
+
+type DataCenter struct {
+	Name                   string `json:"name"`
+	DisplayName            string `json:"displayName"`
+	Region                 string `json:"region"`
+	Environment            string `json:"environment"`
+	IaasProvider           string `json:"iaasProvider"`
+	SupportsTrial          bool   `json:"supportsTrial"`
+	ProvisioningServiceUrl string `json:"provisioningServiceUrl"`
+	SaasRegistryServiceUrl string `json:"saasRegistryServiceUrl"`
+	Domain                 string `json:"domain"`
+}
+
+type assignment struct {
+	entityId             string
+	entityType           string
+	amount               float64
+	enabled              bool
+	autoAssign           bool
+	autoDistributeAmount int
+	state                string
+	createdDate          int64
+	modifiedDate         int64
+}
+
+type entitledPlan struct {
+	Entitlement
+
+	// Assignments per entity GUID, in assignment order.
+	assignments map[string]*assignment
+	order       []string
+}
+
+type entitlementsState struct {
+	plans       map[string]*entitledPlan
+	order       []string
+	dataCenters []DataCenter
+}
+
+func newEntitlementsState() *entitlementsState {
+	return &entitlementsState{
+		plans: make(map[string]*entitledPlan),
+	}
+}
+
+func planKey(service, plan string) string {
+	return service + "/" + plan
+}
+
+// Entitles a service plan to the global account.
+func (s *Server) AddEntitlement(e Entitlement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.ServiceDisplayName == "" {
+		e.ServiceDisplayName = e.Service
+	}
+	if e.PlanDisplayName == "" {
+		e.PlanDisplayName = e.Plan
+	}
+	if e.Category == "" {
+		e.Category = "SERVICE"
+	}
+
+	key := planKey(e.Service, e.Plan)
+	if p, ok := s.entitlements.plans[key]; ok {
+		p.Entitlement = e
+		return
+	}
+	s.entitlements.plans[key] = &entitledPlan{Entitlement: e, assignments: make(map[string]*assignment)}
+	s.entitlements.order = append(s.entitlements.order, key)
+}
+
+// Adds a data center to the ones returned by the entitlements API; defaults to one in Options.Region.
+func (s *Server) AddDataCenter(dc DataCenter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entitlements.dataCenters = append(s.entitlements.dataCenters, dc)
+}
+
+// Amount assigned to an entity for a plan and whether the plan is assigned at all.
+func (s *Server) Assignment(entityGuid, service, plan string) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.entitlements.plans[planKey(service, plan)]
+	if !ok {
+		return 0, false
+	}
+	a, ok := p.assignments[entityGuid]
+	if !ok {
+		return 0, false
+	}
+	return a.amount, true
+}
+
+func (e *entitlementsState) removeEntity(guid string) {
+	for _, p := range e.plans {
+		if _, ok := p.assignments[guid]; ok {
+			delete(p.assignments, guid)
+			p.order = removeString(p.order, guid)
+		}
+	}
+}
+
+func (p *entitledPlan) assign(s *Server, entityId, entityType string) *assignment {
+	a, ok := p.assignments[entityId]
+	if !ok {
+		now := s.nowMillis()
+		a = &assignment{entityId: entityId, entityType: entityType, state: "OK", createdDate: now, modifiedDate: now}
+		p.assignments[entityId] = a
+		p.order = append(p.order, entityId)
+	}
+	return a
+}
+
+func (p *entitledPlan) unassign(entityId string) {
+	delete(p.assignments, entityId)
+	p.order = removeString(p.order, entityId)
+}
+
+// Nearest directory managing entitlements that holds an assignment of the plan, or the global account.
+func (s *Server) quotaSource(p *entitledPlan, entityId string) string {
+	a := s.accounts
+	parent := ""
+	if sa, ok := a.subaccounts[entityId]; ok {
+		parent = sa.ParentGuid
+	} else if d, ok := a.directories[entityId]; ok {
+		parent = d.ParentGuid
+	}
+	for parent != "" && parent != a.global.Guid {
+		d, ok := a.directories[parent]
+		if !ok {
+			break
+		}
+		if hasString(d.DirectoryFeatures, "ENTITLEMENTS") {
+			if _, assigned := p.assignments[d.Guid]; assigned {
+				return d.Guid
+			}
+		}
+		parent = d.ParentGuid
+	}
+	return a.global.Guid
+}
+
+func (s *Server) totalAmount(p *entitledPlan, source string) float64 {
+	if source == s.accounts.global.Guid {
+		return p.Amount
+	}
+	if a, ok := p.assignments[source]; ok {
+		return a.amount
+	}
+	return 0
+}
+
+func (s *Server) remainingAmount(p *entitledPlan, source string) float64 {
+	remaining := s.totalAmount(p, source)
+	for _, id := range p.order {
+		if id != source && s.quotaSource(p, id) == source {
+			remaining -= p.assignments[id].amount
+		}
+	}
+	return remaining
+}
+
+// Checks that moving the entity's assignment to amount fits the quota available at its source.
+func (s *Server) checkQuota(p *entitledPlan, entityId string, amount float64) string {
+	if !p.quotaBased() {
+		return ""
+	}
+	if amount < 0 {
+		return "amount must not be negative"
+	}
+	if p.MaxAllowedSubaccountQuota > 0 && amount > float64(p.MaxAllowedSubaccountQuota) {
+		if _, isSubAccount := s.accounts.subaccounts[entityId]; isSubAccount {
+			return fmt.Sprintf("amount %v exceeds the maximum allowed subaccount quota %d of plan '%s'", amount, p.MaxAllowedSubaccountQuota, p.Plan)
+		}
+	}
+	source := s.quotaSource(p, entityId)
+	available := s.remainingAmount(p, source)
+	if current, ok := p.assignments[entityId]; ok {
+		available += current.amount
+	}
+	if amount > available {
+		return fmt.Sprintf("insufficient quota for plan '%s'; requested %v, available %v", p.Plan, amount, available)
+	}
+	return ""
+}
+
+// Assigns plans to a newly created subaccount, following global and directory auto-assignment settings.
+func (e *entitlementsState) autoAssign(s *Server, subaccountGuid string) {
+	sa, ok := s.accounts.subaccounts[subaccountGuid]
+	if !ok {
+		return
+	}
+	for _, key := range e.order {
+		p := e.plans[key]
+
+		amount, assign := 0.0, false
+		if p.AutoAssign {
+			amount, assign = float64(p.AutoDistributeAmount), true
+		}
+		if da, ok := p.assignments[sa.ParentGuid]; ok && da.autoAssign {
+			amount, assign = float64(da.autoDistributeAmount), true
+		}
+		if !assign {
+			continue
+		}
+		if p.quotaBased() && s.checkQuota(p, sa.Guid, amount) != "" {
+			continue
+		}
+		a := p.assign(s, sa.Guid, "SUBACCOUNT")
+		if p.quotaBased() {
+			a.amount = amount
+		} else {
+			a.enabled = true
+		}
+	}
+}
+
+type subAccountServicePlansBody struct {
+	SubAccountServicePlans []struct {
+		ServiceName     string `json:"serviceName"`
+		ServicePlanName string `json:"servicePlanName"`
+		AssignmentInfo  []struct {
+			Amount         *float64 `json:"amount"`
+			Enable         *bool    `json:"enable"`
+			SubAccountGuid string   `json:"subaccountGUID"`
+		} `json:"assignmentInfo"`
+	} `json:"subaccountServicePlans"`
+}
+
+type directoryEntitlementsBody struct {
+	Entitlements []struct {
+		Amount               *float64 `json:"amount"`
+		Plan                 string   `json:"plan"`
+		Enable               *bool    `json:"enable"`
+		Service              string   `json:"service"`
+		Distribute           bool     `json:"distribute"`
+		AutoAssign           bool     `json:"autoAssign"`
+		AutoDistributeAmount *int     `json:"autoDistributeAmount"`
+	} `json:"entitlements"`
+}
+
+func (s *Server) registerEntitlements() {
+	e := s.entitlements
+
+	s.handle(http.MethodGet, "/entitlements/v1/globalAccountAllowedDataCenters", func(c *call) {
+		dcs := e.dataCenters
+		if len(dcs) == 0 {
+			dcs = []DataCenter{{
+				Name:                   "cf-" + s.opts.Region,
+				DisplayName:            "Fake (" + s.opts.Region + ")",
+				Region:                 s.opts.Region,
+				Environment:            "cloudfoundry",
+				IaasProvider:           "AWS",
+				ProvisioningServiceUrl: s.URL,
+				SaasRegistryServiceUrl: s.URL,
+				Domain:                 s.opts.Region + ".btpfake.local",
+			}}
+		}
+		region := c.query("region")
+		out := make([]DataCenter, 0, len(dcs))
+		for _, dc := range dcs {
+			if region == "" || dc.Region == region {
+				out = append(out, dc)
+			}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"datacenters": out})
+	})
+
+	s.handle(http.MethodGet, "/entitlements/v1/globalAccountAssignments", func(c *call) {
+		filter := c.query("subaccountGUID")
+		writeJSON(c, http.StatusOK, s.assignmentsView(func(id string) bool {
+			return filter == "" || id == filter
+		}, c.queryBool("includeAutoManagedPlans")))
+	})
+
+	s.handle(http.MethodGet, "/entitlements/v1/assignments", func(c *call) {
+		subaccount, directory := c.query("subaccountGUID"), c.query("directoryGUID")
+		if directory != "" {
+			if _, ok := s.accounts.directories[directory]; !ok {
+				writeError(c, http.StatusNotFound, fmt.Sprintf("directory '%s' not found", directory))
+				return
+			}
+		}
+		writeJSON(c, http.StatusOK, s.assignmentsView(func(id string) bool {
+			if subaccount != "" && id != subaccount {
+				return false
+			}
+			if directory != "" {
+				if id == directory {
+					return true
+				}
+				sa, ok := s.accounts.subaccounts[id]
+				return ok && sa.ParentGuid == directory
+			}
+			return true
+		}, c.queryBool("includeAutoManagedPlans")))
+	})
+
+	s.handle(http.MethodPut, "/entitlements/v1/subaccountServicePlans", func(c *call) {
+		var body subAccountServicePlansBody
+		if !c.decode(&body) {
+			return
+		}
+
+		// validate everything first, so a rejected request does not change any assignment
+		for _, sp := range body.SubAccountServicePlans {
+			p, ok := e.plans[planKey(sp.ServiceName, sp.ServicePlanName)]
+			if !ok {
+				writeError(c, http.StatusBadRequest, fmt.Sprintf("plan '%s' of service '%s' is not entitled to the global account", sp.ServicePlanName, sp.ServiceName))
+				return
+			}
+			for _, ai := range sp.AssignmentInfo {
+				if _, ok := s.accounts.subaccounts[ai.SubAccountGuid]; !ok {
+					writeError(c, http.StatusNotFound, fmt.Sprintf("subaccount '%s' not found", ai.SubAccountGuid))
+					return
+				}
+				if p.quotaBased() {
+					if ai.Amount == nil {
+						writeError(c, http.StatusBadRequest, fmt.Sprintf("amount is required for plan '%s'", p.Plan))
+						return
+					}
+					if msg := s.checkQuota(p, ai.SubAccountGuid, *ai.Amount); msg != "" {
+						writeError(c, http.StatusBadRequest, msg)
+						return
+					}
+				} else if ai.Enable == nil {
+					writeError(c, http.StatusBadRequest, fmt.Sprintf("enable is required for plan '%s'", p.Plan))
+					return
+				}
+			}
+		}
+
+		jobId := s.newGuid()
+		var changed []*assignment
+		for _, sp := range body.SubAccountServicePlans {
+			p := e.plans[planKey(sp.ServiceName, sp.ServicePlanName)]
+			for _, ai := range sp.AssignmentInfo {
+				if (p.quotaBased() && *ai.Amount == 0) || (!p.quotaBased() && !*ai.Enable) {
+					p.unassign(ai.SubAccountGuid)
+				} else {
+					a := p.assign(s, ai.SubAccountGuid, "SUBACCOUNT")
+					if p.quotaBased() {
+						a.amount = *ai.Amount
+					} else {
+						a.enabled = true
+					}
+					a.state = "PROCESSING"
+					a.modifiedDate = s.nowMillis()
+					changed = append(changed, a)
+				}
+				s.emit("EntityEntitlements_Update", "Subaccount", ai.SubAccountGuid, map[string]interface{}{
+					"serviceName": sp.ServiceName, "servicePlanName": sp.ServicePlanName,
+				})
+			}
+		}
+		s.startJob(jobId, "Update subaccount service plans", func(failed bool) {
+			for _, a := range changed {
+				if failed {
+					a.state = "PROCESSING_FAILED"
+				} else {
+					a.state = "OK"
+				}
+			}
+		})
+		writeText(c, http.StatusAccepted, jobId)
+	})
+
+	s.handle(http.MethodPut, "/entitlements/v1/directories/{directoryGUID}/assignments", func(c *call) {
+		d, ok := s.accounts.directories[c.param("directoryGUID")]
+		if !ok {
+			writeError(c, http.StatusNotFound, "directory not found")
+			return
+		}
+		if !hasString(d.DirectoryFeatures, "ENTITLEMENTS") {
+			writeError(c, http.StatusBadRequest, "directory does not manage entitlements; enable the ENTITLEMENTS feature first")
+			return
+		}
+		var body directoryEntitlementsBody
+		if !c.decode(&body) {
+			return
+		}
+		for _, de := range body.Entitlements {
+			p, ok := e.plans[planKey(de.Service, de.Plan)]
+			if !ok {
+				writeError(c, http.StatusBadRequest, fmt.Sprintf("plan '%s' of service '%s' is not entitled to the global account", de.Plan, de.Service))
+				return
+			}
+			if p.quotaBased() {
+				if de.Amount == nil {
+					writeError(c, http.StatusBadRequest, fmt.Sprintf("amount is required for plan '%s'", p.Plan))
+					return
+				}
+				if msg := s.checkQuota(p, d.Guid, *de.Amount); msg != "" {
+					writeError(c, http.StatusBadRequest, msg)
+					return
+				}
+			} else if de.Enable == nil {
+				writeError(c, http.StatusBadRequest, fmt.Sprintf("enable is required for plan '%s'", p.Plan))
+				return
+			}
+		}
+
+		for _, de := range body.Entitlements {
+			p := e.plans[planKey(de.Service, de.Plan)]
+			if (p.quotaBased() && *de.Amount == 0) || (!p.quotaBased() && !*de.Enable) {
+				p.unassign(d.Guid)
+				continue
+			}
+			a := p.assign(s, d.Guid, "DIRECTORY")
+			if p.quotaBased() {
+				a.amount = *de.Amount
+			} else {
+				a.enabled = true
+			}
+			a.autoAssign = de.AutoAssign
+			if de.AutoDistributeAmount != nil {
+				a.autoDistributeAmount = *de.AutoDistributeAmount
+			}
+			a.modifiedDate = s.nowMillis()
+
+			if de.Distribute {
+				for _, sa := range s.accounts.childSubAccounts(d.Guid) {
+					if _, assigned := p.assignments[sa.Guid]; assigned {
+						continue
+					}
+					amount := float64(a.autoDistributeAmount)
+					if p.quotaBased() && s.checkQuota(p, sa.Guid, amount) != "" {
+						continue
+					}
+					sub := p.assign(s, sa.Guid, "SUBACCOUNT")
+					sub.amount, sub.enabled = amount, !p.quotaBased()
+				}
+			}
+			s.emit("EntityEntitlements_Update", "Directory", d.Guid, map[string]interface{}{
+				"serviceName": de.Service, "servicePlanName": de.Plan,
+			})
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+}
+
+// Builds the entitledServices/assignedServices payload, keeping only assignments of entities accepted by include.
+func (s *Server) assignmentsView(include func(entityId string) bool, includeAutoManaged bool) map[string]interface{} {
+	type service struct {
+		Name         string                   `json:"name"`
+		DisplayName  string                   `json:"displayName"`
+		ServicePlans []map[string]interface{} `json:"servicePlans"`
+	}
+
+	var entitled, assigned []*service
+	entitledIdx, assignedIdx := map[string]*service{}, map[string]*service{}
+
+	for _, key := range s.entitlements.order {
+		p := s.entitlements.plans[key]
+		if p.AutoAssign && !includeAutoManaged && len(p.assignments) == 0 {
+			continue
+		}
+
+		es, ok := entitledIdx[p.Service]
+		if !ok {
+			es = &service{Name: p.Service, DisplayName: p.ServiceDisplayName}
+			entitledIdx[p.Service] = es
+			entitled = append(entitled, es)
+		}
+		es.ServicePlans = append(es.ServicePlans, map[string]interface{}{
+			"name":                      p.Plan,
+			"displayName":               p.PlanDisplayName,
+			"uniqueIdentifier":          p.Service + "-" + p.Plan,
+			"category":                  p.Category,
+			"amount":                    p.Amount,
+			"remainingAmount":           s.remainingAmount(p, s.accounts.global.Guid),
+			"unlimited":                 p.Unlimited,
+			"autoAssign":                p.AutoAssign,
+			"autoDistributeAmount":      p.AutoDistributeAmount,
+			"maxAllowedSubaccountQuota": p.MaxAllowedSubaccountQuota,
+		})
+
+		var infos []map[string]interface{}
+		for _, id := range p.order {
+			if !include(id) {
+				continue
+			}
+			a := p.assignments[id]
+			source := s.quotaSource(p, id)
+			parentType := "GLOBAL_ACCOUNT"
+			if source != s.accounts.global.Guid {
+				parentType = "DIRECTORY"
+			}
+			infos = append(infos, map[string]interface{}{
+				"entityId":                a.entityId,
+				"entityType":              a.entityType,
+				"amount":                  a.amount,
+				"requestedAmount":         a.amount,
+				"parentId":                source,
+				"parentType":              parentType,
+				"parentAmount":            s.totalAmount(p, source),
+				"parentRemainingAmount":   s.remainingAmount(p, source),
+				"entityState":             a.state,
+				"autoAssign":              a.autoAssign,
+				"autoDistributeAmount":    a.autoDistributeAmount,
+				"unlimitedAmountAssigned": a.enabled && !p.quotaBased(),
+				"createdDate":             a.createdDate,
+				"modifiedDate":            a.modifiedDate,
+			})
+		}
+		if len(infos) == 0 {
+			continue
+		}
+		as, ok := assignedIdx[p.Service]
+		if !ok {
+			as = &service{Name: p.Service, DisplayName: p.ServiceDisplayName}
+			assignedIdx[p.Service] = as
+			assigned = append(assigned, as)
+		}
+		as.ServicePlans = append(as.ServicePlans, map[string]interface{}{
+			"name":                      p.Plan,
+			"displayName":               p.PlanDisplayName,
+			"uniqueIdentifier":          p.Service + "-" + p.Plan,
+			"category":                  p.Category,
+			"unlimited":                 p.Unlimited,
+			"maxAllowedSubaccountQuota": p.MaxAllowedSubaccountQuota,
+			"assignmentInfo":            infos,
+		})
+	}
+
+	if entitled == nil {
+		entitled = []*service{}
+	}
+	if assigned == nil {
+		assigned = []*service{}
+	}
+	return map[string]interface{}{
+		"entitledServices": entitled,
+		"assignedServices": assigned,
+	}
+}
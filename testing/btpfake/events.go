@@ -0,0 +1,142 @@
+package btpfake
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Audit event recorded by the fake for every mutating call.
+type Event struct {
+	Id                int64                  `json:"id"`
+	ActionTime        int64                  `json:"actionTime"`
+	CreationTime      int64                  `json:"creationTime"`
+	Details           map[string]interface{} `json:"details,omitempty"`
+	EntityId          string                 `json:"entityId"`
+	EntityType        string                 `json:"entityType"`
+	EventOrigin       string                 `json:"eventOrigin"`
+	EventType         string                 `json:"eventType"`
+	GlobalAccountGuid string                 `json:"globalAccountGUID"`
+}
+
+type eventsState struct {
+	events []Event
+}
+
+func newEventsState() *eventsState {
+	return &eventsState{}
+}
+
+var eventTypes = []map[string]interface{}{
+	{"type": "GlobalAccount_Update", "category": "CENTRAL", "description": "Global account was updated"},
+	{"type": "AccountDirectory_Creation", "category": "CENTRAL", "description": "Directory was created"},
+	{"type": "AccountDirectory_Update", "category": "CENTRAL", "description": "Directory was updated"},
+	{"type": "AccountDirectory_Update_Type", "category": "CENTRAL", "description": "Directory features were changed"},
+	{"type": "AccountDirectory_Deletion", "category": "CENTRAL", "description": "Directory was deleted"},
+	{"type": "Subaccount_Creation", "category": "CENTRAL", "description": "Subaccount was created"},
+	{"type": "Subaccount_Update", "category": "CENTRAL", "description": "Subaccount was updated"},
+	{"type": "Subaccount_Deletion", "category": "CENTRAL", "description": "Subaccount was deleted"},
+	{"type": "Subaccount_Move", "category": "CENTRAL", "description": "Subaccount was moved"},
+	{"type": "EntityEntitlements_Update", "category": "CENTRAL", "description": "Entitlements of an entity were updated"},
+	{"type": "SubaccountAppSubscription_Creation", "category": "LOCAL", "description": "Subaccount subscribed to an application"},
+	{"type": "SubaccountAppSubscription_Deletion", "category": "LOCAL", "description": "Subaccount unsubscribed from an application"},
+	{"type": "EnvironmentInstance_Creation", "category": "LOCAL", "description": "Environment instance was created"},
+	{"type": "EnvironmentInstance_Update", "category": "LOCAL", "description": "Environment instance was updated"},
+	{"type": "EnvironmentInstance_Deletion", "category": "LOCAL", "description": "Environment instance was deleted"},
+}
+
+// Events recorded so far, in creation order.
+func (s *Server) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Event, len(s.events.events))
+	copy(out, s.events.events)
+	return out
+}
+
+func (s *Server) emit(eventType, entityType, entityId string, details map[string]interface{}) {
+	now := s.nowMillis()
+	s.events.events = append(s.events.events, Event{
+		Id:                int64(len(s.events.events) + 1),
+		ActionTime:        now,
+		CreationTime:      now,
+		Details:           details,
+		EntityId:          entityId,
+		EntityType:        entityType,
+		EventOrigin:       "btpfake",
+		EventType:         eventType,
+		GlobalAccountGuid: s.accounts.global.Guid,
+	})
+}
+
+// Parses a unix timestamp in seconds, possibly with a fractional part, into milliseconds.
+func parseUnixMillis(v string) (int64, bool) {
+	if v == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(f * 1e3), true
+}
+
+func (s *Server) registerEvents() {
+	s.handle(http.MethodGet, "/cloud-management/v1/events/types", func(c *call) {
+		writeJSON(c, http.StatusOK, eventTypes)
+	})
+
+	s.handle(http.MethodGet, "/cloud-management/v1/events", func(c *call) {
+		q := c.r.URL.Query()
+		entityId := q.Get("entityId")
+		entityTypes, eventTypes := q["entityType"], q["eventType"]
+		from, hasFrom := parseUnixMillis(q.Get("fromActionTime"))
+		to, hasTo := parseUnixMillis(q.Get("toActionTime"))
+
+		matched := make([]Event, 0)
+		for _, e := range s.events.events {
+			if entityId != "" && e.EntityId != entityId {
+				continue
+			}
+			if len(entityTypes) > 0 && !hasString(entityTypes, e.EntityType) {
+				continue
+			}
+			if len(eventTypes) > 0 && !hasString(eventTypes, e.EventType) {
+				continue
+			}
+			if (hasFrom && e.ActionTime < from) || (hasTo && e.ActionTime > to) {
+				continue
+			}
+			matched = append(matched, e)
+		}
+		if q.Get("sortOrder") == "DESC" {
+			sort.SliceStable(matched, func(i, j int) bool { return matched[i].Id > matched[j].Id })
+		}
+
+		pageSize, _ := strconv.Atoi(q.Get("pageSize"))
+		if pageSize <= 0 {
+			pageSize = 150
+		}
+		pageNum, _ := strconv.Atoi(q.Get("pageNum"))
+		if pageNum <= 0 {
+			pageNum = 1
+		}
+		totalPages := (len(matched) + pageSize - 1) / pageSize
+		start := (pageNum - 1) * pageSize
+		if start > len(matched) {
+			start = len(matched)
+		}
+		end := start + pageSize
+		if end > len(matched) {
+			end = len(matched)
+		}
+
+		writeJSON(c, http.StatusOK, map[string]interface{}{
+			"events":     matched[start:end],
+			"morePages":  pageNum < totalPages,
+			"pageNum":    pageNum,
+			"total":      len(matched),
+			"totalPages": totalPages,
+		})
+	})
+}
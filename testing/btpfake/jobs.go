@@ -0,0 +1,107 @@
+package btpfake
+
+import "net/http"
+
+const (
+	jobInProgress = "IN_PROGRESS"
+	jobCompleted  = "COMPLETED"
+	jobFailed     = "FAILED"
+)
+
+// Asynchronous job; completes after it was polled Options.AsyncSteps times.
+type job struct {
+	id          string
+	status      string
+	description string
+	remaining   int
+	failed      bool
+	finish      func(failed bool)
+}
+
+// Starts a job identified by id; finish is called once the job reaches its final state.
+func (s *Server) startJob(id, description string, finish func(failed bool)) *job {
+	j := &job{
+		id:          id,
+		status:      jobInProgress,
+		description: description,
+		remaining:   s.opts.AsyncSteps,
+		finish:      finish,
+	}
+	if s.failJobs > 0 {
+		s.failJobs--
+		j.failed = true
+	}
+	s.jobs[id] = j
+
+	if j.remaining <= 0 {
+		s.completeJob(j)
+	}
+	return j
+}
+
+// Advances the job identified by id, if any, and returns it.
+func (s *Server) pollJob(id string) *job {
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil
+	}
+	if j.status == jobInProgress {
+		j.remaining--
+		if j.remaining <= 0 {
+			s.completeJob(j)
+		}
+	}
+	return j
+}
+
+func (s *Server) completeJob(j *job) {
+	if j.failed {
+		j.status = jobFailed
+	} else {
+		j.status = jobCompleted
+	}
+	if j.finish != nil {
+		j.finish(j.failed)
+	}
+}
+
+func (s *Server) registerJobs() {
+	s.handle(http.MethodGet, "/jobs-management/v1/jobs/{jobId}/status", func(c *call) {
+		j := s.pollJob(c.param("jobId"))
+		if j == nil {
+			writeError(c, http.StatusNotFound, "job not found")
+			return
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{
+			"description": j.description,
+			"status":      j.status,
+		})
+	})
+
+	s.handle(http.MethodGet, "/api/v2.0/jobs/{jobUuid}", func(c *call) {
+		j := s.pollJob(c.param("jobUuid"))
+		if j == nil {
+			writeError(c, http.StatusNotFound, "job not found")
+			return
+		}
+		state := "STARTED"
+		switch j.status {
+		case jobCompleted:
+			state = "SUCCEEDED"
+		case jobFailed:
+			state = "FAILED"
+		}
+		out := map[string]interface{}{
+			"id":    j.id,
+			"state": state,
+		}
+		if j.failed {
+			out["error"] = map[string]interface{}{
+				"error":   "Internal Server Error",
+				"message": j.description + " failed",
+				"status":  http.StatusInternalServerError,
+			}
+		}
+		writeJSON(c, http.StatusOK, out)
+	})
+}
@@ -0,0 +1,347 @@
+package btpfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Environment offered by the provisioning API, seeded with AddAvailableEnvironment.
+type AvailableEnvironment struct {
+	AvailabilityLevel  string `json:"availabilityLevel,omitempty"`
+	CreateSchema       string `json:"createSchema,omitempty"`
+	Description        string `json:"description,omitempty"`
+	EnvironmentType    string `json:"environmentType"`
+	LandscapeLabel     string `json:"landscapeLabel,omitempty"`
+	PlanName           string `json:"planName"`
+	PlanUpdatable      bool   `json:"planUpdatable"`
+	ServiceDisplayName string `json:"serviceDisplayName,omitempty"`
+	ServiceName        string `json:"serviceName"`
+	TechnicalKey       string `json:"technicalKey,omitempty"`
+	UpdateSchema       string `json:"updateSchema,omitempty"`
+}
+
+type EnvironmentInstance struct {
+	BrokerId          string `json:"brokerId,omitempty"`
+	CommercialType    string `json:"commercialType,omitempty"`
+	CreatedDate       int64  `json:"createdDate,omitempty"`
+	DashboardUrl      string `json:"dashboardUrl,omitempty"`
+	Description       string `json:"description,omitempty"`
+	EnvironmentType   string `json:"environmentType"`
+	GlobalAccountGuid string `json:"globalAccountGUID"`
+	Id                string `json:"id"`
+	Labels            string `json:"labels,omitempty"`
+	LandscapeLabel    string `json:"landscapeLabel,omitempty"`
+	ModifiedDate      int64  `json:"modifiedDate,omitempty"`
+	Name              string `json:"name"`
+	Operation         string `json:"operation,omitempty"`
+	Parameters        string `json:"parameters,omitempty"`
+	PlanId            string `json:"planId,omitempty"`
+	PlanName          string `json:"planName"`
+	PlatformId        string `json:"platformId,omitempty"`
+	ServiceId         string `json:"serviceId,omitempty"`
+	ServiceName       string `json:"serviceName"`
+	State             string `json:"state"`
+	StateMessage      string `json:"stateMessage,omitempty"`
+	SubAccountGuid    string `json:"subaccountGUID"`
+	TenantId          string `json:"tenantId,omitempty"`
+	Type              string `json:"type,omitempty"`
+}
+
+type provisioningState struct {
+	available []AvailableEnvironment
+
+	instances map[string]*EnvironmentInstance
+	order     []string
+}
+
+func newProvisioningState() *provisioningState {
+	return &provisioningState{
+		available: []AvailableEnvironment{
+			{
+				AvailabilityLevel:  "GA",
+				EnvironmentType:    "cloudfoundry",
+				LandscapeLabel:     "cf-eu10",
+				PlanName:           "standard",
+				ServiceDisplayName: "Cloud Foundry Runtime",
+				ServiceName:        "cloudfoundry",
+				TechnicalKey:       "cloudfoundry-standard",
+				CreateSchema:       `{"type":"object","properties":{"instance_name":{"type":"string"}}}`,
+			},
+			{
+				AvailabilityLevel:  "GA",
+				EnvironmentType:    "kyma",
+				LandscapeLabel:     "kyma",
+				PlanName:           "azure",
+				PlanUpdatable:      true,
+				ServiceDisplayName: "Kyma Runtime",
+				ServiceName:        "kymaruntime",
+				TechnicalKey:       "kymaruntime-azure",
+				CreateSchema:       `{"type":"object","properties":{"name":{"type":"string"},"region":{"type":"string"}}}`,
+			},
+		},
+		instances: make(map[string]*EnvironmentInstance),
+	}
+}
+
+// Adds an environment to the ones offered by the provisioning API.
+func (s *Server) AddAvailableEnvironment(env AvailableEnvironment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provisioning.available = append(s.provisioning.available, env)
+}
+
+// Current state of an environment instance.
+func (s *Server) EnvironmentInstance(id string) (EnvironmentInstance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if env, ok := s.provisioning.instances[id]; ok {
+		return *env, true
+	}
+	return EnvironmentInstance{}, false
+}
+
+func (p *provisioningState) removeSubAccount(guid string) {
+	for _, id := range append([]string(nil), p.order...) {
+		if p.instances[id].SubAccountGuid == guid {
+			p.remove(id)
+		}
+	}
+}
+
+func (p *provisioningState) remove(id string) {
+	delete(p.instances, id)
+	p.order = removeString(p.order, id)
+}
+
+func (p *provisioningState) visible(scope string) []*EnvironmentInstance {
+	out := make([]*EnvironmentInstance, 0)
+	for _, id := range p.order {
+		if env := p.instances[id]; scope == "" || env.SubAccountGuid == scope {
+			out = append(out, env)
+		}
+	}
+	return out
+}
+
+func (p *provisioningState) find(scope, id string) (*EnvironmentInstance, bool) {
+	env, ok := p.instances[id]
+	if !ok || (scope != "" && env.SubAccountGuid != scope) {
+		return nil, false
+	}
+	return env, true
+}
+
+func (p *provisioningState) offering(serviceName, planName, environmentType string) (AvailableEnvironment, bool) {
+	for _, env := range p.available {
+		if env.ServiceName == serviceName && env.PlanName == planName &&
+			(environmentType == "" || env.EnvironmentType == environmentType) {
+			return env, true
+		}
+	}
+	return AvailableEnvironment{}, false
+}
+
+type environmentBody struct {
+	Description     string                 `json:"description"`
+	EnvironmentType string                 `json:"environmentType"`
+	LandscapeLabel  string                 `json:"landscapeLabel"`
+	Name            string                 `json:"name"`
+	Origin          string                 `json:"origin"`
+	Parameters      map[string]interface{} `json:"parameters"`
+	PlanName        string                 `json:"planName"`
+	ServiceName     string                 `json:"serviceName"`
+	TechnicalKey    string                 `json:"technicalKey"`
+	User            string                 `json:"user"`
+}
+
+func (s *Server) registerProvisioning() {
+	p := s.provisioning
+
+	s.handle(http.MethodGet, "/provisioning/v1/availableEnvironments", func(c *call) {
+		writeJSON(c, http.StatusOK, map[string]interface{}{"availableEnvironments": p.available})
+	})
+
+	s.handle(http.MethodGet, "/provisioning/v1/servicePlanAssignments", func(c *call) {
+		quotas := make([]map[string]interface{}, 0)
+		for _, key := range s.entitlements.order {
+			plan := s.entitlements.plans[key]
+			for _, id := range plan.order {
+				if _, ok := s.accounts.subaccounts[id]; !ok || (c.scope != "" && c.scope != id) {
+					continue
+				}
+				a := plan.assignments[id]
+				quotas = append(quotas, map[string]interface{}{
+					"globalAccountGUID": s.accounts.global.Guid,
+					"subaccountGUID":    id,
+					"service":           plan.Service,
+					"plan":              plan.Plan,
+					"serviceCategory":   plan.Category,
+					"quota":             int32(a.amount),
+					"unlimited":         plan.Unlimited || a.enabled,
+				})
+			}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"quotas": quotas})
+	})
+
+	s.handle(http.MethodGet, "/provisioning/v1/environments", func(c *call) {
+		out := make([]EnvironmentInstance, 0)
+		for _, env := range p.visible(c.scope) {
+			s.pollJob(env.Id)
+			if _, ok := p.instances[env.Id]; ok {
+				out = append(out, *env)
+			}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"environmentInstances": out})
+	})
+
+	s.handle(http.MethodPost, "/provisioning/v1/environments", func(c *call) {
+		var body environmentBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.Name == "" {
+			writeError(c, http.StatusBadRequest, "name must not be empty")
+			return
+		}
+		offering, ok := p.offering(body.ServiceName, body.PlanName, body.EnvironmentType)
+		if !ok {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("environment '%s' with plan '%s' is not available", body.ServiceName, body.PlanName))
+			return
+		}
+		for _, env := range p.visible(c.scope) {
+			if env.EnvironmentType == offering.EnvironmentType && env.State != "DELETING" {
+				writeError(c, http.StatusConflict, fmt.Sprintf("an environment of type '%s' already exists", env.EnvironmentType))
+				return
+			}
+		}
+		parameters, _ := json.Marshal(body.Parameters)
+		if body.Parameters == nil {
+			parameters = []byte("{}")
+		}
+
+		now := s.nowMillis()
+		env := &EnvironmentInstance{
+			BrokerId:          "broker-" + offering.ServiceName,
+			CommercialType:    offering.PlanName,
+			CreatedDate:       now,
+			Description:       body.Description,
+			EnvironmentType:   offering.EnvironmentType,
+			GlobalAccountGuid: s.accounts.global.Guid,
+			Id:                s.newGuid(),
+			LandscapeLabel:    offering.LandscapeLabel,
+			ModifiedDate:      now,
+			Name:              body.Name,
+			Operation:         "provision",
+			Parameters:        string(parameters),
+			PlanName:          offering.PlanName,
+			ServiceName:       offering.ServiceName,
+			State:             "CREATING",
+			SubAccountGuid:    c.scope,
+			TenantId:          c.scope,
+			Type:              "Provision",
+		}
+		if body.LandscapeLabel != "" {
+			env.LandscapeLabel = body.LandscapeLabel
+		}
+		p.instances[env.Id] = env
+		p.order = append(p.order, env.Id)
+
+		s.startJob(env.Id, "Provision environment", func(failed bool) {
+			env.ModifiedDate = s.nowMillis()
+			if failed {
+				env.State, env.StateMessage = "CREATION_FAILED", "Environment provisioning failed."
+				return
+			}
+			env.State, env.StateMessage = "OK", "Environment provisioned."
+			labels, _ := json.Marshal(map[string]string{
+				"Org Name":     env.Name,
+				"API Endpoint": fmt.Sprintf("%s/%s", s.URL, env.EnvironmentType),
+			})
+			env.Labels = string(labels)
+			env.DashboardUrl = fmt.Sprintf("%s/dashboard/%s", s.URL, env.Id)
+		})
+		s.emit("EnvironmentInstance_Creation", "EnvironmentInstance", env.Id, map[string]interface{}{"environmentType": env.EnvironmentType})
+		writeJSON(c, http.StatusAccepted, map[string]interface{}{"id": env.Id})
+	})
+
+	s.handle(http.MethodDelete, "/provisioning/v1/environments", func(c *call) {
+		out := make([]EnvironmentInstance, 0)
+		for _, env := range p.visible(c.scope) {
+			s.deleteEnvironment(env)
+			out = append(out, *env)
+		}
+		writeJSON(c, http.StatusAccepted, map[string]interface{}{"environmentInstances": out})
+	})
+
+	s.handle(http.MethodGet, "/provisioning/v1/environments/{environmentInstanceId}", func(c *call) {
+		id := c.param("environmentInstanceId")
+		s.pollJob(id)
+		env, ok := p.find(c.scope, id)
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("environment instance '%s' not found", id))
+			return
+		}
+		writeJSON(c, http.StatusOK, env)
+	})
+
+	s.handle(http.MethodDelete, "/provisioning/v1/environments/{environmentInstanceId}", func(c *call) {
+		env, ok := p.find(c.scope, c.param("environmentInstanceId"))
+		if !ok {
+			writeError(c, http.StatusNotFound, "environment instance not found")
+			return
+		}
+		s.deleteEnvironment(env)
+		writeJSON(c, http.StatusAccepted, env)
+	})
+
+	s.handle(http.MethodPatch, "/provisioning/v1/environments/{environmentInstanceId}", func(c *call) {
+		env, ok := p.find(c.scope, c.param("environmentInstanceId"))
+		if !ok {
+			writeError(c, http.StatusNotFound, "environment instance not found")
+			return
+		}
+		var body environmentBody
+		if !c.decode(&body) {
+			return
+		}
+		if body.PlanName != "" && body.PlanName != env.PlanName {
+			offering, ok := p.offering(env.ServiceName, body.PlanName, env.EnvironmentType)
+			if !ok || !offering.PlanUpdatable {
+				writeError(c, http.StatusBadRequest, fmt.Sprintf("plan of environment '%s' cannot be changed to '%s'", env.Id, body.PlanName))
+				return
+			}
+			env.PlanName = body.PlanName
+		}
+		if body.Parameters != nil {
+			parameters, _ := json.Marshal(body.Parameters)
+			env.Parameters = string(parameters)
+		}
+		env.State, env.Operation, env.Type = "UPDATING", "update", "Update"
+		env.ModifiedDate = s.nowMillis()
+		s.startJob(env.Id, "Update environment", func(failed bool) {
+			if failed {
+				env.State, env.StateMessage = "UPDATE_FAILED", "Environment update failed."
+				return
+			}
+			env.State, env.StateMessage = "OK", "Environment updated."
+		})
+		s.emit("EnvironmentInstance_Update", "EnvironmentInstance", env.Id, nil)
+		writeJSON(c, http.StatusAccepted, env)
+	})
+}
+
+func (s *Server) deleteEnvironment(env *EnvironmentInstance) {
+	env.State, env.Operation, env.Type = "DELETING", "deprovision", "Deprovision"
+	env.ModifiedDate = s.nowMillis()
+	id := env.Id
+	s.startJob(id, "Deprovision environment", func(failed bool) {
+		if failed {
+			env.State, env.StateMessage = "DELETION_FAILED", "Environment deprovisioning failed."
+			return
+		}
+		s.provisioning.remove(id)
+	})
+	s.emit("EnvironmentInstance_Deletion", "EnvironmentInstance", id, nil)
+}
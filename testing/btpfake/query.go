@@ -0,0 +1,209 @@
+package btpfake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Single criterion of a Service Manager fieldQuery or labelQuery, e.g. "name eq 'x'".
+type criterion struct {
+	left     string
+	operator string
+	values   []string
+}
+
+// Parses a Service Manager query; criteria are joined with "and".
+func parseQuery(query string) ([]criterion, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []criterion
+	for i := 0; i < len(tokens); {
+		if len(out) > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, fmt.Errorf("expected 'and' but found '%s'", tokens[i])
+			}
+			i++
+		}
+		if i+2 >= len(tokens) {
+			return nil, fmt.Errorf("incomplete criterion at '%s'", strings.Join(tokens[i:], " "))
+		}
+		c := criterion{left: tokens[i], operator: strings.ToLower(tokens[i+1])}
+		i += 2
+
+		switch c.operator {
+		case "in", "notin":
+			if tokens[i] != "(" {
+				return nil, fmt.Errorf("operator '%s' expects a list of values", c.operator)
+			}
+			i++
+			for ; i < len(tokens) && tokens[i] != ")"; i++ {
+				if tokens[i] == "," {
+					continue
+				}
+				c.values = append(c.values, unquote(tokens[i]))
+			}
+			if i >= len(tokens) {
+				return nil, fmt.Errorf("unterminated list of values")
+			}
+			i++
+		case "eq", "ne", "gt", "lt", "ge", "le", "en":
+			if tokens[i] == "(" {
+				return nil, fmt.Errorf("operator '%s' expects a single value", c.operator)
+			}
+			c.values = []string{unquote(tokens[i])}
+			i++
+		default:
+			return nil, fmt.Errorf("unsupported operator '%s'", c.operator)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// Splits a query into words, quoted literals (kept quoted), parentheses and commas.
+func tokenizeQuery(query string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case ch == '(' || ch == ')' || ch == ',':
+			tokens = append(tokens, string(ch))
+			i++
+		case ch == '\'':
+			j := i + 1
+			for {
+				if j >= len(query) {
+					return nil, fmt.Errorf("unterminated literal in query")
+				}
+				if query[j] == '\'' {
+					if j+1 < len(query) && query[j+1] == '\'' {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			tokens = append(tokens, query[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(query) && !strings.ContainsRune(" \t\n(),'", rune(query[j])) {
+				j++
+			}
+			tokens = append(tokens, query[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func unquote(token string) string {
+	if len(token) >= 2 && token[0] == '\'' && token[len(token)-1] == '\'' {
+		return strings.Replace(token[1:len(token)-1], "''", "'", -1)
+	}
+	return token
+}
+
+// Evaluates field criteria against the JSON representation of a resource.
+func matchFields(criteria []criterion, item map[string]interface{}) bool {
+	for _, c := range criteria {
+		v, present := item[c.left]
+		if v == nil {
+			present = false
+		}
+		value := ""
+		if present {
+			value = fmt.Sprint(v)
+		}
+		if !matchValue(c, value, present) {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluates label criteria; a label matches when any of its values matches.
+func matchLabels(criteria []criterion, labels map[string][]string) bool {
+	for _, c := range criteria {
+		values, present := labels[c.left]
+		switch c.operator {
+		case "ne", "notin":
+			for _, v := range values {
+				if !matchValue(c, v, true) {
+					return false
+				}
+			}
+		case "en":
+			if !present {
+				continue
+			}
+			fallthrough
+		default:
+			matched := false
+			for _, v := range values {
+				if matchValue(c, v, true) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchValue(c criterion, value string, present bool) bool {
+	switch c.operator {
+	case "eq":
+		return present && value == c.values[0]
+	case "en":
+		return !present || value == c.values[0]
+	case "ne":
+		return !present || value != c.values[0]
+	case "in":
+		return present && hasString(c.values, value)
+	case "notin":
+		return !present || !hasString(c.values, value)
+	case "gt", "lt", "ge", "le":
+		if !present {
+			return false
+		}
+		cmp := compareValues(value, c.values[0])
+		switch c.operator {
+		case "gt":
+			return cmp > 0
+		case "lt":
+			return cmp < 0
+		case "ge":
+			return cmp >= 0
+		default:
+			return cmp <= 0
+		}
+	}
+	return false
+}
+
+// Compares numerically when both values are numbers, lexicographically otherwise (ISO-8601 dates included).
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
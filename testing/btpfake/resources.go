@@ -0,0 +1,235 @@
+package btpfake
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Monthly cost of a subaccount, seeded with AddCost.
+type CostRecord struct {
+	Cost            float64 `json:"cost"`
+	CrmSku          string  `json:"crmSku,omitempty"`
+	Currency        string  `json:"currency"`
+	DataCenter      string  `json:"dataCenter,omitempty"`
+	DataCenterName  string  `json:"dataCenterName,omitempty"`
+	DirectoryId     string  `json:"directoryId,omitempty"`
+	DirectoryName   string  `json:"directoryName,omitempty"`
+	Estimated       bool    `json:"estimated"`
+	MeasureId       string  `json:"measureId,omitempty"`
+	MetricName      string  `json:"metricName,omitempty"`
+	Plan            string  `json:"plan,omitempty"`
+	PlanName        string  `json:"planName,omitempty"`
+	ReportYearMonth int32   `json:"reportYearMonth"`
+	ServiceId       string  `json:"serviceId,omitempty"`
+	ServiceName     string  `json:"serviceName,omitempty"`
+	SubAccountId    string  `json:"subaccountId,omitempty"`
+	SubAccountName  string  `json:"subaccountName,omitempty"`
+	UnitPlural      string  `json:"unitPlural,omitempty"`
+	UnitSingular    string  `json:"unitSingular,omitempty"`
+	Usage           float64 `json:"usage,omitempty"`
+
+	GlobalAccountId   string `json:"globalAccountId"`
+	GlobalAccountName string `json:"globalAccountName"`
+}
+
+// Monthly usage of a global account, seeded with AddUsage.
+type UsageRecord struct {
+	DataCenter              string  `json:"dataCenter,omitempty"`
+	DataCenterName          string  `json:"dataCenterName,omitempty"`
+	DirectoryId             string  `json:"directoryId,omitempty"`
+	DirectoryName           string  `json:"directoryName,omitempty"`
+	EnvironmentInstanceId   string  `json:"environmentInstanceId,omitempty"`
+	EnvironmentInstanceName string  `json:"environmentInstanceName,omitempty"`
+	IdentityZone            string  `json:"identityZone,omitempty"`
+	InstanceId              string  `json:"instanceId,omitempty"`
+	MeasureId               string  `json:"measureId,omitempty"`
+	MetricName              string  `json:"metricName,omitempty"`
+	Plan                    string  `json:"plan,omitempty"`
+	PlanName                string  `json:"planName,omitempty"`
+	ReportYearMonth         int32   `json:"reportYearMonth"`
+	ServiceId               string  `json:"serviceId,omitempty"`
+	ServiceName             string  `json:"serviceName,omitempty"`
+	SpaceId                 string  `json:"spaceId,omitempty"`
+	SpaceName               string  `json:"spaceName,omitempty"`
+	SubAccountId            string  `json:"subaccountId,omitempty"`
+	SubAccountName          string  `json:"subaccountName,omitempty"`
+	UnitPlural              string  `json:"unitPlural,omitempty"`
+	UnitSingular            string  `json:"unitSingular,omitempty"`
+	Usage                   float64 `json:"usage"`
+
+	// Day within the reported month, used by the subaccount usage report; defaults to the first.
+	Day int `json:"-"`
+
+	GlobalAccountId   string `json:"globalAccountId"`
+	GlobalAccountName string `json:"globalAccountName"`
+}
+
+// Cloud credits phase of a contract, seeded with SetCloudCredits.
+type CloudCreditsPhase struct {
+	StartDate string               `json:"phaseStartDate"`
+	EndDate   string               `json:"phaseEndDate"`
+	Updates   []CloudCreditsUpdate `json:"phaseUpdates"`
+}
+
+type CloudCreditsUpdate struct {
+	Balance              float64 `json:"balance"`
+	CloudCreditsForPhase float64 `json:"cloudCreditsForPhase"`
+	UpdatedOn            string  `json:"phaseUpdatedOn"`
+}
+
+type CloudCreditsContract struct {
+	ContractStartDate string              `json:"contractStartDate"`
+	ContractEndDate   string              `json:"contractEndDate"`
+	Currency          string              `json:"currency"`
+	Phases            []CloudCreditsPhase `json:"phases"`
+}
+
+type resourcesState struct {
+	contracts []CloudCreditsContract
+	costs     []CostRecord
+	usage     []UsageRecord
+}
+
+func newResourcesState() *resourcesState {
+	return &resourcesState{}
+}
+
+// Replaces the cloud credit contracts of the global account.
+func (s *Server) SetCloudCredits(contracts ...CloudCreditsContract) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources.contracts = contracts
+}
+
+// Adds monthly cost records of subaccounts.
+func (s *Server) AddCost(records ...CostRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		r.GlobalAccountId, r.GlobalAccountName = s.accounts.global.Guid, s.accounts.global.DisplayName
+		if r.Currency == "" {
+			r.Currency = "EUR"
+		}
+		s.resources.costs = append(s.resources.costs, r)
+	}
+}
+
+// Adds monthly usage records; they also feed the subaccount usage report.
+func (s *Server) AddUsage(records ...UsageRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		r.GlobalAccountId, r.GlobalAccountName = s.accounts.global.Guid, s.accounts.global.DisplayName
+		if r.Day <= 0 {
+			r.Day = 1
+		}
+		s.resources.usage = append(s.resources.usage, r)
+	}
+}
+
+// Inclusive range filter over numeric dates; a missing bound is unbounded.
+type dateRange struct {
+	from, to int64
+}
+
+func parseDateRange(c *call) dateRange {
+	q := c.r.URL.Query()
+	rng := dateRange{from: -1, to: -1}
+	if v, err := strconv.ParseInt(q.Get("fromDate"), 10, 64); err == nil {
+		rng.from = v
+	}
+	if v, err := strconv.ParseInt(q.Get("toDate"), 10, 64); err == nil {
+		rng.to = v
+	}
+	return rng
+}
+
+func (r dateRange) contains(v int64) bool {
+	return (r.from < 0 || v >= r.from) && (r.to < 0 || v <= r.to)
+}
+
+func (s *Server) registerResources() {
+	res := s.resources
+
+	s.handle(http.MethodGet, "/reports/v1/cloudCreditsDetails", func(c *call) {
+		contracts := res.contracts
+		if c.query("viewPhases") != "ALL" {
+			// only the last phase of every contract is the current one
+			current := make([]CloudCreditsContract, 0, len(contracts))
+			for _, contract := range contracts {
+				if n := len(contract.Phases); n > 1 {
+					contract.Phases = contract.Phases[n-1:]
+				}
+				current = append(current, contract)
+			}
+			contracts = current
+		}
+		if contracts == nil {
+			contracts = []CloudCreditsContract{}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{
+			"contracts":         contracts,
+			"globalAccountId":   s.accounts.global.Guid,
+			"globalAccountName": s.accounts.global.DisplayName,
+		})
+	})
+
+	s.handle(http.MethodGet, "/reports/v1/monthlySubaccountsCost", func(c *call) {
+		rng := parseDateRange(c)
+		out := make([]CostRecord, 0)
+		for _, r := range res.costs {
+			if rng.contains(int64(r.ReportYearMonth)) {
+				out = append(out, r)
+			}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"content": out})
+	})
+
+	s.handle(http.MethodGet, "/reports/v1/monthlyUsage", func(c *call) {
+		rng := parseDateRange(c)
+		out := make([]UsageRecord, 0)
+		for _, r := range res.usage {
+			if rng.contains(int64(r.ReportYearMonth)) {
+				out = append(out, r)
+			}
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"content": out})
+	})
+
+	s.handle(http.MethodGet, "/reports/v1/subaccountUsage", func(c *call) {
+		subaccount := c.query("subaccountId")
+		if subaccount == "" {
+			writeError(c, http.StatusBadRequest, "subaccountId is required")
+			return
+		}
+		rng := parseDateRange(c)
+		out := make([]map[string]interface{}, 0)
+		for _, r := range res.usage {
+			day := int64(r.ReportYearMonth)*100 + int64(r.Day)
+			if r.SubAccountId != subaccount || !rng.contains(day) {
+				continue
+			}
+			out = append(out, map[string]interface{}{
+				"dataCenter":      r.DataCenter,
+				"dataCenterName":  r.DataCenterName,
+				"directoryId":     r.DirectoryId,
+				"directoryName":   r.DirectoryName,
+				"globalAccountId": r.GlobalAccountId,
+				"measureId":       r.MeasureId,
+				"metricName":      r.MetricName,
+				"periodStartDate": day,
+				"periodEndDate":   day,
+				"plan":            r.Plan,
+				"planName":        r.PlanName,
+				"serviceId":       r.ServiceId,
+				"serviceName":     r.ServiceName,
+				"subaccountId":    r.SubAccountId,
+				"subaccountName":  r.SubAccountName,
+				"unitPlural":      r.UnitPlural,
+				"unitSingular":    r.UnitSingular,
+				"usage":           r.Usage,
+			})
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"content": out})
+	})
+}
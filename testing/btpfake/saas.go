@@ -0,0 +1,429 @@
+package btpfake
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Multitenant application offered to subscribers, seeded with AddApplication.
+type Application struct {
+	AppId               string `json:"appId"`
+	AppName             string `json:"appName"`
+	Category            string `json:"category,omitempty"`
+	CategoryDisplayName string `json:"categoryDisplayName,omitempty"`
+	CommercialAppName   string `json:"commercialAppName,omitempty"`
+	Description         string `json:"description,omitempty"`
+	DisplayName         string `json:"displayName,omitempty"`
+	PlanName            string `json:"planName,omitempty"`
+	Quota               int32  `json:"quota,omitempty"`
+	ShortDescription    string `json:"shortDescription,omitempty"`
+}
+
+// Multitenant application registered by the provider, seeded with RegisterApplication.
+type ApplicationRegistration struct {
+	ServiceInstanceId string `json:"serviceInstanceId,omitempty"`
+	OrganizationGuid  string `json:"organizationGuid,omitempty"`
+	SpaceGuid         string `json:"spaceGuid,omitempty"`
+	XSAppName         string `json:"xsappname,omitempty"`
+	AppId             string `json:"appId,omitempty"`
+	AppName           string `json:"appName"`
+	CommercialAppName string `json:"commercialAppName,omitempty"`
+	AppUrls           string `json:"appUrls,omitempty"`
+	ProviderTenantId  string `json:"providerTenantId,omitempty"`
+	AppType           string `json:"appType,omitempty"`
+	DisplayName       string `json:"displayName,omitempty"`
+	Description       string `json:"description,omitempty"`
+	Category          string `json:"category,omitempty"`
+	GlobalAccountId   string `json:"globalAccountId,omitempty"`
+}
+
+type subscription struct {
+	appName     string
+	planName    string
+	state       string
+	subaccount  string
+	subdomain   string
+	url         string
+	err         string
+	createdOn   string
+	changedOn   string
+	createdDate int64
+
+	// Job of the last subscribe or unsubscribe request.
+	job string
+}
+
+type saasState struct {
+	registration *ApplicationRegistration
+
+	applications []*Application
+
+	// Subscriptions keyed by subaccount GUID and application name.
+	subscriptions map[string]*subscription
+	order         []string
+}
+
+func newSaaSState() *saasState {
+	return &saasState{
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+func subscriptionKey(subaccount, appName string) string {
+	return subaccount + "/" + appName
+}
+
+// Adds a multitenant application subaccounts can subscribe to.
+func (s *Server) AddApplication(app Application) Application {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if app.AppId == "" {
+		app.AppId = app.AppName + "!t" + fmt.Sprint(s.nextID())
+	}
+	if app.DisplayName == "" {
+		app.DisplayName = app.AppName
+	}
+	if app.CommercialAppName == "" {
+		app.CommercialAppName = app.AppName
+	}
+	if app.PlanName == "" {
+		app.PlanName = "default"
+	}
+	s.saas.applications = append(s.saas.applications, &app)
+	return app
+}
+
+// Registers the multitenant application served by the provider side of the SaaS Provisioning API.
+func (s *Server) RegisterApplication(reg ApplicationRegistration) ApplicationRegistration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reg.ServiceInstanceId == "" {
+		reg.ServiceInstanceId = s.newGuid()
+	}
+	if reg.XSAppName == "" {
+		reg.XSAppName = reg.AppName
+	}
+	if reg.AppId == "" {
+		reg.AppId = reg.XSAppName + "!t" + fmt.Sprint(s.nextID())
+	}
+	if reg.AppType == "" {
+		reg.AppType = "saasApplication"
+	}
+	reg.GlobalAccountId = s.accounts.global.Guid
+	s.saas.registration = &reg
+	return reg
+}
+
+// Subscription state of a subaccount to an application; NOT_SUBSCRIBED when there is none.
+func (s *Server) SubscriptionState(subaccountGuid, appName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.saas.subscriptions[subscriptionKey(subaccountGuid, appName)]; ok {
+		return sub.state
+	}
+	return "NOT_SUBSCRIBED"
+}
+
+func (st *saasState) application(appName, planName string) (*Application, bool) {
+	for _, app := range st.applications {
+		if app.AppName == appName && (planName == "" || app.PlanName == planName) {
+			return app, true
+		}
+	}
+	return nil, false
+}
+
+func (st *saasState) add(sub *subscription) {
+	key := subscriptionKey(sub.subaccount, sub.appName)
+	if _, ok := st.subscriptions[key]; !ok {
+		st.order = append(st.order, key)
+	}
+	st.subscriptions[key] = sub
+}
+
+func (st *saasState) remove(subaccount, appName string) {
+	key := subscriptionKey(subaccount, appName)
+	delete(st.subscriptions, key)
+	st.order = removeString(st.order, key)
+}
+
+func (st *saasState) removeSubAccount(guid string) {
+	for _, key := range append([]string(nil), st.order...) {
+		if sub := st.subscriptions[key]; sub.subaccount == guid {
+			st.remove(sub.subaccount, sub.appName)
+		}
+	}
+}
+
+func (s *Server) applicationView(app *Application, subaccount string) map[string]interface{} {
+	out := map[string]interface{}{
+		"appId":               app.AppId,
+		"appName":             app.AppName,
+		"category":            app.Category,
+		"categoryDisplayName": app.CategoryDisplayName,
+		"commercialAppName":   app.CommercialAppName,
+		"description":         app.Description,
+		"displayName":         app.DisplayName,
+		"globalAccountId":     s.accounts.global.Guid,
+		"planName":            app.PlanName,
+		"quota":               app.Quota,
+		"shortDescription":    app.ShortDescription,
+		"state":               "NOT_SUBSCRIBED",
+	}
+	if sub, ok := s.saas.subscriptions[subscriptionKey(subaccount, app.AppName)]; ok && sub.job != "" {
+		// reading the subscription advances its job; it may remove the subscription
+		s.pollJob(sub.job)
+	}
+	if sub, ok := s.saas.subscriptions[subscriptionKey(subaccount, app.AppName)]; ok {
+		out["state"] = sub.state
+		out["planName"] = sub.planName
+		out["subscribedSubaccountId"] = sub.subaccount
+		out["subscribedTenantId"] = sub.subaccount
+		out["subscriptionUrl"] = sub.url
+		out["createdDate"] = sub.createdDate
+		if sub.err != "" {
+			out["subscriptionError"] = map[string]interface{}{"errorMessage": sub.err}
+		}
+	}
+	return out
+}
+
+func (s *Server) subscriptionView(sub *subscription) map[string]interface{} {
+	out := map[string]interface{}{
+		"appName":                sub.appName,
+		"consumerTenantId":       sub.subaccount,
+		"subaccountId":           sub.subaccount,
+		"globalAccountId":        s.accounts.global.Guid,
+		"subdomain":              sub.subdomain,
+		"state":                  sub.state,
+		"url":                    sub.url,
+		"createdOn":              sub.createdOn,
+		"changedOn":              sub.changedOn,
+		"isConsumerTenantActive": sub.state == "SUBSCRIBED",
+		"licenseType":            "PRODUCTIVE",
+	}
+	if sub.err != "" {
+		out["error"] = sub.err
+	}
+	return out
+}
+
+// Starts an asynchronous subscription; the returned job ID is also the subscription job.
+func (s *Server) subscribe(subaccount, appName, planName string) string {
+	subdomain := ""
+	if sa, ok := s.accounts.subaccounts[subaccount]; ok {
+		subdomain = sa.Subdomain
+	}
+	sub := &subscription{
+		appName:     appName,
+		planName:    planName,
+		state:       "IN_PROCESS",
+		subaccount:  subaccount,
+		subdomain:   subdomain,
+		createdOn:   s.nowISO(),
+		changedOn:   s.nowISO(),
+		createdDate: s.nowMillis(),
+	}
+	s.saas.add(sub)
+
+	jobId := s.newGuid()
+	sub.job = jobId
+	s.startJob(jobId, "Subscribe to application "+appName, func(failed bool) {
+		sub.changedOn = s.nowISO()
+		if failed {
+			sub.state, sub.err = "SUBSCRIBE_FAILED", "subscription callback of the application failed"
+			return
+		}
+		sub.state, sub.err = "SUBSCRIBED", ""
+		sub.url = fmt.Sprintf("https://%s-%s.btpfake.local", subdomain, appName)
+	})
+	s.emit("SubaccountAppSubscription_Creation", "Subaccount", subaccount, map[string]interface{}{"appName": appName, "planName": planName})
+	return jobId
+}
+
+func (s *Server) unsubscribe(sub *subscription) string {
+	sub.state, sub.changedOn = "IN_PROCESS", s.nowISO()
+
+	jobId := s.newGuid()
+	sub.job = jobId
+	s.startJob(jobId, "Unsubscribe from application "+sub.appName, func(failed bool) {
+		sub.changedOn = s.nowISO()
+		if failed {
+			sub.state, sub.err = "UNSUBSCRIBE_FAILED", "unsubscription callback of the application failed"
+			return
+		}
+		s.saas.remove(sub.subaccount, sub.appName)
+	})
+	s.emit("SubaccountAppSubscription_Deletion", "Subaccount", sub.subaccount, map[string]interface{}{"appName": sub.appName})
+	return jobId
+}
+
+func (s *Server) registerSaaS() {
+	st := s.saas
+
+	s.handle(http.MethodGet, "/saas-manager/v1/application", func(c *call) {
+		if st.registration == nil {
+			writeError(c, http.StatusNotFound, "no application is registered")
+			return
+		}
+		writeJSON(c, http.StatusOK, st.registration)
+	})
+
+	s.handle(http.MethodGet, "/saas-manager/v1/application/subscriptions", func(c *call) {
+		if st.registration == nil {
+			writeError(c, http.StatusNotFound, "no application is registered")
+			return
+		}
+		state, subaccount, tenant := c.query("state"), c.query("subaccountId"), c.query("tenantId")
+		values := make([]map[string]interface{}, 0)
+		for _, key := range st.order {
+			sub := st.subscriptions[key]
+			if sub.appName != st.registration.AppName {
+				continue
+			}
+			if (state != "" && sub.state != state) || (subaccount != "" && sub.subaccount != subaccount) ||
+				(tenant != "" && sub.subaccount != tenant) {
+				continue
+			}
+			values = append(values, s.subscriptionView(sub))
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"values": values})
+	})
+
+	s.handle(http.MethodPost, "/saas-manager/v1/application/tenants/{tenantId}/subscriptions", func(c *call) {
+		if st.registration == nil {
+			writeError(c, http.StatusNotFound, "no application is registered")
+			return
+		}
+		tenant := c.param("tenantId")
+		if _, ok := s.accounts.subaccounts[tenant]; !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("tenant '%s' not found", tenant))
+			return
+		}
+		if _, ok := st.subscriptions[subscriptionKey(tenant, st.registration.AppName)]; ok {
+			writeError(c, http.StatusConflict, "tenant is already subscribed")
+			return
+		}
+		jobId := s.subscribe(tenant, st.registration.AppName, "")
+		c.w.Header().Set("Location", "/api/v2.0/jobs/"+jobId)
+		writeText(c, http.StatusAccepted, jobId)
+	})
+
+	s.handle(http.MethodDelete, "/saas-manager/v1/application/tenants/{tenantId}/subscriptions", func(c *call) {
+		if st.registration == nil {
+			writeError(c, http.StatusNotFound, "no application is registered")
+			return
+		}
+		sub, ok := st.subscriptions[subscriptionKey(c.param("tenantId"), st.registration.AppName)]
+		if !ok {
+			writeError(c, http.StatusNotFound, "tenant is not subscribed")
+			return
+		}
+		jobId := s.unsubscribe(sub)
+		c.w.Header().Set("Location", "/api/v2.0/jobs/"+jobId)
+		writeText(c, http.StatusAccepted, jobId)
+	})
+
+	s.handle(http.MethodPatch, "/saas-manager/v1/application/tenants/{tenantId}/subscriptions", func(c *call) {
+		if st.registration == nil {
+			writeError(c, http.StatusNotFound, "no application is registered")
+			return
+		}
+		sub, ok := st.subscriptions[subscriptionKey(c.param("tenantId"), st.registration.AppName)]
+		if !ok || sub.state != "SUBSCRIBED" {
+			writeError(c, http.StatusConflict, "tenant has no active subscription")
+			return
+		}
+		sub.state, sub.changedOn = "IN_PROCESS", s.nowISO()
+		jobId := s.newGuid()
+		s.startJob(jobId, "Update subscription dependencies", func(failed bool) {
+			sub.changedOn = s.nowISO()
+			if failed {
+				sub.state = "UPDATE_FAILED"
+				return
+			}
+			sub.state = "SUBSCRIBED"
+		})
+		c.w.Header().Set("Location", "/api/v2.0/jobs/"+jobId)
+		writeText(c, http.StatusAccepted, jobId)
+	})
+
+	s.handle(http.MethodGet, "/saas-manager/v1/applications", func(c *call) {
+		apps := make([]map[string]interface{}, 0, len(st.applications))
+		for _, app := range st.applications {
+			apps = append(apps, s.applicationView(app, c.scope))
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{"applications": apps})
+	})
+
+	s.handle(http.MethodGet, "/saas-manager/v1/applications/{appName}", func(c *call) {
+		app, ok := st.application(c.param("appName"), c.query("planName"))
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("application '%s' not found", c.param("appName")))
+			return
+		}
+		writeJSON(c, http.StatusOK, s.applicationView(app, c.scope))
+	})
+
+	s.handle(http.MethodPost, "/saas-manager/v1/applications/{appName}/subscription", func(c *call) {
+		var body struct {
+			PlanName string `json:"planName"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		app, ok := st.application(c.param("appName"), body.PlanName)
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("application '%s' with plan '%s' not found", c.param("appName"), body.PlanName))
+			return
+		}
+		if sub, ok := st.subscriptions[subscriptionKey(c.scope, app.AppName)]; ok && sub.state != "SUBSCRIBE_FAILED" {
+			writeError(c, http.StatusConflict, fmt.Sprintf("already subscribed to application '%s'", app.AppName))
+			return
+		}
+		jobId := s.subscribe(c.scope, app.AppName, app.PlanName)
+		c.w.Header().Set("Location", "/api/v2.0/jobs/"+jobId)
+		writeJSON(c, http.StatusAccepted, nil)
+	})
+
+	s.handle(http.MethodDelete, "/saas-manager/v1/applications/{appName}/subscription", func(c *call) {
+		sub, ok := st.subscriptions[subscriptionKey(c.scope, c.param("appName"))]
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("not subscribed to application '%s'", c.param("appName")))
+			return
+		}
+		jobId := s.unsubscribe(sub)
+		c.w.Header().Set("Location", "/api/v2.0/jobs/"+jobId)
+		writeJSON(c, http.StatusAccepted, nil)
+	})
+
+	s.handle(http.MethodPut, "/saas-manager/v1/applications/{appName}/subscription", func(c *call) {
+		var body struct {
+			Message         string `json:"message"`
+			Status          string `json:"status"`
+			SubscriptionUrl string `json:"subscriptionUrl"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		sub, ok := st.subscriptions[subscriptionKey(c.scope, c.param("appName"))]
+		if !ok {
+			writeError(c, http.StatusNotFound, "subscription not found")
+			return
+		}
+		switch body.Status {
+		case "SUCCEEDED":
+			sub.state, sub.err = "SUBSCRIBED", ""
+			if body.SubscriptionUrl != "" {
+				sub.url = body.SubscriptionUrl
+			}
+		case "FAILED":
+			sub.state, sub.err = "SUBSCRIBE_FAILED", body.Message
+		default:
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("invalid status '%s'", body.Status))
+			return
+		}
+		sub.changedOn = s.nowISO()
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+}
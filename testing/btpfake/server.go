@@ -0,0 +1,473 @@
+// Package btpfake provides an in-process fake of the SAP BTP APIs covered by this SDK.
+//
+// The fake is built on top of httptest and keeps all state in memory. It covers the Accounts, Entitlements,
+// Provisioning, SaaS Manager, Events, Resource Consumption and Service Manager APIs, plus an XSUAA-like token
+// endpoint, so that code written against the SDK can be exercised end-to-end without a real global account:
+//
+//	srv := btpfake.NewServer()
+//	defer srv.Close()
+//
+//	sess, _ := srv.Session()
+//	accounts := btpaccounts.New(sess)
+//
+// Asynchronous operations (subaccount creation, entitlement updates, environment provisioning, subscriptions,
+// Service Manager operations) follow a stateful lifecycle: they start in progress and complete once they were
+// polled Options.AsyncSteps times, either through the job status endpoints or by reading the resource itself.
+package btpfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/oauth2"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+)
+
+// Endpoint IDs served by the fake; matching the EndpointsID of every service package.
+var EndpointIDs = []string{
+	"accounts",
+	"entitlements",
+	"events",
+	"provisioning",
+	"resources",
+	"saas-manager",
+	"service-manager",
+}
+
+const tokenPath = "/oauth/token"
+
+type Options struct {
+	// Client credentials accepted by the token endpoint.
+	ClientID     string
+	ClientSecret string
+
+	// Number of polls an asynchronous job needs before reaching its final state.
+	// Zero completes every job synchronously.
+	AsyncSteps int
+
+	// Attributes of the global account the fake is seeded with.
+	GlobalAccountGuid      string
+	GlobalAccountSubdomain string
+	GlobalAccountName      string
+
+	// Default region for created subaccounts.
+	Region string
+
+	// Clock used for every timestamp produced by the fake.
+	Now func() time.Time
+}
+
+// A request received by the fake server, recorded for later assertions.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Fault injected into the fake server; matching requests fail with the given status and body.
+type Fault struct {
+	// HTTP method to match; empty matches every method.
+	Method string
+
+	// Path prefix to match, for example "/accounts/v1/subaccounts".
+	Path string
+
+	// Status code and body returned instead of processing the request.
+	Status int
+	Body   string
+
+	// Number of requests the fault applies to; zero means once.
+	Times int
+
+	// Number of matching requests processed before the fault applies.
+	After int
+}
+
+type Server struct {
+	// Base URL of the fake, usable as host for every endpoint.
+	URL string
+
+	opts Options
+	srv  *httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	routes   []route
+	requests []RecordedRequest
+	faults   []*Fault
+	tokens   map[string]grant
+
+	failJobs int
+	jobs     map[string]*job
+
+	accounts     *accountsState
+	entitlements *entitlementsState
+	provisioning *provisioningState
+	saas         *saasState
+	events       *eventsState
+	resources    *resourcesState
+	sm           *smState
+}
+
+// Start a new fake server; the caller must Close it when done.
+func NewServer(options ...func(*Options)) *Server {
+	opts := Options{
+		ClientID:               "sb-btpfake",
+		ClientSecret:           "btpfake-secret",
+		AsyncSteps:             1,
+		GlobalAccountGuid:      "a0a0a0a0-0000-4000-8000-000000000001",
+		GlobalAccountSubdomain: "fake-global-account",
+		GlobalAccountName:      "Fake Global Account",
+		Region:                 "eu10",
+		Now:                    time.Now,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	s := &Server{
+		opts:   opts,
+		tokens: make(map[string]grant),
+		jobs:   make(map[string]*job),
+	}
+	s.accounts = newAccountsState(s)
+	s.entitlements = newEntitlementsState()
+	s.provisioning = newProvisioningState()
+	s.saas = newSaaSState()
+	s.events = newEventsState()
+	s.resources = newResourcesState()
+	s.sm = newSMState(s)
+
+	s.registerAccounts()
+	s.registerEntitlements()
+	s.registerProvisioning()
+	s.registerSaaS()
+	s.registerEvents()
+	s.registerResources()
+	s.registerServiceManager()
+	s.registerJobs()
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Options the server was started with.
+func (s *Server) Options() Options {
+	return s.opts
+}
+
+// Configuration pointing every SDK endpoint at the fake server, authenticated with client credentials.
+func (s *Server) Config() *sap.Config {
+	endpoints := make(map[string]*sap.EndpointConfig, len(EndpointIDs))
+	for _, id := range EndpointIDs {
+		endpoints[id] = &sap.EndpointConfig{Host: s.URL}
+	}
+	return &sap.Config{
+		Endpoints:     endpoints,
+		DefaultOAuth2: s.OAuth2Config(s.opts.ClientID, s.opts.ClientSecret),
+	}
+}
+
+// OAuth2 configuration for the fake token endpoint using the given client credentials.
+func (s *Server) OAuth2Config(clientID, clientSecret string) *oauth2.Config {
+	return &oauth2.Config{
+		GrantType:    "client_credentials",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     s.URL + tokenPath,
+		Timeout:      30 * time.Second,
+	}
+}
+
+// Configuration of the APIs scoped to the subaccount, provisioning and SaaS Manager, authenticated
+// with the credentials of a service key of the subaccount; they are valid as long as it exists.
+func (s *Server) SubAccountConfig(guid string) *sap.Config {
+	auth := s.OAuth2Config(subAccountClientPrefix+guid, s.subAccountSecret(guid))
+	return &sap.Config{
+		Endpoints: map[string]*sap.EndpointConfig{
+			"provisioning": {Host: s.URL, OAuth2: auth},
+			"saas-manager": {Host: s.URL, OAuth2: auth},
+		},
+		DefaultOAuth2: auth,
+	}
+}
+
+// Runtime session built from Config.
+func (s *Server) Session() (*session.RuntimeSession, error) {
+	return session.BuildFromConfig(s.Config())
+}
+
+// Requests received so far, in arrival order; token requests are not recorded.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]RecordedRequest, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// Inject a fault; matching requests are answered with the fault status instead of being processed.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Times <= 0 {
+		f.Times = 1
+	}
+	s.faults = append(s.faults, &f)
+}
+
+// Make the next n asynchronous jobs end in a failed state.
+func (s *Server) FailNextJobs(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failJobs += n
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  func(*call)
+}
+
+type call struct {
+	w      http.ResponseWriter
+	r      *http.Request
+	params map[string]string
+	body   []byte
+
+	// Subaccount the caller's token is scoped to, if any.
+	scope string
+}
+
+func (c *call) param(name string) string {
+	return c.params[name]
+}
+
+func (c *call) query(name string) string {
+	return c.r.URL.Query().Get(name)
+}
+
+func (c *call) queryBool(name string) bool {
+	return strings.EqualFold(c.query(name), "true")
+}
+
+func (c *call) decode(v interface{}) bool {
+	if len(bytes.TrimSpace(c.body)) == 0 {
+		return true
+	}
+	if err := json.Unmarshal(c.body, v); err != nil {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("invalid request body; %v", err))
+		return false
+	}
+	return true
+}
+
+func (s *Server) handle(method, pattern string, handler func(*call)) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	_ = r.Body.Close()
+
+	if r.URL.Path == tokenPath {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		s.serveToken(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	c := &call{w: w, r: r, body: body}
+
+	g, ok := s.authorize(r)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "missing or invalid bearer token")
+		return
+	}
+	if !g.accepts(r.URL.Path) {
+		writeError(c, http.StatusForbidden, fmt.Sprintf("the token is not valid for %s", r.URL.Path))
+		return
+	}
+	c.scope = g.subaccount
+
+	if f := s.matchFault(r); f != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.Status)
+		_, _ = w.Write([]byte(f.Body))
+		return
+	}
+
+	segments := splitPath(r.URL.Path)
+	methodMismatch := false
+	for _, rt := range s.routes {
+		params, ok := matchSegments(rt.segments, segments)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodMismatch = true
+			continue
+		}
+		c.params = params
+		rt.handler(c)
+		return
+	}
+
+	if methodMismatch {
+		writeError(c, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed on %s", r.Method, r.URL.Path))
+		return
+	}
+	writeError(c, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.After > 0 {
+			f.After--
+			continue
+		}
+		f.Times--
+		if f.Times <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func matchSegments(pattern, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			v, err := url.PathUnescape(segments[i])
+			if err != nil {
+				v = segments[i]
+			}
+			params[p[1:len(p)-1]] = v
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) nextID() int {
+	s.seq++
+	return s.seq
+}
+
+// Deterministic GUID, unique within the server.
+func (s *Server) newGuid() string {
+	n := s.nextID()
+	return fmt.Sprintf("%08x-%04x-4000-8000-%012x", n, n%0xffff, n)
+}
+
+func (s *Server) now() time.Time {
+	return s.opts.Now()
+}
+
+func (s *Server) nowMillis() int64 {
+	return s.now().UnixNano() / int64(time.Millisecond)
+}
+
+func (s *Server) nowISO() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(c *call, status int, v interface{}) {
+	c.w.Header().Set("Content-Type", "application/json")
+	c.w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(c.w).Encode(v)
+	}
+}
+
+func writeText(c *call, status int, text string) {
+	c.w.Header().Set("Content-Type", "text/plain")
+	c.w.WriteHeader(status)
+	_, _ = c.w.Write([]byte(text))
+}
+
+// Writes an error in the format of the API owning the requested path.
+func writeError(c *call, status int, message string) {
+	path := c.r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/v1/"):
+		writeJSON(c, status, map[string]interface{}{
+			"error":       http.StatusText(status),
+			"description": message,
+		})
+	case strings.HasPrefix(path, "/saas-manager/"), strings.HasPrefix(path, "/api/v2.0/"):
+		writeJSON(c, status, map[string]interface{}{
+			"error":             http.StatusText(status),
+			"error_description": message,
+		})
+	default:
+		writeJSON(c, status, map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    status,
+				"message": message,
+				"target":  path,
+			},
+		})
+	}
+}
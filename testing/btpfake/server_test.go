@@ -0,0 +1,268 @@
+package btpfake_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"github.com/nnicora/sap-sdk-go/testing/btpfake"
+)
+
+func newSession(t *testing.T, srv *btpfake.Server) *session.RuntimeSession {
+	sess, err := srv.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func createSubAccount(t *testing.T, svc *btpaccounts.AccountsV1, subdomain string) string {
+	out, err := svc.CreateSubAccount(context.Background(), &btpaccounts.CreateSubAccountInput{
+		DisplayName: subdomain,
+		Region:      "eu10",
+		Subdomain:   subdomain,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.State != "CREATING" {
+		t.Fatalf("expected state CREATING, got %s", out.State)
+	}
+	return out.Guid
+}
+
+func TestSubAccountLifecycle(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+
+	svc := btpaccounts.New(newSession(t, srv))
+	ctx := context.Background()
+
+	guid := createSubAccount(t, svc, "lifecycle")
+	if _, err := svc.CreateSubAccount(ctx, &btpaccounts.CreateSubAccountInput{
+		DisplayName: "duplicate",
+		Region:      "eu10",
+		Subdomain:   "lifecycle",
+	}); err == nil {
+		t.Fatal("expected duplicate subdomain to be rejected")
+	}
+
+	out, err := svc.GetSubAccount(ctx, &btpaccounts.GetSubAccountInput{SubAccountGuid: guid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.State != "OK" {
+		t.Fatalf("expected state OK after polling, got %s", out.State)
+	}
+
+	if _, err := svc.DeleteSubAccount(ctx, &btpaccounts.DeleteSubAccountInput{SubAccountGuid: guid}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetSubAccount(ctx, &btpaccounts.GetSubAccountInput{SubAccountGuid: guid}); err == nil {
+		t.Fatal("expected deleted subaccount to be gone")
+	}
+
+	events := srv.Events()
+	if len(events) != 2 || events[0].EventType != "Subaccount_Creation" || events[1].EventType != "Subaccount_Deletion" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+}
+
+func TestJobFailure(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+
+	svc := btpaccounts.New(newSession(t, srv))
+	srv.FailNextJobs(1)
+	guid := createSubAccount(t, svc, "failing")
+
+	job, err := svc.GetJobStatus(context.Background(), &btpaccounts.GetJobStatusInput{JobId: guid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != "FAILED" {
+		t.Fatalf("expected job to fail, got %s", job.Status)
+	}
+	if sa, _ := srv.SubAccount(guid); sa.State != "CREATION_FAILED" {
+		t.Fatalf("expected state CREATION_FAILED, got %s", sa.State)
+	}
+}
+
+func TestEntitlementQuota(t *testing.T) {
+	srv := btpfake.NewServer(func(o *btpfake.Options) { o.AsyncSteps = 0 })
+	defer srv.Close()
+	srv.AddEntitlement(btpfake.Entitlement{Service: "hana-cloud", Plan: "hana", Amount: 3, MaxAllowedSubaccountQuota: 2})
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "quota", DisplayName: "quota"})
+
+	svc := btpentitlements.New(newSession(t, srv))
+	update := func(amount uint) error {
+		_, err := svc.UpdateSubAccountServicePlan(context.Background(), &btpentitlements.UpdateSubAccountServicePlanInput{
+			SubAccountServicePlans: []btpentitlements.SubAccountServicePlan{{
+				ServiceName:     "hana-cloud",
+				ServicePlanName: "hana",
+				AssignmentInfo:  []btpentitlements.AssignmentInfo{{Amount: &amount, SubAccountGuid: sa.Guid}},
+			}},
+		})
+		return err
+	}
+
+	if err := update(3); err == nil {
+		t.Fatal("expected amount above maxAllowedSubaccountQuota to be rejected")
+	}
+	if err := update(2); err != nil {
+		t.Fatal(err)
+	}
+	if amount, ok := srv.Assignment(sa.Guid, "hana-cloud", "hana"); !ok || amount != 2 {
+		t.Fatalf("expected amount 2, got %v", amount)
+	}
+
+	out, err := svc.GetGlobalAccountAssignments(context.Background(), &btpentitlements.GlobalAccountAssignmentsInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining := out.EntitledServices[0].ServicePlans[0].RemainingAmount; remaining != 1 {
+		t.Fatalf("expected remaining amount 1, got %v", remaining)
+	}
+}
+
+func TestServiceManagerScopedToSubAccount(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "sm", DisplayName: "sm"})
+
+	accounts := btpaccounts.New(newSession(t, srv))
+	binding, err := accounts.CreateSubAccountServiceManagementBinding(context.Background(),
+		&btpaccounts.CreateServiceManagementBindingInput{SubAccountGuid: sa.Guid})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sess, err := session.BuildFromConfig(&sap.Config{
+		Endpoints: map[string]*sap.EndpointConfig{
+			btpmanagment.EndpointsID: {
+				Host:   binding.SMUrl,
+				OAuth2: srv.OAuth2Config(binding.ClientId, binding.ClientSecret),
+			},
+		},
+		DefaultOAuth2: srv.OAuth2Config(binding.ClientId, binding.ClientSecret),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := btpmanagment.New(sess)
+	ctx := context.Background()
+
+	created, err := sm.CreateServiceInstance(ctx, &btpmanagment.CreateServiceInstanceInput{
+		Name:                "my-xsuaa",
+		ServiceOfferingName: "xsuaa",
+		ServicePlanName:     "application",
+		Labels:              map[string][]string{"team": {"core"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Ready || created.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a ready instance, got %+v", created.InstanceItem)
+	}
+
+	list, err := sm.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{
+		FieldQuery: "name eq 'my-xsuaa'",
+		LabelQuery: "team in ('core', 'platform')",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.NumItems != 1 || list.Items[0].Id != created.Id {
+		t.Fatalf("expected the created instance to match, got %+v", list.Items)
+	}
+
+	// the global account client does not see subaccount resources
+	global := btpmanagment.New(newSession(t, srv))
+	all, err := global.GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.NumItems != 0 {
+		t.Fatalf("expected no instances outside of the subaccount, got %d", all.NumItems)
+	}
+}
+
+func TestCredentialScopes(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	sa := srv.AddSubAccount(btpfake.SubAccount{Subdomain: "scoped", DisplayName: "scoped"})
+	binding, err := btpaccounts.New(newSession(t, srv)).CreateSubAccountServiceManagementBinding(context.Background(),
+		&btpaccounts.CreateServiceManagementBindingInput{SubAccountGuid: sa.Guid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// the Service Manager binding is only valid for the Service Manager API
+	cfg := srv.Config()
+	cfg.DefaultOAuth2 = srv.OAuth2Config(binding.ClientId, binding.ClientSecret)
+	sess, err := session.BuildFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := btpmanagment.New(sess).GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := btpprovisioning.New(sess).GetEnvironmentInstances(ctx)
+	if err == nil || out.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the binding to be rejected by the provisioning API, got %v", err)
+	}
+
+	// the service key of the subaccount is only valid for the provisioning and SaaS Manager APIs
+	sess, err = session.BuildFromConfig(srv.SubAccountConfig(sa.Guid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := btpprovisioning.New(sess).GetEnvironmentInstances(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := btpsaasmanager.New(sess).GetEntitledApplications(ctx, &btpsaasmanager.GetEntitledApplicationsInput{}); err != nil {
+		t.Fatal(err)
+	}
+	cfg = srv.Config()
+	cfg.DefaultOAuth2 = srv.SubAccountConfig(sa.Guid).DefaultOAuth2
+	if sess, err = session.BuildFromConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	instances, err := btpmanagment.New(sess).GetServiceInstances(ctx, &btpmanagment.GetServiceInstancesInput{})
+	if err == nil || instances.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the service key to be rejected by the Service Manager API, got %v", err)
+	}
+	accounts, err := btpaccounts.New(sess).GetSubAccount(ctx, &btpaccounts.GetSubAccountInput{SubAccountGuid: sa.Guid})
+	if err == nil || accounts.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the service key to be rejected by the accounts API, got %v", err)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	srv := btpfake.NewServer()
+	defer srv.Close()
+	srv.InjectFault(btpfake.Fault{Method: http.MethodGet, Path: "/accounts/v1/globalAccount", Status: http.StatusServiceUnavailable})
+
+	svc := btpaccounts.New(newSession(t, srv))
+	if _, err := svc.GetGlobalAccount(context.Background(), &btpaccounts.GetGlobalAccountInput{}); err == nil {
+		t.Fatal("expected injected fault")
+	}
+	out, err := svc.GetGlobalAccount(context.Background(), &btpaccounts.GetGlobalAccountInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Guid != srv.GlobalAccount().Guid {
+		t.Fatalf("unexpected global account %s", out.Guid)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Fatalf("expected 2 recorded requests, got %d", n)
+	}
+}
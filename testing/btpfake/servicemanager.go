@@ -0,0 +1,1204 @@
+package btpfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	smPlatforms    = "platforms"
+	smBrokers      = "service_brokers"
+	smOfferings    = "service_offerings"
+	smPlans        = "service_plans"
+	smInstances    = "service_instances"
+	smBindings     = "service_bindings"
+	smVisibilities = "visibilities"
+
+	opInProgress = "in progress"
+	opSucceeded  = "succeeded"
+	opFailed     = "failed"
+)
+
+// Service offering seeded with AddServiceOffering, together with its plans.
+type ServiceOffering struct {
+	Name        string
+	Description string
+	Bindable    bool
+
+	// Names of the plans of the offering.
+	Plans []string
+}
+
+// Resource kept by the fake Service Manager, represented as its JSON object.
+type smObject map[string]interface{}
+
+type smOperation struct {
+	id           string
+	opType       string
+	state        string
+	resourceId   string
+	resourceType string
+	description  string
+	createdAt    string
+	updatedAt    string
+}
+
+func (op *smOperation) view() map[string]interface{} {
+	out := map[string]interface{}{
+		"id":            op.id,
+		"ready":         op.state != opInProgress,
+		"type":          op.opType,
+		"state":         op.state,
+		"resource_id":   op.resourceId,
+		"resource_type": "/v1/" + op.resourceType,
+		"description":   op.description,
+		"created_at":    op.createdAt,
+		"updated_at":    op.updatedAt,
+	}
+	if op.state == opFailed {
+		out["errors"] = []map[string]interface{}{{
+			"error":       "BrokerError",
+			"description": op.description,
+		}}
+	}
+	return out
+}
+
+type smCollection struct {
+	items map[string]smObject
+	order []string
+
+	// Subaccount every resource belongs to; global resources have none.
+	owners map[string]string
+
+	parameters map[string]map[string]interface{}
+	operations map[string]*smOperation
+	lastOp     map[string]*smOperation
+
+	// Operations of every resource, the oldest first.
+	history map[string][]*smOperation
+}
+
+func newSMCollection() *smCollection {
+	return &smCollection{
+		items:      make(map[string]smObject),
+		owners:     make(map[string]string),
+		parameters: make(map[string]map[string]interface{}),
+		operations: make(map[string]*smOperation),
+		lastOp:     make(map[string]*smOperation),
+		history:    make(map[string][]*smOperation),
+	}
+}
+
+func (c *smCollection) add(id, owner string, obj smObject) {
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = obj
+	c.owners[id] = owner
+}
+
+func (c *smCollection) remove(id string) {
+	delete(c.items, id)
+	delete(c.owners, id)
+	delete(c.parameters, id)
+	c.order = removeString(c.order, id)
+}
+
+// Resource visible to the given scope; global resources are visible to everyone.
+func (c *smCollection) get(scope, id string) (smObject, bool) {
+	obj, ok := c.items[id]
+	if !ok {
+		return nil, false
+	}
+	if owner := c.owners[id]; owner != "" && owner != scope {
+		return nil, false
+	}
+	return obj, true
+}
+
+type smState struct {
+	collections map[string]*smCollection
+}
+
+func newSMState(s *Server) *smState {
+	st := &smState{collections: make(map[string]*smCollection)}
+	for _, kind := range []string{smPlatforms, smBrokers, smOfferings, smPlans, smInstances, smBindings, smVisibilities} {
+		st.collections[kind] = newSMCollection()
+	}
+
+	now := s.nowISO()
+	st.collections[smBrokers].add("sm-broker", "", smObject{
+		"id":         "sm-broker",
+		"ready":      true,
+		"name":       "btpfake-broker",
+		"broker_url": "https://broker.btpfake.local",
+		"created_at": now,
+		"updated_at": now,
+	})
+	s.addServiceOffering(st, ServiceOffering{Name: "xsuaa", Description: "Authorization and trust management", Bindable: true, Plans: []string{"application", "broker"}})
+	s.addServiceOffering(st, ServiceOffering{Name: "destination", Description: "Destination service", Bindable: true, Plans: []string{"lite"}})
+	return st
+}
+
+// Adds a service offering with its plans to the Service Manager catalog.
+func (s *Server) AddServiceOffering(o ServiceOffering) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addServiceOffering(s.sm, o)
+}
+
+func (s *Server) addServiceOffering(st *smState, o ServiceOffering) {
+	now := s.nowISO()
+	offeringId := s.newGuid()
+	st.collections[smOfferings].add(offeringId, "", smObject{
+		"id":                    offeringId,
+		"ready":                 true,
+		"name":                  o.Name,
+		"description":           o.Description,
+		"bindable":              o.Bindable,
+		"instances_retrievable": true,
+		"bindings_retrievable":  true,
+		"plan_updateable":       true,
+		"broker_id":             "sm-broker",
+		"catalog_id":            offeringId,
+		"catalog_name":          o.Name,
+		"metadata":              map[string]interface{}{"displayName": o.Name},
+		"created_at":            now,
+		"updated_at":            now,
+	})
+	for _, plan := range o.Plans {
+		planId := s.newGuid()
+		st.collections[smPlans].add(planId, "", smObject{
+			"id":                  planId,
+			"ready":               true,
+			"name":                plan,
+			"description":         fmt.Sprintf("%s plan of %s", plan, o.Name),
+			"catalog_id":          planId,
+			"catalog_name":        plan,
+			"free":                true,
+			"bindable":            o.Bindable,
+			"service_offering_id": offeringId,
+			"metadata":            map[string]interface{}{"supportedPlatforms": []string{"cloudfoundry", "kubernetes", "sapbtp"}},
+			"created_at":          now,
+			"updated_at":          now,
+		})
+	}
+}
+
+func (st *smState) planByNames(offering, plan string) (smObject, bool) {
+	offerings := st.collections[smOfferings]
+	for _, oid := range offerings.order {
+		if offerings.items[oid]["name"] != offering {
+			continue
+		}
+		plans := st.collections[smPlans]
+		for _, pid := range plans.order {
+			p := plans.items[pid]
+			if p["service_offering_id"] == oid && p["name"] == plan {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Decoded labels of a request; either a plain map or a list of label change operations.
+type labelsBody struct {
+	replace map[string][]string
+	changes []labelChange
+}
+
+type labelChange struct {
+	Op     string   `json:"op"`
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+func (l *labelsBody) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &l.changes)
+	}
+	return json.Unmarshal(data, &l.replace)
+}
+
+func (l *labelsBody) apply(labels map[string][]string) (map[string][]string, error) {
+	if labels == nil {
+		labels = make(map[string][]string)
+	}
+	if l.replace != nil {
+		for k, v := range l.replace {
+			labels[k] = v
+		}
+	}
+	for _, c := range l.changes {
+		switch c.Op {
+		case "add":
+			labels[c.Key] = append([]string(nil), c.Values...)
+		case "add_values":
+			for _, v := range c.Values {
+				if !hasString(labels[c.Key], v) {
+					labels[c.Key] = append(labels[c.Key], v)
+				}
+			}
+		case "remove":
+			delete(labels, c.Key)
+		case "remove_values":
+			for _, v := range c.Values {
+				labels[c.Key] = removeString(labels[c.Key], v)
+			}
+			if len(labels[c.Key]) == 0 {
+				delete(labels, c.Key)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported label operation '%s'", c.Op)
+		}
+	}
+	return labels, nil
+}
+
+func objLabels(obj smObject) map[string][]string {
+	labels, _ := obj["labels"].(map[string][]string)
+	return labels
+}
+
+// Starts an operation on a resource; it completes synchronously unless async is set.
+func (s *Server) startOperation(kind, id, opType string, async bool, finish func(failed bool)) *smOperation {
+	now := s.nowISO()
+	op := &smOperation{
+		id:           s.newGuid(),
+		opType:       opType,
+		state:        opInProgress,
+		resourceId:   id,
+		resourceType: kind,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+	col := s.sm.collections[kind]
+	col.operations[op.id] = op
+	col.lastOp[id] = op
+	col.history[id] = append(col.history[id], op)
+
+	complete := func(failed bool) {
+		op.updatedAt = s.nowISO()
+		if failed {
+			op.state, op.description = opFailed, fmt.Sprintf("%s of %s failed", opType, id)
+		} else {
+			op.state = opSucceeded
+		}
+		finish(failed)
+	}
+	if !async {
+		failed := s.failJobs > 0
+		if failed {
+			s.failJobs--
+		}
+		complete(failed)
+		return op
+	}
+	s.startJob(op.id, opType, complete)
+	return op
+}
+
+func (s *Server) writeAccepted(c *call, kind, id string, op *smOperation, body interface{}) {
+	c.w.Header().Set("Location", fmt.Sprintf("/v1/%s/%s/operations/%s", kind, id, op.id))
+	writeJSON(c, http.StatusAccepted, body)
+}
+
+// Lists a collection applying fieldQuery, labelQuery and max_items/token paging.
+func (s *Server) listResources(c *call, kind string) {
+	col := s.sm.collections[kind]
+
+	fieldCriteria, err := parseQuery(c.query("fieldQuery"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("invalid fieldQuery; %v", err))
+		return
+	}
+	labelCriteria, err := parseQuery(c.query("labelQuery"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, fmt.Sprintf("invalid labelQuery; %v", err))
+		return
+	}
+
+	matched := make([]smObject, 0)
+	for _, id := range col.order {
+		s.pollJobOf(kind, id)
+		obj, ok := col.get(c.scope, id)
+		if !ok {
+			continue
+		}
+		if matchFields(fieldCriteria, obj) && matchLabels(labelCriteria, objLabels(obj)) {
+			matched = append(matched, obj)
+		}
+	}
+
+	writePage(c, matched)
+}
+
+// Writes a page of a list, following the max_items and token parameters.
+func writePage(c *call, matched []smObject) {
+	maxItems, _ := strconv.Atoi(c.query("max_items"))
+	if maxItems <= 0 {
+		maxItems = 200
+	}
+	start, _ := strconv.Atoi(c.query("token"))
+	if start < 0 || start > len(matched) {
+		start = len(matched)
+	}
+	end := start + maxItems
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	out := map[string]interface{}{
+		"num_items": len(matched),
+		"items":     matched[start:end],
+	}
+	if end < len(matched) {
+		out["token"] = strconv.Itoa(end)
+	}
+	writeJSON(c, http.StatusOK, out)
+}
+
+// Advances the last asynchronous operation of a resource, if any.
+func (s *Server) pollJobOf(kind, id string) {
+	if op, ok := s.sm.collections[kind].lastOp[id]; ok && op.state == opInProgress {
+		s.pollJob(op.id)
+	}
+}
+
+func (s *Server) getResource(c *call, kind, id string) {
+	s.pollJobOf(kind, id)
+	col := s.sm.collections[kind]
+	obj, ok := col.get(c.scope, id)
+	if !ok {
+		writeError(c, http.StatusNotFound, fmt.Sprintf("could not find such %s", kind))
+		return
+	}
+	out := smObject{}
+	for k, v := range obj {
+		out[k] = v
+	}
+	if op, ok := col.lastOp[id]; ok {
+		out["last_operation"] = op.view()
+	}
+	writeJSON(c, http.StatusOK, out)
+}
+
+func (s *Server) nameTaken(kind, scope, name string) bool {
+	col := s.sm.collections[kind]
+	for _, id := range col.order {
+		if col.owners[id] == scope && col.items[id]["name"] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) registerServiceManager() {
+	st := s.sm
+
+	// catalog
+	for _, kind := range []string{smBrokers, smOfferings, smPlans} {
+		kind := kind
+		s.handle(http.MethodGet, "/v1/"+kind, func(c *call) {
+			s.listResources(c, kind)
+		})
+		s.handle(http.MethodGet, "/v1/"+kind+"/{id}", func(c *call) {
+			s.getResource(c, kind, c.param("id"))
+		})
+	}
+
+	// service brokers
+	s.handle(http.MethodPost, "/v1/service_brokers", func(c *call) {
+		var body struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			BrokerUrl   string `json:"broker_url"`
+			Credentials *struct {
+				Basic struct {
+					Username string `json:"username"`
+					Password string `json:"password"`
+				} `json:"basic"`
+			} `json:"credentials"`
+			Labels map[string][]string `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.Name == "" || body.BrokerUrl == "" || body.Credentials == nil || body.Credentials.Basic.Username == "" {
+			writeError(c, http.StatusBadRequest, "name, broker_url and credentials are required")
+			return
+		}
+		if s.nameTaken(smBrokers, c.scope, body.Name) {
+			writeError(c, http.StatusConflict, fmt.Sprintf("broker with name '%s' already exists", body.Name))
+			return
+		}
+
+		id := s.newGuid()
+		now := s.nowISO()
+		obj := smObject{
+			"id":          id,
+			"ready":       false,
+			"name":        body.Name,
+			"description": body.Description,
+			"broker_url":  body.BrokerUrl,
+			"created_at":  now,
+			"updated_at":  now,
+		}
+		if body.Labels != nil {
+			obj["labels"] = body.Labels
+		}
+		col := st.collections[smBrokers]
+		col.add(id, c.scope, obj)
+
+		async := c.queryBool("async")
+		op := s.startOperation(smBrokers, id, "CREATE", async, func(failed bool) {
+			obj["updated_at"] = s.nowISO()
+			if !failed {
+				obj["ready"] = true
+			}
+		})
+		if async {
+			s.writeAccepted(c, smBrokers, id, op, obj)
+			return
+		}
+		if op.state == opFailed {
+			col.remove(id)
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusCreated, obj)
+	})
+
+	s.handle(http.MethodPatch, "/v1/service_brokers/{serviceBrokerID}", func(c *call) {
+		id := c.param("serviceBrokerID")
+		obj, ok := st.collections[smBrokers].get(c.scope, id)
+		if !ok {
+			writeError(c, http.StatusNotFound, "could not find such service broker")
+			return
+		}
+		var body struct {
+			Name        string     `json:"name"`
+			Description *string    `json:"description"`
+			BrokerUrl   string     `json:"broker_url"`
+			Labels      labelsBody `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		labels, err := body.Labels.apply(objLabels(obj))
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// an update refetches the catalog of the broker, even when nothing else changes
+		async := c.queryBool("async")
+		op := s.startOperation(smBrokers, id, "UPDATE", async, func(failed bool) {
+			if failed {
+				return
+			}
+			if body.Name != "" {
+				obj["name"] = body.Name
+			}
+			if body.Description != nil {
+				obj["description"] = *body.Description
+			}
+			if body.BrokerUrl != "" {
+				obj["broker_url"] = body.BrokerUrl
+			}
+			obj["labels"] = labels
+			obj["updated_at"] = s.nowISO()
+		})
+		if async {
+			s.writeAccepted(c, smBrokers, id, op, obj)
+			return
+		}
+		if op.state == opFailed {
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusOK, obj)
+	})
+
+	s.handle(http.MethodDelete, "/v1/service_brokers/{serviceBrokerID}", func(c *call) {
+		id := c.param("serviceBrokerID")
+		if _, ok := st.collections[smBrokers].get(c.scope, id); !ok {
+			writeError(c, http.StatusNotFound, "could not find such service broker")
+			return
+		}
+		plans := st.brokerPlans(id)
+		instances := st.collections[smInstances]
+		for _, iid := range instances.order {
+			if plan, _ := instances.items[iid]["service_plan_id"].(string); plans[plan] {
+				writeError(c, http.StatusConflict, "service broker has service instances; delete them first")
+				return
+			}
+		}
+
+		async := c.queryBool("async")
+		op := s.startOperation(smBrokers, id, "DELETE", async, func(failed bool) {
+			if !failed {
+				st.removeBroker(id)
+			}
+		})
+		if async {
+			s.writeAccepted(c, smBrokers, id, op, nil)
+			return
+		}
+		if op.state == opFailed {
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+
+	// platforms
+	s.handle(http.MethodGet, "/v1/platforms", func(c *call) {
+		s.listResources(c, smPlatforms)
+	})
+
+	s.handle(http.MethodPost, "/v1/platforms", func(c *call) {
+		var body struct {
+			Id          string              `json:"id"`
+			Name        string              `json:"name"`
+			Type        string              `json:"type"`
+			Description string              `json:"description"`
+			Labels      map[string][]string `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.Name == "" || body.Type == "" {
+			writeError(c, http.StatusBadRequest, "name and type are required")
+			return
+		}
+		if s.nameTaken(smPlatforms, c.scope, body.Name) {
+			writeError(c, http.StatusConflict, fmt.Sprintf("platform with name '%s' already exists", body.Name))
+			return
+		}
+		id := body.Id
+		if id == "" {
+			id = s.newGuid()
+		}
+		now := s.nowISO()
+		obj := smObject{
+			"id":          id,
+			"ready":       true,
+			"name":        body.Name,
+			"type":        body.Type,
+			"description": body.Description,
+			"created_at":  now,
+			"updated_at":  now,
+		}
+		if body.Labels != nil {
+			obj["labels"] = body.Labels
+		}
+		st.collections[smPlatforms].add(id, c.scope, obj)
+
+		out := smObject{"credentials": map[string]interface{}{
+			"basic": map[string]interface{}{"username": "platform-" + id, "password": fmt.Sprintf("pwd-%d", s.nextID())},
+		}}
+		for k, v := range obj {
+			out[k] = v
+		}
+		writeJSON(c, http.StatusCreated, out)
+	})
+
+	s.handle(http.MethodGet, "/v1/platforms/{platformID}", func(c *call) {
+		s.getResource(c, smPlatforms, c.param("platformID"))
+	})
+
+	s.handle(http.MethodPatch, "/v1/platforms/{platformID}", func(c *call) {
+		obj, ok := st.collections[smPlatforms].get(c.scope, c.param("platformID"))
+		if !ok {
+			writeError(c, http.StatusNotFound, "could not find such platform")
+			return
+		}
+		var body struct {
+			Name        string     `json:"name"`
+			Type        string     `json:"type"`
+			Description *string    `json:"description"`
+			Labels      labelsBody `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		labels, err := body.Labels.apply(objLabels(obj))
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if body.Name != "" {
+			obj["name"] = body.Name
+		}
+		if body.Type != "" {
+			obj["type"] = body.Type
+		}
+		if body.Description != nil {
+			obj["description"] = *body.Description
+		}
+		obj["labels"] = labels
+		obj["updated_at"] = s.nowISO()
+		writeJSON(c, http.StatusOK, obj)
+	})
+
+	s.handle(http.MethodDelete, "/v1/platforms/{platformID}", func(c *call) {
+		id := c.param("platformID")
+		if _, ok := st.collections[smPlatforms].get(c.scope, id); !ok {
+			writeError(c, http.StatusNotFound, "could not find such platform")
+			return
+		}
+		instances := st.collections[smInstances]
+		for _, iid := range append([]string(nil), instances.order...) {
+			if instances.items[iid]["platform_id"] != id {
+				continue
+			}
+			if !c.queryBool("cascade") {
+				writeError(c, http.StatusConflict, "platform has service instances; use cascade to delete them")
+				return
+			}
+			s.removeInstance(iid)
+		}
+		st.collections[smPlatforms].remove(id)
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+
+	// service instances
+	s.handle(http.MethodGet, "/v1/service_instances", func(c *call) {
+		s.listResources(c, smInstances)
+	})
+
+	s.handle(http.MethodPost, "/v1/service_instances", func(c *call) {
+		var body struct {
+			Name                string                 `json:"name"`
+			ServicePlanId       string                 `json:"service_plan_id"`
+			ServiceOfferingName string                 `json:"service_offering_name"`
+			ServicePlanName     string                 `json:"service_plan_name"`
+			Parameters          map[string]interface{} `json:"parameters"`
+			Labels              map[string][]string    `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.Name == "" {
+			writeError(c, http.StatusBadRequest, "name is required")
+			return
+		}
+		var plan smObject
+		if body.ServicePlanId != "" {
+			plan = st.collections[smPlans].items[body.ServicePlanId]
+		} else {
+			plan, _ = st.planByNames(body.ServiceOfferingName, body.ServicePlanName)
+		}
+		if plan == nil {
+			writeError(c, http.StatusBadRequest, "service plan not found")
+			return
+		}
+		if s.nameTaken(smInstances, c.scope, body.Name) {
+			writeError(c, http.StatusConflict, fmt.Sprintf("instance with name '%s' already exists", body.Name))
+			return
+		}
+
+		id := s.newGuid()
+		now := s.nowISO()
+		obj := smObject{
+			"id":              id,
+			"ready":           false,
+			"usable":          false,
+			"name":            body.Name,
+			"service_plan_id": plan["id"],
+			"platform_id":     "service-manager",
+			"context": map[string]string{
+				"platform":          "service-manager",
+				"subaccount_id":     c.scope,
+				"global_account_id": s.accounts.global.Guid,
+				"instance_name":     body.Name,
+			},
+			"created_at": now,
+			"updated_at": now,
+		}
+		if body.Labels != nil {
+			obj["labels"] = body.Labels
+		}
+		col := st.collections[smInstances]
+		col.add(id, c.scope, obj)
+		if body.Parameters != nil {
+			col.parameters[id] = body.Parameters
+		}
+
+		async := c.queryBool("async")
+		op := s.startOperation(smInstances, id, "CREATE", async, func(failed bool) {
+			obj["updated_at"] = s.nowISO()
+			if !failed {
+				obj["ready"], obj["usable"] = true, true
+				obj["dashboard_url"] = fmt.Sprintf("%s/dashboard/%s", s.URL, id)
+			}
+		})
+		if async {
+			s.writeAccepted(c, smInstances, id, op, obj)
+			return
+		}
+		if op.state == opFailed {
+			col.remove(id)
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusCreated, obj)
+	})
+
+	s.handle(http.MethodGet, "/v1/service_instances/{serviceInstanceID}", func(c *call) {
+		s.getResource(c, smInstances, c.param("serviceInstanceID"))
+	})
+
+	s.handle(http.MethodPatch, "/v1/service_instances/{serviceInstanceID}", func(c *call) {
+		id := c.param("serviceInstanceID")
+		col := st.collections[smInstances]
+		obj, ok := col.get(c.scope, id)
+		if !ok {
+			writeError(c, http.StatusNotFound, "could not find such service instance")
+			return
+		}
+		var body struct {
+			Name          string                 `json:"name"`
+			ServicePlanId string                 `json:"service_plan_id"`
+			Parameters    map[string]interface{} `json:"parameters"`
+			Labels        labelsBody             `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.ServicePlanId != "" {
+			if _, ok := st.collections[smPlans].items[body.ServicePlanId]; !ok {
+				writeError(c, http.StatusBadRequest, "service plan not found")
+				return
+			}
+		}
+		labels, err := body.Labels.apply(objLabels(obj))
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		async := c.queryBool("async")
+		op := s.startOperation(smInstances, id, "UPDATE", async, func(failed bool) {
+			if failed {
+				return
+			}
+			if body.Name != "" {
+				obj["name"] = body.Name
+			}
+			if body.ServicePlanId != "" {
+				obj["service_plan_id"] = body.ServicePlanId
+			}
+			if body.Parameters != nil {
+				params := col.parameters[id]
+				if params == nil {
+					params = make(map[string]interface{})
+				}
+				for k, v := range body.Parameters {
+					params[k] = v
+				}
+				col.parameters[id] = params
+			}
+			obj["labels"] = labels
+			obj["updated_at"] = s.nowISO()
+		})
+		if async {
+			s.writeAccepted(c, smInstances, id, op, obj)
+			return
+		}
+		if op.state == opFailed {
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusOK, obj)
+	})
+
+	s.handle(http.MethodDelete, "/v1/service_instances/{serviceInstanceID}", func(c *call) {
+		id := c.param("serviceInstanceID")
+		if _, ok := st.collections[smInstances].get(c.scope, id); !ok {
+			writeError(c, http.StatusNotFound, "could not find such service instance")
+			return
+		}
+		bindings := st.collections[smBindings]
+		for _, bid := range bindings.order {
+			if bindings.items[bid]["service_instance_id"] == id {
+				writeError(c, http.StatusConflict, "service instance has bindings; delete them first")
+				return
+			}
+		}
+
+		async := c.queryBool("async")
+		op := s.startOperation(smInstances, id, "DELETE", async, func(failed bool) {
+			if !failed {
+				s.removeInstance(id)
+			}
+		})
+		if async {
+			s.writeAccepted(c, smInstances, id, op, nil)
+			return
+		}
+		if op.state == opFailed {
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+
+	s.handle(http.MethodGet, "/v1/service_instances/{serviceInstanceID}/parameters", func(c *call) {
+		s.getParameters(c, smInstances, c.param("serviceInstanceID"))
+	})
+
+	// service bindings
+	s.handle(http.MethodGet, "/v1/service_bindings", func(c *call) {
+		s.listResources(c, smBindings)
+	})
+
+	s.handle(http.MethodPost, "/v1/service_bindings", func(c *call) {
+		var body struct {
+			Name              string                 `json:"name"`
+			ServiceInstanceId string                 `json:"service_instance_id"`
+			Parameters        map[string]interface{} `json:"parameters"`
+			BindResource      map[string]string      `json:"bind_resource"`
+			Labels            map[string][]string    `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.Name == "" || body.ServiceInstanceId == "" {
+			writeError(c, http.StatusBadRequest, "name and service_instance_id are required")
+			return
+		}
+		instance, ok := st.collections[smInstances].get(c.scope, body.ServiceInstanceId)
+		if !ok {
+			writeError(c, http.StatusNotFound, "could not find such service instance")
+			return
+		}
+		if ready, _ := instance["ready"].(bool); !ready {
+			writeError(c, http.StatusUnprocessableEntity, "service instance is not ready")
+			return
+		}
+		if s.nameTaken(smBindings, c.scope, body.Name) {
+			writeError(c, http.StatusConflict, fmt.Sprintf("binding with name '%s' already exists", body.Name))
+			return
+		}
+
+		id := s.newGuid()
+		now := s.nowISO()
+		obj := smObject{
+			"id":                  id,
+			"ready":               false,
+			"name":                body.Name,
+			"service_instance_id": body.ServiceInstanceId,
+			"context":             instance["context"],
+			"created_at":          now,
+			"updated_at":          now,
+		}
+		if body.BindResource != nil {
+			obj["bind_resource"] = body.BindResource
+		}
+		if body.Labels != nil {
+			obj["labels"] = body.Labels
+		}
+		col := st.collections[smBindings]
+		col.add(id, c.scope, obj)
+		if body.Parameters != nil {
+			col.parameters[id] = body.Parameters
+		}
+
+		async := c.queryBool("async")
+		op := s.startOperation(smBindings, id, "CREATE", async, func(failed bool) {
+			obj["updated_at"] = s.nowISO()
+			if failed {
+				return
+			}
+			obj["ready"] = true
+			obj["credentials"] = map[string]interface{}{
+				"clientid":     "sb-" + id,
+				"clientsecret": fmt.Sprintf("secret-%d", s.nextID()),
+				"url":          s.URL,
+				"uri":          fmt.Sprintf("%s/instances/%s", s.URL, body.ServiceInstanceId),
+			}
+		})
+		if async {
+			s.writeAccepted(c, smBindings, id, op, obj)
+			return
+		}
+		if op.state == opFailed {
+			col.remove(id)
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusCreated, obj)
+	})
+
+	s.handle(http.MethodGet, "/v1/service_bindings/{serviceBindingID}", func(c *call) {
+		s.getResource(c, smBindings, c.param("serviceBindingID"))
+	})
+
+	s.handle(http.MethodPatch, "/v1/service_bindings/{serviceBindingID}", func(c *call) {
+		obj, ok := st.collections[smBindings].get(c.scope, c.param("serviceBindingID"))
+		if !ok {
+			writeError(c, http.StatusNotFound, "could not find such service binding")
+			return
+		}
+		var body struct {
+			Labels labelsBody `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		labels, err := body.Labels.apply(objLabels(obj))
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		obj["labels"] = labels
+		obj["updated_at"] = s.nowISO()
+		writeJSON(c, http.StatusOK, obj)
+	})
+
+	s.handle(http.MethodDelete, "/v1/service_bindings/{serviceBindingID}", func(c *call) {
+		id := c.param("serviceBindingID")
+		col := st.collections[smBindings]
+		if _, ok := col.get(c.scope, id); !ok {
+			writeError(c, http.StatusNotFound, "could not find such service binding")
+			return
+		}
+		async := c.queryBool("async")
+		op := s.startOperation(smBindings, id, "DELETE", async, func(failed bool) {
+			if !failed {
+				col.remove(id)
+			}
+		})
+		if async {
+			s.writeAccepted(c, smBindings, id, op, nil)
+			return
+		}
+		if op.state == opFailed {
+			writeError(c, http.StatusBadGateway, op.description)
+			return
+		}
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+
+	s.handle(http.MethodGet, "/v1/service_bindings/{serviceBindingID}/parameters", func(c *call) {
+		s.getParameters(c, smBindings, c.param("serviceBindingID"))
+	})
+
+	// visibilities
+	s.handle(http.MethodGet, "/v1/visibilities", func(c *call) {
+		s.listResources(c, smVisibilities)
+	})
+
+	s.handle(http.MethodPost, "/v1/visibilities", func(c *call) {
+		var body struct {
+			ServicePlanId string              `json:"service_plan_id"`
+			PlatformId    string              `json:"platform_id"`
+			Labels        map[string][]string `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.ServicePlanId == "" {
+			writeError(c, http.StatusBadRequest, "service_plan_id is required")
+			return
+		}
+		if _, ok := st.collections[smPlans].get(c.scope, body.ServicePlanId); !ok {
+			writeError(c, http.StatusBadRequest, "service plan not found")
+			return
+		}
+		if body.PlatformId != "" {
+			if _, ok := st.collections[smPlatforms].get(c.scope, body.PlatformId); !ok {
+				writeError(c, http.StatusBadRequest, "platform not found")
+				return
+			}
+		}
+
+		id := s.newGuid()
+		now := s.nowISO()
+		obj := smObject{
+			"id":              id,
+			"ready":           true,
+			"service_plan_id": body.ServicePlanId,
+			"created_at":      now,
+			"updated_at":      now,
+		}
+		if body.PlatformId != "" {
+			obj["platform_id"] = body.PlatformId
+		}
+		if body.Labels != nil {
+			obj["labels"] = body.Labels
+		}
+		st.collections[smVisibilities].add(id, c.scope, obj)
+		writeJSON(c, http.StatusCreated, obj)
+	})
+
+	s.handle(http.MethodGet, "/v1/visibilities/{visibilityID}", func(c *call) {
+		s.getResource(c, smVisibilities, c.param("visibilityID"))
+	})
+
+	s.handle(http.MethodPatch, "/v1/visibilities/{visibilityID}", func(c *call) {
+		obj, ok := st.collections[smVisibilities].get(c.scope, c.param("visibilityID"))
+		if !ok {
+			writeError(c, http.StatusNotFound, "could not find such visibility")
+			return
+		}
+		var body struct {
+			ServicePlanId string     `json:"service_plan_id"`
+			PlatformId    string     `json:"platform_id"`
+			Labels        labelsBody `json:"labels"`
+		}
+		if !c.decode(&body) {
+			return
+		}
+		if body.ServicePlanId != "" {
+			if _, ok := st.collections[smPlans].get(c.scope, body.ServicePlanId); !ok {
+				writeError(c, http.StatusBadRequest, "service plan not found")
+				return
+			}
+			obj["service_plan_id"] = body.ServicePlanId
+		}
+		if body.PlatformId != "" {
+			if _, ok := st.collections[smPlatforms].get(c.scope, body.PlatformId); !ok {
+				writeError(c, http.StatusBadRequest, "platform not found")
+				return
+			}
+			obj["platform_id"] = body.PlatformId
+		}
+		labels, err := body.Labels.apply(objLabels(obj))
+		if err != nil {
+			writeError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		obj["labels"] = labels
+		obj["updated_at"] = s.nowISO()
+		writeJSON(c, http.StatusOK, obj)
+	})
+
+	s.handle(http.MethodDelete, "/v1/visibilities/{visibilityID}", func(c *call) {
+		id := c.param("visibilityID")
+		if _, ok := st.collections[smVisibilities].get(c.scope, id); !ok {
+			writeError(c, http.StatusNotFound, "could not find such visibility")
+			return
+		}
+		st.collections[smVisibilities].remove(id)
+		writeJSON(c, http.StatusOK, map[string]interface{}{})
+	})
+
+	// operations
+	s.handle(http.MethodGet, "/v1/{resourceType}/{resourceID}/operations/{operationID}", func(c *call) {
+		col, ok := st.collections[c.param("resourceType")]
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("unknown resource type '%s'", c.param("resourceType")))
+			return
+		}
+		op, ok := col.operations[c.param("operationID")]
+		if !ok || op.resourceId != c.param("resourceID") {
+			writeError(c, http.StatusNotFound, "could not find such operation")
+			return
+		}
+		if owner := col.owners[op.resourceId]; owner != "" && owner != c.scope {
+			writeError(c, http.StatusNotFound, "could not find such operation")
+			return
+		}
+		if op.state == opInProgress {
+			s.pollJob(op.id)
+		}
+		writeJSON(c, http.StatusOK, op.view())
+	})
+
+	s.handle(http.MethodGet, "/v1/{resourceType}/{resourceID}/operations", func(c *call) {
+		kind, id := c.param("resourceType"), c.param("resourceID")
+		col, ok := st.collections[kind]
+		if !ok {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("unknown resource type '%s'", kind))
+			return
+		}
+		if _, ok := col.get(c.scope, id); !ok && len(col.history[id]) == 0 {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("could not find such %s", kind))
+			return
+		}
+		if owner := col.owners[id]; owner != "" && owner != c.scope {
+			writeError(c, http.StatusNotFound, fmt.Sprintf("could not find such %s", kind))
+			return
+		}
+		fieldCriteria, err := parseQuery(c.query("fieldQuery"))
+		if err != nil {
+			writeError(c, http.StatusBadRequest, fmt.Sprintf("invalid fieldQuery; %v", err))
+			return
+		}
+
+		s.pollJobOf(kind, id)
+		matched := make([]smObject, 0)
+		for _, op := range col.history[id] {
+			if view := smObject(op.view()); matchFields(fieldCriteria, view) {
+				matched = append(matched, view)
+			}
+		}
+		writePage(c, matched)
+	})
+}
+
+func (s *Server) getParameters(c *call, kind, id string) {
+	col := s.sm.collections[kind]
+	if _, ok := col.get(c.scope, id); !ok {
+		writeError(c, http.StatusNotFound, fmt.Sprintf("could not find such %s", kind))
+		return
+	}
+	params := col.parameters[id]
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	writeJSON(c, http.StatusOK, params)
+}
+
+func (s *Server) removeInstance(id string) {
+	bindings := s.sm.collections[smBindings]
+	for _, bid := range append([]string(nil), bindings.order...) {
+		if bindings.items[bid]["service_instance_id"] == id {
+			bindings.remove(bid)
+		}
+	}
+	s.sm.collections[smInstances].remove(id)
+}
+
+// Plans offered by a broker, by ID.
+func (st *smState) brokerPlans(brokerId string) map[string]bool {
+	offerings, plans := st.collections[smOfferings], st.collections[smPlans]
+	out := make(map[string]bool)
+	for _, pid := range plans.order {
+		oid, _ := plans.items[pid]["service_offering_id"].(string)
+		if offering, ok := offerings.items[oid]; ok && offering["broker_id"] == brokerId {
+			out[pid] = true
+		}
+	}
+	return out
+}
+
+// Removes a broker with its catalog and the visibilities of its plans.
+func (st *smState) removeBroker(id string) {
+	plans := st.brokerPlans(id)
+	visibilities := st.collections[smVisibilities]
+	for _, vid := range append([]string(nil), visibilities.order...) {
+		if plan, _ := visibilities.items[vid]["service_plan_id"].(string); plans[plan] {
+			visibilities.remove(vid)
+		}
+	}
+	for pid := range plans {
+		st.collections[smPlans].remove(pid)
+	}
+	offerings := st.collections[smOfferings]
+	for _, oid := range append([]string(nil), offerings.order...) {
+		if offerings.items[oid]["broker_id"] == id {
+			offerings.remove(oid)
+		}
+	}
+	st.collections[smBrokers].remove(id)
+}
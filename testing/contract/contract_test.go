@@ -0,0 +1,286 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nnicora/sap-sdk-go/sap"
+	"github.com/nnicora/sap-sdk-go/sap/endpoints"
+	"github.com/nnicora/sap-sdk-go/sap/http/defaults"
+	"github.com/nnicora/sap-sdk-go/sap/http/request"
+	"github.com/nnicora/sap-sdk-go/sap/service"
+	"github.com/nnicora/sap-sdk-go/sap/session"
+	"github.com/nnicora/sap-sdk-go/service/btpaccounts"
+	"github.com/nnicora/sap-sdk-go/service/btpentitlements"
+	"github.com/nnicora/sap-sdk-go/service/btpevents"
+	"github.com/nnicora/sap-sdk-go/service/btpmanagment"
+	"github.com/nnicora/sap-sdk-go/service/btpprovisioning"
+	"github.com/nnicora/sap-sdk-go/service/btpresources"
+	"github.com/nnicora/sap-sdk-go/service/btpsaasmanager"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const host = "https://contract.test"
+
+var clients = []struct {
+	spec        string
+	endpointsID string
+	new         func(service.RequesterConfig) interface{}
+}{
+	{"accounts.json", btpaccounts.EndpointsID, func(c service.RequesterConfig) interface{} { return btpaccounts.New(c) }},
+	{"entitlements.json", btpentitlements.EndpointsID, func(c service.RequesterConfig) interface{} { return btpentitlements.New(c) }},
+	{"events.json", btpevents.EndpointsID, func(c service.RequesterConfig) interface{} { return btpevents.New(c) }},
+	{"provisioning.json", btpprovisioning.EndpointsID, func(c service.RequesterConfig) interface{} { return btpprovisioning.New(c) }},
+	{"resources.json", btpresources.EndpointsID, func(c service.RequesterConfig) interface{} { return btpresources.New(c) }},
+	{"saas-manager.json", btpsaasmanager.EndpointsID, func(c service.RequesterConfig) interface{} { return btpsaasmanager.New(c) }},
+	{"service-manager.json", btpmanagment.EndpointsID, func(c service.RequesterConfig) interface{} { return btpmanagment.New(c) }},
+}
+
+// Headers set by the transport or the pipeline itself, not described by the documents.
+var transportHeaders = map[string]bool{
+	"Accept":          true,
+	"Accept-Encoding": true,
+	"Authorization":   true,
+	"Content-Length":  true,
+	"Content-Type":    true,
+	"User-Agent":      true,
+}
+
+type captured struct {
+	method string
+	path   string
+	query  map[string][]string
+	header http.Header
+	body   []byte
+}
+
+// Transport recording the requests and answering with the example of the operation they match.
+type transport struct {
+	doc *document
+
+	mu       sync.Mutex
+	requests []*captured
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c := &captured{method: r.Method, path: r.URL.Path, query: r.URL.Query(), header: r.Header.Clone()}
+	if r.Body != nil {
+		c.body, _ = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+	}
+	t.mu.Lock()
+	t.requests = append(t.requests, c)
+	t.mu.Unlock()
+
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    r,
+	}
+	if op, _ := t.doc.match(r.Method, r.URL.Path); op != nil {
+		status, contentType, example := op.success()
+		resp.StatusCode = status
+		resp.Body = ioutil.NopCloser(bytes.NewReader(example))
+		if contentType != "" {
+			resp.Header.Set("Content-Type", contentType)
+		}
+	}
+	resp.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	return resp, nil
+}
+
+func (t *transport) last() *captured {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.requests) == 0 {
+		return nil
+	}
+	c := t.requests[len(t.requests)-1]
+	t.requests = nil
+	return c
+}
+
+func newSession(endpointsID string, rt http.RoundTripper) *session.RuntimeSession {
+	return &session.RuntimeSession{
+		RuntimeConfig: &sap.RuntimeConfig{
+			Endpoints: map[string]*endpoints.Endpoint{
+				endpointsID: {Host: host, Client: &http.Client{Transport: rt}},
+			},
+		},
+		Processors: defaults.Processors(),
+	}
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	requestType = reflect.TypeOf((*request.Request)(nil))
+)
+
+// XxxRequest builders of a client: func(ctx, *Input) (*request.Request, *Output).
+func builders(client reflect.Value) []reflect.Method {
+	var out []reflect.Method
+	t := client.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if !strings.HasSuffix(m.Name, "Request") || m.Type.NumIn() != 3 || m.Type.NumOut() != 2 {
+			continue
+		}
+		if m.Type.In(1) != contextType || m.Type.In(2).Kind() != reflect.Ptr || m.Type.Out(0) != requestType {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestOperations(t *testing.T) {
+	for _, c := range clients {
+		c := c
+		t.Run(strings.TrimSuffix(c.spec, ".json"), func(t *testing.T) {
+			doc, err := loadDocument(c.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tr := &transport{doc: doc}
+			client := reflect.ValueOf(c.new(newSession(c.endpointsID, tr)))
+
+			exercised := make(map[*operation]bool)
+			for _, m := range builders(client) {
+				m := m
+				t.Run(strings.TrimSuffix(m.Name, "Request"), func(t *testing.T) {
+					input := reflect.New(m.Type.In(2).Elem())
+					fill(input.Elem(), "")
+
+					results := m.Func.Call([]reflect.Value{client, reflect.ValueOf(context.Background()), input})
+					req := results[0].Interface().(*request.Request)
+					sendErr := req.Send()
+
+					got := tr.last()
+					if got == nil {
+						t.Fatalf("no request was sent: %v", sendErr)
+					}
+					op, values := doc.match(got.method, got.path)
+					if op == nil {
+						t.Fatalf("%s %s is not an operation of %s", got.method, got.path, doc.Info.Title)
+					}
+					exercised[op] = true
+
+					for _, msg := range checkRequest(doc, op, values, got) {
+						t.Errorf("%s: %s", op, msg)
+					}
+					if sendErr != nil {
+						t.Fatalf("%s: %v", op, sendErr)
+					}
+					checkResponse(t, op, req, results[1].Type())
+				})
+			}
+
+			for _, op := range doc.operations {
+				if !exercised[op] {
+					t.Errorf("%s is described but no builder sends it", op)
+				}
+			}
+		})
+	}
+}
+
+func checkRequest(doc *document, op *operation, values map[string]string, got *captured) []string {
+	var errs []string
+
+	// path parameters carry their name as sample value
+	for _, name := range op.names {
+		if v := values[name]; v != name {
+			errs = append(errs, fmt.Sprintf("path parameter %s is %q", name, v))
+		}
+	}
+
+	for name, vs := range got.query {
+		p := op.parameter("query", name)
+		if p == nil {
+			errs = append(errs, fmt.Sprintf("query parameter %q is not described", name))
+			continue
+		}
+		typ := doc.resolve(p.Schema).Type
+		if typ == "array" {
+			typ = doc.resolve(doc.resolve(p.Schema).Items).Type
+		} else if len(vs) > 1 {
+			errs = append(errs, fmt.Sprintf("query parameter %q is sent %d times", name, len(vs)))
+		}
+		for _, v := range vs {
+			if msg := checkText(typ, v); msg != "" {
+				errs = append(errs, fmt.Sprintf("query parameter %q: %s", name, msg))
+			}
+		}
+	}
+	for name := range got.header {
+		if transportHeaders[name] {
+			continue
+		}
+		if p := op.parameter("header", name); p == nil {
+			errs = append(errs, fmt.Sprintf("header %q is not described", name))
+		}
+	}
+	for _, p := range op.Parameters {
+		switch {
+		case !p.Required:
+		case p.In == "query" && len(got.query[p.Name]) == 0:
+			errs = append(errs, fmt.Sprintf("required query parameter %q is missing", p.Name))
+		case p.In == "header" && got.header.Get(p.Name) == "":
+			errs = append(errs, fmt.Sprintf("required header %q is missing", p.Name))
+		}
+	}
+
+	content := bytes.TrimSpace(got.body)
+	s := op.requestSchema()
+	switch {
+	case s == nil && len(content) > 0:
+		errs = append(errs, fmt.Sprintf("takes no body, got %s", content))
+	case s != nil && len(content) == 0:
+		if op.RequestBody.Required {
+			errs = append(errs, "the body is missing")
+		}
+	case s != nil:
+		if ct := got.header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			errs = append(errs, fmt.Sprintf("body sent as %q", ct))
+		}
+		var v interface{}
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			errs = append(errs, fmt.Sprintf("body is not JSON: %v", err))
+			break
+		}
+		errs = append(errs, doc.validate("body", s, v)...)
+	}
+	return errs
+}
+
+func checkResponse(t *testing.T, op *operation, req *request.Request, output reflect.Type) {
+	_, contentType, example := op.success()
+	if !strings.Contains(contentType, "json") && req.ResponseBody == nil {
+		return
+	}
+	// the body as decoded, after the response body handler of the operation
+	decoded := req.ResponseBody
+	if len(bytes.TrimSpace(decoded)) == 0 {
+		decoded = example
+	}
+	if len(bytes.TrimSpace(decoded)) == 0 {
+		return
+	}
+	var v interface{}
+	if err := json.Unmarshal(decoded, &v); err != nil {
+		t.Errorf("%s: example is not JSON: %v", op, err)
+		return
+	}
+	for _, msg := range lost("response", output, v) {
+		t.Errorf("%s: %s", op, msg)
+	}
+}
@@ -0,0 +1,12 @@
+// Package contract holds the contract tests of the service clients.
+//
+// Every service has an OpenAPI 3 document vendored in testdata, reduced to the
+// operations the client implements. The tests drive each XxxRequest builder of a
+// client with sample inputs through a capturing transport and check the request
+// against the document: the method and path template, the query parameters and
+// headers, and the JSON body against the request body schema. The example of the
+// success response is answered back and has to decode into the Output without
+// leaving any of its properties behind.
+//
+// Enumerations are not checked, the sample inputs do not know about them.
+package contract
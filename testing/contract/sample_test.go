@@ -0,0 +1,166 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// Fills every field of an input with a sample value. Path, query and header parameters
+// take their own name as value, so a parameter sent under a different name than the one
+// of the document shows up in the request.
+func fill(v reflect.Value, name string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem(), name)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" && !f.Anonymous {
+				continue
+			}
+			fieldName := f.Tag.Get("dest-name")
+			if fieldName == "" {
+				fieldName = jsonName(f)
+			}
+			fill(v.Field(i), fieldName)
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0), name)
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fill(elem, name)
+		key := reflect.New(v.Type().Key()).Elem()
+		fill(key, "key")
+		v.SetMapIndex(key, elem)
+	case reflect.Interface:
+		// free-form values of the services are objects
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(map[string]interface{}{"key": name}))
+		}
+	case reflect.String:
+		// enumerations take their first documented value, so the input passes validation
+		if values := v.MethodByName("Values"); values.IsValid() {
+			if out := values.Call(nil)[0]; out.Len() > 0 {
+				v.Set(out.Index(0))
+				return
+			}
+		}
+		v.SetString(name)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// Properties of a JSON value that have no field to be decoded into, following the
+// rules of encoding/json: embedded structs are promoted and names match case insensitively.
+func lost(at string, t reflect.Type, v interface{}) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v == nil || t.Kind() == reflect.Interface || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	var out []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for _, key := range sortedKeys(v) {
+				out = append(out, lost(at+"."+key, t.Elem(), v[key])...)
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for _, key := range sortedKeys(v) {
+				f, ok := fields[key]
+				if !ok {
+					for name, candidate := range fields {
+						if strings.EqualFold(name, key) {
+							f, ok = candidate, true
+							break
+						}
+					}
+				}
+				if !ok {
+					out = append(out, fmt.Sprintf("%s.%s has no field in %s", at, key, t))
+					continue
+				}
+				out = append(out, lost(at+"."+key, f.Type, v[key])...)
+			}
+		default:
+			out = append(out, fmt.Sprintf("%s is an object, decoded into %s", at, t))
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return []string{fmt.Sprintf("%s is an array, decoded into %s", at, t)}
+		}
+		for i, item := range v {
+			out = append(out, lost(fmt.Sprintf("%s[%d]", at, i), t.Elem(), item)...)
+		}
+	}
+	return out
+}
+
+// JSON fields of a struct by name, with the fields of embedded structs promoted.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+			embedded = append(embedded, f)
+			continue
+		}
+		fields[jsonName(f)] = f
+	}
+	// fields of the outer struct shadow the promoted ones
+	for _, e := range embedded {
+		ft := e.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		for name, f := range jsonFields(ft) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = f
+			}
+		}
+	}
+	return fields
+}
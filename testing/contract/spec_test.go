@@ -0,0 +1,287 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OpenAPI 3 document, only the parts the contract is checked against.
+type document struct {
+	Info struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+
+	operations []*operation
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Parameters  []*parameter         `json:"parameters"`
+	RequestBody *body                `json:"requestBody"`
+	Responses   map[string]*response `json:"responses"`
+
+	method   string
+	path     string
+	pattern  *regexp.Regexp
+	names    []string
+	literals int
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type body struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema  *schema         `json:"schema"`
+	Example json.RawMessage `json:"example"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+}
+
+var (
+	placeholder       = regexp.MustCompile(`\{([^}/]+)\}`)
+	quotedPlaceholder = regexp.MustCompile(`\\\{[^}/]+\\\}`)
+)
+
+func loadDocument(name string) (*document, error) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			op.method, op.path = strings.ToUpper(method), path
+
+			// QuoteMeta escapes the braces, the placeholders are replaced in their quoted form
+			expr := quotedPlaceholder.ReplaceAllString(regexp.QuoteMeta(path), `([^/]+)`)
+			op.pattern = regexp.MustCompile("^" + expr + "$")
+			for _, m := range placeholder.FindAllStringSubmatch(path, -1) {
+				op.names = append(op.names, m[1])
+			}
+			op.literals = strings.Count(path, "/") - len(op.names)
+			doc.operations = append(doc.operations, op)
+		}
+	}
+	sort.Slice(doc.operations, func(i, j int) bool {
+		a, b := doc.operations[i], doc.operations[j]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		return a.method < b.method
+	})
+	return doc, nil
+}
+
+// Operation of the document serving the method and path; literal segments win over placeholders.
+func (d *document) match(method, path string) (*operation, map[string]string) {
+	var (
+		found  *operation
+		values map[string]string
+	)
+	for _, op := range d.operations {
+		if op.method != method {
+			continue
+		}
+		m := op.pattern.FindStringSubmatch(path)
+		if m == nil || (found != nil && found.literals >= op.literals) {
+			continue
+		}
+		found, values = op, make(map[string]string, len(op.names))
+		for i, name := range op.names {
+			values[name], _ = url.PathUnescape(m[i+1])
+		}
+	}
+	return found, values
+}
+
+func (op *operation) String() string {
+	return op.method + " " + op.path
+}
+
+func (op *operation) parameter(in, name string) *parameter {
+	for _, p := range op.Parameters {
+		if p.In == in && strings.EqualFold(p.Name, name) && (in == "header" || p.Name == name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Schema of the JSON request body, nil when the operation does not take one.
+func (op *operation) requestSchema() *schema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	if mt := op.RequestBody.Content["application/json"]; mt != nil {
+		return mt.Schema
+	}
+	return nil
+}
+
+// Status code and example of the first success response.
+func (op *operation) success() (int, string, []byte) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		status, _ := strconv.Atoi(code)
+		resp := op.Responses[code]
+		for contentType, mt := range resp.Content {
+			if mt == nil || len(mt.Example) == 0 {
+				continue
+			}
+			if strings.Contains(contentType, "json") {
+				return status, contentType, mt.Example
+			}
+			// plain text examples are written as JSON strings in the document
+			var text string
+			if err := json.Unmarshal(mt.Example, &text); err == nil {
+				return status, contentType, []byte(text)
+			}
+		}
+		return status, "", nil
+	}
+	return 200, "", nil
+}
+
+func (d *document) resolve(s *schema) *schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[s.Ref[strings.LastIndex(s.Ref, "/")+1:]]
+	}
+	return s
+}
+
+// Checks a decoded JSON value against a schema, returning a message per violation.
+func (d *document) validate(at string, s *schema, v interface{}) []string {
+	if s = d.resolve(s); s == nil || v == nil {
+		return nil
+	}
+	if msg := checkType(s.Type, v); msg != "" {
+		return []string{fmt.Sprintf("%s: %s", at, msg)}
+	}
+
+	var errs []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: required property %q is missing", at, name))
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			if prop, ok := s.Properties[key]; ok {
+				errs = append(errs, d.validate(at+"."+key, prop, v[key])...)
+				continue
+			}
+			switch extra := bytes.TrimSpace(s.AdditionalProperties); {
+			case len(extra) == 0 || string(extra) == "false":
+				errs = append(errs, fmt.Sprintf("%s: property %q is not part of the schema", at, key))
+			case string(extra) != "true":
+				additional := &schema{}
+				if err := json.Unmarshal(extra, additional); err == nil {
+					errs = append(errs, d.validate(at+"."+key, additional, v[key])...)
+				}
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			errs = append(errs, d.validate(fmt.Sprintf("%s[%d]", at, i), s.Items, item)...)
+		}
+	}
+	return errs
+}
+
+func checkType(typ string, v interface{}) string {
+	ok := true
+	switch typ {
+	case "object":
+		_, ok = v.(map[string]interface{})
+	case "array":
+		_, ok = v.([]interface{})
+	case "string":
+		_, ok = v.(string)
+	case "boolean":
+		_, ok = v.(bool)
+	case "number":
+		_, ok = v.(json.Number)
+	case "integer":
+		n, isNumber := v.(json.Number)
+		if ok = isNumber; ok {
+			_, err := n.Int64()
+			ok = err == nil
+		}
+	}
+	if !ok {
+		return fmt.Sprintf("expected %s, got %T", typ, v)
+	}
+	return ""
+}
+
+// Checks the textual value of a query parameter or header against a scalar schema.
+func checkText(typ, v string) string {
+	var err error
+	switch typ {
+	case "integer":
+		_, err = strconv.ParseInt(v, 10, 64)
+	case "number":
+		_, err = strconv.ParseFloat(v, 64)
+	case "boolean":
+		_, err = strconv.ParseBool(v)
+	}
+	if err != nil {
+		return fmt.Sprintf("expected %s, got %q", typ, v)
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}